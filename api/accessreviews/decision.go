@@ -0,0 +1,167 @@
+package accessreviews
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// decisions maps the decision argument to the value recorded by Graph.
+var decisions = map[string]string{
+	"approve": "Approve",
+	"deny":    "Deny",
+}
+
+func init() {
+	// Access Review Decision Tool is a tool that records decisions on access reviews.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "access_review_decision",
+			Tool: mcp.NewTool("access_review_decision",
+				mcp.WithDescription("Record the decision, approve or deny, on a pending access review decision returned by the access_reviews tool. Decisions can only be recorded while the review instance is in progress, and are applied when the review completes. Requires AccessReview.ReadWrite.All."),
+				mcp.WithString("definition_id",
+					mcp.Required(),
+					mcp.Description("The id of the access review definition."),
+				),
+				mcp.WithString("instance_id",
+					mcp.Required(),
+					mcp.Description("The id of the access review instance."),
+				),
+				mcp.WithString("decision_id",
+					mcp.Required(),
+					mcp.Description("The id of the decision to record."),
+				),
+				mcp.WithString("decision",
+					mcp.Required(),
+					mcp.Enum("approve", "deny"),
+					mcp.Description("'approve' keeps the access of the principal, 'deny' removes it when the decisions are applied."),
+				),
+				mcp.WithString("justification",
+					mcp.Description("The reason of the decision, required by some reviews."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"AccessReview.ReadWrite.All"},
+			OutputSchema:   decisionSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a decisionArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := RecordDecision(ctx, client, a.DefinitionId, a.InstanceId, a.DecisionId, a.decision, a.Justification)
+				if err != nil {
+					switch odata.StatusCode(err) {
+					case http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound:
+						return mcp.NewToolResultError(fmt.Sprintf("the decision cannot be recorded: %s", odata.ErrorMessage(err))), nil
+					}
+					return mcp.NewToolResultError("failed to record access review decision"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// decisionSchema describes the result of the access_review_decision tool.
+var decisionSchema = schema.Object(map[string]schema.Schema{
+	"success":        schema.Boolean(),
+	"decisionId":     schema.String(),
+	"decision":       schema.Enum("Approve", "Deny"),
+	"instanceStatus": schema.String(),
+	"error":          schema.String(),
+})
+
+// decisionArgs are the arguments of the access_review_decision tool.
+type decisionArgs struct {
+	DefinitionId  string `json:"definition_id"`
+	InstanceId    string `json:"instance_id"`
+	DecisionId    string `json:"decision_id"`
+	Decision      string `json:"decision"`
+	Justification string `json:"justification"`
+
+	decision string
+}
+
+// Validate checks that the decision item is given and maps the decision to the value recorded by
+// Graph.
+func (a *decisionArgs) Validate() error {
+
+	if a.DefinitionId == "" {
+		return fmt.Errorf("definition_id is required")
+	}
+	if a.InstanceId == "" {
+		return fmt.Errorf("instance_id is required")
+	}
+	if a.DecisionId == "" {
+		return fmt.Errorf("decision_id is required")
+	}
+	decision, ok := decisions[a.Decision]
+	if !ok {
+		return fmt.Errorf("decision must be approve or deny")
+	}
+	a.decision = decision
+
+	return nil
+}
+
+// RecordDecision records the decision on a decision item of an access review instance, after
+// checking that the instance is still in progress.
+func RecordDecision(ctx context.Context, client *msgraphsdk.GraphServiceClient, definitionId string, instanceId string, decisionId string, decision string, justification string) ([]byte, error) {
+
+	instance := client.IdentityGovernance().AccessReviews().Definitions().ByAccessReviewScheduleDefinitionId(definitionId).
+		Instances().ByAccessReviewInstanceId(instanceId)
+
+	current, err := instance.Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching access review instance: %w", err)
+	}
+
+	// Completed reviews no longer accept decisions, Graph would reject them
+	status := ""
+	if current.GetStatus() != nil {
+		status = *current.GetStatus()
+	}
+	if status != inProgress {
+		return json.MarshalIndent(map[string]interface{}{
+			"success":        false,
+			"decisionId":     decisionId,
+			"instanceStatus": status,
+			"error":          fmt.Sprintf("the review instance is %s, decisions can only be recorded while it is %s", status, inProgress),
+		}, "", "  ")
+	}
+
+	body := models.NewAccessReviewInstanceDecisionItem()
+	body.SetDecision(&decision)
+	if justification != "" {
+		body.SetJustification(&justification)
+	}
+
+	if _, err := instance.Decisions().ByAccessReviewInstanceDecisionItemId(decisionId).Patch(ctx, body, nil); err != nil {
+		return nil, fmt.Errorf("error recording access review decision: %w", err)
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"success":        true,
+		"decisionId":     decisionId,
+		"decision":       decision,
+		"instanceStatus": status,
+	}, "", "  ")
+}
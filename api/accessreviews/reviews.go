@@ -0,0 +1,311 @@
+package accessreviews
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/identitygovernance"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// inProgress is the status of the review instances still accepting decisions.
+const inProgress = "InProgress"
+
+func init() {
+	// Access Reviews Tool is a tool that interacts with microsoft for access review APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "access_reviews",
+			Tool: mcp.NewTool("access_reviews",
+				mcp.WithDescription("Read the access review definitions with the status of their instances, and the decisions still pending per reviewer. Only the instances in progress have pending decisions, completed ones are listed with their status. The pending decisions of an instance are listed under each reviewer contacted for it. Requires AccessReview.Read.All."),
+				mcp.WithString("definition_id",
+					mcp.Description("The id of the access review definition to read, all of them if not given."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"AccessReview.Read.All"},
+			OutputSchema:   reviewsSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a reviewsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetReviews(ctx, client, a.DefinitionId)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the access review definition '%s' does not exist", a.DefinitionId)), nil
+					}
+					return mcp.NewToolResultError("failed to get access reviews"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// pendingDecisionSchema describes a decision waiting for a reviewer.
+var pendingDecisionSchema = schema.Object(map[string]schema.Schema{
+	"definitionId":   schema.String(),
+	"instanceId":     schema.String(),
+	"decisionId":     schema.String(),
+	"principalId":    schema.String(),
+	"principal":      schema.String(),
+	"resourceId":     schema.String(),
+	"resource":       schema.String(),
+	"recommendation": schema.String(),
+})
+
+// reviewsSchema describes the result of the access_reviews tool.
+var reviewsSchema = schema.Object(map[string]schema.Schema{
+	"definitions": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":          schema.String(),
+		"displayName": schema.String(),
+		"status":      schema.String(),
+		"instances": schema.Map(schema.Object(map[string]schema.Schema{
+			"id":               schema.String(),
+			"status":           schema.String(),
+			"startDateTime":    schema.DateTime(),
+			"endDateTime":      schema.DateTime(),
+			"pendingDecisions": schema.Integer(),
+		})),
+	})),
+	"reviewers": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":                schema.String(),
+		"displayName":       schema.String(),
+		"userPrincipalName": schema.String(),
+		"pendingDecisions":  schema.Array(pendingDecisionSchema),
+	})),
+})
+
+// reviewsArgs are the arguments of the access_reviews tool.
+type reviewsArgs struct {
+	DefinitionId string `json:"definition_id"`
+}
+
+// GetReviews retrieves the access review definitions, or the given one, with their instances,
+// and the decisions of the instances in progress not reviewed yet, per contacted reviewer.
+func GetReviews(ctx context.Context, client *msgraphsdk.GraphServiceClient, definitionId string) ([]byte, error) {
+
+	definitions := []models.AccessReviewScheduleDefinitionable{}
+	if definitionId != "" {
+		definition, err := client.IdentityGovernance().AccessReviews().Definitions().ByAccessReviewScheduleDefinitionId(definitionId).Get(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching access review definition: %w", err)
+		}
+		definitions = append(definitions, definition)
+	} else {
+		result, err := client.IdentityGovernance().AccessReviews().Definitions().Get(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching access review definitions: %w", err)
+		}
+		err = paginate.Iterate(ctx, client, result, models.CreateAccessReviewScheduleDefinitionCollectionResponseFromDiscriminatorValue, func(definition models.AccessReviewScheduleDefinitionable) bool {
+			definitions = append(definitions, definition)
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error iterating through access review definitions: %v", err)
+		}
+	}
+
+	// Create a map to store the JSON-friendly data
+	definitionsData := make(map[string]interface{})
+	reviewersData := make(map[string]interface{})
+
+	for _, definition := range definitions {
+		if definition.GetId() == nil {
+			continue
+		}
+		id := *definition.GetId()
+		definitionData := map[string]interface{}{
+			"id": id,
+		}
+		if displayName := definition.GetDisplayName(); displayName != nil {
+			definitionData["displayName"] = *displayName
+		}
+		if status := definition.GetStatus(); status != nil {
+			definitionData["status"] = *status
+		}
+
+		instancesData, err := getInstances(ctx, client, id, reviewersData)
+		if err != nil {
+			return nil, err
+		}
+		definitionData["instances"] = instancesData
+		definitionsData[id] = definitionData
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"definitions": definitionsData,
+		"reviewers":   reviewersData,
+	}, "", "  ")
+}
+
+// getInstances retrieves the instances of a definition, and adds the pending decisions of the
+// instances in progress to each of their contacted reviewers.
+func getInstances(ctx context.Context, client *msgraphsdk.GraphServiceClient, definitionId string, reviewersData map[string]interface{}) (map[string]interface{}, error) {
+
+	definition := client.IdentityGovernance().AccessReviews().Definitions().ByAccessReviewScheduleDefinitionId(definitionId)
+
+	result, err := definition.Instances().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching access review instances: %w", err)
+	}
+
+	instances := []models.AccessReviewInstanceable{}
+	err = paginate.Iterate(ctx, client, result, models.CreateAccessReviewInstanceCollectionResponseFromDiscriminatorValue, func(instance models.AccessReviewInstanceable) bool {
+		instances = append(instances, instance)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through access review instances: %v", err)
+	}
+
+	instancesData := make(map[string]interface{})
+	for _, instance := range instances {
+		if instance.GetId() == nil {
+			continue
+		}
+		id := *instance.GetId()
+		instanceData := map[string]interface{}{
+			"id": id,
+		}
+		if startDateTime := instance.GetStartDateTime(); startDateTime != nil {
+			instanceData["startDateTime"] = startDateTime.Format(time.RFC3339)
+		}
+		if endDateTime := instance.GetEndDateTime(); endDateTime != nil {
+			instanceData["endDateTime"] = endDateTime.Format(time.RFC3339)
+		}
+		instancesData[id] = instanceData
+
+		status := ""
+		if instance.GetStatus() != nil {
+			status = *instance.GetStatus()
+			instanceData["status"] = status
+		}
+		// Completed or applied instances no longer accept decisions
+		if status != inProgress {
+			continue
+		}
+
+		pending, err := getPendingDecisions(ctx, client, definitionId, id)
+		if err != nil {
+			return nil, err
+		}
+		instanceData["pendingDecisions"] = len(pending)
+		if len(pending) == 0 {
+			continue
+		}
+
+		reviewers, err := definition.Instances().ByAccessReviewInstanceId(id).ContactedReviewers().Get(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching contacted reviewers: %w", err)
+		}
+		err = paginate.Iterate(ctx, client, reviewers, models.CreateAccessReviewReviewerCollectionResponseFromDiscriminatorValue, func(reviewer models.AccessReviewReviewerable) bool {
+			if reviewer.GetId() == nil {
+				return true
+			}
+			reviewerData, ok := reviewersData[*reviewer.GetId()].(map[string]interface{})
+			if !ok {
+				reviewerData = map[string]interface{}{
+					"id":               *reviewer.GetId(),
+					"pendingDecisions": []interface{}{},
+				}
+				if displayName := reviewer.GetDisplayName(); displayName != nil {
+					reviewerData["displayName"] = *displayName
+				}
+				if userPrincipalName := reviewer.GetUserPrincipalName(); userPrincipalName != nil {
+					reviewerData["userPrincipalName"] = *userPrincipalName
+				}
+				reviewersData[*reviewer.GetId()] = reviewerData
+			}
+			reviewerData["pendingDecisions"] = append(reviewerData["pendingDecisions"].([]interface{}), pending...)
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error iterating through contacted reviewers: %v", err)
+		}
+	}
+
+	return instancesData, nil
+}
+
+// getPendingDecisions retrieves the decisions of an instance not reviewed yet.
+func getPendingDecisions(ctx context.Context, client *msgraphsdk.GraphServiceClient, definitionId string, instanceId string) ([]interface{}, error) {
+
+	result, err := client.IdentityGovernance().AccessReviews().Definitions().ByAccessReviewScheduleDefinitionId(definitionId).
+		Instances().ByAccessReviewInstanceId(instanceId).Decisions().Get(ctx, &identitygovernance.AccessReviewsDefinitionsItemInstancesItemDecisionsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &identitygovernance.AccessReviewsDefinitionsItemInstancesItemDecisionsRequestBuilderGetQueryParameters{
+			Filter: to.Ptr(odata.Eq("decision", "NotReviewed")),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching access review decisions: %w", err)
+	}
+
+	pending := []interface{}{}
+	err = paginate.Iterate(ctx, client, result, models.CreateAccessReviewInstanceDecisionItemCollectionResponseFromDiscriminatorValue, func(decision models.AccessReviewInstanceDecisionItemable) bool {
+		decisionData := convertDecisionToMap(decision)
+		decisionData["definitionId"] = definitionId
+		decisionData["instanceId"] = instanceId
+		pending = append(pending, decisionData)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through access review decisions: %v", err)
+	}
+
+	return pending, nil
+}
+
+// convertDecisionToMap converts a decision to a map with the principal whose access is reviewed
+// and the resource it has access to
+func convertDecisionToMap(decision models.AccessReviewInstanceDecisionItemable) map[string]interface{} {
+
+	decisionData := make(map[string]interface{})
+
+	if id := decision.GetId(); id != nil {
+		decisionData["decisionId"] = *id
+	}
+	if principal := decision.GetPrincipal(); principal != nil {
+		if id := principal.GetId(); id != nil {
+			decisionData["principalId"] = *id
+		}
+		if displayName := principal.GetDisplayName(); displayName != nil {
+			decisionData["principal"] = *displayName
+		}
+	}
+	if resource := decision.GetResource(); resource != nil {
+		if id := resource.GetId(); id != nil {
+			decisionData["resourceId"] = *id
+		}
+		if displayName := resource.GetDisplayName(); displayName != nil {
+			decisionData["resource"] = *displayName
+		}
+	}
+	if recommendation := decision.GetRecommendation(); recommendation != nil {
+		decisionData["recommendation"] = *recommendation
+	}
+
+	return decisionData
+}
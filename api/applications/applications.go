@@ -6,12 +6,17 @@ import (
 	"encoding/json"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
 	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
 	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
 	"github.com/mark3labs/mcp-go/mcp"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
 	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
-	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
 	"github.com/microsoftgraph/msgraph-sdk-go/applications"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 )
@@ -26,7 +31,15 @@ func init() {
 				mcp.WithString("name",
 					mcp.Description("The name of the application. If not provided, all applications will be returned."),
 				),
+				odata.WithMatchMode(),
+				paginate.WithLimit(),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
 			),
+			OutputSchema: applicationSchema,
 			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 
 				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
@@ -34,15 +47,31 @@ func init() {
 					return mcp.NewToolResultError("client not found"), nil
 				}
 
+				var a applicationsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
 				params := &applications.ApplicationsRequestBuilderGetQueryParameters{}
-				if name, ok := request.Params.Arguments["name"]; ok {
-					params.Filter = to.Ptr("displayName eq '" + name.(string) + "'")
+				if a.Name != "" {
+					filter, search, err := odata.Match("displayName", a.Name, a.MatchMode)
+					if err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+					params.Filter, params.Search = filter, search
+				}
+				limit, capped, err := paginate.Limit(request, a.Limit, params.Filter != nil || params.Search != nil)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
 				}
 				// Get the list of applications
-				jsonData, err := Get(ctx, client, params)
+				jsonData, err := Get(ctx, client, params, limit)
 				if err != nil {
 					return mcp.NewToolResultError("failed to get applications"), err
 				}
+				if capped {
+					return paginate.CappedNote(mcp.NewToolResultText(string(jsonData)), limit), nil
+				}
 
 				return mcp.NewToolResultText(string(jsonData)), nil
 			},
@@ -50,8 +79,9 @@ func init() {
 	)
 }
 
-// Get retrieves all applications from Microsoft Graph and returns their preferred names or IDs.
-func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *applications.ApplicationsRequestBuilderGetQueryParameters) ([]byte, error) {
+// Get retrieves all applications from Microsoft Graph, or the first limit ones if it is not zero,
+// and returns their preferred names or IDs.
+func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *applications.ApplicationsRequestBuilderGetQueryParameters, limit int) ([]byte, error) {
 
 	if params == nil {
 		params = &applications.ApplicationsRequestBuilderGetQueryParameters{}
@@ -60,37 +90,25 @@ func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *app
 	requestConfig := &applications.ApplicationsRequestBuilderGetRequestConfiguration{
 		QueryParameters: params,
 	}
+	// $search is an advanced query, it requires the ConsistencyLevel header
+	if params.Search != nil {
+		requestConfig.Headers = abstractions.NewRequestHeaders()
+		requestConfig.Headers.Add("ConsistencyLevel", "eventual")
+	}
 
 	result, err := client.Applications().Get(ctx, requestConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get the applications from the result
-	applications := result.GetValue()
-	if applications == nil {
-		return nil, err
-	}
-
 	// Create a map to store the JSON-friendly data
 	applicationsData := make(map[string]interface{})
 
-	// Convert each application to a map of attributes
-	for _, application := range applications {
-		id, applicationData := convertApplicationToMap(application)
-		applicationsData[id] = applicationData
-	}
-
-	// Use PageIterator to iterate through all applications
-	pageIterator, err := msgraphcore.NewPageIterator[models.Applicationable](result, client.GetAdapter(), models.CreateApplicationCollectionResponseFromDiscriminatorValue)
-	if err != nil {
-		return nil, err
-	}
-
-	err = pageIterator.Iterate(context.Background(), func(application models.Applicationable) bool {
+	// Convert each application of every page to a map of attributes
+	err = paginate.Iterate(ctx, client, result, models.CreateApplicationCollectionResponseFromDiscriminatorValue, func(application models.Applicationable) bool {
 		id, applicationData := convertApplicationToMap(application)
 		applicationsData[id] = applicationData
-		return true
+		return limit == 0 || len(applicationsData) < limit
 	})
 	if err != nil {
 		return nil, err
@@ -100,6 +118,37 @@ func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *app
 	return json.MarshalIndent(applicationsData, "", "  ")
 }
 
+// applicationSchema describes the result of the applications tool.
+var applicationSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":                         schema.String(),
+	"displayName":                schema.String(),
+	"appId":                      schema.String(),
+	"publisherDomain":            schema.String(),
+	"createdDateTime":            schema.DateTime(),
+	"applicationTemplateId":      schema.String(),
+	"defaultRedirectUri":         schema.String(),
+	"description":                schema.String(),
+	"disabledByMicrosoftStatus":  schema.String(),
+	"groupMembershipClaims":      schema.String(),
+	"isDeviceOnlyAuthSupported":  schema.Boolean(),
+	"isFallbackPublicClient":     schema.Boolean(),
+	"notes":                      schema.String(),
+	"oauth2RequirePostResponse":  schema.Boolean(),
+	"samlMetadataUrl":            schema.String(),
+	"serviceManagementReference": schema.String(),
+	"signInAudience":             schema.String(),
+	"tags":                       schema.Array(schema.String()),
+	"tokenEncryptionKeyId":       schema.String(),
+	"uniqueName":                 schema.String(),
+	"logo":                       schema.String(),
+	"api":                        schema.String(),
+	"web":                        schema.String(),
+	"spa":                        schema.String(),
+	"certification":              schema.String(),
+	"info":                       schema.String(),
+	"verifiedPublisher":          schema.String(),
+}))
+
 // convertApplicationToMap converts a application model to a map with all attributes
 func convertApplicationToMap(application models.Applicationable) (string, map[string]interface{}) {
 	appId := ""
@@ -201,3 +250,10 @@ func convertApplicationToMap(application models.Applicationable) (string, map[st
 
 	return appId, appMap
 }
+
+// applicationsArgs are the arguments of the applications tool.
+type applicationsArgs struct {
+	Name      string `json:"name"`
+	MatchMode string `json:"matchMode"`
+	Limit     int    `json:"limit"`
+}
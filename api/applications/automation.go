@@ -0,0 +1,211 @@
+package applications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/serviceprincipals"
+)
+
+// automationServices are the first-party applications behind Power Automate, Power Apps and
+// Logic Apps connections, keyed by app id.
+var automationServices = map[string]string{
+	"7df0a125-d3be-4c96-aa54-591f83ff541c": "Microsoft Flow Service",
+	"475226c6-020e-4fb2-8a90-7a972cbfc1d4": "PowerApps Service",
+	"7cd684f4-8a78-49b0-91ec-6a35d38739ba": "Azure Logic Apps",
+}
+
+// logicAppResource identifies the managed identities of Logic Apps in their alternative names.
+const logicAppResource = "/providers/microsoft.logic/workflows/"
+
+func init() {
+	// Automation Connections Tool is a tool that interacts with microsoft for service principal APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "automation_connections",
+			Tool: mcp.NewTool("automation_connections",
+				mcp.WithDescription("Inventory the automation identities of the tenant: the Logic Apps with a managed identity, and the Power Automate, Power Apps and Logic Apps connector services with the number of delegated permission grants users gave them. Microsoft Graph does not expose the flows and connections themselves, use the Power Platform admin APIs for them. Requires Application.Read.All and DelegatedPermissionGrant.Read.All."),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Application.Read.All", "DelegatedPermissionGrant.Read.All"},
+			OutputSchema:   automationSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				jsonData, err := GetAutomationConnections(ctx, client)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get automation connections"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// automationSchema describes the result of the automation_connections tool.
+var automationSchema = schema.Object(map[string]schema.Schema{
+	"logicApps": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":          schema.String(),
+		"displayName": schema.String(),
+		"appId":       schema.String(),
+		"resourceId":  schema.String(),
+	})),
+	"connectorServices": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":                   schema.String(),
+		"displayName":          schema.String(),
+		"appId":                schema.String(),
+		"accountEnabled":       schema.Boolean(),
+		"delegatedGrants":      schema.Integer(),
+		"delegatedGrantsError": schema.String(),
+	})),
+	"message": schema.String(),
+})
+
+// GetAutomationConnections retrieves the Logic Apps managed identities and the connector services
+// present in the tenant.
+func GetAutomationConnections(ctx context.Context, client *msgraphsdk.GraphServiceClient) ([]byte, error) {
+
+	logicAppsData, err := getLogicApps(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	appIds := make([]string, 0, len(automationServices))
+	for appId := range automationServices {
+		appIds = append(appIds, "'"+appId+"'")
+	}
+
+	result, err := client.ServicePrincipals().Get(ctx, &serviceprincipals.ServicePrincipalsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &serviceprincipals.ServicePrincipalsRequestBuilderGetQueryParameters{
+			Filter: to.Ptr(fmt.Sprintf("appId in (%s)", strings.Join(appIds, ","))),
+			Select: []string{"id", "appId", "displayName", "accountEnabled"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching connector service principals: %v", err)
+	}
+
+	servicesData := make(map[string]interface{})
+	err = paginate.Iterate(ctx, client, result, models.CreateServicePrincipalCollectionResponseFromDiscriminatorValue, func(sp models.ServicePrincipalable) bool {
+		if sp.GetId() == nil {
+			return true
+		}
+		serviceData := map[string]interface{}{
+			"id": *sp.GetId(),
+		}
+		if displayName := sp.GetDisplayName(); displayName != nil {
+			serviceData["displayName"] = *displayName
+		}
+		if appId := sp.GetAppId(); appId != nil {
+			serviceData["appId"] = *appId
+			if _, ok := serviceData["displayName"]; !ok {
+				serviceData["displayName"] = automationServices[*appId]
+			}
+		}
+		if accountEnabled := sp.GetAccountEnabled(); accountEnabled != nil {
+			serviceData["accountEnabled"] = *accountEnabled
+		}
+		servicesData[*sp.GetId()] = serviceData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through connector service principals: %v", err)
+	}
+
+	// Users consenting to a connector grant delegated permissions to its service principal
+	for id, serviceData := range servicesData {
+		grants, err := client.ServicePrincipals().ByServicePrincipalId(id).Oauth2PermissionGrants().Get(ctx, nil)
+		if err != nil {
+			serviceData.(map[string]interface{})["delegatedGrantsError"] = odata.ErrorMessage(err)
+			continue
+		}
+		count := 0
+		err = paginate.Iterate(ctx, client, grants, models.CreateOAuth2PermissionGrantCollectionResponseFromDiscriminatorValue, func(grant models.OAuth2PermissionGrantable) bool {
+			count++
+			return true
+		})
+		if err != nil {
+			serviceData.(map[string]interface{})["delegatedGrantsError"] = odata.ErrorMessage(err)
+			continue
+		}
+		serviceData.(map[string]interface{})["delegatedGrants"] = count
+	}
+
+	automationData := map[string]interface{}{
+		"logicApps":         logicAppsData,
+		"connectorServices": servicesData,
+	}
+	if len(logicAppsData) == 0 && len(servicesData) == 0 {
+		automationData["message"] = "No Logic App managed identity nor automation connector service was found in the tenant."
+	}
+
+	return json.MarshalIndent(automationData, "", "  ")
+}
+
+// getLogicApps returns the managed identities whose Azure resource is a Logic App workflow.
+// Filtering on servicePrincipalType is an advanced query, it requires the ConsistencyLevel header.
+func getLogicApps(ctx context.Context, client *msgraphsdk.GraphServiceClient) (map[string]interface{}, error) {
+
+	headers := abstractions.NewRequestHeaders()
+	headers.Add("ConsistencyLevel", "eventual")
+
+	result, err := client.ServicePrincipals().Get(ctx, &serviceprincipals.ServicePrincipalsRequestBuilderGetRequestConfiguration{
+		Headers: headers,
+		QueryParameters: &serviceprincipals.ServicePrincipalsRequestBuilderGetQueryParameters{
+			Filter: to.Ptr("servicePrincipalType eq 'ManagedIdentity'"),
+			Count:  to.Ptr(true),
+			Select: []string{"id", "appId", "displayName", "alternativeNames"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching managed identities: %v", err)
+	}
+
+	logicAppsData := make(map[string]interface{})
+	err = paginate.Iterate(ctx, client, result, models.CreateServicePrincipalCollectionResponseFromDiscriminatorValue, func(sp models.ServicePrincipalable) bool {
+		if sp.GetId() == nil {
+			return true
+		}
+		for _, name := range sp.GetAlternativeNames() {
+			if !strings.Contains(strings.ToLower(name), logicAppResource) {
+				continue
+			}
+			logicAppData := map[string]interface{}{
+				"id":         *sp.GetId(),
+				"resourceId": name,
+			}
+			if displayName := sp.GetDisplayName(); displayName != nil {
+				logicAppData["displayName"] = *displayName
+			}
+			if appId := sp.GetAppId(); appId != nil {
+				logicAppData["appId"] = *appId
+			}
+			logicAppsData[*sp.GetId()] = logicAppData
+			break
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through managed identities: %v", err)
+	}
+
+	return logicAppsData, nil
+}
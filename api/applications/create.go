@@ -0,0 +1,159 @@
+package applications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// signInAudiences are the accounts an application can be signed in with.
+var signInAudiences = []string{"AzureADMyOrg", "AzureADMultipleOrgs", "AzureADandPersonalMicrosoftAccount", "PersonalMicrosoftAccount"}
+
+func init() {
+	// Create Application Tool is a tool that registers an application.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "create_application",
+			Tool: mcp.NewTool("create_application",
+				mcp.WithDescription("Register a new application in Microsoft Entra ID, optionally as a web application with redirect URIs. Returns its object id, used to manage it, and its application (client) id, used to sign in. No service principal, credential or permission is created. Requires Application.ReadWrite.All."),
+				mcp.WithString("display_name",
+					mcp.Required(),
+					mcp.Description("The name of the application."),
+				),
+				mcp.WithString("sign_in_audience",
+					mcp.Enum(signInAudiences...),
+					mcp.Description("The accounts that can sign in to the application. Defaults to AzureADMyOrg, the accounts of this tenant only."),
+				),
+				mcp.WithString("redirect_uris",
+					mcp.Description("Comma separated list of the web redirect URIs of the application, e.g. https://app.contoso.com/auth/callback."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"Application.ReadWrite.All"},
+			OutputSchema:   createApplicationSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := createApplicationArgs{SignInAudience: "AzureADMyOrg"}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := CreateApplication(ctx, client, a.application())
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusForbidden {
+						return mcp.NewToolResultError(fmt.Sprintf("failed to create application: %s. The application this server runs as must be granted the Application.ReadWrite.All application permission of Microsoft Graph, with admin consent.", odata.ErrorMessage(err))), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to create application: %s", odata.ErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// createApplicationSchema describes the result of the create_application tool.
+var createApplicationSchema = schema.Object(map[string]schema.Schema{
+	"id":             schema.String(),
+	"appId":          schema.String(),
+	"displayName":    schema.String(),
+	"signInAudience": schema.String(),
+	"redirectUris":   schema.Array(schema.String()),
+	"success":        schema.Boolean(),
+})
+
+// createApplicationArgs are the arguments of the create_application tool.
+type createApplicationArgs struct {
+	DisplayName    string `json:"display_name"`
+	SignInAudience string `json:"sign_in_audience"`
+	RedirectUris   string `json:"redirect_uris"`
+
+	redirectUris []string
+}
+
+// Validate checks that the name is given, that the audience is supported and that the redirect
+// URIs are absolute urls.
+func (a *createApplicationArgs) Validate() error {
+
+	if a.DisplayName == "" {
+		return fmt.Errorf("display_name is required")
+	}
+	if !slices.Contains(signInAudiences, a.SignInAudience) {
+		return fmt.Errorf("unsupported sign_in_audience '%s', expected one of %s", a.SignInAudience, strings.Join(signInAudiences, ", "))
+	}
+
+	for _, uri := range strings.Split(a.RedirectUris, ",") {
+		if uri = strings.TrimSpace(uri); uri == "" {
+			continue
+		}
+		if parsed, err := url.Parse(uri); err != nil || !parsed.IsAbs() {
+			return fmt.Errorf("invalid redirect uri '%s', expected an absolute url", uri)
+		}
+		a.redirectUris = append(a.redirectUris, uri)
+	}
+
+	return nil
+}
+
+// application builds the application to register from the arguments.
+func (a *createApplicationArgs) application() models.Applicationable {
+
+	application := models.NewApplication()
+	application.SetDisplayName(&a.DisplayName)
+	application.SetSignInAudience(&a.SignInAudience)
+	if len(a.redirectUris) > 0 {
+		web := models.NewWebApplication()
+		web.SetRedirectUris(a.redirectUris)
+		application.SetWeb(web)
+	}
+
+	return application
+}
+
+// CreateApplication registers the application and returns its object id and application id.
+func CreateApplication(ctx context.Context, client *msgraphsdk.GraphServiceClient, application models.Applicationable) ([]byte, error) {
+
+	created, err := client.Applications().Post(ctx, application, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating application: %w", err)
+	}
+
+	applicationData := map[string]interface{}{
+		"success": true,
+	}
+	if id := created.GetId(); id != nil {
+		applicationData["id"] = *id
+	}
+	if appId := created.GetAppId(); appId != nil {
+		applicationData["appId"] = *appId
+	}
+	if displayName := created.GetDisplayName(); displayName != nil {
+		applicationData["displayName"] = *displayName
+	}
+	if signInAudience := created.GetSignInAudience(); signInAudience != nil {
+		applicationData["signInAudience"] = *signInAudience
+	}
+	if web := created.GetWeb(); web != nil && len(web.GetRedirectUris()) > 0 {
+		applicationData["redirectUris"] = web.GetRedirectUris()
+	}
+
+	return json.MarshalIndent(applicationData, "", "  ")
+}
@@ -0,0 +1,290 @@
+package applications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/beta"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/applications"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+const (
+	// defaultUnusedDays is the number of days without sign-in after which a credential is unused.
+	defaultUnusedDays = 90
+	// maxUnusedDays is the largest number of days without sign-in accepted.
+	maxUnusedDays = 365
+)
+
+func init() {
+	// Credential Usage Tool is a tool that interacts with microsoft for application credential APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "credential_usage",
+			Tool: mcp.NewTool("credential_usage",
+				mcp.WithDescription("Correlate the secrets and certificates of app registrations with their sign-in activity: for each credential, the last time it was used to sign in and whether it is unused, not used for the last days. Per-credential usage comes from the beta app credential sign-in activity report; when it cannot be read, the last sign-in of the application is used for all its credentials instead, and usageSource says so. Credentials created within the last days are never flagged. Requires Application.Read.All and AuditLog.Read.All."),
+				mcp.WithString("app_id",
+					mcp.Description("The application (client) id of the application. If not provided, all applications are returned."),
+				),
+				mcp.WithNumber("days",
+					mcp.Description(fmt.Sprintf("The number of days without sign-in after which a credential is unused, at most %d. Defaults to %d.", maxUnusedDays, defaultUnusedDays)),
+				),
+				paginate.WithLimit(),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Application.Read.All", "AuditLog.Read.All"},
+			OutputSchema:   credentialUsageSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := credentialUsageArgs{Days: defaultUnusedDays}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				limit, capped, err := paginate.Limit(request, a.Limit, a.AppId != "")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetCredentialUsage(ctx, client, a.AppId, time.Now().UTC().AddDate(0, 0, -a.Days), limit)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get credential usage"), err
+				}
+				if capped {
+					return paginate.CappedNote(mcp.NewToolResultText(string(jsonData)), limit), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// credentialUsageSchema describes the result of the credential_usage tool, keyed by application id.
+var credentialUsageSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":                 schema.String(),
+	"appId":              schema.String(),
+	"displayName":        schema.String(),
+	"lastSignInDateTime": schema.DateTime(),
+	"usageSource":        schema.Enum("credential", "application"),
+	"unusedCredentials":  schema.Integer(),
+	"credentials": schema.Map(schema.Object(map[string]schema.Schema{
+		"keyId":            schema.String(),
+		"type":             schema.Enum("secret", "certificate"),
+		"displayName":      schema.String(),
+		"startDateTime":    schema.DateTime(),
+		"endDateTime":      schema.DateTime(),
+		"expired":          schema.Boolean(),
+		"lastUsedDateTime": schema.DateTime(),
+		"unused":           schema.Boolean(),
+	})),
+	"warning": schema.String(),
+}))
+
+// signInActivity is the last sign-in of a beta sign-in activity report entry.
+type signInActivity struct {
+	LastSignInDateTime *time.Time `json:"lastSignInDateTime"`
+}
+
+// credentialUsageArgs are the arguments of the credential_usage tool.
+type credentialUsageArgs struct {
+	Days  int    `json:"days"`
+	AppId string `json:"app_id"`
+	Limit int    `json:"limit"`
+}
+
+// Validate checks the bounds of the window.
+func (a *credentialUsageArgs) Validate() error {
+
+	if a.Days <= 0 || a.Days > maxUnusedDays {
+		return fmt.Errorf("days must be between 1 and %d", maxUnusedDays)
+	}
+
+	return nil
+}
+
+// credentialActivity is an entry of the beta app credential sign-in activity report.
+type credentialActivity struct {
+	KeyId          string          `json:"keyId"`
+	SignInActivity *signInActivity `json:"signInActivity"`
+}
+
+// applicationActivity is an entry of the beta service principal sign-in activity report.
+type applicationActivity struct {
+	AppId              string          `json:"appId"`
+	LastSignInActivity *signInActivity `json:"lastSignInActivity"`
+}
+
+// GetCredentialUsage retrieves the credentials of the application with the given app id, or of
+// the first limit applications if it is empty, with their last use. A credential created before
+// unusedSince and not used since is flagged as unused.
+func GetCredentialUsage(ctx context.Context, client *msgraphsdk.GraphServiceClient, appId string, unusedSince time.Time, limit int) ([]byte, error) {
+
+	params := &applications.ApplicationsRequestBuilderGetQueryParameters{
+		Select: []string{"id", "appId", "displayName", "passwordCredentials", "keyCredentials"},
+	}
+	query := url.Values{}
+	if appId != "" {
+		params.Filter = to.Ptr(odata.Eq("appId", appId))
+		query.Set("$filter", odata.Eq("appId", appId))
+	}
+
+	result, err := client.Applications().Get(ctx, &applications.ApplicationsRequestBuilderGetRequestConfiguration{
+		QueryParameters: params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching applications: %w", err)
+	}
+
+	apps := []models.Applicationable{}
+	err = paginate.Iterate(ctx, client, result, models.CreateApplicationCollectionResponseFromDiscriminatorValue, func(application models.Applicationable) bool {
+		apps = append(apps, application)
+		return limit == 0 || len(apps) < limit
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through applications: %v", err)
+	}
+
+	// Graph only reports the use of each credential in a beta report, the last sign-in of the
+	// applications is the fallback when it cannot be read
+	credentialsUsed := make(map[string]time.Time)
+	credentialErr := beta.Iterate(ctx, client, "/reports/appCredentialSignInActivities?"+query.Encode(), func(activity credentialActivity) bool {
+		if activity.SignInActivity != nil && activity.SignInActivity.LastSignInDateTime != nil {
+			keyId := strings.ToLower(activity.KeyId)
+			if last := *activity.SignInActivity.LastSignInDateTime; last.After(credentialsUsed[keyId]) {
+				credentialsUsed[keyId] = last
+			}
+		}
+		return true
+	})
+
+	applicationsUsed := make(map[string]time.Time)
+	applicationErr := beta.Iterate(ctx, client, "/reports/servicePrincipalSignInActivities?"+query.Encode(), func(activity applicationActivity) bool {
+		if activity.LastSignInActivity != nil && activity.LastSignInActivity.LastSignInDateTime != nil {
+			applicationsUsed[activity.AppId] = *activity.LastSignInActivity.LastSignInDateTime
+		}
+		return true
+	})
+	if credentialErr != nil && applicationErr != nil {
+		return nil, fmt.Errorf("error fetching sign-in activity: %w", credentialErr)
+	}
+
+	// Create a map to store the JSON-friendly data
+	usageData := make(map[string]interface{})
+
+	for _, application := range apps {
+		id, applicationData := convertCredentialUsageToMap(application, unusedSince, credentialsUsed, applicationsUsed, credentialErr == nil)
+		if credentialErr != nil {
+			applicationData["warning"] = fmt.Sprintf("the use of each credential could not be read, the last sign-in of the application is used instead: %s", odata.ErrorMessage(credentialErr))
+		}
+		usageData[id] = applicationData
+	}
+
+	return json.MarshalIndent(usageData, "", "  ")
+}
+
+// convertCredentialUsageToMap converts the credentials of an application to a map with their
+// last use, taken from the credential report when perCredential is set, from the last sign-in of
+// the application otherwise
+func convertCredentialUsageToMap(application models.Applicationable, unusedSince time.Time, credentialsUsed map[string]time.Time, applicationsUsed map[string]time.Time, perCredential bool) (string, map[string]interface{}) {
+
+	applicationId := ""
+	applicationData := make(map[string]interface{})
+
+	if id := application.GetId(); id != nil {
+		applicationId = *id
+		applicationData["id"] = applicationId
+	}
+	if displayName := application.GetDisplayName(); displayName != nil {
+		applicationData["displayName"] = *displayName
+	}
+
+	var applicationUsed time.Time
+	if appId := application.GetAppId(); appId != nil {
+		applicationData["appId"] = *appId
+		applicationUsed = applicationsUsed[*appId]
+	}
+	if !applicationUsed.IsZero() {
+		applicationData["lastSignInDateTime"] = applicationUsed.Format(time.RFC3339)
+	}
+	applicationData["usageSource"] = "application"
+	if perCredential {
+		applicationData["usageSource"] = "credential"
+	}
+
+	credentialsData := make(map[string]interface{})
+	unused := 0
+	addCredential := func(keyId string, credentialType string, displayName *string, start *time.Time, end *time.Time) {
+		credentialData := map[string]interface{}{
+			"keyId": keyId,
+			"type":  credentialType,
+		}
+		if displayName != nil {
+			credentialData["displayName"] = *displayName
+		}
+		if start != nil {
+			credentialData["startDateTime"] = start.Format(time.RFC3339)
+		}
+		if end != nil {
+			credentialData["endDateTime"] = end.Format(time.RFC3339)
+			credentialData["expired"] = end.Before(time.Now())
+		}
+
+		lastUsed := applicationUsed
+		if perCredential {
+			lastUsed = credentialsUsed[keyId]
+		}
+		if !lastUsed.IsZero() {
+			credentialData["lastUsedDateTime"] = lastUsed.Format(time.RFC3339)
+		}
+
+		// A credential too recent to have been used is not flagged
+		isUnused := lastUsed.Before(unusedSince) && (start == nil || start.Before(unusedSince))
+		credentialData["unused"] = isUnused
+		if isUnused {
+			unused++
+		}
+		credentialsData[keyId] = credentialData
+	}
+
+	for _, credential := range application.GetPasswordCredentials() {
+		if credential.GetKeyId() == nil {
+			continue
+		}
+		addCredential(strings.ToLower(credential.GetKeyId().String()), "secret", credential.GetDisplayName(), credential.GetStartDateTime(), credential.GetEndDateTime())
+	}
+	for _, credential := range application.GetKeyCredentials() {
+		if credential.GetKeyId() == nil {
+			continue
+		}
+		addCredential(strings.ToLower(credential.GetKeyId().String()), "certificate", credential.GetDisplayName(), credential.GetStartDateTime(), credential.GetEndDateTime())
+	}
+	applicationData["credentials"] = credentialsData
+	applicationData["unusedCredentials"] = unused
+
+	return applicationId, applicationData
+}
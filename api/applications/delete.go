@@ -0,0 +1,138 @@
+package applications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/applications"
+)
+
+func init() {
+	// Delete Application Tool is a tool that deletes an application registration.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "delete_application",
+			Tool: mcp.NewTool("delete_application",
+				mcp.WithDescription("Delete an application registration. The application is moved to the deleted items of the directory, where it can be restored for 30 days, unless permanent is set. The application must be given by its object id, not by its application (client) id. Requires Application.ReadWrite.All."),
+				mcp.WithString("object_id",
+					mcp.Required(),
+					mcp.Description("The object id of the application, a GUID. This is the 'id' of the application, not its 'appId'."),
+				),
+				mcp.WithBoolean("permanent",
+					mcp.Description("Also remove the application from the deleted items, so that it cannot be restored. Defaults to false."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"Application.ReadWrite.All"},
+			OutputSchema:   deleteApplicationSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a deleteApplicationArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := DeleteApplication(ctx, client, a.ObjectId, a.Permanent)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(notFoundMessage(ctx, client, a.ObjectId)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to delete application: %s", odata.ErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// deleteApplicationSchema describes the result of the delete_application tool.
+var deleteApplicationSchema = schema.Object(map[string]schema.Schema{
+	"id":        schema.String(),
+	"success":   schema.Boolean(),
+	"permanent": schema.Boolean(),
+	"message":   schema.String(),
+})
+
+// deleteApplicationArgs are the arguments of the delete_application tool.
+type deleteApplicationArgs struct {
+	ObjectId  string `json:"object_id"`
+	Permanent bool   `json:"permanent"`
+}
+
+// Validate checks that the application is given by its object id.
+func (a *deleteApplicationArgs) Validate() error {
+
+	if a.ObjectId == "" {
+		return fmt.Errorf("object_id is required")
+	}
+	if !odata.IsGUID(a.ObjectId) {
+		return fmt.Errorf("object_id must be the object id of the application, a GUID, not '%s'", a.ObjectId)
+	}
+
+	return nil
+}
+
+// DeleteApplication deletes an application and, if permanent is set, removes it from the deleted items.
+func DeleteApplication(ctx context.Context, client *msgraphsdk.GraphServiceClient, objectId string, permanent bool) ([]byte, error) {
+
+	if err := client.Applications().ByApplicationId(objectId).Delete(ctx, nil); err != nil {
+		return nil, fmt.Errorf("error deleting application: %w", err)
+	}
+
+	deleteData := map[string]interface{}{
+		"id":        objectId,
+		"success":   true,
+		"permanent": permanent,
+		"message":   "The application was deleted, it can be restored from the deleted items for 30 days.",
+	}
+
+	if permanent {
+		if err := client.Directory().DeletedItems().ByDirectoryObjectId(objectId).Delete(ctx, nil); err != nil {
+			// The application is deleted all the same, only the hard delete failed
+			deleteData["permanent"] = false
+			deleteData["message"] = fmt.Sprintf("The application was deleted but could not be removed from the deleted items: %s. It can still be restored for 30 days.", odata.ErrorMessage(err))
+			return json.MarshalIndent(deleteData, "", "  ")
+		}
+		deleteData["message"] = "The application was permanently deleted."
+	}
+
+	return json.MarshalIndent(deleteData, "", "  ")
+}
+
+// notFoundMessage explains why no application has the given object id. An application id is
+// often given instead of the object id: when it is one, the object id to use is returned.
+func notFoundMessage(ctx context.Context, client *msgraphsdk.GraphServiceClient, objectId string) string {
+
+	result, err := client.Applications().Get(ctx, &applications.ApplicationsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &applications.ApplicationsRequestBuilderGetQueryParameters{
+			Filter: to.Ptr(odata.Eq("appId", objectId)),
+			Select: []string{"id", "displayName"},
+		},
+	})
+	if err == nil && len(result.GetValue()) > 0 && result.GetValue()[0].GetId() != nil {
+		application := result.GetValue()[0]
+		name := ""
+		if application.GetDisplayName() != nil {
+			name = fmt.Sprintf(" of '%s'", *application.GetDisplayName())
+		}
+		return fmt.Sprintf("'%s' is the application (client) id%s, not its object id: use object_id '%s' to delete it", objectId, name, *application.GetId())
+	}
+
+	return fmt.Sprintf("the application '%s' does not exist", objectId)
+}
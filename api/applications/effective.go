@@ -0,0 +1,269 @@
+package applications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/permissions"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/oauth2permissiongrants"
+	"github.com/spf13/viper"
+)
+
+// effectiveCacheDuration is how long the effective permissions of the application are kept before being looked up again.
+const effectiveCacheDuration = 10 * time.Minute
+
+// graphAppId is the application id of Microsoft Graph, whose permissions the tools require.
+const graphAppId = "00000003-0000-0000-c000-000000000000"
+
+type effectiveEntry struct {
+	data    []byte
+	fetched time.Time
+}
+
+var (
+	effectiveLock  sync.Mutex
+	effectiveCache = map[string]effectiveEntry{}
+)
+
+func init() {
+	// Effective Permissions Tool is a tool that reports the permissions the server application holds.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "effective_permissions",
+			Tool: mcp.NewTool("effective_permissions",
+				mcp.WithDescription(fmt.Sprintf("Show what the application this server runs as can actually do: the application permissions assigned to its service principal and the delegated permissions granted to it, per resource API, along with the tools missing a Microsoft Graph permission they require. Use it to understand why a tool is denied access. The result is cached for %s. Requires Application.Read.All and DelegatedPermissionGrant.Read.All.", effectiveCacheDuration)),
+				mcp.WithBoolean("refresh",
+					mcp.Description("Look the permissions up again instead of returning the cached ones."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Application.Read.All", "DelegatedPermissionGrant.Read.All"},
+			OutputSchema:   effectiveSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				clientID := viper.GetString("client-id")
+				if clientID == "" {
+					return mcp.NewToolResultError("the client id of the application is not configured"), nil
+				}
+
+				var a effectiveArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetEffectivePermissions(ctx, client, clientID, a.Refresh)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get effective permissions"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// effectiveSchema describes the result of the effective_permissions tool.
+var effectiveSchema = schema.Object(map[string]schema.Schema{
+	"appId":              schema.String(),
+	"servicePrincipalId": schema.String(),
+	"displayName":        schema.String(),
+	"fetchedDateTime":    schema.DateTime(),
+	"resources": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":                     schema.String(),
+		"appId":                  schema.String(),
+		"displayName":            schema.String(),
+		"applicationPermissions": schema.Array(schema.String()),
+		"delegatedPermissions":   schema.Array(schema.String()),
+		"delegatedGrants": schema.Array(schema.Object(map[string]schema.Schema{
+			"consentType": schema.String(),
+			"principalId": schema.String(),
+			"scopes":      schema.Array(schema.String()),
+		})),
+	})),
+	"toolsMissingPermissions": schema.Map(schema.Array(schema.String())),
+})
+
+// effectiveArgs are the arguments of the effective_permissions tool.
+type effectiveArgs struct {
+	Refresh bool `json:"refresh"`
+}
+
+// GetEffectivePermissions retrieves the permissions held by the service principal of the
+// application with the given client id, with their resource names. The result is cached for
+// effectiveCacheDuration unless refresh is set.
+func GetEffectivePermissions(ctx context.Context, client *msgraphsdk.GraphServiceClient, clientID string, refresh bool) ([]byte, error) {
+
+	effectiveLock.Lock()
+	defer effectiveLock.Unlock()
+
+	if entry, ok := effectiveCache[clientID]; ok && !refresh && time.Since(entry.fetched) < effectiveCacheDuration {
+		return entry.data, nil
+	}
+
+	data, err := lookupEffectivePermissions(ctx, client, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveCache[clientID] = effectiveEntry{data: data, fetched: time.Now()}
+	return data, nil
+}
+
+// lookupEffectivePermissions resolves the app role assignments and the oauth2 permission grants
+// of the application's service principal to permission names, grouped by resource.
+func lookupEffectivePermissions(ctx context.Context, client *msgraphsdk.GraphServiceClient, clientID string) ([]byte, error) {
+
+	sp, err := getResourceByAppId(ctx, client, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if sp == nil {
+		return nil, fmt.Errorf("no service principal found for application '%s'", clientID)
+	}
+
+	// Each resource is fetched once, to name both its app roles and its scopes
+	resources := map[string]*resource{}
+	resourcesData := map[string]map[string]interface{}{}
+	resourceData := func(resourceId string) (map[string]interface{}, *resource, error) {
+		if res, ok := resources[resourceId]; ok {
+			return resourcesData[resourceId], res, nil
+		}
+		res, err := getResourceById(ctx, client, resourceId)
+		if err != nil {
+			return nil, nil, err
+		}
+		resources[resourceId] = res
+		resourcesData[resourceId] = map[string]interface{}{
+			"id":                     res.id,
+			"appId":                  res.appId,
+			"displayName":            res.displayName,
+			"applicationPermissions": []string{},
+			"delegatedPermissions":   []string{},
+			"delegatedGrants":        []interface{}{},
+		}
+		return resourcesData[resourceId], res, nil
+	}
+
+	applicationGrants, err := getApplicationGrants(ctx, client, sp.id)
+	if err != nil {
+		return nil, err
+	}
+	for resourceId, roleIds := range applicationGrants {
+		data, res, err := resourceData(resourceId)
+		if err != nil {
+			return nil, err
+		}
+		names := []string{}
+		for roleId := range roleIds {
+			if name, ok := res.appRoles[roleId]; ok {
+				names = append(names, name)
+			} else {
+				names = append(names, roleId)
+			}
+		}
+		sort.Strings(names)
+		data["applicationPermissions"] = names
+	}
+
+	result, err := client.Oauth2PermissionGrants().Get(ctx, &oauth2permissiongrants.Oauth2PermissionGrantsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &oauth2permissiongrants.Oauth2PermissionGrantsRequestBuilderGetQueryParameters{
+			Filter: to.Ptr(odata.Eq("clientId", sp.id)),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching oauth2 permission grants: %v", err)
+	}
+
+	delegated := map[string]map[string]bool{}
+	var lookupErr error
+	err = paginate.Iterate(ctx, client, result, models.CreateOAuth2PermissionGrantCollectionResponseFromDiscriminatorValue, func(grant models.OAuth2PermissionGrantable) bool {
+		if grant.GetResourceId() == nil || grant.GetScope() == nil {
+			return true
+		}
+		var data map[string]interface{}
+		data, _, lookupErr = resourceData(*grant.GetResourceId())
+		if lookupErr != nil {
+			return false
+		}
+
+		scopes := strings.Fields(*grant.GetScope())
+		grantData := map[string]interface{}{
+			"scopes": scopes,
+		}
+		if consentType := grant.GetConsentType(); consentType != nil {
+			grantData["consentType"] = *consentType
+		}
+		if principalId := grant.GetPrincipalId(); principalId != nil {
+			grantData["principalId"] = *principalId
+		}
+		data["delegatedGrants"] = append(data["delegatedGrants"].([]interface{}), grantData)
+
+		if delegated[*grant.GetResourceId()] == nil {
+			delegated[*grant.GetResourceId()] = map[string]bool{}
+		}
+		for _, scope := range scopes {
+			delegated[*grant.GetResourceId()][scope] = true
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through oauth2 permission grants: %v", err)
+	}
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+	for resourceId, scopes := range delegated {
+		names := make([]string, 0, len(scopes))
+		for scope := range scopes {
+			names = append(names, scope)
+		}
+		sort.Strings(names)
+		resourcesData[resourceId]["delegatedPermissions"] = names
+	}
+
+	// The tools run with the application permissions of Microsoft Graph
+	graphPermissions := []string{}
+	for resourceId, res := range resources {
+		if res.appId == graphAppId {
+			graphPermissions = resourcesData[resourceId]["applicationPermissions"].([]string)
+		}
+	}
+	toolsMissing := map[string]interface{}{}
+	for _, tool := range collection.Tools {
+		if missing := permissions.Missing(graphPermissions, tool.RequiredScopes); len(missing) > 0 {
+			toolsMissing[tool.Name] = missing
+		}
+	}
+
+	effectiveData := map[string]interface{}{
+		"appId":                   clientID,
+		"servicePrincipalId":      sp.id,
+		"displayName":             sp.displayName,
+		"fetchedDateTime":         time.Now().UTC().Format(time.RFC3339),
+		"resources":               resourcesData,
+		"toolsMissingPermissions": toolsMissing,
+	}
+
+	return json.MarshalIndent(effectiveData, "", "  ")
+}
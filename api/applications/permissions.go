@@ -0,0 +1,333 @@
+package applications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/applications"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/oauth2permissiongrants"
+	"github.com/microsoftgraph/msgraph-sdk-go/serviceprincipals"
+)
+
+func init() {
+	// Application Permissions Tool is a tool that compares the requested and granted permissions of an application.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "application_permissions",
+			Tool: mcp.NewTool("application_permissions",
+				mcp.WithDescription("List the API permissions requested by an application registration (requiredResourceAccess) with their type (delegated or application) and whether admin consent has been granted to its service principal. Requires Application.Read.All and DelegatedPermissionGrant.Read.All."),
+				mcp.WithString("app_id",
+					mcp.Required(),
+					mcp.Description("The application (client) id of the application."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Application.Read.All", "DelegatedPermissionGrant.Read.All"},
+			OutputSchema:   permissionsSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a permissionsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetPermissions(ctx, client, a.AppId)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get application permissions"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// resource holds what is needed from a resource service principal to name the permissions it exposes.
+type resource struct {
+	id          string
+	appId       string
+	displayName string
+	scopes      map[string]string
+	appRoles    map[string]string
+}
+
+// permissionsSchema describes the result of the application_permissions tool.
+var permissionsSchema = schema.Object(map[string]schema.Schema{
+	"appId":       schema.String(),
+	"displayName": schema.String(),
+	"permissions": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":                  schema.String(),
+		"resourceAppId":       schema.String(),
+		"resourceDisplayName": schema.String(),
+		"type":                schema.String(),
+		"name":                schema.String(),
+		"adminConsent":        schema.Boolean(),
+	})),
+	"message": schema.String(),
+})
+
+// permissionsArgs are the arguments of the application_permissions tool.
+type permissionsArgs struct {
+	AppId string `json:"app_id"`
+}
+
+// Validate checks that the application is given.
+func (a *permissionsArgs) Validate() error {
+
+	if a.AppId == "" {
+		return fmt.Errorf("app_id is required")
+	}
+
+	return nil
+}
+
+// GetPermissions retrieves the permissions requested by an application and, for each of them,
+// whether admin consent has been granted to the application's service principal.
+func GetPermissions(ctx context.Context, client *msgraphsdk.GraphServiceClient, appId string) ([]byte, error) {
+
+	apps, err := client.Applications().Get(ctx, &applications.ApplicationsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &applications.ApplicationsRequestBuilderGetQueryParameters{
+			Filter: to.Ptr(odata.Eq("appId", appId)),
+			Select: []string{"id", "appId", "displayName", "requiredResourceAccess"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching application: %v", err)
+	}
+	if len(apps.GetValue()) == 0 {
+		return nil, fmt.Errorf("no application found with app id '%s'", appId)
+	}
+	app := apps.GetValue()[0]
+
+	// The service principal holds the grants, it only exists once the application has been consented to in the tenant
+	delegatedGrants := map[string]map[string]bool{}
+	applicationGrants := map[string]map[string]bool{}
+
+	sp, err := getResourceByAppId(ctx, client, appId)
+	if err != nil {
+		return nil, err
+	}
+	if sp != nil {
+		delegatedGrants, err = getAdminDelegatedGrants(ctx, client, sp.id)
+		if err != nil {
+			return nil, err
+		}
+		applicationGrants, err = getApplicationGrants(ctx, client, sp.id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Create a map to store the JSON-friendly data
+	permissionsData := make(map[string]interface{})
+
+	for _, requiredAccess := range app.GetRequiredResourceAccess() {
+		if requiredAccess.GetResourceAppId() == nil {
+			continue
+		}
+
+		resourceAppId := *requiredAccess.GetResourceAppId()
+		res, err := getResourceByAppId(ctx, client, resourceAppId)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, access := range requiredAccess.GetResourceAccess() {
+			if access.GetId() == nil || access.GetTypeEscaped() == nil {
+				continue
+			}
+
+			permissionId := access.GetId().String()
+			permissionData := map[string]interface{}{
+				"id":            permissionId,
+				"resourceAppId": resourceAppId,
+				"adminConsent":  false,
+			}
+
+			name := ""
+			switch *access.GetTypeEscaped() {
+			case "Scope":
+				permissionData["type"] = "delegated"
+				if res != nil {
+					name = res.scopes[permissionId]
+					permissionData["adminConsent"] = name != "" && delegatedGrants[res.id][strings.ToLower(name)]
+				}
+			case "Role":
+				permissionData["type"] = "application"
+				if res != nil {
+					name = res.appRoles[permissionId]
+					permissionData["adminConsent"] = applicationGrants[res.id][permissionId]
+				}
+			default:
+				permissionData["type"] = *access.GetTypeEscaped()
+			}
+
+			if name != "" {
+				permissionData["name"] = name
+			}
+			if res != nil {
+				permissionData["resourceDisplayName"] = res.displayName
+			}
+
+			permissionsData[resourceAppId+"/"+permissionId] = permissionData
+		}
+	}
+
+	result := map[string]interface{}{
+		"appId":       appId,
+		"permissions": permissionsData,
+	}
+	if app.GetDisplayName() != nil {
+		result["displayName"] = *app.GetDisplayName()
+	}
+	if sp == nil {
+		result["message"] = "The application has no service principal in this tenant, no permission has been consented to."
+	}
+
+	return json.MarshalIndent(result, "", "  ")
+}
+
+// getResourceByAppId returns the service principal of the application with the given app id,
+// or nil if the application has no service principal in the tenant.
+func getResourceByAppId(ctx context.Context, client *msgraphsdk.GraphServiceClient, appId string) (*resource, error) {
+
+	result, err := client.ServicePrincipals().Get(ctx, &serviceprincipals.ServicePrincipalsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &serviceprincipals.ServicePrincipalsRequestBuilderGetQueryParameters{
+			Filter: to.Ptr(odata.Eq("appId", appId)),
+			Select: []string{"id", "appId", "displayName", "appRoles", "oauth2PermissionScopes"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching service principal of '%s': %v", appId, err)
+	}
+	if len(result.GetValue()) == 0 || result.GetValue()[0].GetId() == nil {
+		return nil, nil
+	}
+
+	return newResource(result.GetValue()[0]), nil
+}
+
+// getResourceById returns the resource service principal with the given object id.
+func getResourceById(ctx context.Context, client *msgraphsdk.GraphServiceClient, spId string) (*resource, error) {
+
+	sp, err := client.ServicePrincipals().ByServicePrincipalId(spId).Get(ctx, &serviceprincipals.ServicePrincipalItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &serviceprincipals.ServicePrincipalItemRequestBuilderGetQueryParameters{
+			Select: []string{"id", "appId", "displayName", "appRoles", "oauth2PermissionScopes"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching service principal '%s': %v", spId, err)
+	}
+	if sp.GetId() == nil {
+		return nil, fmt.Errorf("service principal '%s' has no id", spId)
+	}
+
+	return newResource(sp), nil
+}
+
+// newResource indexes the permissions exposed by a service principal by their id.
+func newResource(sp models.ServicePrincipalable) *resource {
+
+	res := &resource{
+		id:       *sp.GetId(),
+		scopes:   map[string]string{},
+		appRoles: map[string]string{},
+	}
+	if sp.GetAppId() != nil {
+		res.appId = *sp.GetAppId()
+	}
+	if sp.GetDisplayName() != nil {
+		res.displayName = *sp.GetDisplayName()
+	}
+	for _, scope := range sp.GetOauth2PermissionScopes() {
+		if scope.GetId() != nil && scope.GetValue() != nil {
+			res.scopes[scope.GetId().String()] = *scope.GetValue()
+		}
+	}
+	for _, role := range sp.GetAppRoles() {
+		if role.GetId() != nil && role.GetValue() != nil {
+			res.appRoles[role.GetId().String()] = *role.GetValue()
+		}
+	}
+
+	return res
+}
+
+// getAdminDelegatedGrants returns the delegated permissions consented to on behalf of all users,
+// keyed by resource service principal id and lower cased scope.
+func getAdminDelegatedGrants(ctx context.Context, client *msgraphsdk.GraphServiceClient, spId string) (map[string]map[string]bool, error) {
+
+	result, err := client.Oauth2PermissionGrants().Get(ctx, &oauth2permissiongrants.Oauth2PermissionGrantsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &oauth2permissiongrants.Oauth2PermissionGrantsRequestBuilderGetQueryParameters{
+			Filter: to.Ptr(odata.Eq("clientId", spId)),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching oauth2 permission grants: %v", err)
+	}
+
+	grants := map[string]map[string]bool{}
+	err = paginate.Iterate(ctx, client, result, models.CreateOAuth2PermissionGrantCollectionResponseFromDiscriminatorValue, func(grant models.OAuth2PermissionGrantable) bool {
+		if grant.GetResourceId() == nil || grant.GetScope() == nil || grant.GetConsentType() == nil || *grant.GetConsentType() != "AllPrincipals" {
+			return true
+		}
+		if grants[*grant.GetResourceId()] == nil {
+			grants[*grant.GetResourceId()] = map[string]bool{}
+		}
+		for _, scope := range strings.Fields(*grant.GetScope()) {
+			grants[*grant.GetResourceId()][strings.ToLower(scope)] = true
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through oauth2 permission grants: %v", err)
+	}
+
+	return grants, nil
+}
+
+// getApplicationGrants returns the application permissions granted to a service principal,
+// keyed by resource service principal id and app role id.
+func getApplicationGrants(ctx context.Context, client *msgraphsdk.GraphServiceClient, spId string) (map[string]map[string]bool, error) {
+
+	result, err := client.ServicePrincipals().ByServicePrincipalId(spId).AppRoleAssignments().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching app role assignments: %v", err)
+	}
+
+	grants := map[string]map[string]bool{}
+	err = paginate.Iterate(ctx, client, result, models.CreateAppRoleAssignmentCollectionResponseFromDiscriminatorValue, func(assignment models.AppRoleAssignmentable) bool {
+		if assignment.GetResourceId() == nil || assignment.GetAppRoleId() == nil {
+			return true
+		}
+		resourceId := assignment.GetResourceId().String()
+		if grants[resourceId] == nil {
+			grants[resourceId] = map[string]bool{}
+		}
+		grants[resourceId][assignment.GetAppRoleId().String()] = true
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through app role assignments: %v", err)
+	}
+
+	return grants, nil
+}
@@ -0,0 +1,295 @@
+package applications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/applications"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+const (
+	// defaultExpiryDays is the window secrets must expire within to be rotated when no days are given.
+	defaultExpiryDays = 30
+	// maxExpiryDays is the largest window secrets must expire within to be rotated.
+	maxExpiryDays = 365
+	// defaultValidityDays is the validity of the new secrets when no validity_days is given.
+	defaultValidityDays = 180
+	// maxValidityDays is the largest validity of the new secrets, as recommended by Microsoft.
+	maxValidityDays = 730
+	// defaultSecretName is the display name of the new secrets when no secret_name is given.
+	defaultSecretName = "Rotated secret"
+)
+
+func init() {
+	// Rotate Secrets Tool is a tool that renews the expiring client secrets of applications.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "rotate_expiring_secrets",
+			Tool: mcp.NewTool("rotate_expiring_secrets",
+				mcp.WithDescription("Renew the client secrets of applications expiring within the next days: a new password credential is added to each such application and its secretText is returned, only once, in the result. The expiring secrets are left in place, to be removed once the new ones are deployed. Applications already holding a secret valid beyond the window are reported as already rotated and left untouched, so the tool can be called again safely. Rotating all the applications requires a limit. Use dry_run to preview the applications that would be rotated. Each application is processed on its own and reported individually. Requires Application.ReadWrite.All."),
+				mcp.WithNumber("days",
+					mcp.Description(fmt.Sprintf("Rotate the applications with a secret expired or expiring within this number of days, at most %d. Defaults to %d.", maxExpiryDays, defaultExpiryDays)),
+				),
+				mcp.WithNumber("validity_days",
+					mcp.Description(fmt.Sprintf("The number of days the new secrets are valid, at most %d. Defaults to %d.", maxValidityDays, defaultValidityDays)),
+				),
+				mcp.WithString("app_ids",
+					mcp.Description("Comma separated list of application object ids to restrict the rotation to. If not provided, all the applications are considered, up to limit."),
+				),
+				mcp.WithNumber("limit",
+					mcp.Description("The maximum number of applications to rotate. Required to rotate the secrets of all the applications when app_ids is not given, unless dry_run is set."),
+				),
+				mcp.WithString("secret_name",
+					mcp.Description(fmt.Sprintf("The display name of the new secrets. Defaults to '%s'.", defaultSecretName)),
+				),
+				mcp.WithBoolean("dry_run",
+					mcp.Description("Only list the applications and the secrets that would be rotated, without adding any secret."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"Application.ReadWrite.All"},
+			OutputSchema:   rotationSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := rotationArgs{Days: defaultExpiryDays, ValidityDays: defaultValidityDays, SecretName: defaultSecretName}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				now := time.Now().UTC()
+				jsonData, err := RotateExpiringSecrets(ctx, client, a.appIds, now.AddDate(0, 0, a.Days), now.AddDate(0, 0, a.ValidityDays), a.SecretName, a.Limit, a.DryRun)
+				if err != nil {
+					return mcp.NewToolResultError("failed to rotate expiring secrets"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// rotationSchema describes the result of the rotate_expiring_secrets tool, keyed by application object id.
+var rotationSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"displayName":    schema.String(),
+	"appId":          schema.String(),
+	"dryRun":         schema.Boolean(),
+	"alreadyRotated": schema.Boolean(),
+	"success":        schema.Boolean(),
+	"error":          schema.String(),
+	"expiringSecrets": schema.Array(schema.Object(map[string]schema.Schema{
+		"keyId":       schema.String(),
+		"displayName": schema.String(),
+		"endDateTime": schema.DateTime(),
+	})),
+	"newSecret": schema.Object(map[string]schema.Schema{
+		"keyId":       schema.String(),
+		"displayName": schema.String(),
+		"endDateTime": schema.DateTime(),
+		"secretText":  schema.String(),
+	}),
+}))
+
+// RotateExpiringSecrets adds a new secret valid until validUntil to each application, among the
+// given ones or all of them, holding a secret expiring before deadline and none valid beyond it.
+// At most limit applications are rotated if it is not zero, and nothing is added when dryRun is
+// set. The applications are processed one by one so that a failure only affects the application
+// it concerns.
+func RotateExpiringSecrets(ctx context.Context, client *msgraphsdk.GraphServiceClient, appIds []string, deadline time.Time, validUntil time.Time, secretName string, limit int, dryRun bool) ([]byte, error) {
+
+	selectFields := []string{"id", "displayName", "appId", "passwordCredentials"}
+	resultsData := make(map[string]interface{})
+	candidates := []models.Applicationable{}
+	rotated := 0
+
+	if len(appIds) > 0 {
+		for _, appId := range appIds {
+			application, err := client.Applications().ByApplicationId(appId).Get(ctx, &applications.ApplicationItemRequestBuilderGetRequestConfiguration{
+				QueryParameters: &applications.ApplicationItemRequestBuilderGetQueryParameters{
+					Select: selectFields,
+				},
+			})
+			if err != nil {
+				resultsData[appId] = map[string]interface{}{"success": false, "error": odata.ErrorMessage(err)}
+				continue
+			}
+			candidates = append(candidates, application)
+		}
+	} else {
+		result, err := client.Applications().Get(ctx, &applications.ApplicationsRequestBuilderGetRequestConfiguration{
+			QueryParameters: &applications.ApplicationsRequestBuilderGetQueryParameters{
+				Select: selectFields,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error fetching applications: %w", err)
+		}
+		err = paginate.Iterate(ctx, client, result, models.CreateApplicationCollectionResponseFromDiscriminatorValue, func(application models.Applicationable) bool {
+			candidates = append(candidates, application)
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error iterating through applications: %v", err)
+		}
+	}
+
+	for _, application := range candidates {
+		if application.GetId() == nil {
+			continue
+		}
+		appId := *application.GetId()
+
+		expiringSecrets := []interface{}{}
+		alreadyRotated := false
+		for _, credential := range application.GetPasswordCredentials() {
+			end := credential.GetEndDateTime()
+			if end == nil {
+				continue
+			}
+			if end.Before(deadline) {
+				expiringSecrets = append(expiringSecrets, convertPasswordCredentialToMap(credential))
+			} else {
+				alreadyRotated = true
+			}
+		}
+		// Applications given explicitly are reported even when they have nothing to rotate
+		if len(expiringSecrets) == 0 {
+			if len(appIds) > 0 {
+				resultsData[appId] = map[string]interface{}{"success": false, "error": "the application has no secret expiring within the window"}
+			}
+			continue
+		}
+
+		// Applications beyond the limit are left for a next call
+		if !alreadyRotated && limit > 0 && rotated >= limit {
+			continue
+		}
+
+		rotationData := map[string]interface{}{
+			"dryRun":          dryRun,
+			"alreadyRotated":  alreadyRotated,
+			"expiringSecrets": expiringSecrets,
+		}
+		if displayName := application.GetDisplayName(); displayName != nil {
+			rotationData["displayName"] = *displayName
+		}
+		if applicationId := application.GetAppId(); applicationId != nil {
+			rotationData["appId"] = *applicationId
+		}
+		resultsData[appId] = rotationData
+
+		// A secret valid beyond the window is the one of a previous rotation, adding another
+		// would only leave a live credential nobody ever sees again
+		if alreadyRotated {
+			rotationData["success"] = true
+			continue
+		}
+		rotated++
+
+		if dryRun {
+			rotationData["success"] = true
+			continue
+		}
+
+		credential := models.NewPasswordCredential()
+		credential.SetDisplayName(to.Ptr(secretName))
+		credential.SetEndDateTime(to.Ptr(validUntil))
+
+		body := applications.NewItemAddPasswordPostRequestBody()
+		body.SetPasswordCredential(credential)
+
+		newCredential, err := client.Applications().ByApplicationId(appId).AddPassword().Post(ctx, body, nil)
+		if err != nil {
+			rotationData["success"] = false
+			rotationData["error"] = odata.ErrorMessage(err)
+			continue
+		}
+
+		// The secret text is only ever returned by Graph in this response
+		newSecret := convertPasswordCredentialToMap(newCredential)
+		if secretText := newCredential.GetSecretText(); secretText != nil {
+			newSecret["secretText"] = *secretText
+		}
+		rotationData["newSecret"] = newSecret
+		rotationData["success"] = true
+	}
+
+	return json.MarshalIndent(resultsData, "", "  ")
+}
+
+// rotationArgs are the arguments of the rotate_expiring_secrets tool.
+type rotationArgs struct {
+	Days         int    `json:"days"`
+	ValidityDays int    `json:"validity_days"`
+	AppIds       string `json:"app_ids"`
+	SecretName   string `json:"secret_name"`
+	Limit        int    `json:"limit"`
+	DryRun       bool   `json:"dry_run"`
+
+	appIds []string
+}
+
+// Validate checks the bounds of the windows and of the limit and splits the application ids.
+func (a *rotationArgs) Validate() error {
+
+	if a.Days <= 0 || a.Days > maxExpiryDays {
+		return fmt.Errorf("days must be between 1 and %d", maxExpiryDays)
+	}
+	if a.ValidityDays <= 0 || a.ValidityDays > maxValidityDays {
+		return fmt.Errorf("validity_days must be between 1 and %d", maxValidityDays)
+	}
+	// New secrets expiring within the window would be rotated again by the next call
+	if a.ValidityDays <= a.Days {
+		return fmt.Errorf("validity_days must be greater than days")
+	}
+	if a.Limit < 0 {
+		return fmt.Errorf("limit must be a positive number")
+	}
+
+	for _, appId := range strings.Split(a.AppIds, ",") {
+		if appId = strings.TrimSpace(appId); appId != "" {
+			a.appIds = append(a.appIds, appId)
+		}
+	}
+	if len(a.appIds) == 0 && a.Limit == 0 && !a.DryRun {
+		return fmt.Errorf("app_ids or limit is required to rotate the secrets of all the applications, use dry_run to preview them first")
+	}
+
+	return nil
+}
+
+// convertPasswordCredentialToMap converts a password credential to a map of its identity and
+// expiry, never including its secret
+func convertPasswordCredentialToMap(credential models.PasswordCredentialable) map[string]interface{} {
+
+	credentialData := make(map[string]interface{})
+
+	if keyId := credential.GetKeyId(); keyId != nil {
+		credentialData["keyId"] = keyId.String()
+	}
+	if displayName := credential.GetDisplayName(); displayName != nil {
+		credentialData["displayName"] = *displayName
+	}
+	if endDateTime := credential.GetEndDateTime(); endDateTime != nil {
+		credentialData["endDateTime"] = endDateTime.Format(time.RFC3339)
+	}
+
+	return credentialData
+}
@@ -0,0 +1,69 @@
+package applications
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+func TestRotateExpiringSecretsTwice(t *testing.T) {
+
+	const appObjectId = "5c1e8a3d-7f2b-4e6a-9d0c-3b8f2a1e4d57"
+
+	// The fake application keeps the secrets added to it, as Graph does
+	credentials := []interface{}{
+		map[string]interface{}{"keyId": "10000000-0000-0000-0000-000000000001", "displayName": "CI secret", "endDateTime": time.Now().AddDate(0, 0, 10).UTC().Format(time.RFC3339)},
+	}
+	posts := 0
+	routes := graphtest.Routes{
+		"GET /v1.0/applications/" + appObjectId: func(r *http.Request) interface{} {
+			return map[string]interface{}{"id": appObjectId, "displayName": "Payroll", "passwordCredentials": credentials}
+		},
+		"POST /v1.0/applications/" + appObjectId + "/addPassword": func(r *http.Request) interface{} {
+			posts++
+			credential := map[string]interface{}{"keyId": "10000000-0000-0000-0000-000000000002", "displayName": "Rotated secret", "endDateTime": time.Now().AddDate(0, 0, 180).UTC().Format(time.RFC3339)}
+			credentials = append(credentials, credential)
+			return map[string]interface{}{"keyId": credential["keyId"], "displayName": credential["displayName"], "endDateTime": credential["endDateTime"], "secretText": "secret"}
+		},
+	}
+
+	arguments := map[string]interface{}{"app_ids": appObjectId}
+	first := graphtest.CheckTool(t, "rotate_expiring_secrets", arguments, routes)
+	second := graphtest.CheckTool(t, "rotate_expiring_secrets", arguments, routes)
+
+	if posts != 1 {
+		t.Errorf("addPassword posted %d times, want 1", posts)
+	}
+	if appData, _ := first.(map[string]interface{})[appObjectId].(map[string]interface{}); appData["newSecret"] == nil {
+		t.Errorf("first call: got %v, want a new secret", appData)
+	}
+	if appData, _ := second.(map[string]interface{})[appObjectId].(map[string]interface{}); appData["alreadyRotated"] != true || appData["newSecret"] != nil {
+		t.Errorf("second call: got %v, want the application already rotated", appData)
+	}
+}
+
+func TestRotateExpiringSecretsSweep(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		arguments map[string]interface{}
+		wantErr   bool
+	}{
+		{"no app_ids nor limit", map[string]interface{}{}, true},
+		{"dry run", map[string]interface{}{"dry_run": true}, false},
+		{"limit", map[string]interface{}{"limit": 1}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := graphtest.CallTool(t, "rotate_expiring_secrets", test.arguments, graphtest.Routes{
+				"GET /v1.0/applications": map[string]interface{}{"value": []interface{}{}},
+			})
+			if result.IsError != test.wantErr {
+				t.Errorf("IsError = %v, want %v: %+v", result.IsError, test.wantErr, result.Content)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package applications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/beta"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	jsonserialization "github.com/microsoft/kiota-serialization-json-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/auditlogs"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+const (
+	// defaultSignInDays is the window looked at when no days are given.
+	defaultSignInDays = 7
+	// maxSignInDays is the retention of the sign-in logs.
+	maxSignInDays = 30
+	// defaultSignInLimit is the number of failed sign-ins read when no limit is given.
+	defaultSignInLimit = 500
+	// maxSignInLimit is the largest number of failed sign-ins read in one call.
+	maxSignInLimit = 5000
+	// maxSignInPageSize is the largest page size accepted by the sign-in logs API.
+	maxSignInPageSize = 1000
+)
+
+func init() {
+	// Application Sign In Failures Tool is a tool that interacts with microsoft for sign-in logs APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "application_sign_in_failures",
+			Tool: mcp.NewTool("application_sign_in_failures",
+				mcp.WithDescription("Find why users or the application itself fail to sign in: read the failed sign-ins (error code other than 0) of the application over the last days, either aggregated by error code with the failure reason and count, or one by one. The interactive user sign-ins are read by default, the service principal sign-ins of the application on demand, from the Microsoft Graph beta endpoint. Requires AuditLog.Read.All (the tenant needs an Entra ID P1 or P2 license)."),
+				mcp.WithString("app_id",
+					mcp.Required(),
+					mcp.Description("The application (client) id of the application."),
+				),
+				mcp.WithString("mode",
+					mcp.Enum("summary", "signins"),
+					mcp.DefaultString("summary"),
+					mcp.Description("The operation to run. 'summary' aggregates the failures by error code, 'signins' returns each failed sign-in."),
+				),
+				mcp.WithNumber("days",
+					mcp.Description(fmt.Sprintf("The number of days to look back, at most %d. Defaults to %d.", maxSignInDays, defaultSignInDays)),
+				),
+				mcp.WithNumber("limit",
+					mcp.Description(fmt.Sprintf("The maximum number of failed sign-ins to read of each type, most recent first, at most %d. Defaults to %d. When it is reached, the result only covers the most recent failures and says so in a note.", maxSignInLimit, defaultSignInLimit)),
+				),
+				mcp.WithBoolean("include_service_principals",
+					mcp.Description("Also read the sign-ins of the application as a service principal, e.g. with its client credentials, from the beta endpoint. Defaults to false."),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"AuditLog.Read.All"},
+			OutputSchema:   signInFailuresSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := signInFailuresArgs{Mode: "summary", Days: defaultSignInDays, Limit: defaultSignInLimit}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				since := time.Now().UTC().AddDate(0, 0, -a.Days)
+
+				jsonData, truncated, err := GetSignInFailures(ctx, client, a.AppId, since, a.Limit, a.Mode == "summary", a.IncludeServicePrincipals)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get application sign-in failures"), err
+				}
+
+				result := mcp.NewToolResultText(string(jsonData))
+				if truncated {
+					result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf("truncated: the limit of %d failed sign-ins was reached, the result only covers the most recent failures of the last %d days. Raise the limit or lower the days to cover the whole period.", a.Limit, a.Days)))
+				}
+
+				return result, nil
+			},
+		},
+	)
+}
+
+// signInFailuresSchema describes the result of the application_sign_in_failures tool in both modes.
+var signInFailuresSchema = schema.OneOf(
+	schema.Map(schema.Object(map[string]schema.Schema{
+		"id":            schema.String(),
+		"errorCode":     schema.Integer(),
+		"failureReason": schema.String(),
+		"count":         schema.Integer(),
+		"firstSeen":     schema.DateTime(),
+		"lastSeen":      schema.DateTime(),
+	})),
+	schema.Map(schema.Object(map[string]schema.Schema{
+		"id":                      schema.String(),
+		"createdDateTime":         schema.DateTime(),
+		"signInType":              schema.String(),
+		"userPrincipalName":       schema.String(),
+		"servicePrincipalName":    schema.String(),
+		"appDisplayName":          schema.String(),
+		"resourceDisplayName":     schema.String(),
+		"clientAppUsed":           schema.String(),
+		"ipAddress":               schema.String(),
+		"conditionalAccessStatus": schema.String(),
+		"errorCode":               schema.Integer(),
+		"failureReason":           schema.String(),
+		"additionalDetails":       schema.String(),
+	})),
+)
+
+// signInFailuresArgs are the arguments of the application_sign_in_failures tool.
+type signInFailuresArgs struct {
+	AppId                    string `json:"app_id"`
+	Mode                     string `json:"mode"`
+	Days                     int    `json:"days"`
+	Limit                    int    `json:"limit"`
+	IncludeServicePrincipals bool   `json:"include_service_principals"`
+}
+
+// Validate checks that the application is given and that the mode and the bounds are supported.
+func (a *signInFailuresArgs) Validate() error {
+
+	if a.AppId == "" {
+		return fmt.Errorf("app_id is required")
+	}
+	if a.Days <= 0 || a.Days > maxSignInDays {
+		return fmt.Errorf("days must be between 1 and %d", maxSignInDays)
+	}
+	if a.Limit <= 0 || a.Limit > maxSignInLimit {
+		return fmt.Errorf("limit must be between 1 and %d", maxSignInLimit)
+	}
+	if a.Mode != "summary" && a.Mode != "signins" {
+		return fmt.Errorf("unsupported mode '%s'", a.Mode)
+	}
+
+	return nil
+}
+
+// GetSignInFailures retrieves up to limit failed user sign-ins of an application since the given
+// time, most recent first, and as many service principal sign-ins if servicePrincipals is set.
+// When summarize is set they are aggregated by error code. truncated is set when the limit was
+// reached, the result then misses older failures.
+func GetSignInFailures(ctx context.Context, client *msgraphsdk.GraphServiceClient, appId string, since time.Time, limit int, summarize bool, servicePrincipals bool) ([]byte, bool, error) {
+
+	filter := fmt.Sprintf("%s and status/errorCode ne 0 and createdDateTime ge %s", odata.Eq("appId", appId), since.Format(time.RFC3339))
+
+	result, err := client.AuditLogs().SignIns().Get(ctx, &auditlogs.SignInsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &auditlogs.SignInsRequestBuilderGetQueryParameters{
+			Filter:  to.Ptr(filter),
+			Orderby: []string{"createdDateTime desc"},
+			Top:     to.Ptr(int32(min(limit, maxSignInPageSize))),
+		},
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("error fetching sign-ins: %v", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	signInsData := make(map[string]interface{})
+	add := func(signIn models.SignInable, signInType string) {
+		if summarize {
+			aggregateSignInFailure(signInsData, signIn)
+			return
+		}
+		id, signInData := convertSignInToMap(signIn)
+		signInData["signInType"] = signInType
+		signInsData[id] = signInData
+	}
+
+	count := 0
+	err = paginate.Iterate(ctx, client, result, models.CreateSignInCollectionResponseFromDiscriminatorValue, func(signIn models.SignInable) bool {
+		count++
+		add(signIn, "user")
+		return count < limit
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("error iterating through sign-ins: %v", err)
+	}
+	truncated := count >= limit
+
+	if servicePrincipals {
+		// The service principal sign-ins are only available from the beta endpoint
+		query := url.Values{}
+		query.Set("$filter", filter+" and signInEventTypes/any(t: t eq 'servicePrincipal')")
+		query.Set("$orderby", "createdDateTime desc")
+		query.Set("$top", strconv.Itoa(min(limit, maxSignInPageSize)))
+
+		count = 0
+		err = beta.Iterate(ctx, client, "/auditLogs/signIns?"+query.Encode(), func(item json.RawMessage) bool {
+			signIn, err := parseSignIn(item)
+			if err != nil {
+				return true
+			}
+			count++
+			add(signIn, "servicePrincipal")
+			return count < limit
+		})
+		if err != nil {
+			return nil, false, fmt.Errorf("error fetching service principal sign-ins: %w", err)
+		}
+		truncated = truncated || count >= limit
+	}
+
+	jsonData, err := json.MarshalIndent(signInsData, "", "  ")
+	return jsonData, truncated, err
+}
+
+// parseSignIn parses a sign-in of the beta endpoint with the v1.0 model. The properties the model
+// does not have, such as servicePrincipalName, are kept in its additional data.
+func parseSignIn(content []byte) (models.SignInable, error) {
+
+	node, err := jsonserialization.NewJsonParseNode(content)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := node.GetObjectValue(models.CreateSignInFromDiscriminatorValue)
+	if err != nil {
+		return nil, err
+	}
+	signIn, ok := parsed.(models.SignInable)
+	if !ok {
+		return nil, fmt.Errorf("unexpected sign-in %T", parsed)
+	}
+
+	return signIn, nil
+}
+
+// aggregateSignInFailure counts a failed sign-in under its error code. Sign-ins are read most
+// recent first, so the first one seen for a code is the last occurrence.
+func aggregateSignInFailure(failuresData map[string]interface{}, signIn models.SignInable) {
+
+	errorCode := int32(0)
+	failureReason := ""
+	if status := signIn.GetStatus(); status != nil {
+		if status.GetErrorCode() != nil {
+			errorCode = *status.GetErrorCode()
+		}
+		if status.GetFailureReason() != nil {
+			failureReason = *status.GetFailureReason()
+		}
+	}
+
+	code := strconv.Itoa(int(errorCode))
+	failureData, ok := failuresData[code].(map[string]interface{})
+	if !ok {
+		failureData = map[string]interface{}{
+			"id":            code,
+			"errorCode":     errorCode,
+			"failureReason": failureReason,
+			"count":         0,
+		}
+		if createdDateTime := signIn.GetCreatedDateTime(); createdDateTime != nil {
+			failureData["lastSeen"] = createdDateTime.Format(time.RFC3339)
+		}
+		failuresData[code] = failureData
+	}
+
+	failureData["count"] = failureData["count"].(int) + 1
+	if createdDateTime := signIn.GetCreatedDateTime(); createdDateTime != nil {
+		failureData["firstSeen"] = createdDateTime.Format(time.RFC3339)
+	}
+}
+
+// convertSignInToMap converts a sign-in model to a map with the failure details
+func convertSignInToMap(signIn models.SignInable) (string, map[string]interface{}) {
+
+	signInId := ""
+	signInData := make(map[string]interface{})
+
+	if id := signIn.GetId(); id != nil {
+		signInId = *id
+		signInData["id"] = signInId
+	}
+	if createdDateTime := signIn.GetCreatedDateTime(); createdDateTime != nil {
+		signInData["createdDateTime"] = createdDateTime.Format(time.RFC3339)
+	}
+	if userPrincipalName := signIn.GetUserPrincipalName(); userPrincipalName != nil {
+		signInData["userPrincipalName"] = *userPrincipalName
+	}
+	if servicePrincipalName, ok := signIn.GetAdditionalData()["servicePrincipalName"].(*string); ok && servicePrincipalName != nil {
+		signInData["servicePrincipalName"] = *servicePrincipalName
+	}
+	if appDisplayName := signIn.GetAppDisplayName(); appDisplayName != nil {
+		signInData["appDisplayName"] = *appDisplayName
+	}
+	if resourceDisplayName := signIn.GetResourceDisplayName(); resourceDisplayName != nil {
+		signInData["resourceDisplayName"] = *resourceDisplayName
+	}
+	if clientAppUsed := signIn.GetClientAppUsed(); clientAppUsed != nil {
+		signInData["clientAppUsed"] = *clientAppUsed
+	}
+	if ipAddress := signIn.GetIpAddress(); ipAddress != nil {
+		signInData["ipAddress"] = *ipAddress
+	}
+	if conditionalAccessStatus := signIn.GetConditionalAccessStatus(); conditionalAccessStatus != nil {
+		signInData["conditionalAccessStatus"] = conditionalAccessStatus.String()
+	}
+	if status := signIn.GetStatus(); status != nil {
+		if errorCode := status.GetErrorCode(); errorCode != nil {
+			signInData["errorCode"] = *errorCode
+		}
+		if failureReason := status.GetFailureReason(); failureReason != nil {
+			signInData["failureReason"] = *failureReason
+		}
+		if additionalDetails := status.GetAdditionalDetails(); additionalDetails != nil {
+			signInData["additionalDetails"] = *additionalDetails
+		}
+	}
+
+	return signInId, signInData
+}
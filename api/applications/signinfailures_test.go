@@ -0,0 +1,122 @@
+package applications
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// failedSignIn returns a failed sign-in with the given id and error code.
+func failedSignIn(id string, errorCode int, extra map[string]interface{}) map[string]interface{} {
+
+	signIn := map[string]interface{}{
+		"id":              id,
+		"createdDateTime": "2024-01-01T00:00:00Z",
+		"status":          map[string]interface{}{"errorCode": errorCode, "failureReason": "failure " + id},
+	}
+	for name, value := range extra {
+		signIn[name] = value
+	}
+
+	return signIn
+}
+
+// signInRoutes answer two failed user sign-ins, and one failed service principal sign-in checking
+// that only those are asked to the beta endpoint.
+func signInRoutes(t *testing.T) graphtest.Routes {
+	return graphtest.Routes{
+		"GET /v1.0/auditLogs/signIns": map[string]interface{}{
+			"value": []interface{}{
+				failedSignIn("user-1", 50126, map[string]interface{}{"userPrincipalName": "adele@contoso.com"}),
+				failedSignIn("user-2", 50126, map[string]interface{}{"userPrincipalName": "alex@contoso.com"}),
+			},
+		},
+		"GET /beta/auditLogs/signIns": func(r *http.Request) interface{} {
+			if filter := r.URL.Query().Get("$filter"); !strings.Contains(filter, "signInEventTypes/any(t: t eq 'servicePrincipal')") {
+				t.Errorf("service principal sign-ins not filtered: %s", filter)
+			}
+			return map[string]interface{}{
+				"value": []interface{}{
+					failedSignIn("sp-1", 7000215, map[string]interface{}{"servicePrincipalName": "Payroll"}),
+				},
+			}
+		},
+	}
+}
+
+func TestSignInFailures(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		arguments map[string]interface{}
+		want      map[string]interface{}
+		truncated bool
+	}{
+		{
+			name:      "user sign-ins",
+			arguments: map[string]interface{}{"mode": "summary"},
+			want:      map[string]interface{}{"50126": float64(2)},
+		},
+		{
+			name:      "with service principals",
+			arguments: map[string]interface{}{"mode": "summary", "include_service_principals": true},
+			want:      map[string]interface{}{"50126": float64(2), "7000215": float64(1)},
+		},
+		{
+			name:      "limit reached",
+			arguments: map[string]interface{}{"mode": "summary", "limit": 1},
+			want:      map[string]interface{}{"50126": float64(1)},
+			truncated: true,
+		},
+		{
+			name:      "sign-ins",
+			arguments: map[string]interface{}{"mode": "signins", "include_service_principals": true},
+			want:      map[string]interface{}{"user-1": "user", "user-2": "user", "sp-1": "servicePrincipal"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			arguments := map[string]interface{}{"app_id": "10000000-0000-0000-0000-000000000002"}
+			for name, value := range test.arguments {
+				arguments[name] = value
+			}
+
+			result := graphtest.CallTool(t, "application_sign_in_failures", arguments, signInRoutes(t))
+			if result.IsError || len(result.Content) == 0 {
+				t.Fatalf("unexpected result: %+v", result)
+			}
+
+			text, _ := mcp.AsTextContent(result.Content[0])
+			var failuresData map[string]map[string]interface{}
+			if err := json.Unmarshal([]byte(text.Text), &failuresData); err != nil {
+				t.Fatalf("decoding result: %v", err)
+			}
+			got := map[string]interface{}{}
+			for id, failureData := range failuresData {
+				if test.arguments["mode"] == "summary" {
+					got[id] = failureData["count"]
+				} else {
+					got[id] = failureData["signInType"]
+				}
+			}
+			if len(got) != len(test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+			for id, want := range test.want {
+				if got[id] != want {
+					t.Errorf("got %v for %s, want %v", got[id], id, want)
+				}
+			}
+
+			truncated := len(result.Content) > 1 && strings.HasPrefix(result.Content[1].(mcp.TextContent).Text, "truncated:")
+			if truncated != test.truncated {
+				t.Errorf("got truncated %t, want %t: %+v", truncated, test.truncated, result.Content)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/auditlogs"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// consentActivities are the directory audit activities recording a consent grant.
+var consentActivities = []string{"Consent to application", "Add delegated permission grant"}
+
+// consentProperties maps the modified properties of a consent activity to the field
+// receiving their value.
+var consentProperties = map[string]string{
+	"ConsentContext.IsAdminConsent":  "isAdminConsent",
+	"ConsentContext.OnBehalfOfAll":   "onBehalfOfAll",
+	"ConsentAction.Permissions":      "permissions",
+	"DelegatedPermissionGrant.Scope": "scope",
+}
+
+func init() {
+	// Consent Audits Tool is a tool that interacts with microsoft for directory audit APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "consent_audits",
+			Tool: mcp.NewTool("consent_audits",
+				mcp.WithDescription(fmt.Sprintf("Investigate consent grants: the directory audit entries of the %s activities over the last days, most recent first, with who consented, to which application, when, and the permissions granted. Requires AuditLog.Read.All.", strings.Join(consentActivities, " and "))),
+				mcp.WithNumber("days",
+					mcp.Description(fmt.Sprintf("The number of days to look back, at most %d. Defaults to %d.", maxDays, defaultDays)),
+				),
+				mcp.WithNumber("limit",
+					mcp.Description(fmt.Sprintf("The maximum number of audit entries to return, at most %d. Defaults to %d.", maxLimit, defaultLimit)),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"AuditLog.Read.All"},
+			OutputSchema:   consentSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := consentsArgs{Days: defaultDays, Limit: defaultLimit}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetConsents(ctx, client, time.Now().UTC().AddDate(0, 0, -a.Days), a.Limit)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get consent audits"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// consentSchema describes the result of the consent_audits tool.
+var consentSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":                  schema.String(),
+	"activityDateTime":    schema.DateTime(),
+	"activityDisplayName": schema.String(),
+	"result":              schema.String(),
+	"resultReason":        schema.String(),
+	"initiatedBy":         schema.String(),
+	"initiatedByType":     schema.Enum("user", "app"),
+	"initiatedById":       schema.String(),
+	"appId":               schema.String(),
+	"appDisplayName":      schema.String(),
+	"isAdminConsent":      schema.String(),
+	"onBehalfOfAll":       schema.String(),
+	"permissions":         schema.String(),
+	"scope":               schema.String(),
+}))
+
+// GetConsents retrieves the limit most recent consent audit entries since the given time, with
+// the names of the consenting users and consented applications.
+func GetConsents(ctx context.Context, client *msgraphsdk.GraphServiceClient, since time.Time, limit int) ([]byte, error) {
+
+	audits := []models.DirectoryAuditable{}
+
+	// Each activity is fetched on its own, the directory audits do not support or in filters
+	for _, activity := range consentActivities {
+		filter := fmt.Sprintf("activityDisplayName eq %s and activityDateTime ge %s", odata.Quote(activity), since.Format(time.RFC3339))
+
+		result, err := client.AuditLogs().DirectoryAudits().Get(ctx, &auditlogs.DirectoryAuditsRequestBuilderGetRequestConfiguration{
+			QueryParameters: &auditlogs.DirectoryAuditsRequestBuilderGetQueryParameters{
+				Filter:  to.Ptr(filter),
+				Orderby: []string{"activityDateTime desc"},
+				Top:     to.Ptr(int32(limit)),
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error fetching directory audits: %v", err)
+		}
+
+		count := 0
+		err = paginate.Iterate(ctx, client, result, models.CreateDirectoryAuditCollectionResponseFromDiscriminatorValue, func(audit models.DirectoryAuditable) bool {
+			audits = append(audits, audit)
+			count++
+			return count < limit
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error iterating through directory audits: %v", err)
+		}
+	}
+
+	// Keep the most recent entries across the activities
+	sort.SliceStable(audits, func(i, j int) bool {
+		a, b := audits[i].GetActivityDateTime(), audits[j].GetActivityDateTime()
+		return a != nil && (b == nil || a.After(*b))
+	})
+	if len(audits) > limit {
+		audits = audits[:limit]
+	}
+
+	// Create a map to store the JSON-friendly data
+	consentsData := make(map[string]interface{})
+	for _, audit := range audits {
+		id, consentData := convertConsentToMap(audit)
+		consentsData[id] = consentData
+	}
+
+	if err := resolveConsentNames(ctx, client, consentsData); err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(consentsData, "", "  ")
+}
+
+// consentsArgs are the arguments of the consent_audits tool.
+type consentsArgs struct {
+	Days  int `json:"days"`
+	Limit int `json:"limit"`
+}
+
+// Validate checks the bounds of the window and of the number of entries.
+func (a *consentsArgs) Validate() error {
+
+	if a.Days <= 0 || a.Days > maxDays {
+		return fmt.Errorf("days must be between 1 and %d", maxDays)
+	}
+	if a.Limit <= 0 || a.Limit > maxLimit {
+		return fmt.Errorf("limit must be between 1 and %d", maxLimit)
+	}
+
+	return nil
+}
+
+// convertConsentToMap converts a consent audit entry to a map with who consented, to which
+// application, and the consent details found in its modified properties
+func convertConsentToMap(audit models.DirectoryAuditable) (string, map[string]interface{}) {
+
+	auditId := ""
+	consentData := make(map[string]interface{})
+
+	if id := audit.GetId(); id != nil {
+		auditId = *id
+		consentData["id"] = auditId
+	}
+	if activityDateTime := audit.GetActivityDateTime(); activityDateTime != nil {
+		consentData["activityDateTime"] = activityDateTime.Format(time.RFC3339)
+	}
+	if activityDisplayName := audit.GetActivityDisplayName(); activityDisplayName != nil {
+		consentData["activityDisplayName"] = *activityDisplayName
+	}
+	if result := audit.GetResult(); result != nil {
+		consentData["result"] = result.String()
+	}
+	if resultReason := audit.GetResultReason(); resultReason != nil && *resultReason != "" {
+		consentData["resultReason"] = *resultReason
+	}
+	addInitiator(consentData, audit.GetInitiatedBy())
+
+	for _, target := range audit.GetTargetResources() {
+		if target.GetTypeEscaped() == nil || *target.GetTypeEscaped() != "ServicePrincipal" {
+			continue
+		}
+		if id := target.GetId(); id != nil {
+			consentData["appId"] = *id
+		}
+		if displayName := target.GetDisplayName(); displayName != nil && *displayName != "" {
+			consentData["appDisplayName"] = *displayName
+		}
+		for _, property := range target.GetModifiedProperties() {
+			if property.GetDisplayName() == nil || property.GetNewValue() == nil {
+				continue
+			}
+			if field, ok := consentProperties[*property.GetDisplayName()]; ok {
+				// The values are JSON encoded strings
+				consentData[field] = strings.TrimSpace(strings.Trim(*property.GetNewValue(), `"`))
+			}
+		}
+		break
+	}
+
+	return auditId, consentData
+}
+
+// resolveConsentNames names the applications and initiators the audit entries only hold the id of.
+func resolveConsentNames(ctx context.Context, client *msgraphsdk.GraphServiceClient, consentsData map[string]interface{}) error {
+
+	// getByIds resolves at most maxLimit ids at once
+	names := map[string]string{}
+	for _, value := range consentsData {
+		if len(names) >= maxLimit-1 {
+			break
+		}
+		consentData := value.(map[string]interface{})
+		if appId, ok := consentData["appId"].(string); ok && consentData["appDisplayName"] == nil {
+			names[appId] = ""
+		}
+		if initiatedById, ok := consentData["initiatedById"].(string); ok && consentData["initiatedBy"] == initiatedById {
+			names[initiatedById] = ""
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	if err := output.LookupNames(ctx, client, names); err != nil {
+		return fmt.Errorf("error resolving names: %v", err)
+	}
+
+	for _, value := range consentsData {
+		consentData := value.(map[string]interface{})
+		if appId, ok := consentData["appId"].(string); ok && consentData["appDisplayName"] == nil && names[appId] != "" {
+			consentData["appDisplayName"] = names[appId]
+		}
+		if initiatedById, ok := consentData["initiatedById"].(string); ok && consentData["initiatedBy"] == initiatedById && names[initiatedById] != "" {
+			consentData["initiatedBy"] = names[initiatedById]
+		}
+	}
+
+	return nil
+}
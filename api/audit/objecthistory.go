@@ -0,0 +1,253 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/auditlogs"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+const (
+	// defaultDays is the window looked at when no days are given.
+	defaultDays = 7
+	// maxDays is the retention of the directory audit logs.
+	maxDays = 30
+	// defaultLimit is the number of audit entries returned when no limit is given.
+	defaultLimit = 100
+	// maxLimit is the largest number of audit entries returned in one call.
+	maxLimit = 1000
+)
+
+func init() {
+	// Object History Tool is a tool that interacts with microsoft for directory audit APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "object_audit_history",
+			Tool: mcp.NewTool("object_audit_history",
+				mcp.WithDescription("Show what happened to a directory object (user, group, application, ...): the directory audit entries targeting it over the last days, most recent first, with who initiated each change and the properties modified. Requires AuditLog.Read.All."),
+				mcp.WithString("object_id",
+					mcp.Required(),
+					mcp.Description("The object id of the user, group, application or service principal."),
+				),
+				mcp.WithNumber("days",
+					mcp.Description(fmt.Sprintf("The number of days to look back, at most %d. Defaults to %d.", maxDays, defaultDays)),
+				),
+				mcp.WithNumber("limit",
+					mcp.Description(fmt.Sprintf("The maximum number of audit entries to return, at most %d. Defaults to %d.", maxLimit, defaultLimit)),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"AuditLog.Read.All"},
+			OutputSchema:   historySchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := historyArgs{Days: defaultDays, Limit: defaultLimit}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetObjectHistory(ctx, client, a.ObjectId, time.Now().UTC().AddDate(0, 0, -a.Days), a.Limit)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get object audit history"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// historySchema describes the result of the object_audit_history tool.
+var historySchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":                  schema.String(),
+	"activityDateTime":    schema.DateTime(),
+	"activityDisplayName": schema.String(),
+	"category":            schema.String(),
+	"operationType":       schema.String(),
+	"loggedByService":     schema.String(),
+	"result":              schema.String(),
+	"resultReason":        schema.String(),
+	"initiatedBy":         schema.String(),
+	"initiatedByType":     schema.Enum("user", "app"),
+	"initiatedById":       schema.String(),
+	"modifiedProperties": schema.Array(schema.Object(map[string]schema.Schema{
+		"name":     schema.String(),
+		"oldValue": schema.String(),
+		"newValue": schema.String(),
+	})),
+}))
+
+// GetObjectHistory retrieves up to limit directory audit entries targeting the object since the given time.
+func GetObjectHistory(ctx context.Context, client *msgraphsdk.GraphServiceClient, objectId string, since time.Time, limit int) ([]byte, error) {
+
+	filter := fmt.Sprintf("targetResources/any(t:t/id eq %s) and activityDateTime ge %s", odata.Quote(objectId), since.Format(time.RFC3339))
+
+	result, err := client.AuditLogs().DirectoryAudits().Get(ctx, &auditlogs.DirectoryAuditsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &auditlogs.DirectoryAuditsRequestBuilderGetQueryParameters{
+			Filter:  to.Ptr(filter),
+			Orderby: []string{"activityDateTime desc"},
+			Top:     to.Ptr(int32(limit)),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching directory audits: %v", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	auditsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateDirectoryAuditCollectionResponseFromDiscriminatorValue, func(audit models.DirectoryAuditable) bool {
+		id, auditData := convertAuditToMap(audit, objectId)
+		auditsData[id] = auditData
+		return len(auditsData) < limit
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through directory audits: %v", err)
+	}
+
+	return json.MarshalIndent(auditsData, "", "  ")
+}
+
+// historyArgs are the arguments of the object_audit_history tool.
+type historyArgs struct {
+	ObjectId string `json:"object_id"`
+	Days     int    `json:"days"`
+	Limit    int    `json:"limit"`
+}
+
+// Validate checks that the object is given and the bounds of the window and of the number of
+// entries.
+func (a *historyArgs) Validate() error {
+
+	if a.ObjectId == "" {
+		return fmt.Errorf("object_id is required")
+	}
+	if a.Days <= 0 || a.Days > maxDays {
+		return fmt.Errorf("days must be between 1 and %d", maxDays)
+	}
+	if a.Limit <= 0 || a.Limit > maxLimit {
+		return fmt.Errorf("limit must be between 1 and %d", maxLimit)
+	}
+
+	return nil
+}
+
+// convertAuditToMap converts a directory audit entry to a map with its initiator and the
+// properties modified on the given object
+func convertAuditToMap(audit models.DirectoryAuditable, objectId string) (string, map[string]interface{}) {
+
+	auditId := ""
+	auditData := make(map[string]interface{})
+
+	if id := audit.GetId(); id != nil {
+		auditId = *id
+		auditData["id"] = auditId
+	}
+	if activityDateTime := audit.GetActivityDateTime(); activityDateTime != nil {
+		auditData["activityDateTime"] = activityDateTime.Format(time.RFC3339)
+	}
+	if activityDisplayName := audit.GetActivityDisplayName(); activityDisplayName != nil {
+		auditData["activityDisplayName"] = *activityDisplayName
+	}
+	if category := audit.GetCategory(); category != nil {
+		auditData["category"] = *category
+	}
+	if operationType := audit.GetOperationType(); operationType != nil {
+		auditData["operationType"] = *operationType
+	}
+	if loggedByService := audit.GetLoggedByService(); loggedByService != nil {
+		auditData["loggedByService"] = *loggedByService
+	}
+	if result := audit.GetResult(); result != nil {
+		auditData["result"] = result.String()
+	}
+	if resultReason := audit.GetResultReason(); resultReason != nil && *resultReason != "" {
+		auditData["resultReason"] = *resultReason
+	}
+	addInitiator(auditData, audit.GetInitiatedBy())
+
+	for _, target := range audit.GetTargetResources() {
+		if target.GetId() == nil || *target.GetId() != objectId {
+			continue
+		}
+		properties := []interface{}{}
+		for _, property := range target.GetModifiedProperties() {
+			propertyData := make(map[string]interface{})
+			if displayName := property.GetDisplayName(); displayName != nil {
+				propertyData["name"] = *displayName
+			}
+			if oldValue := property.GetOldValue(); oldValue != nil {
+				propertyData["oldValue"] = *oldValue
+			}
+			if newValue := property.GetNewValue(); newValue != nil {
+				propertyData["newValue"] = *newValue
+			}
+			properties = append(properties, propertyData)
+		}
+		auditData["modifiedProperties"] = properties
+		break
+	}
+
+	return auditId, auditData
+}
+
+// addInitiator adds the friendliest name available for the user or the application that
+// initiated the change, along with its id.
+func addInitiator(auditData map[string]interface{}, initiator models.AuditActivityInitiatorable) {
+
+	if initiator == nil {
+		return
+	}
+
+	if user := initiator.GetUser(); user != nil && user.GetId() != nil {
+		auditData["initiatedByType"] = "user"
+		auditData["initiatedById"] = *user.GetId()
+		switch {
+		case user.GetDisplayName() != nil && *user.GetDisplayName() != "":
+			auditData["initiatedBy"] = *user.GetDisplayName()
+		case user.GetUserPrincipalName() != nil && *user.GetUserPrincipalName() != "":
+			auditData["initiatedBy"] = *user.GetUserPrincipalName()
+		default:
+			auditData["initiatedBy"] = *user.GetId()
+		}
+		return
+	}
+
+	if app := initiator.GetApp(); app != nil {
+		auditData["initiatedByType"] = "app"
+		if servicePrincipalId := app.GetServicePrincipalId(); servicePrincipalId != nil {
+			auditData["initiatedById"] = *servicePrincipalId
+		}
+		switch {
+		case app.GetDisplayName() != nil && *app.GetDisplayName() != "":
+			auditData["initiatedBy"] = *app.GetDisplayName()
+		case app.GetServicePrincipalName() != nil && *app.GetServicePrincipalName() != "":
+			auditData["initiatedBy"] = *app.GetServicePrincipalName()
+		case app.GetAppId() != nil:
+			auditData["initiatedBy"] = *app.GetAppId()
+		}
+	}
+}
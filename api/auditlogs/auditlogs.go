@@ -0,0 +1,176 @@
+package auditlogs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/auditlogs"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+const (
+	// defaultSignInTop is the number of sign-ins returned when no top is given.
+	defaultSignInTop = 50
+	// maxSignInTop is the largest number of sign-ins returned in one call.
+	maxSignInTop = 1000
+)
+
+func init() {
+	// Sign-in Logs Tool is a tool that interacts with microsoft for sign-in audit APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "signin_logs",
+			Tool: mcp.NewTool("signin_logs",
+				mcp.WithDescription("List the most recent sign-ins of the tenant, or of a user, with the application, the IP address, the client used and whether the sign-in succeeded or why it failed. Requires AuditLog.Read.All."),
+				mcp.WithString("user_principal_name",
+					mcp.Description("Only return the sign-ins of the user with this user principal name."),
+				),
+				mcp.WithNumber("top",
+					mcp.Description(fmt.Sprintf("The number of sign-ins to return, most recent first, at most %d. Defaults to %d.", maxSignInTop, defaultSignInTop)),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"AuditLog.Read.All"},
+			OutputSchema:   signInSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := signInsArgs{Top: defaultSignInTop}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetSignIns(ctx, client, a.UserPrincipalName, a.Top)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get sign-in logs"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// signInSchema describes the result of the signin_logs tool.
+var signInSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":                schema.String(),
+	"createdDateTime":   schema.DateTime(),
+	"userPrincipalName": schema.String(),
+	"appDisplayName":    schema.String(),
+	"ipAddress":         schema.String(),
+	"clientAppUsed":     schema.String(),
+	"status": schema.Object(map[string]schema.Schema{
+		"errorCode":     schema.Integer(),
+		"failureReason": schema.String(),
+	}),
+}))
+
+// GetSignIns retrieves the top most recent sign-ins, only those of the user with the given user
+// principal name if it is not empty.
+func GetSignIns(ctx context.Context, client *msgraphsdk.GraphServiceClient, userPrincipalName string, top int) ([]byte, error) {
+
+	params := &auditlogs.SignInsRequestBuilderGetQueryParameters{
+		Orderby: []string{"createdDateTime desc"},
+		Top:     to.Ptr(int32(top)),
+	}
+	if userPrincipalName != "" {
+		params.Filter = to.Ptr(odata.Eq("userPrincipalName", userPrincipalName))
+	}
+
+	result, err := client.AuditLogs().SignIns().Get(ctx, &auditlogs.SignInsRequestBuilderGetRequestConfiguration{
+		QueryParameters: params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching sign-ins: %v", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	signInsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateSignInCollectionResponseFromDiscriminatorValue, func(signIn models.SignInable) bool {
+		id, signInData := convertSignInToMap(signIn)
+		signInsData[id] = signInData
+		return len(signInsData) < top
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through sign-ins: %v", err)
+	}
+
+	return json.MarshalIndent(signInsData, "", "  ")
+}
+
+// signInsArgs are the arguments of the signin_logs tool.
+type signInsArgs struct {
+	UserPrincipalName string `json:"user_principal_name"`
+	Top               int    `json:"top"`
+}
+
+// Validate checks the bounds of the number of sign-ins.
+func (a *signInsArgs) Validate() error {
+
+	if a.Top <= 0 || a.Top > maxSignInTop {
+		return fmt.Errorf("top must be between 1 and %d", maxSignInTop)
+	}
+
+	return nil
+}
+
+// convertSignInToMap converts a sign-in model to a map with its status
+func convertSignInToMap(signIn models.SignInable) (string, map[string]interface{}) {
+
+	signInId := ""
+	signInData := make(map[string]interface{})
+
+	if id := signIn.GetId(); id != nil {
+		signInId = *id
+		signInData["id"] = signInId
+	}
+	if createdDateTime := signIn.GetCreatedDateTime(); createdDateTime != nil {
+		signInData["createdDateTime"] = createdDateTime.Format(time.RFC3339)
+	}
+	if userPrincipalName := signIn.GetUserPrincipalName(); userPrincipalName != nil {
+		signInData["userPrincipalName"] = *userPrincipalName
+	}
+	if appDisplayName := signIn.GetAppDisplayName(); appDisplayName != nil {
+		signInData["appDisplayName"] = *appDisplayName
+	}
+	if ipAddress := signIn.GetIpAddress(); ipAddress != nil {
+		signInData["ipAddress"] = *ipAddress
+	}
+	if clientAppUsed := signIn.GetClientAppUsed(); clientAppUsed != nil {
+		signInData["clientAppUsed"] = *clientAppUsed
+	}
+	if status := signIn.GetStatus(); status != nil {
+		statusData := make(map[string]interface{})
+		if errorCode := status.GetErrorCode(); errorCode != nil {
+			statusData["errorCode"] = *errorCode
+		}
+		if failureReason := status.GetFailureReason(); failureReason != nil {
+			statusData["failureReason"] = *failureReason
+		}
+		signInData["status"] = statusData
+	}
+
+	return signInId, signInData
+}
@@ -0,0 +1,272 @@
+package consents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/serviceprincipals"
+	"github.com/spf13/viper"
+)
+
+// defaultRiskyPermissions is used when no risk list is configured.
+var defaultRiskyPermissions = []string{
+	"Mail.ReadWrite",
+	"Mail.Send",
+	"Files.ReadWrite.All",
+	"Sites.ReadWrite.All",
+	"Directory.ReadWrite.All",
+	"Application.ReadWrite.All",
+	"AppRoleAssignment.ReadWrite.All",
+	"RoleManagement.ReadWrite.Directory",
+	"User.ReadWrite.All",
+	"Group.ReadWrite.All",
+}
+
+func init() {
+	// Consent Review Tool is a tool that surfaces applications holding high-risk permissions.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "consent_review",
+			Tool: mcp.NewTool("consent_review",
+				mcp.WithDescription("Find service principals holding high-risk delegated (oauth2PermissionGrants) or application (appRoleAssignments) permissions. Requires Application.Read.All and DelegatedPermissionGrant.Read.All."),
+				mcp.WithString("risk_permissions",
+					mcp.Description("Comma separated list of permissions considered high-risk. If not provided, the configured 'risky-permissions' list is used."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Application.Read.All", "DelegatedPermissionGrant.Read.All"},
+			OutputSchema:   consentSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a consentsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				risky := viper.GetStringSlice("risky-permissions")
+				if a.RiskPermissions != "" {
+					risky = strings.Split(a.RiskPermissions, ",")
+				}
+				if len(risky) == 0 {
+					risky = defaultRiskyPermissions
+				}
+
+				jsonData, err := Get(ctx, client, risky)
+				if err != nil {
+					return mcp.NewToolResultError("failed to review consent grants"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// servicePrincipal holds what is needed from a service principal to review its grants.
+type servicePrincipal struct {
+	id          string
+	appId       string
+	displayName string
+	appRoles    map[string]string
+}
+
+// consentSchema describes the result of the consent_review tool.
+var consentSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":          schema.String(),
+	"appId":       schema.String(),
+	"displayName": schema.String(),
+	"permissions": schema.Array(schema.Object(map[string]schema.Schema{
+		"permission":          schema.String(),
+		"grantType":           schema.Enum("delegated", "application"),
+		"consentType":         schema.String(),
+		"principalId":         schema.String(),
+		"resourceId":          schema.String(),
+		"resourceDisplayName": schema.String(),
+	})),
+}))
+
+// Get cross-references service principals with their delegated and application
+// permission grants and returns the ones holding any of the risky permissions.
+func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, risky []string) ([]byte, error) {
+
+	riskySet := make(map[string]bool, len(risky))
+	for _, permission := range risky {
+		riskySet[strings.ToLower(strings.TrimSpace(permission))] = true
+	}
+
+	servicePrincipals, err := getServicePrincipals(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a map to store the JSON-friendly data
+	findingsData := make(map[string]interface{})
+
+	addFinding := func(sp *servicePrincipal, finding map[string]interface{}) {
+		entry, ok := findingsData[sp.id].(map[string]interface{})
+		if !ok {
+			entry = map[string]interface{}{
+				"id":          sp.id,
+				"appId":       sp.appId,
+				"displayName": sp.displayName,
+				"permissions": []interface{}{},
+			}
+			findingsData[sp.id] = entry
+		}
+		entry["permissions"] = append(entry["permissions"].([]interface{}), finding)
+	}
+
+	// Delegated permissions
+	grants, err := client.Oauth2PermissionGrants().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching oauth2 permission grants: %v", err)
+	}
+
+	err = paginate.Iterate(ctx, client, grants, models.CreateOAuth2PermissionGrantCollectionResponseFromDiscriminatorValue, func(grant models.OAuth2PermissionGrantable) bool {
+		if grant.GetClientId() == nil || grant.GetScope() == nil {
+			return true
+		}
+		sp, ok := servicePrincipals[*grant.GetClientId()]
+		if !ok {
+			return true
+		}
+		for _, scope := range strings.Fields(*grant.GetScope()) {
+			if !riskySet[strings.ToLower(scope)] {
+				continue
+			}
+			finding := map[string]interface{}{
+				"permission": scope,
+				"grantType":  "delegated",
+			}
+			if grant.GetConsentType() != nil {
+				finding["consentType"] = *grant.GetConsentType()
+			}
+			if grant.GetPrincipalId() != nil {
+				finding["principalId"] = *grant.GetPrincipalId()
+			}
+			if grant.GetResourceId() != nil {
+				finding["resourceId"] = *grant.GetResourceId()
+				if resource, ok := servicePrincipals[*grant.GetResourceId()]; ok {
+					finding["resourceDisplayName"] = resource.displayName
+				}
+			}
+			addFinding(sp, finding)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through oauth2 permission grants: %v", err)
+	}
+
+	// Application permissions
+	for _, sp := range servicePrincipals {
+		assignments, err := getAppRoleAssignments(ctx, client, sp.id)
+		if err != nil {
+			continue
+		}
+		for _, assignment := range assignments {
+			if assignment.GetResourceId() == nil || assignment.GetAppRoleId() == nil {
+				continue
+			}
+			resource, ok := servicePrincipals[assignment.GetResourceId().String()]
+			if !ok {
+				continue
+			}
+			permission, ok := resource.appRoles[assignment.GetAppRoleId().String()]
+			if !ok || !riskySet[strings.ToLower(permission)] {
+				continue
+			}
+			addFinding(sp, map[string]interface{}{
+				"permission":          permission,
+				"grantType":           "application",
+				"resourceId":          resource.id,
+				"resourceDisplayName": resource.displayName,
+			})
+		}
+	}
+
+	return json.MarshalIndent(findingsData, "", "  ")
+}
+
+// consentsArgs are the arguments of the consent_review tool.
+type consentsArgs struct {
+	RiskPermissions string `json:"risk_permissions"`
+}
+
+// getServicePrincipals returns all the service principals of the tenant keyed by id.
+func getServicePrincipals(ctx context.Context, client *msgraphsdk.GraphServiceClient) (map[string]*servicePrincipal, error) {
+
+	result, err := client.ServicePrincipals().Get(ctx, &serviceprincipals.ServicePrincipalsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &serviceprincipals.ServicePrincipalsRequestBuilderGetQueryParameters{
+			Select: []string{"id", "appId", "displayName", "appRoles"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching service principals: %v", err)
+	}
+
+	servicePrincipals := map[string]*servicePrincipal{}
+	err = paginate.Iterate(ctx, client, result, models.CreateServicePrincipalCollectionResponseFromDiscriminatorValue, func(item models.ServicePrincipalable) bool {
+		if item.GetId() == nil {
+			return true
+		}
+		sp := &servicePrincipal{
+			id:       *item.GetId(),
+			appRoles: map[string]string{},
+		}
+		if item.GetAppId() != nil {
+			sp.appId = *item.GetAppId()
+		}
+		if item.GetDisplayName() != nil {
+			sp.displayName = *item.GetDisplayName()
+		}
+		for _, role := range item.GetAppRoles() {
+			if role.GetId() != nil && role.GetValue() != nil {
+				sp.appRoles[role.GetId().String()] = *role.GetValue()
+			}
+		}
+		servicePrincipals[sp.id] = sp
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through service principals: %v", err)
+	}
+
+	return servicePrincipals, nil
+}
+
+// getAppRoleAssignments returns all the application permissions granted to a service principal.
+func getAppRoleAssignments(ctx context.Context, client *msgraphsdk.GraphServiceClient, id string) ([]models.AppRoleAssignmentable, error) {
+
+	result, err := client.ServicePrincipals().ByServicePrincipalId(id).AppRoleAssignments().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching app role assignments: %v", err)
+	}
+
+	var assignments []models.AppRoleAssignmentable
+	err = paginate.Iterate(ctx, client, result, models.CreateAppRoleAssignmentCollectionResponseFromDiscriminatorValue, func(assignment models.AppRoleAssignmentable) bool {
+		assignments = append(assignments, assignment)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through app role assignments: %v", err)
+	}
+
+	return assignments, nil
+}
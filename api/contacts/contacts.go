@@ -0,0 +1,179 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+)
+
+// contactFields are the attributes of a contact read by the contacts tool.
+var contactFields = []string{"id", "displayName", "givenName", "surname", "emailAddresses", "businessPhones", "mobilePhone", "companyName", "jobTitle"}
+
+func init() {
+	// Contacts Tool is a tool that interacts with microsoft for personal contact APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "contacts",
+			Tool: mcp.NewTool("contacts",
+				mcp.WithDescription("List the personal contacts of the address book of a user with their email addresses, phones, company and job title. Requires Contacts.Read."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The id or user principal name of the user."),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Contacts.Read"},
+			OutputSchema:   contactSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a contactsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := Get(ctx, client, a.UserId)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the user '%s' does not exist", a.UserId)), nil
+					}
+					return mcp.NewToolResultError("failed to get contacts"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// contactSchema describes the result of the contacts tool.
+var contactSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":          schema.String(),
+	"displayName": schema.String(),
+	"givenName":   schema.String(),
+	"surname":     schema.String(),
+	"emailAddresses": schema.Array(schema.Object(map[string]schema.Schema{
+		"name":    schema.String(),
+		"address": schema.String(),
+	})),
+	"businessPhones": schema.Array(schema.String()),
+	"mobilePhone":    schema.String(),
+	"companyName":    schema.String(),
+	"jobTitle":       schema.String(),
+}))
+
+// Get retrieves the personal contacts of the user.
+func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string) ([]byte, error) {
+
+	result, err := client.Users().ByUserId(userId).Contacts().Get(ctx, &users.ItemContactsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemContactsRequestBuilderGetQueryParameters{
+			Select: contactFields,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching contacts: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	contactsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateContactCollectionResponseFromDiscriminatorValue, func(contact models.Contactable) bool {
+		id, contactData := convertContactToMap(contact)
+		contactsData[id] = contactData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through contacts: %v", err)
+	}
+
+	return json.MarshalIndent(contactsData, "", "  ")
+}
+
+// contactsArgs are the arguments of the contacts tool.
+type contactsArgs struct {
+	UserId string `json:"user_id"`
+}
+
+// Validate checks that the user is given.
+func (a *contactsArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+
+	return nil
+}
+
+// convertContactToMap converts a contact model to a map of its names, addresses and phones.
+// Contacts without email addresses are returned with an empty list
+func convertContactToMap(contact models.Contactable) (string, map[string]interface{}) {
+
+	contactId := ""
+	contactData := make(map[string]interface{})
+
+	if id := contact.GetId(); id != nil {
+		contactId = *id
+		contactData["id"] = contactId
+	}
+	if displayName := contact.GetDisplayName(); displayName != nil {
+		contactData["displayName"] = *displayName
+	}
+	if givenName := contact.GetGivenName(); givenName != nil {
+		contactData["givenName"] = *givenName
+	}
+	if surname := contact.GetSurname(); surname != nil {
+		contactData["surname"] = *surname
+	}
+
+	emailAddresses := []interface{}{}
+	for _, emailAddress := range contact.GetEmailAddresses() {
+		if emailAddress.GetAddress() == nil {
+			continue
+		}
+		emailData := map[string]interface{}{
+			"address": *emailAddress.GetAddress(),
+		}
+		if name := emailAddress.GetName(); name != nil {
+			emailData["name"] = *name
+		}
+		emailAddresses = append(emailAddresses, emailData)
+	}
+	contactData["emailAddresses"] = emailAddresses
+
+	if businessPhones := contact.GetBusinessPhones(); businessPhones != nil {
+		contactData["businessPhones"] = businessPhones
+	}
+	if mobilePhone := contact.GetMobilePhone(); mobilePhone != nil {
+		contactData["mobilePhone"] = *mobilePhone
+	}
+	if companyName := contact.GetCompanyName(); companyName != nil {
+		contactData["companyName"] = *companyName
+	}
+	if jobTitle := contact.GetJobTitle(); jobTitle != nil {
+		contactData["jobTitle"] = *jobTitle
+	}
+
+	return contactId, contactData
+}
@@ -0,0 +1,215 @@
+package devicemanagement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/devicemanagement"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Intune Policies Tool is a tool that interacts with microsoft for Intune device management APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "intune_policies",
+			Tool: mcp.NewTool("intune_policies",
+				mcp.WithDescription("List the Intune device compliance policies and device configuration profiles with their description and number of assignments. Requires DeviceManagementConfiguration.Read.All."),
+				mcp.WithString("kind",
+					mcp.Enum("compliance", "configuration"),
+					mcp.DefaultString("compliance"),
+					mcp.Description("'compliance' returns the device compliance policies, 'configuration' the device configuration profiles."),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"DeviceManagementConfiguration.Read.All"},
+			OutputSchema:   policySchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := intuneArgs{Kind: "compliance"}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				var jsonData []byte
+				var err error
+				switch a.Kind {
+				case "compliance":
+					jsonData, err = GetCompliancePolicies(ctx, client)
+				case "configuration":
+					jsonData, err = GetConfigurations(ctx, client)
+				default:
+					return mcp.NewToolResultError(fmt.Sprintf("unsupported kind '%s'", a.Kind)), nil
+				}
+				if err != nil {
+					if isIntuneUnavailable(err) {
+						return mcp.NewToolResultError(fmt.Sprintf("Intune is not available for this tenant: %s", odata.ErrorMessage(err))), nil
+					}
+					if odata.StatusCode(err) == http.StatusForbidden {
+						return mcp.NewToolResultError(fmt.Sprintf("permission denied: %s Listing Intune policies requires DeviceManagementConfiguration.Read.All.", odata.ErrorMessage(err))), nil
+					}
+					return mcp.NewToolResultError("failed to get Intune policies"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// policy is what device compliance policies and device configurations have in common.
+type policy interface {
+	GetId() *string
+	GetOdataType() *string
+	GetDisplayName() *string
+	GetDescription() *string
+	GetVersion() *int32
+	GetCreatedDateTime() *time.Time
+	GetLastModifiedDateTime() *time.Time
+}
+
+// GetCompliancePolicies retrieves the device compliance policies with their assignments.
+func GetCompliancePolicies(ctx context.Context, client *msgraphsdk.GraphServiceClient) ([]byte, error) {
+
+	result, err := client.DeviceManagement().DeviceCompliancePolicies().Get(ctx, &devicemanagement.DeviceCompliancePoliciesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &devicemanagement.DeviceCompliancePoliciesRequestBuilderGetQueryParameters{
+			Expand: []string{"assignments"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching device compliance policies: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	policiesData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateDeviceCompliancePolicyCollectionResponseFromDiscriminatorValue, func(item models.DeviceCompliancePolicyable) bool {
+		id, policyData := convertPolicyToMap(item, len(item.GetAssignments()))
+		policiesData[id] = policyData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through device compliance policies: %w", err)
+	}
+
+	return json.MarshalIndent(policiesData, "", "  ")
+}
+
+// GetConfigurations retrieves the device configuration profiles with their assignments.
+func GetConfigurations(ctx context.Context, client *msgraphsdk.GraphServiceClient) ([]byte, error) {
+
+	result, err := client.DeviceManagement().DeviceConfigurations().Get(ctx, &devicemanagement.DeviceConfigurationsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &devicemanagement.DeviceConfigurationsRequestBuilderGetQueryParameters{
+			Expand: []string{"assignments"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching device configurations: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	policiesData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateDeviceConfigurationCollectionResponseFromDiscriminatorValue, func(item models.DeviceConfigurationable) bool {
+		id, policyData := convertPolicyToMap(item, len(item.GetAssignments()))
+		policiesData[id] = policyData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through device configurations: %w", err)
+	}
+
+	return json.MarshalIndent(policiesData, "", "  ")
+}
+
+// intuneUnavailableMessage is the message of the error Graph answers device management requests
+// with when the tenant has no Intune license or service.
+const intuneUnavailableMessage = "request not applicable to target tenant"
+
+// isIntuneUnavailable reports whether the error is Graph refusing device management requests
+// because the tenant has no Intune license or service. Other errors, such as a missing
+// permission, are not.
+func isIntuneUnavailable(err error) bool {
+
+	switch odata.StatusCode(err) {
+	case http.StatusBadRequest, http.StatusForbidden:
+		return strings.Contains(strings.ToLower(odata.ErrorMessage(err)), intuneUnavailableMessage)
+	default:
+		return false
+	}
+}
+
+// policySchema describes the result of the intune_policies tool.
+var policySchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":                   schema.String(),
+	"type":                 schema.String(),
+	"displayName":          schema.String(),
+	"description":          schema.String(),
+	"version":              schema.Integer(),
+	"createdDateTime":      schema.DateTime(),
+	"lastModifiedDateTime": schema.DateTime(),
+	"assignmentCount":      schema.Integer(),
+}))
+
+// convertPolicyToMap converts a compliance policy or a configuration profile to a map of attributes
+func convertPolicyToMap(item policy, assignments int) (string, map[string]interface{}) {
+
+	policyId := ""
+	policyData := map[string]interface{}{
+		"assignmentCount": assignments,
+	}
+
+	if id := item.GetId(); id != nil {
+		policyId = *id
+		policyData["id"] = policyId
+	}
+	if odataType := item.GetOdataType(); odataType != nil {
+		// e.g. #microsoft.graph.windows10CompliancePolicy
+		policyData["type"] = strings.TrimPrefix(*odataType, "#microsoft.graph.")
+	}
+	if displayName := item.GetDisplayName(); displayName != nil {
+		policyData["displayName"] = *displayName
+	}
+	if description := item.GetDescription(); description != nil {
+		policyData["description"] = *description
+	}
+	if version := item.GetVersion(); version != nil {
+		policyData["version"] = *version
+	}
+	if createdDateTime := item.GetCreatedDateTime(); createdDateTime != nil {
+		policyData["createdDateTime"] = createdDateTime.Format(time.RFC3339)
+	}
+	if lastModifiedDateTime := item.GetLastModifiedDateTime(); lastModifiedDateTime != nil {
+		policyData["lastModifiedDateTime"] = lastModifiedDateTime.Format(time.RFC3339)
+	}
+
+	return policyId, policyData
+}
+
+// intuneArgs are the arguments of the intune_policies tool.
+type intuneArgs struct {
+	Kind string `json:"kind"`
+}
@@ -0,0 +1,44 @@
+package devicemanagement
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestIntuneErrors(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		error graphtest.Error
+		want  string
+	}{
+		{
+			name:  "unlicensed tenant",
+			error: graphtest.Error{Status: http.StatusBadRequest, Code: "BadRequest", Message: "Request not applicable to target tenant."},
+			want:  "Intune is not available for this tenant",
+		},
+		{
+			name:  "missing permission",
+			error: graphtest.Error{Status: http.StatusForbidden, Code: "Forbidden", Message: "Application is not authorized to perform this operation."},
+			want:  "permission denied",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := graphtest.CallTool(t, "intune_policies", nil, graphtest.Routes{
+				"GET /v1.0/deviceManagement/deviceCompliancePolicies": test.error,
+			})
+			if !result.IsError || len(result.Content) == 0 {
+				t.Fatalf("expected an error, got %+v", result)
+			}
+			if text, _ := mcp.AsTextContent(result.Content[0]); !strings.HasPrefix(text.Text, test.want) {
+				t.Errorf("got %s, want it to start with %s", text.Text, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package devices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/devices"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// deviceFields are the attributes of a device returned by the devices tool.
+var deviceFields = []string{"id", "displayName", "operatingSystem", "operatingSystemVersion", "deviceId", "isCompliant", "isManaged", "accountEnabled", "trustType", "approximateLastSignInDateTime"}
+
+func init() {
+	// Devices Tool is a tool that interacts with microsoft for directory device APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "devices",
+			Tool: mcp.NewTool("devices",
+				mcp.WithDescription("List the devices registered or joined to the directory, or find them by name, with their operating system, compliance, management state and last sign-in. Requires Device.Read.All."),
+				mcp.WithString("name",
+					mcp.Description("The display name of the device. If not provided, all devices will be returned."),
+				),
+				odata.WithMatchMode(),
+				paginate.WithLimit(),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Device.Read.All"},
+			OutputSchema:   deviceSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a devicesArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				params := &devices.DevicesRequestBuilderGetQueryParameters{}
+				if a.Name != "" {
+					filter, search, err := odata.Match("displayName", a.Name, a.MatchMode)
+					if err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+					params.Filter, params.Search = filter, search
+				}
+				limit, capped, err := paginate.Limit(request, a.Limit, params.Filter != nil || params.Search != nil)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				// Get the list of devices
+				jsonData, err := Get(ctx, client, params, limit)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get devices"), err
+				}
+				if capped {
+					return paginate.CappedNote(mcp.NewToolResultText(string(jsonData)), limit), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// deviceSchema describes the result of the devices tool.
+var deviceSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":                            schema.String(),
+	"displayName":                   schema.String(),
+	"operatingSystem":               schema.String(),
+	"operatingSystemVersion":        schema.String(),
+	"deviceId":                      schema.String(),
+	"isCompliant":                   schema.Boolean(),
+	"isManaged":                     schema.Boolean(),
+	"accountEnabled":                schema.Boolean(),
+	"trustType":                     schema.String(),
+	"approximateLastSignInDateTime": schema.DateTime(),
+}))
+
+// Get retrieves the devices of the directory, or the first limit ones if it is not zero.
+func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *devices.DevicesRequestBuilderGetQueryParameters, limit int) ([]byte, error) {
+
+	if params == nil {
+		params = &devices.DevicesRequestBuilderGetQueryParameters{}
+	}
+	if len(params.Select) == 0 {
+		params.Select = deviceFields
+	}
+
+	requestConfig := &devices.DevicesRequestBuilderGetRequestConfiguration{
+		QueryParameters: params,
+	}
+	// $search is an advanced query, it requires the ConsistencyLevel header
+	if params.Search != nil {
+		requestConfig.Headers = abstractions.NewRequestHeaders()
+		requestConfig.Headers.Add("ConsistencyLevel", "eventual")
+	}
+
+	result, err := client.Devices().Get(ctx, requestConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching devices: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	devicesData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateDeviceCollectionResponseFromDiscriminatorValue, func(device models.Deviceable) bool {
+		id, deviceData := convertDeviceToMap(device)
+		devicesData[id] = deviceData
+		return limit == 0 || len(devicesData) < limit
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through devices: %v", err)
+	}
+
+	return json.MarshalIndent(devicesData, "", "  ")
+}
+
+// devicesArgs are the arguments of the devices tool.
+type devicesArgs struct {
+	Name      string `json:"name"`
+	MatchMode string `json:"matchMode"`
+	Limit     int    `json:"limit"`
+}
+
+// convertDeviceToMap converts a device model to a map with its operating system and management state
+func convertDeviceToMap(device models.Deviceable) (string, map[string]interface{}) {
+
+	deviceId := ""
+	deviceData := make(map[string]interface{})
+
+	if id := device.GetId(); id != nil {
+		deviceId = *id
+		deviceData["id"] = deviceId
+	}
+	if displayName := device.GetDisplayName(); displayName != nil {
+		deviceData["displayName"] = *displayName
+	}
+	if operatingSystem := device.GetOperatingSystem(); operatingSystem != nil {
+		deviceData["operatingSystem"] = *operatingSystem
+	}
+	if operatingSystemVersion := device.GetOperatingSystemVersion(); operatingSystemVersion != nil {
+		deviceData["operatingSystemVersion"] = *operatingSystemVersion
+	}
+	if id := device.GetDeviceId(); id != nil {
+		deviceData["deviceId"] = *id
+	}
+	if isCompliant := device.GetIsCompliant(); isCompliant != nil {
+		deviceData["isCompliant"] = *isCompliant
+	}
+	if isManaged := device.GetIsManaged(); isManaged != nil {
+		deviceData["isManaged"] = *isManaged
+	}
+	if accountEnabled := device.GetAccountEnabled(); accountEnabled != nil {
+		deviceData["accountEnabled"] = *accountEnabled
+	}
+	if trustType := device.GetTrustType(); trustType != nil {
+		deviceData["trustType"] = *trustType
+	}
+	if lastSignIn := device.GetApproximateLastSignInDateTime(); lastSignIn != nil {
+		deviceData["approximateLastSignInDateTime"] = lastSignIn.Format(time.RFC3339)
+	}
+
+	return deviceId, deviceData
+}
@@ -0,0 +1,200 @@
+package directoryroles
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Directory Roles Tool is a tool that interacts with microsoft for directory role APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "directory_roles",
+			Tool: mcp.NewTool("directory_roles",
+				mcp.WithDescription("List the activated directory roles of the tenant, or the members of one of them, to audit privileged access. Only roles with at least one member are activated. Requires RoleManagement.Read.Directory."),
+				mcp.WithString("role_id",
+					mcp.Description("The id of the directory role whose members are returned. If not provided, the roles are returned."),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"RoleManagement.Read.Directory"},
+			OutputSchema:   directoryRolesSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a directoryRolesArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				if a.RoleId != "" {
+					jsonData, err := GetDirectoryRoleMembers(ctx, client, a.RoleId)
+					if err != nil {
+						if odata.StatusCode(err) == http.StatusNotFound {
+							return mcp.NewToolResultError(fmt.Sprintf("the directory role '%s' does not exist or is not activated", a.RoleId)), nil
+						}
+						return mcp.NewToolResultError("failed to get directory role members"), err
+					}
+					return mcp.NewToolResultText(string(jsonData)), nil
+				}
+
+				jsonData, err := GetDirectoryRoles(ctx, client)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get directory roles"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// directoryRolesSchema describes the result of the directory_roles tool: the roles, or the members of a role.
+var directoryRolesSchema = schema.OneOf(
+	schema.Map(schema.Object(map[string]schema.Schema{
+		"id":             schema.String(),
+		"displayName":    schema.String(),
+		"description":    schema.String(),
+		"roleTemplateId": schema.String(),
+	})),
+	schema.Map(schema.Object(map[string]schema.Schema{
+		"id":                schema.String(),
+		"displayName":       schema.String(),
+		"type":              schema.Enum("user", "group", "servicePrincipal", "directoryObject"),
+		"userPrincipalName": schema.String(),
+	})),
+)
+
+// directoryRolesArgs are the arguments of the directory_roles tool.
+type directoryRolesArgs struct {
+	RoleId string `json:"role_id"`
+}
+
+// GetDirectoryRoles retrieves the activated directory roles of the tenant.
+func GetDirectoryRoles(ctx context.Context, client *msgraphsdk.GraphServiceClient) ([]byte, error) {
+
+	result, err := client.DirectoryRoles().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching directory roles: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	rolesData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateDirectoryRoleCollectionResponseFromDiscriminatorValue, func(role models.DirectoryRoleable) bool {
+		id, roleData := convertDirectoryRoleToMap(role)
+		rolesData[id] = roleData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through directory roles: %v", err)
+	}
+
+	return json.MarshalIndent(rolesData, "", "  ")
+}
+
+// GetDirectoryRoleMembers retrieves the members of an activated directory role.
+func GetDirectoryRoleMembers(ctx context.Context, client *msgraphsdk.GraphServiceClient, roleId string) ([]byte, error) {
+
+	result, err := client.DirectoryRoles().ByDirectoryRoleId(roleId).Members().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching directory role members: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	membersData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateDirectoryObjectCollectionResponseFromDiscriminatorValue, func(member models.DirectoryObjectable) bool {
+		id, memberData := convertRoleMemberToMap(member)
+		membersData[id] = memberData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through directory role members: %v", err)
+	}
+
+	return json.MarshalIndent(membersData, "", "  ")
+}
+
+// convertDirectoryRoleToMap converts a directory role model to a map of its attributes
+func convertDirectoryRoleToMap(role models.DirectoryRoleable) (string, map[string]interface{}) {
+
+	roleId := ""
+	roleData := make(map[string]interface{})
+
+	if id := role.GetId(); id != nil {
+		roleId = *id
+		roleData["id"] = roleId
+	}
+	if displayName := role.GetDisplayName(); displayName != nil {
+		roleData["displayName"] = *displayName
+	}
+	if description := role.GetDescription(); description != nil {
+		roleData["description"] = *description
+	}
+	if roleTemplateId := role.GetRoleTemplateId(); roleTemplateId != nil {
+		roleData["roleTemplateId"] = *roleTemplateId
+	}
+
+	return roleId, roleData
+}
+
+// convertRoleMemberToMap converts a member of a directory role, a user, a group or a service
+// principal, to a map with its type and names
+func convertRoleMemberToMap(member models.DirectoryObjectable) (string, map[string]interface{}) {
+
+	memberId := ""
+	memberData := make(map[string]interface{})
+
+	if id := member.GetId(); id != nil {
+		memberId = *id
+		memberData["id"] = memberId
+	}
+
+	switch m := member.(type) {
+	case models.Userable:
+		memberData["type"] = "user"
+		if displayName := m.GetDisplayName(); displayName != nil {
+			memberData["displayName"] = *displayName
+		}
+		if userPrincipalName := m.GetUserPrincipalName(); userPrincipalName != nil {
+			memberData["userPrincipalName"] = *userPrincipalName
+		}
+	case models.Groupable:
+		memberData["type"] = "group"
+		if displayName := m.GetDisplayName(); displayName != nil {
+			memberData["displayName"] = *displayName
+		}
+	case models.ServicePrincipalable:
+		memberData["type"] = "servicePrincipal"
+		if displayName := m.GetDisplayName(); displayName != nil {
+			memberData["displayName"] = *displayName
+		}
+	default:
+		memberData["type"] = "directoryObject"
+	}
+
+	return memberId, memberData
+}
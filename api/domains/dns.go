@@ -0,0 +1,226 @@
+package domains
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Domain DNS Records Tool is a tool that interacts with microsoft for domain APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "domain_dns_records",
+			Tool: mcp.NewTool("domain_dns_records",
+				mcp.WithDescription("Read the DNS records of a domain registered in the tenant: the records to create to verify the domain and the ones to configure its services (Exchange, Intune, ...). For a domain already verified only the service records are returned. Requires Domain.Read.All."),
+				mcp.WithString("domain_id",
+					mcp.Required(),
+					mcp.Description("The fully qualified name of the domain, e.g. contoso.com."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Domain.Read.All"},
+			OutputSchema:   dnsSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a dnsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetDnsRecords(ctx, client, a.DomainId)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the domain '%s' is not registered in the tenant", a.DomainId)), nil
+					}
+					return mcp.NewToolResultError("failed to get domain DNS records"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// recordSchema describes a DNS record, the value attributes depend on its type.
+var recordSchema = schema.Object(map[string]schema.Schema{
+	"id":               schema.String(),
+	"recordType":       schema.String(),
+	"label":            schema.String(),
+	"ttl":              schema.Integer(),
+	"isOptional":       schema.Boolean(),
+	"supportedService": schema.String(),
+	"text":             schema.String(),
+	"mailExchange":     schema.String(),
+	"preference":       schema.Integer(),
+	"canonicalName":    schema.String(),
+	"nameTarget":       schema.String(),
+	"port":             schema.Integer(),
+	"priority":         schema.Integer(),
+	"protocol":         schema.String(),
+	"service":          schema.String(),
+	"weight":           schema.Integer(),
+})
+
+// dnsSchema describes the result of the domain_dns_records tool.
+var dnsSchema = schema.Object(map[string]schema.Schema{
+	"id":                          schema.String(),
+	"isVerified":                  schema.Boolean(),
+	"verificationDnsRecords":      schema.Map(recordSchema),
+	"serviceConfigurationRecords": schema.Map(recordSchema),
+	"message":                     schema.String(),
+})
+
+// dnsArgs are the arguments of the domain_dns_records tool.
+type dnsArgs struct {
+	DomainId string `json:"domain_id"`
+}
+
+// Validate checks that the domain is given.
+func (a *dnsArgs) Validate() error {
+
+	if a.DomainId == "" {
+		return fmt.Errorf("domain_id is required")
+	}
+
+	return nil
+}
+
+// GetDnsRecords retrieves the verification records of a domain, unless it is already verified,
+// and its service configuration records.
+func GetDnsRecords(ctx context.Context, client *msgraphsdk.GraphServiceClient, domainId string) ([]byte, error) {
+
+	domain, err := client.Domains().ByDomainId(domainId).Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching domain: %w", err)
+	}
+
+	verified := domain.GetIsVerified() != nil && *domain.GetIsVerified()
+	dnsData := map[string]interface{}{
+		"id":         domainId,
+		"isVerified": verified,
+	}
+
+	if verified {
+		dnsData["message"] = "The domain is already verified, only the service configuration records are returned."
+	} else {
+		verification, err := client.Domains().ByDomainId(domainId).VerificationDnsRecords().Get(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching verification DNS records: %w", err)
+		}
+		recordsData, err := collectRecords(ctx, client, verification)
+		if err != nil {
+			return nil, fmt.Errorf("error iterating through verification DNS records: %v", err)
+		}
+		dnsData["verificationDnsRecords"] = recordsData
+	}
+
+	service, err := client.Domains().ByDomainId(domainId).ServiceConfigurationRecords().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching service configuration records: %w", err)
+	}
+	recordsData, err := collectRecords(ctx, client, service)
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through service configuration records: %v", err)
+	}
+	dnsData["serviceConfigurationRecords"] = recordsData
+
+	return json.MarshalIndent(dnsData, "", "  ")
+}
+
+// collectRecords converts every page of DNS records to maps keyed by id.
+func collectRecords(ctx context.Context, client *msgraphsdk.GraphServiceClient, result models.DomainDnsRecordCollectionResponseable) (map[string]interface{}, error) {
+
+	recordsData := make(map[string]interface{})
+
+	err := paginate.Iterate(ctx, client, result, models.CreateDomainDnsRecordCollectionResponseFromDiscriminatorValue, func(record models.DomainDnsRecordable) bool {
+		id, recordData := convertRecordToMap(record)
+		recordsData[id] = recordData
+		return true
+	})
+
+	return recordsData, err
+}
+
+// convertRecordToMap converts a DNS record to a map with the value matching its type
+func convertRecordToMap(record models.DomainDnsRecordable) (string, map[string]interface{}) {
+
+	recordId := ""
+	recordData := make(map[string]interface{})
+
+	if id := record.GetId(); id != nil {
+		recordId = *id
+		recordData["id"] = recordId
+	}
+	if recordType := record.GetRecordType(); recordType != nil {
+		recordData["recordType"] = *recordType
+	}
+	if label := record.GetLabel(); label != nil {
+		recordData["label"] = *label
+	}
+	if ttl := record.GetTtl(); ttl != nil {
+		recordData["ttl"] = *ttl
+	}
+	if isOptional := record.GetIsOptional(); isOptional != nil {
+		recordData["isOptional"] = *isOptional
+	}
+	if supportedService := record.GetSupportedService(); supportedService != nil {
+		recordData["supportedService"] = *supportedService
+	}
+
+	switch r := record.(type) {
+	case models.DomainDnsTxtRecordable:
+		if text := r.GetText(); text != nil {
+			recordData["text"] = *text
+		}
+	case models.DomainDnsMxRecordable:
+		if mailExchange := r.GetMailExchange(); mailExchange != nil {
+			recordData["mailExchange"] = *mailExchange
+		}
+		if preference := r.GetPreference(); preference != nil {
+			recordData["preference"] = *preference
+		}
+	case models.DomainDnsCnameRecordable:
+		if canonicalName := r.GetCanonicalName(); canonicalName != nil {
+			recordData["canonicalName"] = *canonicalName
+		}
+	case models.DomainDnsSrvRecordable:
+		if nameTarget := r.GetNameTarget(); nameTarget != nil {
+			recordData["nameTarget"] = *nameTarget
+		}
+		if port := r.GetPort(); port != nil {
+			recordData["port"] = *port
+		}
+		if priority := r.GetPriority(); priority != nil {
+			recordData["priority"] = *priority
+		}
+		if protocol := r.GetProtocol(); protocol != nil {
+			recordData["protocol"] = *protocol
+		}
+		if service := r.GetService(); service != nil {
+			recordData["service"] = *service
+		}
+		if weight := r.GetWeight(); weight != nil {
+			recordData["weight"] = *weight
+		}
+	}
+
+	return recordId, recordData
+}
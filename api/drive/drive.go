@@ -0,0 +1,193 @@
+package drive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/drives"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+)
+
+// driveItemFields are the attributes of a drive item read by the drive_items tool.
+var driveItemFields = []string{"id", "name", "size", "webUrl", "lastModifiedDateTime", "folder", "file"}
+
+func init() {
+	// Drive Items Tool is a tool that interacts with microsoft for OneDrive APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "drive_items",
+			Tool: mcp.NewTool("drive_items",
+				mcp.WithDescription("Browse the OneDrive of a user: list the files and folders of a folder with their size, url and last modification. An empty folder gives an empty result. Requires Files.Read.All."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The id or user principal name of the user."),
+				),
+				mcp.WithString("path",
+					mcp.Description("The path of the folder from the root of the drive, like 'Documents/Reports'. Defaults to the root folder."),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Files.Read.All"},
+			OutputSchema:   DriveItemSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a itemsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetItems(ctx, client, a.UserId, a.Path)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the user '%s' has no OneDrive or the folder '%s' does not exist", a.UserId, a.Path)), nil
+					}
+					return mcp.NewToolResultError("failed to get drive items"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// DriveItemSchema describes the files and folders of a drive, as returned by GetChildren.
+var DriveItemSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":                   schema.String(),
+	"name":                 schema.String(),
+	"type":                 schema.Enum("folder", "file"),
+	"size":                 schema.Integer(),
+	"webUrl":               schema.String(),
+	"lastModifiedDateTime": schema.DateTime(),
+	"childCount":           schema.Integer(),
+	"mimeType":             schema.String(),
+}))
+
+// GetItems retrieves the children of the folder at the given path of the OneDrive of the user,
+// or of its root folder when the path is empty.
+func GetItems(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, path string) ([]byte, error) {
+
+	drive, err := client.Users().ByUserId(userId).Drive().Get(ctx, &users.ItemDriveRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemDriveRequestBuilderGetQueryParameters{
+			Select: []string{"id"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching drive: %w", err)
+	}
+	if drive.GetId() == nil {
+		return nil, fmt.Errorf("the drive of user '%s' has no id", userId)
+	}
+
+	return GetChildren(ctx, client, *drive.GetId(), path)
+}
+
+// itemsArgs are the arguments of the drive_items tool.
+type itemsArgs struct {
+	UserId string `json:"user_id"`
+	Path   string `json:"path"`
+}
+
+// Validate checks that the user is given.
+func (a *itemsArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+
+	return nil
+}
+
+// GetChildren retrieves the children of the folder at the given path of a drive, or of its root
+// folder when the path is empty.
+func GetChildren(ctx context.Context, client *msgraphsdk.GraphServiceClient, driveId string, path string) ([]byte, error) {
+
+	// Folders are addressed relatively to the root with the root:/path: syntax
+	itemId := "root"
+	if path = strings.Trim(path, "/"); path != "" {
+		itemId = fmt.Sprintf("root:/%s:", path)
+	}
+
+	result, err := client.Drives().ByDriveId(driveId).Items().ByDriveItemId(itemId).Children().Get(ctx, &drives.ItemItemsItemChildrenRequestBuilderGetRequestConfiguration{
+		QueryParameters: &drives.ItemItemsItemChildrenRequestBuilderGetQueryParameters{
+			Select: driveItemFields,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching drive items: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	itemsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateDriveItemCollectionResponseFromDiscriminatorValue, func(item models.DriveItemable) bool {
+		id, itemData := convertDriveItemToMap(item)
+		itemsData[id] = itemData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through drive items: %v", err)
+	}
+
+	return json.MarshalIndent(itemsData, "", "  ")
+}
+
+// convertDriveItemToMap converts a drive item model to a map with its type, folder or file
+func convertDriveItemToMap(item models.DriveItemable) (string, map[string]interface{}) {
+
+	itemId := ""
+	itemData := make(map[string]interface{})
+
+	if id := item.GetId(); id != nil {
+		itemId = *id
+		itemData["id"] = itemId
+	}
+	if name := item.GetName(); name != nil {
+		itemData["name"] = *name
+	}
+	if size := item.GetSize(); size != nil {
+		itemData["size"] = *size
+	}
+	if webUrl := item.GetWebUrl(); webUrl != nil {
+		itemData["webUrl"] = *webUrl
+	}
+	if lastModifiedDateTime := item.GetLastModifiedDateTime(); lastModifiedDateTime != nil {
+		itemData["lastModifiedDateTime"] = lastModifiedDateTime.Format(time.RFC3339)
+	}
+	if folder := item.GetFolder(); folder != nil {
+		itemData["type"] = "folder"
+		if childCount := folder.GetChildCount(); childCount != nil {
+			itemData["childCount"] = *childCount
+		}
+	} else {
+		itemData["type"] = "file"
+		if file := item.GetFile(); file != nil && file.GetMimeType() != nil {
+			itemData["mimeType"] = *file.GetMimeType()
+		}
+	}
+
+	return itemId, itemData
+}
@@ -0,0 +1,261 @@
+package drives
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// maxQuotaUsers is the maximum number of users whose quota can be read in one call.
+const maxQuotaUsers = 50
+
+func init() {
+	// Drive Quota Tool is a tool that interacts with microsoft for OneDrive and SharePoint storage APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "drive_quota",
+			Tool: mcp.NewTool("drive_quota",
+				mcp.WithDescription(fmt.Sprintf("Read the storage quota (total, used, remaining and state: normal, nearing, critical or exceeded) of the OneDrive of users, or of the document libraries of a SharePoint site. At most %d users per call. Requires Files.Read.All and Sites.Read.All.", maxQuotaUsers)),
+				mcp.WithString("user_ids",
+					mcp.Description("Comma separated list of user ids or user principal names whose OneDrive quota is returned."),
+				),
+				mcp.WithString("site_id",
+					mcp.Description("The id of a site whose document libraries usage is returned."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Files.Read.All", "Sites.Read.All"},
+			OutputSchema:   quotaSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a quotaArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				if a.SiteId != "" {
+					jsonData, err := GetSiteQuota(ctx, client, a.SiteId)
+					if err != nil {
+						return mcp.NewToolResultError("failed to get site quota"), err
+					}
+					return mcp.NewToolResultText(string(jsonData)), nil
+				}
+
+				jsonData, err := GetUserQuotas(ctx, client, a.userIds)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get user quotas"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// quotaProperties are the attributes of a drive quota.
+var quotaProperties = map[string]schema.Schema{
+	"quotaReported": schema.Boolean(),
+	"total":         schema.Integer(),
+	"used":          schema.Integer(),
+	"remaining":     schema.Integer(),
+	"deleted":       schema.Integer(),
+	"state":         schema.Enum("normal", "nearing", "critical", "exceeded"),
+}
+
+// quotaSchema describes the result of the drive_quota tool, for users or for a site.
+var quotaSchema = schema.OneOf(
+	schema.Map(schema.Object(schema.Merge(quotaProperties, map[string]schema.Schema{
+		"driveId": schema.String(),
+		"webUrl":  schema.String(),
+		"error":   schema.String(),
+	}))),
+	schema.Object(schema.Merge(quotaProperties, map[string]schema.Schema{
+		"siteId": schema.String(),
+		"drives": schema.Map(schema.Object(schema.Merge(quotaProperties, map[string]schema.Schema{
+			"id":   schema.String(),
+			"name": schema.String(),
+		}))),
+	})),
+)
+
+// quotaArgs are the arguments of the drive_quota tool.
+type quotaArgs struct {
+	UserIds string `json:"user_ids"`
+	SiteId  string `json:"site_id"`
+
+	userIds []string
+}
+
+// Validate splits the user ids, which are required unless a site is given.
+func (a *quotaArgs) Validate() error {
+
+	if a.SiteId != "" {
+		return nil
+	}
+
+	for _, userId := range strings.Split(a.UserIds, ",") {
+		if userId = strings.TrimSpace(userId); userId != "" {
+			a.userIds = append(a.userIds, userId)
+		}
+	}
+	if len(a.userIds) == 0 {
+		return fmt.Errorf("either user_ids or site_id is required")
+	}
+	if len(a.userIds) > maxQuotaUsers {
+		return fmt.Errorf("at most %d users can be given, got %d", maxQuotaUsers, len(a.userIds))
+	}
+
+	return nil
+}
+
+// GetUserQuotas retrieves the OneDrive quota of each user. A user without a OneDrive, or whose
+// drive cannot be read, is reported with the error instead of failing the whole call.
+func GetUserQuotas(ctx context.Context, client *msgraphsdk.GraphServiceClient, userIds []string) ([]byte, error) {
+
+	quotasData := make(map[string]interface{})
+
+	for _, userId := range userIds {
+		drive, err := client.Users().ByUserId(userId).Drive().Get(ctx, nil)
+		if err != nil {
+			quotasData[userId] = map[string]interface{}{"error": odata.ErrorMessage(err)}
+			continue
+		}
+
+		quotaData := convertQuotaToMap(drive.GetQuota())
+		if id := drive.GetId(); id != nil {
+			quotaData["driveId"] = *id
+		}
+		if webUrl := drive.GetWebUrl(); webUrl != nil {
+			quotaData["webUrl"] = *webUrl
+		}
+		quotasData[userId] = quotaData
+	}
+
+	return json.MarshalIndent(quotasData, "", "  ")
+}
+
+// GetSiteQuota retrieves the usage of each document library of a site and their aggregate.
+// The libraries of a site share the quota of the site collection: the total is the one reported
+// for the site collection, while the usage and the deleted bytes are summed up over the libraries,
+// and the remaining bytes and the state are computed from them.
+func GetSiteQuota(ctx context.Context, client *msgraphsdk.GraphServiceClient, siteId string) ([]byte, error) {
+
+	result, err := client.Sites().BySiteId(siteId).Drives().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching site drives: %v", err)
+	}
+
+	drivesData := make(map[string]interface{})
+	var used, deleted int64
+	var total *int64
+	reported := false
+
+	err = paginate.Iterate(ctx, client, result, models.CreateDriveCollectionResponseFromDiscriminatorValue, func(drive models.Driveable) bool {
+		if drive.GetId() == nil {
+			return true
+		}
+		quota := drive.GetQuota()
+		driveData := convertQuotaToMap(quota)
+		driveData["id"] = *drive.GetId()
+		if name := drive.GetName(); name != nil {
+			driveData["name"] = *name
+		}
+		if quota != nil {
+			reported = true
+			if quota.GetUsed() != nil {
+				used += *quota.GetUsed()
+			}
+			if quota.GetDeleted() != nil {
+				deleted += *quota.GetDeleted()
+			}
+			if total == nil && quota.GetTotal() != nil {
+				total = quota.GetTotal()
+			}
+		}
+		drivesData[*drive.GetId()] = driveData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through site drives: %v", err)
+	}
+
+	siteData := map[string]interface{}{
+		"quotaReported": reported,
+		"siteId":        siteId,
+		"drives":        drivesData,
+	}
+	if reported {
+		siteData["used"] = used
+		siteData["deleted"] = deleted
+	}
+	if total != nil {
+		siteData["total"] = *total
+		siteData["remaining"] = max(*total-used, 0)
+		siteData["state"] = quotaState(used, *total)
+	}
+
+	return json.MarshalIndent(siteData, "", "  ")
+}
+
+// quotaState returns the state of a quota the way Microsoft Graph reports it: nearing within 10%
+// of the total, critical within 1%, and exceeded beyond it.
+func quotaState(used int64, total int64) string {
+
+	switch {
+	case used > total:
+		return "exceeded"
+	case used*100 >= total*99:
+		return "critical"
+	case used*10 >= total*9:
+		return "nearing"
+	default:
+		return "normal"
+	}
+}
+
+// convertQuotaToMap converts a drive quota to a map. Drives not reporting a quota are flagged as such.
+func convertQuotaToMap(quota models.Quotaable) map[string]interface{} {
+
+	quotaData := make(map[string]interface{})
+
+	if quota == nil {
+		quotaData["quotaReported"] = false
+		return quotaData
+	}
+
+	quotaData["quotaReported"] = true
+	if total := quota.GetTotal(); total != nil {
+		quotaData["total"] = *total
+	}
+	if used := quota.GetUsed(); used != nil {
+		quotaData["used"] = *used
+	}
+	if remaining := quota.GetRemaining(); remaining != nil {
+		quotaData["remaining"] = *remaining
+	}
+	if deleted := quota.GetDeleted(); deleted != nil {
+		quotaData["deleted"] = *deleted
+	}
+	if state := quota.GetState(); state != nil {
+		quotaData["state"] = *state
+	}
+
+	return quotaData
+}
@@ -0,0 +1,85 @@
+package drives
+
+import (
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+func TestSiteQuota(t *testing.T) {
+
+	result := graphtest.CheckTool(t, "drive_quota", map[string]interface{}{"site_id": "site-id"}, graphtest.Routes{
+		"GET /v1.0/sites/site-id/drives": map[string]interface{}{
+			"value": []interface{}{
+				// Each library reports the quota of the site collection, with its own usage
+				map[string]interface{}{"id": "documents-id", "name": "Documents", "quota": map[string]interface{}{"total": 1000, "used": 600, "remaining": 400, "deleted": 10, "state": "normal"}},
+				map[string]interface{}{"id": "archive-id", "name": "Archive", "quota": map[string]interface{}{"total": 1000, "used": 350, "remaining": 650, "deleted": 5, "state": "normal"}},
+				map[string]interface{}{"id": "other-id", "name": "Other"},
+			},
+		},
+	})
+
+	siteData, _ := result.(map[string]interface{})
+	want := map[string]interface{}{
+		"quotaReported": true,
+		"total":         float64(1000),
+		"used":          float64(950),
+		"remaining":     float64(50),
+		"deleted":       float64(15),
+		"state":         "nearing",
+	}
+	for name, value := range want {
+		if siteData[name] != value {
+			t.Errorf("got %s %v, want %v", name, siteData[name], value)
+		}
+	}
+
+	drivesData, _ := siteData["drives"].(map[string]interface{})
+	if len(drivesData) != 3 {
+		t.Errorf("got %d drives, want 3", len(drivesData))
+	}
+	if other, _ := drivesData["other-id"].(map[string]interface{}); other["quotaReported"] != false {
+		t.Errorf("the drive without quota is not flagged: %v", other)
+	}
+}
+
+func TestSiteQuotaNotReported(t *testing.T) {
+
+	result := graphtest.CheckTool(t, "drive_quota", map[string]interface{}{"site_id": "site-id"}, graphtest.Routes{
+		"GET /v1.0/sites/site-id/drives": map[string]interface{}{
+			"value": []interface{}{map[string]interface{}{"id": "documents-id", "name": "Documents"}},
+		},
+	})
+
+	siteData, _ := result.(map[string]interface{})
+	if siteData["quotaReported"] != false {
+		t.Errorf("got quotaReported %v, want false", siteData["quotaReported"])
+	}
+	for _, name := range []string{"total", "used", "remaining", "state"} {
+		if _, ok := siteData[name]; ok {
+			t.Errorf("unexpected %s: %v", name, siteData[name])
+		}
+	}
+}
+
+func TestQuotaState(t *testing.T) {
+
+	tests := []struct {
+		used  int64
+		total int64
+		want  string
+	}{
+		{0, 1000, "normal"},
+		{899, 1000, "normal"},
+		{900, 1000, "nearing"},
+		{990, 1000, "critical"},
+		{1000, 1000, "critical"},
+		{1001, 1000, "exceeded"},
+	}
+
+	for _, test := range tests {
+		if got := quotaState(test.used, test.total); got != test.want {
+			t.Errorf("quotaState(%d, %d) = %s, want %s", test.used, test.total, got, test.want)
+		}
+	}
+}
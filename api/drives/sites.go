@@ -0,0 +1,162 @@
+package drives
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/api/drive"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Site Drives Tool is a tool that interacts with microsoft for SharePoint document library APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "site_drives",
+			Tool: mcp.NewTool("site_drives",
+				mcp.WithDescription("List the document libraries of a SharePoint site with their type, url and quota, or the files and folders at the root of one of them. Requires Sites.Read.All and Files.Read.All."),
+				mcp.WithString("site_id",
+					mcp.Required(),
+					mcp.Description("The id of the site."),
+				),
+				mcp.WithString("drive_id",
+					mcp.Description("The id of a document library of the site, as returned without it. When given, the files and folders at its root are returned instead."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Sites.Read.All", "Files.Read.All"},
+			OutputSchema:   schema.OneOf(siteDriveSchema, drive.DriveItemSchema),
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a siteDrivesArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				if a.DriveId != "" {
+					jsonData, err := GetSiteDriveItems(ctx, client, a.SiteId, a.DriveId)
+					if err != nil {
+						if odata.StatusCode(err) == http.StatusNotFound {
+							return mcp.NewToolResultError(fmt.Sprintf("the document library '%s' does not exist in the site '%s'", a.DriveId, a.SiteId)), nil
+						}
+						return mcp.NewToolResultError("failed to get drive items"), err
+					}
+					return mcp.NewToolResultText(string(jsonData)), nil
+				}
+
+				jsonData, err := GetSiteDrives(ctx, client, a.SiteId)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the site '%s' does not exist", a.SiteId)), nil
+					}
+					return mcp.NewToolResultError("failed to get site drives"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// siteDriveSchema describes the result of the site_drives tool when listing the document libraries.
+var siteDriveSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":        schema.String(),
+	"name":      schema.String(),
+	"driveType": schema.String(),
+	"webUrl":    schema.String(),
+	"quota":     schema.Object(quotaProperties),
+}))
+
+// GetSiteDrives retrieves the document libraries of a site.
+func GetSiteDrives(ctx context.Context, client *msgraphsdk.GraphServiceClient, siteId string) ([]byte, error) {
+
+	result, err := client.Sites().BySiteId(siteId).Drives().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching site drives: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	drivesData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateDriveCollectionResponseFromDiscriminatorValue, func(drive models.Driveable) bool {
+		id, driveData := convertDriveToMap(drive)
+		drivesData[id] = driveData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through site drives: %v", err)
+	}
+
+	return json.MarshalIndent(drivesData, "", "  ")
+}
+
+// siteDrivesArgs are the arguments of the site_drives tool.
+type siteDrivesArgs struct {
+	SiteId  string `json:"site_id"`
+	DriveId string `json:"drive_id"`
+}
+
+// Validate checks that the site is given.
+func (a *siteDrivesArgs) Validate() error {
+
+	if a.SiteId == "" {
+		return fmt.Errorf("site_id is required")
+	}
+
+	return nil
+}
+
+// GetSiteDriveItems retrieves the files and folders at the root of a document library of a site.
+func GetSiteDriveItems(ctx context.Context, client *msgraphsdk.GraphServiceClient, siteId string, driveId string) ([]byte, error) {
+
+	// The drive is looked up through the site so that a library of another site is not found
+	siteDrive, err := client.Sites().BySiteId(siteId).Drives().ByDriveId(driveId).Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching site drive: %w", err)
+	}
+	if siteDrive.GetId() == nil {
+		return nil, fmt.Errorf("the drive '%s' has no id", driveId)
+	}
+
+	return drive.GetChildren(ctx, client, *siteDrive.GetId(), "")
+}
+
+// convertDriveToMap converts a drive model to a map with its type and quota
+func convertDriveToMap(drive models.Driveable) (string, map[string]interface{}) {
+
+	driveId := ""
+	driveData := make(map[string]interface{})
+
+	if id := drive.GetId(); id != nil {
+		driveId = *id
+		driveData["id"] = driveId
+	}
+	if name := drive.GetName(); name != nil {
+		driveData["name"] = *name
+	}
+	if driveType := drive.GetDriveType(); driveType != nil {
+		driveData["driveType"] = *driveType
+	}
+	if webUrl := drive.GetWebUrl(); webUrl != nil {
+		driveData["webUrl"] = *webUrl
+	}
+	driveData["quota"] = convertQuotaToMap(drive.GetQuota())
+
+	return driveId, driveData
+}
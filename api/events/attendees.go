@@ -0,0 +1,273 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+)
+
+const (
+	// defaultWindowDays is the number of days instances are expanded over when no days are given.
+	defaultWindowDays = 30
+	// maxWindowDays is the largest number of days instances are expanded over.
+	maxWindowDays = 365
+	// defaultMaxInstances is the number of instances expanded when no max_instances is given.
+	defaultMaxInstances = 20
+	// maxMaxInstances is the largest number of instances expanded in one call.
+	maxMaxInstances = 100
+)
+
+// attendeeFields are the attributes of an event or an instance read for the attendee responses.
+var attendeeFields = []string{"id", "subject", "type", "start", "end", "organizer", "attendees"}
+
+func init() {
+	// Event Attendees Tool is a tool that interacts with microsoft for calendar event APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "event_attendees",
+			Tool: mcp.NewTool("event_attendees",
+				mcp.WithDescription("Read the attendees of an event with their response (accepted, declined, tentativelyAccepted, notResponded, none or organizer) and a count per response. For a recurring series, the occurrences within the next days can be expanded with their own responses. Requires Calendars.Read."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The id or user principal name of the user whose calendar holds the event."),
+				),
+				mcp.WithString("event_id",
+					mcp.Required(),
+					mcp.Description("The id of the event."),
+				),
+				mcp.WithBoolean("expand_instances",
+					mcp.Description("For the master event of a recurring series, also return the attendee responses of each occurrence within the next days."),
+				),
+				mcp.WithNumber("days",
+					mcp.Description(fmt.Sprintf("expand_instances: the number of days from now to expand the occurrences over, at most %d. Defaults to %d.", maxWindowDays, defaultWindowDays)),
+				),
+				mcp.WithNumber("max_instances",
+					mcp.Description(fmt.Sprintf("expand_instances: the maximum number of occurrences to expand, at most %d. Defaults to %d.", maxMaxInstances, defaultMaxInstances)),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Calendars.Read"},
+			OutputSchema:   attendeesSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := attendeesArgs{Days: defaultWindowDays, MaxInstances: defaultMaxInstances}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				maxExpanded := 0
+				if a.ExpandInstances {
+					maxExpanded = a.MaxInstances
+				}
+
+				now := time.Now().UTC()
+				jsonData, err := GetAttendees(ctx, client, a.UserId, a.EventId, now, now.AddDate(0, 0, a.Days), maxExpanded)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the event '%s' does not exist in the calendar of '%s'", a.EventId, a.UserId)), nil
+					}
+					return mcp.NewToolResultError("failed to get event attendees"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// attendanceProperties are the attributes of an event and of its occurrences.
+var attendanceProperties = map[string]schema.Schema{
+	"id":        schema.String(),
+	"subject":   schema.String(),
+	"type":      schema.String(),
+	"start":     schema.String(),
+	"end":       schema.String(),
+	"timeZone":  schema.String(),
+	"organizer": schema.String(),
+	"attendees": schema.Map(schema.Object(map[string]schema.Schema{
+		"name":             schema.String(),
+		"email":            schema.String(),
+		"type":             schema.String(),
+		"response":         schema.String(),
+		"responseDateTime": schema.DateTime(),
+	})),
+	"responses": schema.Map(schema.Integer()),
+}
+
+// attendeesSchema describes the result of the event_attendees tool.
+var attendeesSchema = schema.Object(schema.Merge(attendanceProperties, map[string]schema.Schema{
+	"instances":          schema.Map(schema.Object(attendanceProperties)),
+	"instancesTruncated": schema.Boolean(),
+	"message":            schema.String(),
+}))
+
+// attendeesArgs are the arguments of the event_attendees tool.
+type attendeesArgs struct {
+	UserId          string `json:"user_id"`
+	EventId         string `json:"event_id"`
+	Days            int    `json:"days"`
+	ExpandInstances bool   `json:"expand_instances"`
+	MaxInstances    int    `json:"max_instances"`
+}
+
+// Validate checks that the event is given and the bounds of the window and of the number of
+// instances.
+func (a *attendeesArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	if a.EventId == "" {
+		return fmt.Errorf("event_id is required")
+	}
+	if a.Days <= 0 || a.Days > maxWindowDays {
+		return fmt.Errorf("days must be between 1 and %d", maxWindowDays)
+	}
+	if a.MaxInstances <= 0 || a.MaxInstances > maxMaxInstances {
+		return fmt.Errorf("max_instances must be between 1 and %d", maxMaxInstances)
+	}
+
+	return nil
+}
+
+// GetAttendees retrieves the attendee responses of an event and, when maxInstances is not zero
+// and the event is the master of a series, of at most maxInstances occurrences between start and end.
+func GetAttendees(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, eventId string, start time.Time, end time.Time, maxInstances int) ([]byte, error) {
+
+	event, err := client.Users().ByUserId(userId).Events().ByEventId(eventId).Get(ctx, &users.ItemEventsEventItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemEventsEventItemRequestBuilderGetQueryParameters{
+			Select: attendeeFields,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching event: %w", err)
+	}
+
+	_, eventData := convertAttendanceToMap(event)
+	if maxInstances == 0 {
+		return json.MarshalIndent(eventData, "", "  ")
+	}
+
+	if event.GetTypeEscaped() == nil || *event.GetTypeEscaped() != models.SERIESMASTER_EVENTTYPE {
+		eventData["message"] = "The event is not the master of a recurring series, it has no occurrences to expand."
+		return json.MarshalIndent(eventData, "", "  ")
+	}
+
+	result, err := client.Users().ByUserId(userId).Events().ByEventId(eventId).Instances().Get(ctx, &users.ItemEventsItemInstancesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemEventsItemInstancesRequestBuilderGetQueryParameters{
+			StartDateTime: to.Ptr(start.Format(time.RFC3339)),
+			EndDateTime:   to.Ptr(end.Format(time.RFC3339)),
+			Select:        attendeeFields,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching event instances: %w", err)
+	}
+
+	instancesData := make(map[string]interface{})
+	truncated := false
+	err = paginate.Iterate(ctx, client, result, models.CreateEventCollectionResponseFromDiscriminatorValue, func(instance models.Eventable) bool {
+		if len(instancesData) == maxInstances {
+			truncated = true
+			return false
+		}
+		id, instanceData := convertAttendanceToMap(instance)
+		instancesData[id] = instanceData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through event instances: %v", err)
+	}
+
+	eventData["instances"] = instancesData
+	eventData["instancesTruncated"] = truncated
+
+	return json.MarshalIndent(eventData, "", "  ")
+}
+
+// convertAttendanceToMap converts an event to a map with its attendees keyed by email address
+// and the number of attendees per response
+func convertAttendanceToMap(event models.Eventable) (string, map[string]interface{}) {
+
+	eventId := ""
+	eventData := make(map[string]interface{})
+
+	if id := event.GetId(); id != nil {
+		eventId = *id
+		eventData["id"] = eventId
+	}
+	if subject := event.GetSubject(); subject != nil {
+		eventData["subject"] = *subject
+	}
+	if eventType := event.GetTypeEscaped(); eventType != nil {
+		eventData["type"] = eventType.String()
+	}
+	if start := event.GetStart(); start != nil && start.GetDateTime() != nil {
+		eventData["start"] = *start.GetDateTime()
+		if timeZone := start.GetTimeZone(); timeZone != nil {
+			eventData["timeZone"] = *timeZone
+		}
+	}
+	if end := event.GetEnd(); end != nil && end.GetDateTime() != nil {
+		eventData["end"] = *end.GetDateTime()
+	}
+	if organizer := event.GetOrganizer(); organizer != nil && organizer.GetEmailAddress() != nil {
+		if address := organizer.GetEmailAddress().GetAddress(); address != nil {
+			eventData["organizer"] = *address
+		}
+	}
+
+	attendeesData := make(map[string]interface{})
+	responses := make(map[string]int)
+	for _, attendee := range event.GetAttendees() {
+		emailAddress := attendee.GetEmailAddress()
+		if emailAddress == nil || emailAddress.GetAddress() == nil {
+			continue
+		}
+		attendeeData := map[string]interface{}{
+			"email": *emailAddress.GetAddress(),
+		}
+		if name := emailAddress.GetName(); name != nil {
+			attendeeData["name"] = *name
+		}
+		if attendeeType := attendee.GetTypeEscaped(); attendeeType != nil {
+			attendeeData["type"] = attendeeType.String()
+		}
+		response := "none"
+		if status := attendee.GetStatus(); status != nil {
+			if status.GetResponse() != nil {
+				response = status.GetResponse().String()
+			}
+			if responseTime := status.GetTime(); responseTime != nil && responseTime.Year() > 1 {
+				attendeeData["responseDateTime"] = responseTime.Format(time.RFC3339)
+			}
+		}
+		attendeeData["response"] = response
+		responses[response]++
+		attendeesData[*emailAddress.GetAddress()] = attendeeData
+	}
+	eventData["attendees"] = attendeesData
+	eventData["responses"] = responses
+
+	return eventId, eventData
+}
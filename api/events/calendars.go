@@ -0,0 +1,146 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Calendars Tool is a tool that interacts with microsoft for calendar APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "calendars",
+			Tool: mcp.NewTool("calendars",
+				mcp.WithDescription("List the calendars of a user with their name, color and whether the user can edit them. The calendar ids can then be used to target a specific calendar for events. Requires Calendars.Read."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The id or user principal name of the user."),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Calendars.Read"},
+			OutputSchema:   calendarSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a calendarsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetCalendars(ctx, client, a.UserId)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get calendars"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// GetCalendars retrieves the calendars of a user. Users who never created a calendar
+// only have their default one.
+func GetCalendars(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string) ([]byte, error) {
+
+	result, err := client.Users().ByUserId(userId).Calendars().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching calendars: %v", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	calendarsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateCalendarCollectionResponseFromDiscriminatorValue, func(calendar models.Calendarable) bool {
+		id, calendarData := convertCalendarToMap(calendar)
+		calendarsData[id] = calendarData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through calendars: %v", err)
+	}
+
+	return json.MarshalIndent(calendarsData, "", "  ")
+}
+
+// calendarSchema describes the result of the calendars tool.
+var calendarSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":                schema.String(),
+	"name":              schema.String(),
+	"color":             schema.String(),
+	"hexColor":          schema.String(),
+	"canEdit":           schema.Boolean(),
+	"canShare":          schema.Boolean(),
+	"isDefaultCalendar": schema.Boolean(),
+	"owner":             schema.String(),
+}))
+
+// calendarsArgs are the arguments of the calendars tool.
+type calendarsArgs struct {
+	UserId string `json:"user_id"`
+}
+
+// Validate checks that the user is given.
+func (a *calendarsArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+
+	return nil
+}
+
+// convertCalendarToMap converts a calendar model to a map of attributes
+func convertCalendarToMap(calendar models.Calendarable) (string, map[string]interface{}) {
+
+	calendarId := ""
+	calendarData := make(map[string]interface{})
+
+	if id := calendar.GetId(); id != nil {
+		calendarId = *id
+		calendarData["id"] = calendarId
+	}
+	if name := calendar.GetName(); name != nil {
+		calendarData["name"] = *name
+	}
+	if color := calendar.GetColor(); color != nil {
+		calendarData["color"] = color.String()
+	}
+	if hexColor := calendar.GetHexColor(); hexColor != nil && *hexColor != "" {
+		calendarData["hexColor"] = *hexColor
+	}
+	if canEdit := calendar.GetCanEdit(); canEdit != nil {
+		calendarData["canEdit"] = *canEdit
+	}
+	if canShare := calendar.GetCanShare(); canShare != nil {
+		calendarData["canShare"] = *canShare
+	}
+	if isDefaultCalendar := calendar.GetIsDefaultCalendar(); isDefaultCalendar != nil {
+		calendarData["isDefaultCalendar"] = *isDefaultCalendar
+	}
+	if owner := calendar.GetOwner(); owner != nil && owner.GetAddress() != nil {
+		calendarData["owner"] = *owner.GetAddress()
+	}
+
+	return calendarId, calendarData
+}
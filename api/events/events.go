@@ -0,0 +1,219 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+)
+
+// defaultViewDays is the length of the calendar view when start or end is not given.
+const defaultViewDays = 7
+
+// eventFields are the attributes of an event read by the events tool.
+var eventFields = []string{"id", "subject", "organizer", "start", "end", "location", "isAllDay", "attendees"}
+
+func init() {
+	// Events Tool is a tool that interacts with microsoft for calendar event APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "events",
+			Tool: mcp.NewTool("events",
+				mcp.WithDescription(fmt.Sprintf("List the events of the calendar of a user between two dates, the occurrences of recurring events included, with their organizer, location and number of attendees. Defaults to the next %d days. Requires Calendars.Read.", defaultViewDays)),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The id or user principal name of the user."),
+				),
+				mcp.WithString("start",
+					mcp.Description(fmt.Sprintf("The start of the time range (YYYY-MM-DD or RFC 3339). Defaults to now, or to %d days before end.", defaultViewDays)),
+				),
+				mcp.WithString("end",
+					mcp.Description(fmt.Sprintf("The end of the time range (YYYY-MM-DD or RFC 3339). Defaults to %d days after start.", defaultViewDays)),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Calendars.Read"},
+			OutputSchema:   eventSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a eventsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := Get(ctx, client, a.UserId, a.start, a.end)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the user '%s' does not exist or has no calendar", a.UserId)), nil
+					}
+					return mcp.NewToolResultError("failed to get events"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// eventSchema describes the result of the events tool.
+var eventSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":        schema.String(),
+	"subject":   schema.String(),
+	"organizer": schema.String(),
+	"start":     schema.String(),
+	"end":       schema.String(),
+	"timeZone":  schema.String(),
+	"location":  schema.String(),
+	"isAllDay":  schema.Boolean(),
+	"attendees": schema.Integer(),
+}))
+
+// eventsArgs are the arguments of the events tool.
+type eventsArgs struct {
+	UserId string `json:"user_id"`
+	Start  string `json:"start"`
+	End    string `json:"end"`
+
+	start, end time.Time
+}
+
+// Validate checks that the user is given and parses the window, which spans defaultViewDays from
+// the bound given, or from now when none is.
+func (a *eventsArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+
+	if a.Start != "" {
+		parsed, err := parseDate(a.Start)
+		if err != nil {
+			return fmt.Errorf("invalid start '%s', expected YYYY-MM-DD or RFC 3339", a.Start)
+		}
+		a.start = parsed
+	}
+	if a.End != "" {
+		parsed, err := parseDate(a.End)
+		if err != nil {
+			return fmt.Errorf("invalid end '%s', expected YYYY-MM-DD or RFC 3339", a.End)
+		}
+		a.end = parsed
+	}
+	switch {
+	case a.start.IsZero() && a.end.IsZero():
+		a.start = time.Now().UTC()
+		a.end = a.start.AddDate(0, 0, defaultViewDays)
+	case a.start.IsZero():
+		a.start = a.end.AddDate(0, 0, -defaultViewDays)
+	case a.end.IsZero():
+		a.end = a.start.AddDate(0, 0, defaultViewDays)
+	}
+	if !a.end.After(a.start) {
+		return fmt.Errorf("end must be after start")
+	}
+
+	return nil
+}
+
+// Get retrieves the events of the calendar of the user between start and end, through the
+// calendar view expanding the occurrences of recurring events.
+func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, start time.Time, end time.Time) ([]byte, error) {
+
+	result, err := client.Users().ByUserId(userId).CalendarView().Get(ctx, &users.ItemCalendarViewRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemCalendarViewRequestBuilderGetQueryParameters{
+			StartDateTime: to.Ptr(start.UTC().Format(time.RFC3339)),
+			EndDateTime:   to.Ptr(end.UTC().Format(time.RFC3339)),
+			Select:        eventFields,
+			Orderby:       []string{"start/dateTime"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching calendar view: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	eventsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateEventCollectionResponseFromDiscriminatorValue, func(event models.Eventable) bool {
+		id, eventData := convertEventToMap(event)
+		eventsData[id] = eventData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through calendar view: %v", err)
+	}
+
+	return json.MarshalIndent(eventsData, "", "  ")
+}
+
+// parseDate parses a date given as YYYY-MM-DD or RFC 3339.
+func parseDate(value string) (time.Time, error) {
+
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// convertEventToMap converts an event model to a map with its organizer, time range and location
+func convertEventToMap(event models.Eventable) (string, map[string]interface{}) {
+
+	eventId := ""
+	eventData := make(map[string]interface{})
+
+	if id := event.GetId(); id != nil {
+		eventId = *id
+		eventData["id"] = eventId
+	}
+	if subject := event.GetSubject(); subject != nil {
+		eventData["subject"] = *subject
+	}
+	if organizer := event.GetOrganizer(); organizer != nil && organizer.GetEmailAddress() != nil {
+		if address := organizer.GetEmailAddress().GetAddress(); address != nil {
+			eventData["organizer"] = *address
+		}
+	}
+	if start := event.GetStart(); start != nil && start.GetDateTime() != nil {
+		eventData["start"] = *start.GetDateTime()
+		if timeZone := start.GetTimeZone(); timeZone != nil {
+			eventData["timeZone"] = *timeZone
+		}
+	}
+	if end := event.GetEnd(); end != nil && end.GetDateTime() != nil {
+		eventData["end"] = *end.GetDateTime()
+	}
+	if location := event.GetLocation(); location != nil && location.GetDisplayName() != nil && *location.GetDisplayName() != "" {
+		eventData["location"] = *location.GetDisplayName()
+	}
+	if isAllDay := event.GetIsAllDay(); isAllDay != nil {
+		eventData["isAllDay"] = *isAllDay
+	}
+	eventData["attendees"] = len(event.GetAttendees())
+
+	return eventId, eventData
+}
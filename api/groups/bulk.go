@@ -0,0 +1,211 @@
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+)
+
+// maxBindMembers is the maximum number of members Graph accepts in a single members@odata.bind update.
+const maxBindMembers = 20
+
+func init() {
+	// Add Group Members Tool is a tool that adds users to a group in bulk.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "add_group_members",
+			Tool: mcp.NewTool("add_group_members",
+				mcp.WithDescription(fmt.Sprintf("Add a list of users to a group as members, to onboard a cohort at once. The users are added %d per request, and each user is reported individually: a user who cannot be found or added does not prevent the others from being added. Requires GroupMember.ReadWrite.All and User.Read.All.", maxBindMembers)),
+				mcp.WithString("group_id",
+					mcp.Required(),
+					mcp.Description("The id of the group."),
+				),
+				mcp.WithString("user_ids",
+					mcp.Required(),
+					mcp.Description("Comma separated list of user ids or user principal names."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"GroupMember.ReadWrite.All", "User.Read.All"},
+			OutputSchema:   addMembersSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a addMembersArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := AddMembers(ctx, client, a.GroupId, a.userIds)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the group '%s' does not exist", a.GroupId)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to add group members: %s", odata.ErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// addMembersSchema describes the result of the add_group_members tool, keyed by user id or user principal name as given.
+var addMembersSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"success": schema.Boolean(),
+	"id":      schema.String(),
+	"error":   schema.String(),
+}))
+
+// addMembersArgs are the arguments of the add_group_members tool.
+type addMembersArgs struct {
+	GroupId string `json:"group_id"`
+	UserIds string `json:"user_ids"`
+
+	userIds []string
+}
+
+// Validate checks that the group and the users are given.
+func (a *addMembersArgs) Validate() error {
+
+	if a.GroupId == "" {
+		return fmt.Errorf("group_id is required")
+	}
+
+	for _, userId := range strings.Split(a.UserIds, ",") {
+		if userId = strings.TrimSpace(userId); userId != "" {
+			a.userIds = append(a.userIds, userId)
+		}
+	}
+	if len(a.userIds) == 0 {
+		return fmt.Errorf("user_ids is required")
+	}
+
+	return nil
+}
+
+// AddMembers adds the users to the group, maxBindMembers at a time. The users are first looked up
+// to get their ids. When a batch is rejected, its users are added one by one to report the error
+// of the ones that cannot be added.
+func AddMembers(ctx context.Context, client *msgraphsdk.GraphServiceClient, groupId string, userIds []string) ([]byte, error) {
+
+	if err := checkGroup(ctx, client, groupId); err != nil {
+		return nil, err
+	}
+
+	resultsData := make(map[string]interface{})
+	failed := func(userId string, message string) {
+		resultsData[userId] = map[string]interface{}{
+			"success": false,
+			"error":   message,
+		}
+	}
+
+	// The object ids are kept along with the user ids as given, to report by the latter
+	objectIds := map[string]string{}
+	resolved := []string{}
+	for _, userId := range userIds {
+		if _, ok := resultsData[userId]; ok {
+			continue
+		}
+		if _, ok := objectIds[userId]; ok {
+			continue
+		}
+		user, err := client.Users().ByUserId(userId).Get(ctx, &users.UserItemRequestBuilderGetRequestConfiguration{
+			QueryParameters: &users.UserItemRequestBuilderGetQueryParameters{
+				Select: []string{"id"},
+			},
+		})
+		switch {
+		case odata.StatusCode(err) == http.StatusNotFound:
+			failed(userId, "the user does not exist")
+		case err != nil:
+			failed(userId, odata.ErrorMessage(err))
+		case user.GetId() == nil:
+			failed(userId, "the user has no id")
+		default:
+			objectIds[userId] = *user.GetId()
+			resolved = append(resolved, userId)
+		}
+	}
+
+	baseUrl := client.GetAdapter().GetBaseUrl()
+	for _, batch := range chunk(resolved, maxBindMembers) {
+
+		references := make([]string, 0, len(batch))
+		for _, userId := range batch {
+			references = append(references, fmt.Sprintf("%s/directoryObjects/%s", baseUrl, objectIds[userId]))
+		}
+
+		body := models.NewGroup()
+		body.SetAdditionalData(map[string]interface{}{
+			"members@odata.bind": references,
+		})
+
+		_, batchErr := client.Groups().ByGroupId(groupId).Patch(ctx, body, nil)
+
+		for i, userId := range batch {
+			err := batchErr
+			// A rejected batch is retried user by user to find out which ones fail
+			if batchErr != nil {
+				reference := models.NewReferenceCreate()
+				reference.SetOdataId(&references[i])
+				err = client.Groups().ByGroupId(groupId).Members().Ref().Post(ctx, reference, nil)
+			}
+			if err != nil {
+				failed(userId, odata.ErrorMessage(err))
+				continue
+			}
+			resultsData[userId] = map[string]interface{}{
+				"success": true,
+				"id":      objectIds[userId],
+			}
+		}
+	}
+
+	return json.MarshalIndent(resultsData, "", "  ")
+}
+
+// checkGroup returns an error if the group cannot be read, so that a missing group is reported
+// once rather than for each of its members.
+func checkGroup(ctx context.Context, client *msgraphsdk.GraphServiceClient, groupId string) error {
+
+	if _, err := client.Groups().ByGroupId(groupId).Get(ctx, &groups.GroupItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.GroupItemRequestBuilderGetQueryParameters{
+			Select: []string{"id"},
+		},
+	}); err != nil {
+		return fmt.Errorf("error fetching group: %w", err)
+	}
+
+	return nil
+}
+
+// chunk splits the values in consecutive slices of at most size values.
+func chunk(values []string, size int) [][]string {
+
+	chunks := make([][]string, 0, (len(values)+size-1)/size)
+	for start := 0; start < len(values); start += size {
+		end := min(start+size, len(values))
+		chunks = append(chunks, values[start:end])
+	}
+
+	return chunks
+}
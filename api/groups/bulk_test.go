@@ -0,0 +1,135 @@
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+func TestChunk(t *testing.T) {
+
+	values := make([]string, 45)
+	for i := range values {
+		values[i] = fmt.Sprint(i)
+	}
+
+	tests := []struct {
+		name  string
+		count int
+		want  []int
+	}{
+		{"none", 0, []int{}},
+		{"less than a batch", 5, []int{5}},
+		{"exactly a batch", 20, []int{20}},
+		{"batches and a remainder", 45, []int{20, 20, 5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chunks := chunk(values[:test.count], maxBindMembers)
+			sizes := []int{}
+			for _, c := range chunks {
+				sizes = append(sizes, len(c))
+			}
+			if !slices.Equal(sizes, test.want) {
+				t.Errorf("got chunks of %v, want %v", sizes, test.want)
+			}
+			if test.count > 0 && (chunks[0][0] != "0" || chunks[len(chunks)-1][len(chunks[len(chunks)-1])-1] != values[test.count-1]) {
+				t.Errorf("the chunks do not keep the order of the values: %v", chunks)
+			}
+		})
+	}
+}
+
+func TestAddMembers(t *testing.T) {
+
+	userIds := make([]string, 45)
+	for i := range userIds {
+		userIds[i] = fmt.Sprintf("user%02d@contoso.com", i)
+	}
+	// The last batch holds a user Graph refuses to add
+	rejected := "object-user42@contoso.com"
+
+	var batches []int
+	var retried []string
+	graph := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1.0/groups/group-id":
+			graphtest.WriteJSON(w, http.StatusOK, map[string]interface{}{"id": "group-id"})
+
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1.0/users/"):
+			graphtest.WriteJSON(w, http.StatusOK, map[string]interface{}{"id": "object-" + strings.TrimPrefix(r.URL.Path, "/v1.0/users/")})
+
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1.0/groups/group-id":
+			var body struct {
+				Members []string `json:"members@odata.bind"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			batches = append(batches, len(body.Members))
+			for _, member := range body.Members {
+				if strings.HasSuffix(member, "/"+rejected) {
+					graphtest.WriteError(w, http.StatusBadRequest, "Request_BadRequest", "One or more added object references already exist.")
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+
+		case r.Method == http.MethodPost && r.URL.Path == "/v1.0/groups/group-id/members/$ref":
+			var body struct {
+				ID string `json:"@odata.id"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			retried = append(retried, body.ID)
+			if strings.HasSuffix(body.ID, "/"+rejected) {
+				graphtest.WriteError(w, http.StatusBadRequest, "Request_BadRequest", "One or more added object references already exist.")
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			graphtest.WriteError(w, http.StatusNotFound, "Request_ResourceNotFound", "unexpected request "+r.Method+" "+r.URL.Path)
+		}
+	})
+	cl, err := graphtest.NewClient(graph)
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+
+	jsonData, err := AddMembers(context.Background(), cl, "group-id", userIds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(batches, []int{20, 20, 5}) {
+		t.Errorf("got batches of %v, want [20 20 5]", batches)
+	}
+	if len(retried) != 5 {
+		t.Errorf("expected the 5 users of the rejected batch to be retried one by one, got %v", retried)
+	}
+
+	var results map[string]map[string]interface{}
+	if err := json.Unmarshal(jsonData, &results); err != nil {
+		t.Fatalf("decoding result: %v", err)
+	}
+	if len(results) != len(userIds) {
+		t.Errorf("expected a result per user, got %d", len(results))
+	}
+	for _, userId := range userIds {
+		result := results[userId]
+		if userId == "user42@contoso.com" {
+			if result["success"] != false || !strings.Contains(fmt.Sprint(result["error"]), "already exist") {
+				t.Errorf("unexpected result for the rejected user: %v", result)
+			}
+			continue
+		}
+		if result["success"] != true || result["id"] != "object-"+userId {
+			t.Errorf("unexpected result for %s: %v", userId, result)
+		}
+	}
+}
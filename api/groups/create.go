@@ -0,0 +1,197 @@
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// unifiedGroupType is the group type of Microsoft 365 groups.
+const unifiedGroupType = "Unified"
+
+// mailNicknameRegex matches the characters accepted in the mail alias of a group.
+var mailNicknameRegex = regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+\-/=?^_{|}~.]+$`)
+
+func init() {
+	// Create Group Tool is a tool that creates a group.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "create_group",
+			Tool: mcp.NewTool("create_group",
+				mcp.WithDescription("Create a group: a Microsoft 365 group, with group_types=Unified, which is mail enabled, or a security group, which is not. Mail-enabled security groups and distribution lists cannot be created through Microsoft Graph. Returns the id of the new group. Requires Group.ReadWrite.All."),
+				mcp.WithString("display_name",
+					mcp.Required(),
+					mcp.Description("The name of the group."),
+				),
+				mcp.WithString("mail_nickname",
+					mcp.Required(),
+					mcp.Description("The mail alias of the group, without spaces nor @, e.g. sales-emea."),
+				),
+				mcp.WithString("description",
+					mcp.Description("The description of the group."),
+				),
+				mcp.WithString("group_types",
+					mcp.Description("Comma separated list of group types: 'Unified' for a Microsoft 365 group, empty for a security group."),
+				),
+				mcp.WithBoolean("mail_enabled",
+					mcp.Description("Whether the group has a mailbox. Defaults to true for Microsoft 365 groups, which require it, and to false for security groups, which cannot have one."),
+				),
+				mcp.WithBoolean("security_enabled",
+					mcp.Description("Whether the group can be used to grant access. Defaults to false for Microsoft 365 groups and to true for security groups, which require it."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"Group.ReadWrite.All"},
+			OutputSchema:   createGroupSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a createGroupArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := CreateGroup(ctx, client, a.group())
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to create group: %s", odata.ErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// createGroupSchema describes the result of the create_group tool.
+var createGroupSchema = schema.Object(map[string]schema.Schema{
+	"id":              schema.String(),
+	"displayName":     schema.String(),
+	"mailNickname":    schema.String(),
+	"mail":            schema.String(),
+	"groupTypes":      schema.Array(schema.String()),
+	"mailEnabled":     schema.Boolean(),
+	"securityEnabled": schema.Boolean(),
+	"success":         schema.Boolean(),
+})
+
+// createGroupArgs are the arguments of the create_group tool.
+type createGroupArgs struct {
+	DisplayName     string `json:"display_name"`
+	MailNickname    string `json:"mail_nickname"`
+	Description     string `json:"description"`
+	GroupTypes      string `json:"group_types"`
+	MailEnabled     *bool  `json:"mail_enabled"`
+	SecurityEnabled *bool  `json:"security_enabled"`
+
+	groupTypes []string
+}
+
+// Validate defaults mailEnabled and securityEnabled from the group types and checks that they
+// describe a group Microsoft Graph can create.
+func (a *createGroupArgs) Validate() error {
+
+	if a.DisplayName == "" {
+		return fmt.Errorf("display_name is required")
+	}
+	if !mailNicknameRegex.MatchString(a.MailNickname) {
+		return fmt.Errorf("invalid mail_nickname '%s', expected an alias without spaces nor @", a.MailNickname)
+	}
+
+	a.groupTypes = []string{}
+	for _, groupType := range strings.Split(a.GroupTypes, ",") {
+		if groupType = strings.TrimSpace(groupType); groupType == "" {
+			continue
+		}
+		if !strings.EqualFold(groupType, unifiedGroupType) {
+			return fmt.Errorf("unsupported group type '%s', only '%s' can be given", groupType, unifiedGroupType)
+		}
+		a.groupTypes = []string{unifiedGroupType}
+	}
+	unified := len(a.groupTypes) > 0
+
+	if a.MailEnabled == nil {
+		a.MailEnabled = &unified
+	}
+	if a.SecurityEnabled == nil {
+		securityEnabled := !unified
+		a.SecurityEnabled = &securityEnabled
+	}
+
+	switch {
+	case unified && !*a.MailEnabled:
+		return fmt.Errorf("a Microsoft 365 group (group_types=Unified) must be mail enabled, set mail_enabled to true")
+	case !unified && *a.MailEnabled:
+		return fmt.Errorf("mail-enabled security groups and distribution lists cannot be created through Microsoft Graph: set group_types to Unified for a Microsoft 365 group, or mail_enabled to false for a security group")
+	case !unified && !*a.SecurityEnabled:
+		return fmt.Errorf("a group that is not a Microsoft 365 group must be a security group, set security_enabled to true")
+	}
+
+	return nil
+}
+
+// group builds the group to create from the arguments.
+func (a *createGroupArgs) group() models.Groupable {
+
+	group := models.NewGroup()
+	group.SetDisplayName(&a.DisplayName)
+	group.SetMailNickname(&a.MailNickname)
+	if a.Description != "" {
+		group.SetDescription(&a.Description)
+	}
+	group.SetGroupTypes(a.groupTypes)
+	group.SetMailEnabled(a.MailEnabled)
+	group.SetSecurityEnabled(a.SecurityEnabled)
+
+	return group
+}
+
+// CreateGroup creates the group and returns its id.
+func CreateGroup(ctx context.Context, client *msgraphsdk.GraphServiceClient, group models.Groupable) ([]byte, error) {
+
+	created, err := client.Groups().Post(ctx, group, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating group: %w", err)
+	}
+
+	groupData := map[string]interface{}{
+		"success": true,
+	}
+	if id := created.GetId(); id != nil {
+		groupData["id"] = *id
+	}
+	if displayName := created.GetDisplayName(); displayName != nil {
+		groupData["displayName"] = *displayName
+	}
+	if mailNickname := created.GetMailNickname(); mailNickname != nil {
+		groupData["mailNickname"] = *mailNickname
+	}
+	if mail := created.GetMail(); mail != nil {
+		groupData["mail"] = *mail
+	}
+	if groupTypes := created.GetGroupTypes(); groupTypes != nil {
+		groupData["groupTypes"] = groupTypes
+	}
+	if mailEnabled := created.GetMailEnabled(); mailEnabled != nil {
+		groupData["mailEnabled"] = *mailEnabled
+	}
+	if securityEnabled := created.GetSecurityEnabled(); securityEnabled != nil {
+		groupData["securityEnabled"] = *securityEnabled
+	}
+
+	return json.MarshalIndent(groupData, "", "  ")
+}
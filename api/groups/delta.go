@@ -0,0 +1,214 @@
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+)
+
+// maxDeltaGroups is the largest number of groups a delta can be restricted to.
+const maxDeltaGroups = 50
+
+func init() {
+	// Group Membership Delta Tool is a tool that interacts with microsoft for group delta APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "group_membership_delta",
+			Tool: mcp.NewTool("group_membership_delta",
+				mcp.WithDescription("Return the members added to and removed from groups since the delta_link of a previous call, along with the delta link to pass on the next call. Without delta_link, every group is returned with all its members as added and a first delta link. Deleted groups are flagged as removed. Requires GroupMember.Read.All."),
+				mcp.WithString("delta_link",
+					mcp.Description("The deltaLink returned by a previous call."),
+				),
+				mcp.WithString("group_ids",
+					mcp.Description(fmt.Sprintf("First call only: comma separated ids of at most %d groups to track instead of all of them. The delta link keeps tracking the same groups.", maxDeltaGroups)),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"GroupMember.Read.All"},
+			OutputSchema:   membershipDeltaSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a membershipDeltaArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetMembershipDelta(ctx, client, a.DeltaLink, a.groupIds)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get group membership delta"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// memberChangeSchema describes a member added to or removed from a group.
+var memberChangeSchema = schema.Array(schema.Object(map[string]schema.Schema{
+	"id":   schema.String(),
+	"type": schema.String(),
+}))
+
+// membershipDeltaSchema describes the result of the group_membership_delta tool.
+var membershipDeltaSchema = schema.Object(map[string]schema.Schema{
+	"groups": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":             schema.String(),
+		"displayName":    schema.String(),
+		"removed":        schema.Boolean(),
+		"membersAdded":   memberChangeSchema,
+		"membersRemoved": memberChangeSchema,
+	})),
+	"deltaLink": schema.String(),
+})
+
+// membershipDeltaArgs are the arguments of the group_membership_delta tool.
+type membershipDeltaArgs struct {
+	DeltaLink string `json:"delta_link"`
+	GroupIds  string `json:"group_ids"`
+
+	groupIds []string
+}
+
+// Validate splits the group ids, which can only be given on the first call.
+func (a *membershipDeltaArgs) Validate() error {
+
+	a.groupIds = []string{}
+	for _, id := range strings.Split(a.GroupIds, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			a.groupIds = append(a.groupIds, id)
+		}
+	}
+	if len(a.groupIds) > maxDeltaGroups {
+		return fmt.Errorf("at most %d group_ids can be given", maxDeltaGroups)
+	}
+	if len(a.groupIds) > 0 && a.DeltaLink != "" {
+		return fmt.Errorf("group_ids can only be given on the first call, the delta link tracks the same groups")
+	}
+
+	return nil
+}
+
+// GetMembershipDelta retrieves the membership changes of the groups since the given delta link,
+// or all the groups and their members if it is empty, along with the delta link to use on the
+// next call. Without delta link, the groups can be restricted to the given ids.
+func GetMembershipDelta(ctx context.Context, client *msgraphsdk.GraphServiceClient, deltaLink string, groupIds []string) ([]byte, error) {
+
+	// Members are only tracked when selected
+	params := &groups.DeltaRequestBuilderGetQueryParameters{
+		Select: []string{"id", "displayName", "members"},
+	}
+	if len(groupIds) > 0 {
+		quoted := make([]string, 0, len(groupIds))
+		for _, id := range groupIds {
+			quoted = append(quoted, odata.Quote(id))
+		}
+		params.Filter = to.Ptr(fmt.Sprintf("id in (%s)", strings.Join(quoted, ",")))
+	}
+
+	builder := client.Groups().Delta()
+	config := &groups.DeltaRequestBuilderGetRequestConfiguration{QueryParameters: params}
+	if deltaLink != "" {
+		builder = builder.WithUrl(deltaLink)
+		config = nil
+	}
+
+	result, err := builder.GetAsDeltaGetResponse(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching groups delta: %v", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	groupsData := make(map[string]interface{})
+
+	// Delta pages end with a delta link instead of a next link, follow them by hand.
+	// A group may appear on several pages, its member changes are accumulated.
+	for {
+		for _, group := range result.GetValue() {
+			if group.GetId() == nil {
+				continue
+			}
+			id := *group.GetId()
+			groupData, ok := groupsData[id].(map[string]interface{})
+			if !ok {
+				groupData = map[string]interface{}{
+					"id":             id,
+					"membersAdded":   []interface{}{},
+					"membersRemoved": []interface{}{},
+				}
+				groupsData[id] = groupData
+			}
+			if displayName := group.GetDisplayName(); displayName != nil {
+				groupData["displayName"] = *displayName
+			}
+			if _, removed := group.GetAdditionalData()["@removed"]; removed {
+				groupData["removed"] = true
+			}
+			addMemberChanges(groupData, group.GetAdditionalData()["members@delta"])
+		}
+
+		nextLink := result.GetOdataNextLink()
+		if nextLink == nil || *nextLink == "" {
+			break
+		}
+		result, err = client.Groups().Delta().WithUrl(*nextLink).GetAsDeltaGetResponse(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching groups delta page: %v", err)
+		}
+	}
+
+	deltaData := map[string]interface{}{
+		"groups": groupsData,
+	}
+	if nextDeltaLink := result.GetOdataDeltaLink(); nextDeltaLink != nil {
+		deltaData["deltaLink"] = *nextDeltaLink
+	}
+
+	return json.MarshalIndent(deltaData, "", "  ")
+}
+
+// addMemberChanges sorts the entries of the members@delta annotation of a group into the members
+// added and the members removed, which carry a @removed annotation.
+func addMemberChanges(groupData map[string]interface{}, membersDelta interface{}) {
+
+	members, ok := membersDelta.([]interface{})
+	if !ok {
+		return
+	}
+
+	for _, entry := range members {
+		member, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		changeData := make(map[string]interface{})
+		if id, ok := member["id"].(*string); ok && id != nil {
+			changeData["id"] = *id
+		}
+		if odataType, ok := member["@odata.type"].(*string); ok && odataType != nil {
+			changeData["type"] = strings.TrimPrefix(*odataType, "#microsoft.graph.")
+		}
+		if _, removed := member["@removed"]; removed {
+			groupData["membersRemoved"] = append(groupData["membersRemoved"].([]interface{}), changeData)
+		} else {
+			groupData["membersAdded"] = append(groupData["membersAdded"].([]interface{}), changeData)
+		}
+	}
+}
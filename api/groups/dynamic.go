@@ -0,0 +1,324 @@
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/beta"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// dynamicFilter selects the groups whose membership is computed from a rule.
+const dynamicFilter = "groupTypes/any(c:c eq 'DynamicMembership')"
+
+func init() {
+	// Dynamic Groups Tool is a tool that interacts with microsoft for dynamic group APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "dynamic_groups",
+			Tool: mcp.NewTool("dynamic_groups",
+				mcp.WithDescription("Read the dynamic membership groups with their membership rule and processing state (On or Paused), flagging the groups whose rule processing failed with the error reported. In evaluate mode, check whether a sample user satisfies a membership rule, given as is or taken from a group, with the detail of each clause. Processing errors and evaluation are only exposed by the beta endpoint. Requires Group.Read.All."),
+				mcp.WithString("mode",
+					mcp.Enum("list", "evaluate"),
+					mcp.Description("list returns the dynamic groups, evaluate checks a rule against member_id. Defaults to list."),
+				),
+				mcp.WithString("member_id",
+					mcp.Description("evaluate: the id of the sample user or device."),
+				),
+				mcp.WithString("membership_rule",
+					mcp.Description("evaluate: the rule to check, e.g. (user.department -eq \"Sales\"). Either membership_rule or group_id is required."),
+				),
+				mcp.WithString("group_id",
+					mcp.Description("evaluate: the id of the dynamic group whose rule is checked."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Group.Read.All"},
+			OutputSchema:   dynamicSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := dynamicArgs{Mode: "list"}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				if a.Mode == "list" {
+					jsonData, err := GetDynamicGroups(ctx, client)
+					if err != nil {
+						return mcp.NewToolResultError("failed to get dynamic groups"), err
+					}
+					return mcp.NewToolResultText(string(jsonData)), nil
+				}
+
+				jsonData, err := EvaluateRule(ctx, client, a.MemberId, a.MembershipRule, a.GroupId)
+				if err != nil {
+					switch odata.StatusCode(err) {
+					case http.StatusBadRequest, http.StatusNotFound:
+						return mcp.NewToolResultError(fmt.Sprintf("the rule cannot be evaluated: %s", odata.ErrorMessage(err))), nil
+					}
+					return mcp.NewToolResultError("failed to evaluate membership rule"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// dynamicSchema describes the result of the dynamic_groups tool in each mode.
+var dynamicSchema = schema.OneOf(
+	schema.Object(map[string]schema.Schema{
+		"groups": schema.Map(schema.Object(map[string]schema.Schema{
+			"id":                            schema.String(),
+			"displayName":                   schema.String(),
+			"membershipRule":                schema.String(),
+			"membershipRuleProcessingState": schema.Enum("On", "Paused"),
+			"processingStatus":              schema.String(),
+			"lastMembershipUpdated":         schema.DateTime(),
+			"processingError":               schema.Boolean(),
+			"errorMessage":                  schema.String(),
+		})),
+		"processingErrors": schema.Array(schema.String()),
+		"warnings":         schema.Array(schema.String()),
+	}),
+	schema.Object(map[string]schema.Schema{
+		"membershipRule":          schema.String(),
+		"membershipRuleSatisfied": schema.Boolean(),
+		"clauses": schema.Array(schema.Object(map[string]schema.Schema{
+			"clause":       schema.String(),
+			"satisfied":    schema.Boolean(),
+			"actualValue":  schema.String(),
+			"errorMessage": schema.String(),
+		})),
+	}),
+)
+
+// dynamicArgs are the arguments of the dynamic_groups tool.
+type dynamicArgs struct {
+	Mode           string `json:"mode"`
+	MemberId       string `json:"member_id"`
+	MembershipRule string `json:"membership_rule"`
+	GroupId        string `json:"group_id"`
+}
+
+// Validate checks the mode and that a member and exactly one rule are given to evaluate.
+func (a *dynamicArgs) Validate() error {
+
+	switch a.Mode {
+	case "list":
+	case "evaluate":
+		if a.MemberId == "" {
+			return fmt.Errorf("member_id is required in evaluate mode")
+		}
+		if (a.MembershipRule == "") == (a.GroupId == "") {
+			return fmt.Errorf("exactly one of membership_rule or group_id is required in evaluate mode")
+		}
+	default:
+		return fmt.Errorf("unknown mode '%s', expected list or evaluate", a.Mode)
+	}
+
+	return nil
+}
+
+// processingStatus is the beta membershipRuleProcessingStatus of a dynamic group.
+type processingStatus struct {
+	Status                string  `json:"status"`
+	ErrorMessage          *string `json:"errorMessage"`
+	LastMembershipUpdated *string `json:"lastMembershipUpdated"`
+}
+
+// dynamicGroupStatus is a dynamic group of the beta listing, with the status of its rule.
+type dynamicGroupStatus struct {
+	Id     string            `json:"id"`
+	Status *processingStatus `json:"membershipRuleProcessingStatus"`
+}
+
+// GetDynamicGroups retrieves the dynamic membership groups with their rule and processing state,
+// and the groups whose rule processing failed.
+func GetDynamicGroups(ctx context.Context, client *msgraphsdk.GraphServiceClient) ([]byte, error) {
+
+	result, err := client.Groups().Get(ctx, &groups.GroupsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.GroupsRequestBuilderGetQueryParameters{
+			Filter: to.Ptr(dynamicFilter),
+			Select: []string{"id", "displayName", "membershipRule", "membershipRuleProcessingState"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching dynamic groups: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	groupsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateGroupCollectionResponseFromDiscriminatorValue, func(group models.Groupable) bool {
+		id, groupData := convertDynamicGroupToMap(group)
+		groupsData[id] = groupData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through dynamic groups: %v", err)
+	}
+
+	dynamicData := map[string]interface{}{
+		"groups":           groupsData,
+		"processingErrors": []string{},
+		"warnings":         []string{},
+	}
+
+	// The processing errors are only reported by the beta endpoint, the rules are still useful
+	// without them
+	statuses, err := getProcessingStatuses(ctx, client)
+	if err != nil {
+		dynamicData["warnings"] = []string{fmt.Sprintf("processing errors could not be read: %s", odata.ErrorMessage(err))}
+		return json.MarshalIndent(dynamicData, "", "  ")
+	}
+
+	processingErrors := []string{}
+	for id, status := range statuses {
+		groupData, ok := groupsData[id].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		groupData["processingStatus"] = status.Status
+		if status.LastMembershipUpdated != nil {
+			groupData["lastMembershipUpdated"] = *status.LastMembershipUpdated
+		}
+		failed := status.Status == "Failed"
+		groupData["processingError"] = failed
+		if failed {
+			if status.ErrorMessage != nil {
+				groupData["errorMessage"] = *status.ErrorMessage
+			}
+			processingErrors = append(processingErrors, id)
+		}
+	}
+	sort.Strings(processingErrors)
+	dynamicData["processingErrors"] = processingErrors
+
+	return json.MarshalIndent(dynamicData, "", "  ")
+}
+
+// getProcessingStatuses retrieves the processing status of the rule of each dynamic group, keyed
+// by group id, following the next links of the beta listing.
+func getProcessingStatuses(ctx context.Context, client *msgraphsdk.GraphServiceClient) (map[string]processingStatus, error) {
+
+	query := url.Values{}
+	query.Set("$filter", dynamicFilter)
+	query.Set("$select", "id,membershipRuleProcessingStatus")
+
+	statuses := make(map[string]processingStatus)
+	err := beta.Iterate(ctx, client, "/groups?"+query.Encode(), func(group dynamicGroupStatus) bool {
+		if group.Status != nil {
+			statuses[group.Id] = *group.Status
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+// EvaluateRule checks whether a member satisfies a membership rule, or the rule of the given
+// group when rule is empty, with the result of each clause.
+func EvaluateRule(ctx context.Context, client *msgraphsdk.GraphServiceClient, memberId string, rule string, groupId string) ([]byte, error) {
+
+	path := "/groups/evaluateDynamicMembership"
+	body := map[string]interface{}{
+		"memberId": memberId,
+	}
+	if rule != "" {
+		body["membershipRule"] = rule
+	} else {
+		path = "/groups/" + url.PathEscape(groupId) + "/evaluateDynamicMembership"
+	}
+
+	content, err := beta.Do(ctx, client, abstractions.POST, path, body)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating membership rule: %w", err)
+	}
+
+	var evaluation struct {
+		MembershipRule          string `json:"membershipRule"`
+		MembershipRuleSatisfied bool   `json:"membershipRuleEvaluationResult"`
+		Details                 *struct {
+			Clauses []struct {
+				Clause       string  `json:"membershipRuleClause"`
+				Satisfied    bool    `json:"result"`
+				ActualValue  *string `json:"actualValue"`
+				ErrorMessage *string `json:"errorMessage"`
+			} `json:"ruleClauses"`
+		} `json:"membershipRuleEvaluationDetails"`
+	}
+	if err := json.Unmarshal(content, &evaluation); err != nil {
+		return nil, fmt.Errorf("error decoding membership rule evaluation: %v", err)
+	}
+
+	clauses := []interface{}{}
+	if evaluation.Details != nil {
+		for _, clause := range evaluation.Details.Clauses {
+			clauseData := map[string]interface{}{
+				"clause":    clause.Clause,
+				"satisfied": clause.Satisfied,
+			}
+			if clause.ActualValue != nil {
+				clauseData["actualValue"] = *clause.ActualValue
+			}
+			if clause.ErrorMessage != nil {
+				clauseData["errorMessage"] = *clause.ErrorMessage
+			}
+			clauses = append(clauses, clauseData)
+		}
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"membershipRule":          evaluation.MembershipRule,
+		"membershipRuleSatisfied": evaluation.MembershipRuleSatisfied,
+		"clauses":                 clauses,
+	}, "", "  ")
+}
+
+// convertDynamicGroupToMap converts a dynamic group to a map with its membership rule
+func convertDynamicGroupToMap(group models.Groupable) (string, map[string]interface{}) {
+
+	groupId := ""
+	groupData := make(map[string]interface{})
+
+	if id := group.GetId(); id != nil {
+		groupId = *id
+		groupData["id"] = groupId
+	}
+	if displayName := group.GetDisplayName(); displayName != nil {
+		groupData["displayName"] = *displayName
+	}
+	if membershipRule := group.GetMembershipRule(); membershipRule != nil {
+		groupData["membershipRule"] = *membershipRule
+	}
+	if state := group.GetMembershipRuleProcessingState(); state != nil {
+		groupData["membershipRuleProcessingState"] = *state
+	}
+
+	return groupId, groupData
+}
@@ -0,0 +1,178 @@
+package groups
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// groupFields are the attributes of a group returned by the groups tool.
+var groupFields = []string{"id", "displayName", "mail", "mailEnabled", "securityEnabled", "groupTypes", "description", "visibility"}
+
+func init() {
+	// Group Tool is a tool that interacts with microsoft for group APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "groups",
+			Tool: mcp.NewTool("groups",
+				mcp.WithDescription("Interact with Microsoft Graph API for group operations: list the security and Microsoft 365 groups, or find them by name. Requires Group.Read.All."),
+				mcp.WithString("name",
+					mcp.Description("The display name of the group. If not provided, all groups will be returned."),
+				),
+				odata.WithMatchMode(),
+				paginate.WithLimit(),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Group.Read.All"},
+			OutputSchema:   groupSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a groupsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				params := &groups.GroupsRequestBuilderGetQueryParameters{}
+				if a.Name != "" {
+					filter, search, err := odata.Match("displayName", a.Name, a.MatchMode)
+					if err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+					params.Filter, params.Search = filter, search
+				}
+				limit, capped, err := paginate.Limit(request, a.Limit, params.Filter != nil || params.Search != nil)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				// Get the list of groups
+				jsonData, err := Get(ctx, client, params, limit)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get groups"), err
+				}
+				if capped {
+					return paginate.CappedNote(mcp.NewToolResultText(string(jsonData)), limit), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// groupSchema describes the result of the groups tool.
+var groupSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":              schema.String(),
+	"displayName":     schema.String(),
+	"mail":            schema.String(),
+	"mailEnabled":     schema.Boolean(),
+	"securityEnabled": schema.Boolean(),
+	"groupTypes":      schema.Array(schema.String()),
+	"description":     schema.String(),
+	"visibility":      schema.String(),
+}))
+
+// groupsArgs are the arguments of the groups tool.
+type groupsArgs struct {
+	Name      string `json:"name"`
+	MatchMode string `json:"matchMode"`
+	Limit     int    `json:"limit"`
+}
+
+// Get retrieves all groups from Microsoft Graph, or the first limit ones if it is not zero.
+func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *groups.GroupsRequestBuilderGetQueryParameters, limit int) ([]byte, error) {
+
+	if params == nil {
+		params = &groups.GroupsRequestBuilderGetQueryParameters{}
+	}
+	if len(params.Select) == 0 {
+		params.Select = groupFields
+	}
+
+	requestConfig := &groups.GroupsRequestBuilderGetRequestConfiguration{
+		QueryParameters: params,
+	}
+	// $search is an advanced query, it requires the ConsistencyLevel header
+	if params.Search != nil {
+		requestConfig.Headers = abstractions.NewRequestHeaders()
+		requestConfig.Headers.Add("ConsistencyLevel", "eventual")
+	}
+
+	result, err := client.Groups().Get(ctx, requestConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a map to store the JSON-friendly data
+	groupsData := make(map[string]interface{})
+
+	// Convert each group of every page to a map of attributes
+	err = paginate.Iterate(ctx, client, result, models.CreateGroupCollectionResponseFromDiscriminatorValue, func(group models.Groupable) bool {
+		id, groupData := convertGroupToMap(group)
+		groupsData[id] = groupData
+		return limit == 0 || len(groupsData) < limit
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(groupsData, "", "  ")
+}
+
+// convertGroupToMap converts a group model to a map with its identity and type attributes.
+// Groups without mail, like most security groups, are returned without it
+func convertGroupToMap(group models.Groupable) (string, map[string]interface{}) {
+
+	groupId := ""
+	groupData := make(map[string]interface{})
+
+	if id := group.GetId(); id != nil {
+		groupId = *id
+		groupData["id"] = groupId
+	}
+	if displayName := group.GetDisplayName(); displayName != nil {
+		groupData["displayName"] = *displayName
+	}
+	if mail := group.GetMail(); mail != nil {
+		groupData["mail"] = *mail
+	}
+	if mailEnabled := group.GetMailEnabled(); mailEnabled != nil {
+		groupData["mailEnabled"] = *mailEnabled
+	}
+	if securityEnabled := group.GetSecurityEnabled(); securityEnabled != nil {
+		groupData["securityEnabled"] = *securityEnabled
+	}
+	groupData["groupTypes"] = []string{}
+	if groupTypes := group.GetGroupTypes(); groupTypes != nil {
+		groupData["groupTypes"] = groupTypes
+	}
+	if description := group.GetDescription(); description != nil {
+		groupData["description"] = *description
+	}
+	if visibility := group.GetVisibility(); visibility != nil {
+		groupData["visibility"] = *visibility
+	}
+
+	return groupId, groupData
+}
@@ -0,0 +1,34 @@
+package groups
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+func TestGroupTypes(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		group map[string]interface{}
+		want  []interface{}
+	}{
+		{"unified", map[string]interface{}{"id": "group-id", "groupTypes": []interface{}{"Unified"}}, []interface{}{"Unified"}},
+		{"security", map[string]interface{}{"id": "group-id", "groupTypes": []interface{}{}}, []interface{}{}},
+		{"not returned", map[string]interface{}{"id": "group-id"}, []interface{}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := graphtest.CheckTool(t, "groups", nil, graphtest.Routes{
+				"GET /v1.0/groups": map[string]interface{}{"value": []interface{}{test.group}},
+			})
+
+			groupData, _ := result.(map[string]interface{})["group-id"].(map[string]interface{})
+			if got := groupData["groupTypes"]; !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got groupTypes %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
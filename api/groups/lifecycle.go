@@ -0,0 +1,184 @@
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Group Lifecycle Tool is a tool that reports the group expiration policy and the groups about to expire.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "group_lifecycle",
+			Tool: mcp.NewTool("group_lifecycle",
+				mcp.WithDescription("Read the group expiration (lifecycle) policy of the tenant and the Microsoft 365 groups nearing expiration"),
+				mcp.WithNumber("within_days",
+					mcp.Description("Report groups expiring within this number of days. Defaults to 30."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Directory.Read.All"},
+			OutputSchema:   lifecycleSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := lifecycleArgs{WithinDays: 30}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetLifecycle(ctx, client, a.WithinDays)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get group lifecycle"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// lifecycleSchema describes the result of the group_lifecycle tool.
+var lifecycleSchema = schema.Object(map[string]schema.Schema{
+	"policies": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":                          schema.String(),
+		"lifetimeInDays":              schema.Integer(),
+		"managedGroupTypes":           schema.String(),
+		"alternateNotificationEmails": schema.String(),
+	})),
+	"nearingExpiration": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":                  schema.String(),
+		"displayName":         schema.String(),
+		"mail":                schema.String(),
+		"renewedDateTime":     schema.DateTime(),
+		"expirationDateTime":  schema.DateTime(),
+		"daysUntilExpiration": schema.Integer(),
+	})),
+	"message": schema.String(),
+})
+
+// lifecycleArgs are the arguments of the group_lifecycle tool.
+type lifecycleArgs struct {
+	WithinDays int `json:"within_days"`
+}
+
+// Validate checks that the window is not negative.
+func (a *lifecycleArgs) Validate() error {
+
+	if a.WithinDays < 0 {
+		return fmt.Errorf("within_days must be positive")
+	}
+
+	return nil
+}
+
+// GetLifecycle retrieves the group lifecycle policies and the groups expiring within the given number of days.
+func GetLifecycle(ctx context.Context, client *msgraphsdk.GraphServiceClient, withinDays int) ([]byte, error) {
+
+	policies, err := client.GroupLifecyclePolicies().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching group lifecycle policies: %v", err)
+	}
+
+	lifecycleData := make(map[string]interface{})
+
+	if len(policies.GetValue()) == 0 {
+		lifecycleData["policies"] = map[string]interface{}{}
+		lifecycleData["message"] = "No group lifecycle policy is configured for this tenant: groups do not expire."
+		return json.MarshalIndent(lifecycleData, "", "  ")
+	}
+
+	policiesData := make(map[string]interface{})
+	for _, policy := range policies.GetValue() {
+		id, policyData := convertLifecyclePolicyToMap(policy)
+		policiesData[id] = policyData
+	}
+	lifecycleData["policies"] = policiesData
+
+	// Only Microsoft 365 groups are subject to expiration
+	params := &groups.GroupsRequestBuilderGetQueryParameters{
+		Filter: to.Ptr("groupTypes/any(c:c eq 'Unified')"),
+		Select: []string{"id", "displayName", "mail", "createdDateTime", "renewedDateTime", "expirationDateTime"},
+	}
+	result, err := client.Groups().Get(ctx, &groups.GroupsRequestBuilderGetRequestConfiguration{QueryParameters: params})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching groups: %v", err)
+	}
+
+	now := time.Now()
+	deadline := now.AddDate(0, 0, withinDays)
+	groupsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateGroupCollectionResponseFromDiscriminatorValue, func(group models.Groupable) bool {
+		expiration := group.GetExpirationDateTime()
+		if group.GetId() == nil || expiration == nil || expiration.After(deadline) {
+			return true
+		}
+
+		groupData := map[string]interface{}{
+			"id":                  *group.GetId(),
+			"expirationDateTime":  expiration.Format(time.RFC3339),
+			"daysUntilExpiration": int(math.Floor(expiration.Sub(now).Hours() / 24)),
+		}
+		if displayName := group.GetDisplayName(); displayName != nil {
+			groupData["displayName"] = *displayName
+		}
+		if mail := group.GetMail(); mail != nil {
+			groupData["mail"] = *mail
+		}
+		if renewed := group.GetRenewedDateTime(); renewed != nil {
+			groupData["renewedDateTime"] = renewed.Format(time.RFC3339)
+		}
+		groupsData[*group.GetId()] = groupData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through groups: %v", err)
+	}
+
+	lifecycleData["nearingExpiration"] = groupsData
+
+	return json.MarshalIndent(lifecycleData, "", "  ")
+}
+
+// convertLifecyclePolicyToMap converts a group lifecycle policy to a map
+func convertLifecyclePolicyToMap(policy models.GroupLifecyclePolicyable) (string, map[string]interface{}) {
+
+	policyId := ""
+	policyData := make(map[string]interface{})
+
+	if id := policy.GetId(); id != nil {
+		policyId = *id
+		policyData["id"] = policyId
+	}
+	if lifetime := policy.GetGroupLifetimeInDays(); lifetime != nil {
+		policyData["lifetimeInDays"] = *lifetime
+	}
+	if managedGroupTypes := policy.GetManagedGroupTypes(); managedGroupTypes != nil {
+		policyData["managedGroupTypes"] = *managedGroupTypes
+	}
+	if emails := policy.GetAlternateNotificationEmails(); emails != nil {
+		policyData["alternateNotificationEmails"] = *emails
+	}
+
+	return policyId, policyData
+}
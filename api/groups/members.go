@@ -0,0 +1,154 @@
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Update Group Members Tool is a tool that adds or removes members of a group.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "update_group_members",
+			Tool: mcp.NewTool("update_group_members",
+				mcp.WithDescription("Add members to a group or remove members from it. Members are directory objects given by id: users, groups, devices or service principals. Each member is processed on its own and reported individually, a failure does not stop the others. To add many users by user principal name use add_group_members. Requires GroupMember.ReadWrite.All."),
+				mcp.WithString("group_id",
+					mcp.Required(),
+					mcp.Description("The id of the group."),
+				),
+				mcp.WithString("action",
+					mcp.Required(),
+					mcp.Enum("add", "remove"),
+					mcp.Description("'add' adds the members to the group, 'remove' removes them from it."),
+				),
+				mcp.WithString("member_ids",
+					mcp.Required(),
+					mcp.Description("Comma separated list of the directory object ids of the members, GUIDs."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"GroupMember.ReadWrite.All"},
+			OutputSchema:   updateMembersSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a updateMembersArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := UpdateMembers(ctx, client, a.GroupId, a.Action, a.memberIds)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the group '%s' does not exist", a.GroupId)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to update group members: %s", odata.ErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// updateMembersSchema describes the result of the update_group_members tool, keyed by member id.
+var updateMembersSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"success": schema.Boolean(),
+	"action":  schema.Enum("add", "remove"),
+	"error":   schema.String(),
+}))
+
+// updateMembersArgs are the arguments of the update_group_members tool.
+type updateMembersArgs struct {
+	GroupId   string `json:"group_id"`
+	Action    string `json:"action"`
+	MemberIds string `json:"member_ids"`
+
+	memberIds []string
+}
+
+// Validate checks that the group, the action and the members are given, the members by object id.
+func (a *updateMembersArgs) Validate() error {
+
+	if a.GroupId == "" {
+		return fmt.Errorf("group_id is required")
+	}
+	if a.Action != "add" && a.Action != "remove" {
+		return fmt.Errorf("unsupported action '%s'", a.Action)
+	}
+
+	for _, memberId := range strings.Split(a.MemberIds, ",") {
+		if memberId = strings.TrimSpace(memberId); memberId == "" {
+			continue
+		}
+		// The ids are put in the URL of the references, anything else than an object id could point elsewhere
+		if !odata.IsGUID(memberId) {
+			return fmt.Errorf("member_ids must be the object ids of the members, not '%s'", memberId)
+		}
+		a.memberIds = append(a.memberIds, memberId)
+	}
+	if len(a.memberIds) == 0 {
+		return fmt.Errorf("member_ids is required")
+	}
+
+	return nil
+}
+
+// UpdateMembers adds each of the members to the group, or removes them from it.
+// The members are processed one by one so that a failure only affects the member it concerns.
+func UpdateMembers(ctx context.Context, client *msgraphsdk.GraphServiceClient, groupId string, action string, memberIds []string) ([]byte, error) {
+
+	if err := checkGroup(ctx, client, groupId); err != nil {
+		return nil, err
+	}
+
+	baseUrl := client.GetAdapter().GetBaseUrl()
+	resultsData := make(map[string]interface{})
+
+	for _, memberId := range memberIds {
+
+		var err error
+		switch action {
+		case "add":
+			odataId := fmt.Sprintf("%s/directoryObjects/%s", baseUrl, memberId)
+			reference := models.NewReferenceCreate()
+			reference.SetOdataId(&odataId)
+			err = client.Groups().ByGroupId(groupId).Members().Ref().Post(ctx, reference, nil)
+		case "remove":
+			err = client.Groups().ByGroupId(groupId).Members().ByDirectoryObjectId(memberId).Ref().Delete(ctx, nil)
+		default:
+			return nil, fmt.Errorf("unsupported action '%s'", action)
+		}
+
+		if err != nil {
+			resultsData[memberId] = map[string]interface{}{
+				"success": false,
+				"action":  action,
+				"error":   odata.ErrorMessage(err),
+			}
+			continue
+		}
+		resultsData[memberId] = map[string]interface{}{
+			"success": true,
+			"action":  action,
+		}
+	}
+
+	return json.MarshalIndent(resultsData, "", "  ")
+}
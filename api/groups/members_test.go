@@ -0,0 +1,36 @@
+package groups
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+func TestUpdateMembers(t *testing.T) {
+
+	const memberId = "9f4b6f2c-3b4a-4a8e-8f4e-2a8f1e6c7d10"
+
+	routes := graphtest.Routes{
+		"GET /v1.0/groups/group-id":                                  map[string]interface{}{"id": "group-id"},
+		"DELETE /v1.0/groups/group-id/members/" + memberId + "/$ref": graphtest.Error{Status: http.StatusBadRequest, Code: "Request_BadRequest", Message: "The member is not in the group."},
+	}
+
+	t.Run("not an object id", func(t *testing.T) {
+		for _, memberIds := range []string{"adelev@contoso.com", memberId + ",../../users/user-id"} {
+			result := graphtest.CallTool(t, "update_group_members", map[string]interface{}{"group_id": "group-id", "action": "remove", "member_ids": memberIds}, routes)
+			if !result.IsError {
+				t.Errorf("%s: expected an error, got %+v", memberIds, result.Content)
+			}
+		}
+	})
+
+	t.Run("failure keeps the action", func(t *testing.T) {
+		result := graphtest.CheckTool(t, "update_group_members", map[string]interface{}{"group_id": "group-id", "action": "remove", "member_ids": memberId}, routes)
+
+		memberData, _ := result.(map[string]interface{})[memberId].(map[string]interface{})
+		if memberData["success"] != false || memberData["action"] != "remove" {
+			t.Errorf("got %v, want a failed remove", memberData)
+		}
+	})
+}
@@ -0,0 +1,322 @@
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+const (
+	// defaultDepth is the number of nesting levels expanded when no max_depth is given.
+	defaultDepth = 5
+	// maxDepth is the largest number of nesting levels expanded in one call.
+	maxDepth = 10
+)
+
+func init() {
+	// Nested Membership Tool is a tool that interacts with microsoft for group member APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "nested_group_membership",
+			Tool: mcp.NewTool("nested_group_membership",
+				mcp.WithDescription("Expand the nested groups of a group to answer who ultimately gets access through it. 'tree' returns the membership hierarchy with the depth of each member, 'flat' returns the users reached through any level of nesting with the shortest chain of groups granting them membership. Cycles between groups are reported and not followed, and nesting deeper than max_depth is not expanded. Requires GroupMember.Read.All."),
+				mcp.WithString("group_id",
+					mcp.Required(),
+					mcp.Description("The id of the group to expand."),
+				),
+				mcp.WithString("mode",
+					mcp.Enum("tree", "flat"),
+					mcp.DefaultString("tree"),
+					mcp.Description("tree: the membership hierarchy. flat: the users ultimately members of the group."),
+				),
+				mcp.WithNumber("max_depth",
+					mcp.Description(fmt.Sprintf("The number of nesting levels to expand, at most %d. Defaults to %d.", maxDepth, defaultDepth)),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"GroupMember.Read.All"},
+			OutputSchema:   nestedSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := nestedArgs{MaxDepth: defaultDepth, Mode: "tree"}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetNestedMembership(ctx, client, a.GroupId, a.MaxDepth, a.Mode == "flat")
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the group '%s' does not exist", a.GroupId)), nil
+					}
+					return mcp.NewToolResultError("failed to get nested group membership"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// memberSchema describes a member of the membership tree, groups nesting their own members.
+var memberSchema = schema.Object(map[string]schema.Schema{
+	"id":                schema.String(),
+	"type":              schema.Enum("user", "group", "servicePrincipal", "device", "orgContact", "directoryObject"),
+	"displayName":       schema.String(),
+	"userPrincipalName": schema.String(),
+	"depth":             schema.Integer(),
+	"members":           schema.Map(schema.Object(map[string]schema.Schema{})),
+	"cycle":             schema.Boolean(),
+	"truncated":         schema.Boolean(),
+})
+
+// nestedSchema describes the result of the nested_group_membership tool.
+var nestedSchema = schema.Object(map[string]schema.Schema{
+	"group":    memberSchema,
+	"maxDepth": schema.Integer(),
+	"users": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":                schema.String(),
+		"displayName":       schema.String(),
+		"userPrincipalName": schema.String(),
+		"depth":             schema.Integer(),
+		"via":               schema.Array(schema.String()),
+	})),
+	"cycles":    schema.Array(schema.String()),
+	"truncated": schema.Boolean(),
+})
+
+// nestedArgs are the arguments of the nested_group_membership tool.
+type nestedArgs struct {
+	GroupId  string `json:"group_id"`
+	MaxDepth int    `json:"max_depth"`
+	Mode     string `json:"mode"`
+}
+
+// Validate checks that the group is given, the bounds of the depth and the mode.
+func (a *nestedArgs) Validate() error {
+
+	if a.GroupId == "" {
+		return fmt.Errorf("group_id is required")
+	}
+	if a.MaxDepth <= 0 || a.MaxDepth > maxDepth {
+		return fmt.Errorf("max_depth must be between 1 and %d", maxDepth)
+	}
+	if a.Mode != "tree" && a.Mode != "flat" {
+		return fmt.Errorf("unsupported mode '%s'", a.Mode)
+	}
+
+	return nil
+}
+
+// membershipWalk expands the members of nested groups, fetching the members of each group once.
+type membershipWalk struct {
+	ctx      context.Context
+	client   *msgraphsdk.GraphServiceClient
+	maxDepth int
+
+	members   map[string][]models.DirectoryObjectable
+	users     map[string]interface{}
+	cycles    []string
+	truncated bool
+}
+
+// GetNestedMembership retrieves the members of the group and of its nested groups, down to
+// maxDepth levels. When flat is set, only the users reached through the hierarchy are returned.
+func GetNestedMembership(ctx context.Context, client *msgraphsdk.GraphServiceClient, groupId string, maxDepth int, flat bool) ([]byte, error) {
+
+	group, err := client.Groups().ByGroupId(groupId).Get(ctx, &groups.GroupItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.GroupItemRequestBuilderGetQueryParameters{
+			Select: []string{"id", "displayName"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching group: %w", err)
+	}
+
+	walk := &membershipWalk{
+		ctx:      ctx,
+		client:   client,
+		maxDepth: maxDepth,
+		members:  make(map[string][]models.DirectoryObjectable),
+		users:    make(map[string]interface{}),
+		cycles:   []string{},
+	}
+
+	groupData := convertMemberToMap(group, 0)
+	if err := walk.expand(groupData, groupId, 0, []string{groupId}); err != nil {
+		return nil, err
+	}
+
+	nestedData := map[string]interface{}{
+		"maxDepth":  maxDepth,
+		"cycles":    walk.cycles,
+		"truncated": walk.truncated,
+	}
+	if flat {
+		nestedData["users"] = walk.users
+	} else {
+		nestedData["group"] = groupData
+	}
+
+	return json.MarshalIndent(nestedData, "", "  ")
+}
+
+// expand adds the members of the group to its map, recursing into the nested groups which are
+// not one of its ancestors in path.
+func (w *membershipWalk) expand(groupData map[string]interface{}, groupId string, depth int, path []string) error {
+
+	members, err := w.fetchMembers(groupId)
+	if err != nil {
+		return err
+	}
+
+	membersData := make(map[string]interface{})
+	for _, member := range members {
+		if member.GetId() == nil {
+			continue
+		}
+		id := *member.GetId()
+		memberData := convertMemberToMap(member, depth+1)
+		membersData[id] = memberData
+
+		switch member.(type) {
+		case models.Userable:
+			w.addUser(memberData, path)
+		case models.Groupable:
+			switch {
+			case slices.Contains(path, id):
+				memberData["cycle"] = true
+				w.cycles = append(w.cycles, strings.Join(append(slices.Clone(path), id), " -> "))
+			case depth+1 >= w.maxDepth:
+				memberData["truncated"] = true
+				w.truncated = true
+			default:
+				if err := w.expand(memberData, id, depth+1, append(slices.Clone(path), id)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	groupData["members"] = membersData
+
+	return nil
+}
+
+// fetchMembers returns the direct members of the group, from the cache when it has already
+// been expanded in another branch of the tree.
+func (w *membershipWalk) fetchMembers(groupId string) ([]models.DirectoryObjectable, error) {
+
+	if members, ok := w.members[groupId]; ok {
+		return members, nil
+	}
+
+	result, err := w.client.Groups().ByGroupId(groupId).Members().Get(w.ctx, &groups.ItemMembersRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.ItemMembersRequestBuilderGetQueryParameters{
+			Select: []string{"id", "displayName", "userPrincipalName"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching members of group %s: %v", groupId, err)
+	}
+
+	members := []models.DirectoryObjectable{}
+	err = paginate.Iterate(w.ctx, w.client, result, models.CreateDirectoryObjectCollectionResponseFromDiscriminatorValue, func(member models.DirectoryObjectable) bool {
+		members = append(members, member)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through members of group %s: %v", groupId, err)
+	}
+
+	w.members[groupId] = members
+
+	return members, nil
+}
+
+// addUser records a user reached through the groups in path, keeping the shortest chain.
+func (w *membershipWalk) addUser(memberData map[string]interface{}, path []string) {
+
+	id := memberData["id"].(string)
+	if existing, ok := w.users[id]; ok && existing.(map[string]interface{})["depth"].(int) <= memberData["depth"].(int) {
+		return
+	}
+
+	userData := map[string]interface{}{
+		"id":    id,
+		"depth": memberData["depth"],
+		"via":   slices.Clone(path),
+	}
+	if displayName, ok := memberData["displayName"]; ok {
+		userData["displayName"] = displayName
+	}
+	if userPrincipalName, ok := memberData["userPrincipalName"]; ok {
+		userData["userPrincipalName"] = userPrincipalName
+	}
+	w.users[id] = userData
+}
+
+// convertMemberToMap converts a directory object to a map with its type and depth in the tree
+func convertMemberToMap(member models.DirectoryObjectable, depth int) map[string]interface{} {
+
+	memberData := map[string]interface{}{
+		"depth": depth,
+	}
+
+	if id := member.GetId(); id != nil {
+		memberData["id"] = *id
+	}
+
+	switch m := member.(type) {
+	case models.Userable:
+		memberData["type"] = "user"
+		if displayName := m.GetDisplayName(); displayName != nil {
+			memberData["displayName"] = *displayName
+		}
+		if userPrincipalName := m.GetUserPrincipalName(); userPrincipalName != nil {
+			memberData["userPrincipalName"] = *userPrincipalName
+		}
+	case models.Groupable:
+		memberData["type"] = "group"
+		if displayName := m.GetDisplayName(); displayName != nil {
+			memberData["displayName"] = *displayName
+		}
+	case models.ServicePrincipalable:
+		memberData["type"] = "servicePrincipal"
+		if displayName := m.GetDisplayName(); displayName != nil {
+			memberData["displayName"] = *displayName
+		}
+	case models.Deviceable:
+		memberData["type"] = "device"
+		if displayName := m.GetDisplayName(); displayName != nil {
+			memberData["displayName"] = *displayName
+		}
+	case models.OrgContactable:
+		memberData["type"] = "orgContact"
+		if displayName := m.GetDisplayName(); displayName != nil {
+			memberData["displayName"] = *displayName
+		}
+	default:
+		memberData["type"] = "directoryObject"
+	}
+
+	return memberData
+}
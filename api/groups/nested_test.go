@@ -0,0 +1,126 @@
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// cyclicGraph serves two groups containing each other, along with their users. It counts the
+// requests listing the members of each group.
+func cyclicGraph(t *testing.T, fetches map[string]int) http.Handler {
+
+	user := func(id string) map[string]interface{} {
+		return map[string]interface{}{"@odata.type": "#microsoft.graph.user", "id": id, "displayName": id, "userPrincipalName": id + "@contoso.com"}
+	}
+	group := func(id string) map[string]interface{} {
+		return map[string]interface{}{"@odata.type": "#microsoft.graph.group", "id": id, "displayName": id}
+	}
+	members := map[string][]interface{}{
+		"group-a": {group("group-b"), user("user-1")},
+		"group-b": {group("group-a"), user("user-1"), user("user-2")},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.0/groups/group-a":
+			graphtest.WriteJSON(w, http.StatusOK, group("group-a"))
+		case "/v1.0/groups/group-a/members", "/v1.0/groups/group-b/members":
+			id := r.URL.Path[len("/v1.0/groups/") : len(r.URL.Path)-len("/members")]
+			fetches[id]++
+			graphtest.WriteJSON(w, http.StatusOK, map[string]interface{}{"value": members[id]})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			graphtest.WriteError(w, http.StatusNotFound, "Request_ResourceNotFound", "unexpected request")
+		}
+	})
+}
+
+func TestNestedMembershipCycle(t *testing.T) {
+
+	fetches := map[string]int{}
+	cl, err := graphtest.NewClient(cyclicGraph(t, fetches))
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+
+	jsonData, err := GetNestedMembership(context.Background(), cl, "group-a", maxDepth, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var nested struct {
+		Users     map[string]map[string]interface{} `json:"users"`
+		Cycles    []string                          `json:"cycles"`
+		Truncated bool                              `json:"truncated"`
+	}
+	if err := json.Unmarshal(jsonData, &nested); err != nil {
+		t.Fatalf("decoding result: %v", err)
+	}
+
+	if fetches["group-a"] != 1 || fetches["group-b"] != 1 {
+		t.Errorf("expected the members of each group to be fetched once, got %v", fetches)
+	}
+	if !slices.Equal(nested.Cycles, []string{"group-a -> group-b -> group-a"}) {
+		t.Errorf("unexpected cycles: %v", nested.Cycles)
+	}
+	if nested.Truncated {
+		t.Errorf("a cycle should not be reported as truncated")
+	}
+
+	if len(nested.Users) != 2 {
+		t.Fatalf("expected each user once, got %v", nested.Users)
+	}
+	if u := nested.Users["user-1"]; u["depth"] != float64(1) || !slices.Equal(toStrings(u["via"]), []string{"group-a"}) {
+		t.Errorf("user-1 should be reported with its shortest chain, got %v", u)
+	}
+	if u := nested.Users["user-2"]; u["depth"] != float64(2) || !slices.Equal(toStrings(u["via"]), []string{"group-a", "group-b"}) {
+		t.Errorf("unexpected user-2: %v", u)
+	}
+}
+
+func TestNestedMembershipCycleTree(t *testing.T) {
+
+	cl, err := graphtest.NewClient(cyclicGraph(t, map[string]int{}))
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+
+	jsonData, err := GetNestedMembership(context.Background(), cl, "group-a", maxDepth, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var nested map[string]interface{}
+	if err := json.Unmarshal(jsonData, &nested); err != nil {
+		t.Fatalf("decoding result: %v", err)
+	}
+
+	groupA, _ := nested["group"].(map[string]interface{})
+	groupB, _ := groupA["members"].(map[string]interface{})["group-b"].(map[string]interface{})
+	backToA, _ := groupB["members"].(map[string]interface{})["group-a"].(map[string]interface{})
+	if backToA["cycle"] != true {
+		t.Errorf("the cycle back to group-a should be reported: %v", backToA)
+	}
+	if _, ok := backToA["members"]; ok {
+		t.Errorf("the cycle back to group-a should not be expanded: %v", backToA)
+	}
+}
+
+// toStrings converts a decoded JSON list to a list of strings.
+func toStrings(value interface{}) []string {
+
+	values := []string{}
+	list, _ := value.([]interface{})
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,268 @@
+package groups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+)
+
+const (
+	// teamMemberLimit is the maximum number of members of a team.
+	teamMemberLimit = 25000
+	// approachingRatio is the share of a limit from which a group is reported as approaching it.
+	approachingRatio = 0.8
+)
+
+func init() {
+	// Owned Groups Tool is a tool that interacts with microsoft for group ownership APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "owned_groups",
+			Tool: mcp.NewTool("owned_groups",
+				mcp.WithDescription("List the groups a user owns with their member count and the problems to act on: member count approaching the team limit, paused dynamic membership processing, and service or on-premises provisioning errors. Group mailbox quotas are not exposed by Microsoft Graph. Requires User.Read.All and GroupMember.Read.All."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The id or user principal name of the owner."),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"User.Read.All", "GroupMember.Read.All"},
+			OutputSchema:   ownedSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a ownedArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetOwnedGroups(ctx, client, a.UserId)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the user '%s' does not exist", a.UserId)), nil
+					}
+					return mcp.NewToolResultError("failed to get owned groups"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// ownedSchema describes the result of the owned_groups tool.
+var ownedSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":                            schema.String(),
+	"displayName":                   schema.String(),
+	"mail":                          schema.String(),
+	"groupTypes":                    schema.Array(schema.String()),
+	"isTeam":                        schema.Boolean(),
+	"membershipRule":                schema.String(),
+	"membershipRuleProcessingState": schema.String(),
+	"memberCount":                   schema.Integer(),
+	"memberCountError":              schema.String(),
+	"serviceProvisioningErrors": schema.Array(schema.Object(map[string]schema.Schema{
+		"createdDateTime": schema.DateTime(),
+		"serviceInstance": schema.String(),
+		"isResolved":      schema.Boolean(),
+		"errorDetail":     schema.String(),
+	})),
+	"onPremisesProvisioningErrors": schema.Array(schema.Object(map[string]schema.Schema{
+		"category":             schema.String(),
+		"propertyCausingError": schema.String(),
+		"value":                schema.String(),
+		"occurredDateTime":     schema.DateTime(),
+	})),
+	"warnings": schema.Array(schema.String()),
+}))
+
+// ownedArgs are the arguments of the owned_groups tool.
+type ownedArgs struct {
+	UserId string `json:"user_id"`
+}
+
+// Validate checks that the user is given.
+func (a *ownedArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+
+	return nil
+}
+
+// GetOwnedGroups retrieves the groups owned by the user with their member count and warnings.
+func GetOwnedGroups(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string) ([]byte, error) {
+
+	// The provisioning errors and resourceProvisioningOptions are only returned when selected
+	result, err := client.Users().ByUserId(userId).OwnedObjects().GraphGroup().Get(ctx, &users.ItemOwnedObjectsGraphGroupRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemOwnedObjectsGraphGroupRequestBuilderGetQueryParameters{
+			Select: []string{
+				"id", "displayName", "mail", "groupTypes", "membershipRule", "membershipRuleProcessingState",
+				"resourceProvisioningOptions", "serviceProvisioningErrors", "onPremisesProvisioningErrors",
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching owned groups: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	groupsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateGroupCollectionResponseFromDiscriminatorValue, func(group models.Groupable) bool {
+		id, groupData := convertOwnedGroupToMap(group)
+		groupsData[id] = groupData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through owned groups: %v", err)
+	}
+
+	// Counting members is an advanced query, it requires the ConsistencyLevel header
+	headers := abstractions.NewRequestHeaders()
+	headers.Add("ConsistencyLevel", "eventual")
+
+	for id, data := range groupsData {
+		groupData := data.(map[string]interface{})
+		count, err := client.Groups().ByGroupId(id).Members().Count().Get(ctx, &groups.ItemMembersCountRequestBuilderGetRequestConfiguration{
+			Headers: headers,
+		})
+		if err != nil {
+			groupData["memberCountError"] = odata.ErrorMessage(err)
+			continue
+		}
+		if count == nil {
+			continue
+		}
+		groupData["memberCount"] = *count
+		if groupData["isTeam"] == true && float64(*count) >= approachingRatio*teamMemberLimit {
+			groupData["warnings"] = append(groupData["warnings"].([]string), fmt.Sprintf("%d members, approaching the team limit of %d", *count, teamMemberLimit))
+		}
+	}
+
+	return json.MarshalIndent(groupsData, "", "  ")
+}
+
+// convertOwnedGroupToMap converts an owned group to a map with its processing state and
+// provisioning errors, and the warnings they raise
+func convertOwnedGroupToMap(group models.Groupable) (string, map[string]interface{}) {
+
+	groupId := ""
+	groupData := make(map[string]interface{})
+	warnings := []string{}
+
+	if id := group.GetId(); id != nil {
+		groupId = *id
+		groupData["id"] = groupId
+	}
+	if displayName := group.GetDisplayName(); displayName != nil {
+		groupData["displayName"] = *displayName
+	}
+	if mail := group.GetMail(); mail != nil {
+		groupData["mail"] = *mail
+	}
+	groupData["groupTypes"] = []string{}
+	if groupTypes := group.GetGroupTypes(); groupTypes != nil {
+		groupData["groupTypes"] = groupTypes
+	}
+	if membershipRule := group.GetMembershipRule(); membershipRule != nil {
+		groupData["membershipRule"] = *membershipRule
+	}
+	if state := group.GetMembershipRuleProcessingState(); state != nil {
+		groupData["membershipRuleProcessingState"] = *state
+		if strings.EqualFold(*state, "Paused") {
+			warnings = append(warnings, "dynamic membership processing is paused, members are not updated")
+		}
+	}
+
+	isTeam := false
+	if options, ok := group.GetAdditionalData()["resourceProvisioningOptions"].([]interface{}); ok {
+		isTeam = slices.ContainsFunc(options, func(option interface{}) bool {
+			value, ok := option.(*string)
+			return ok && value != nil && *value == "Team"
+		})
+	}
+	groupData["isTeam"] = isTeam
+
+	serviceErrors := []interface{}{}
+	unresolved := 0
+	for _, provisioningError := range group.GetServiceProvisioningErrors() {
+		errorData := make(map[string]interface{})
+		if createdDateTime := provisioningError.GetCreatedDateTime(); createdDateTime != nil {
+			errorData["createdDateTime"] = createdDateTime.Format(time.RFC3339)
+		}
+		if serviceInstance := provisioningError.GetServiceInstance(); serviceInstance != nil {
+			errorData["serviceInstance"] = *serviceInstance
+		}
+		if isResolved := provisioningError.GetIsResolved(); isResolved != nil {
+			errorData["isResolved"] = *isResolved
+			if !*isResolved {
+				unresolved++
+			}
+		}
+		if xmlError, ok := provisioningError.(models.ServiceProvisioningXmlErrorable); ok && xmlError.GetErrorDetail() != nil {
+			errorData["errorDetail"] = *xmlError.GetErrorDetail()
+		}
+		serviceErrors = append(serviceErrors, errorData)
+	}
+	if len(serviceErrors) > 0 {
+		groupData["serviceProvisioningErrors"] = serviceErrors
+	}
+	if unresolved > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d unresolved service provisioning errors", unresolved))
+	}
+
+	onPremisesErrors := []interface{}{}
+	for _, provisioningError := range group.GetOnPremisesProvisioningErrors() {
+		errorData := make(map[string]interface{})
+		if category := provisioningError.GetCategory(); category != nil {
+			errorData["category"] = *category
+		}
+		if property := provisioningError.GetPropertyCausingError(); property != nil {
+			errorData["propertyCausingError"] = *property
+		}
+		if value := provisioningError.GetValue(); value != nil {
+			errorData["value"] = *value
+		}
+		if occurredDateTime := provisioningError.GetOccurredDateTime(); occurredDateTime != nil {
+			errorData["occurredDateTime"] = occurredDateTime.Format(time.RFC3339)
+		}
+		onPremisesErrors = append(onPremisesErrors, errorData)
+	}
+	if len(onPremisesErrors) > 0 {
+		groupData["onPremisesProvisioningErrors"] = onPremisesErrors
+		warnings = append(warnings, fmt.Sprintf("%d on-premises provisioning errors", len(onPremisesErrors)))
+	}
+
+	groupData["warnings"] = warnings
+
+	return groupId, groupData
+}
@@ -0,0 +1,132 @@
+package identityprotection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/identityprotection"
+)
+
+func init() {
+	// Risky Users Action Tool is a tool that dismisses or confirms the risk of users.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "risky_users_action",
+			Tool: mcp.NewTool("risky_users_action",
+				mcp.WithDescription("Dismiss the risk of users or confirm them as compromised in Entra ID Protection. Each user is processed on its own and reported individually. Requires IdentityRiskyUser.ReadWrite.All."),
+				mcp.WithString("action",
+					mcp.Required(),
+					mcp.Enum("dismiss", "confirm_compromised"),
+					mcp.Description("'dismiss' clears the risk of the users, 'confirm_compromised' sets their risk to high."),
+				),
+				mcp.WithString("user_ids",
+					mcp.Required(),
+					mcp.Description("Comma separated list of user ids."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"IdentityRiskyUser.ReadWrite.All"},
+			OutputSchema:   riskActionSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a riskActionArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := UpdateRisk(ctx, client, a.Action, a.userIds)
+				if err != nil {
+					return mcp.NewToolResultError("failed to update risky users"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// riskActionSchema describes the result of the risky_users_action tool, keyed by user id.
+var riskActionSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"success": schema.Boolean(),
+	"action":  schema.Enum("dismiss", "confirm_compromised"),
+	"error":   schema.String(),
+}))
+
+// riskActionArgs are the arguments of the risky_users_action tool.
+type riskActionArgs struct {
+	Action  string `json:"action"`
+	UserIds string `json:"user_ids"`
+
+	userIds []string
+}
+
+// Validate checks the action and that the users are given.
+func (a *riskActionArgs) Validate() error {
+
+	if a.Action != "dismiss" && a.Action != "confirm_compromised" {
+		return fmt.Errorf("unsupported action '%s'", a.Action)
+	}
+
+	for _, userId := range strings.Split(a.UserIds, ",") {
+		if userId = strings.TrimSpace(userId); userId != "" {
+			a.userIds = append(a.userIds, userId)
+		}
+	}
+	if len(a.userIds) == 0 {
+		return fmt.Errorf("user_ids is required")
+	}
+
+	return nil
+}
+
+// UpdateRisk dismisses or confirms as compromised each of the given users.
+// The users are processed one by one so that a failure only affects the user it concerns.
+func UpdateRisk(ctx context.Context, client *msgraphsdk.GraphServiceClient, action string, userIds []string) ([]byte, error) {
+
+	resultsData := make(map[string]interface{})
+
+	for _, userId := range userIds {
+
+		var err error
+		switch action {
+		case "dismiss":
+			body := identityprotection.NewRiskyUsersDismissPostRequestBody()
+			body.SetUserIds([]string{userId})
+			err = client.IdentityProtection().RiskyUsers().Dismiss().Post(ctx, body, nil)
+		case "confirm_compromised":
+			body := identityprotection.NewRiskyUsersConfirmCompromisedPostRequestBody()
+			body.SetUserIds([]string{userId})
+			err = client.IdentityProtection().RiskyUsers().ConfirmCompromised().Post(ctx, body, nil)
+		default:
+			return nil, fmt.Errorf("unsupported action '%s'", action)
+		}
+
+		if err != nil {
+			resultsData[userId] = map[string]interface{}{
+				"success": false,
+				"error":   odata.ErrorMessage(err),
+			}
+			continue
+		}
+		resultsData[userId] = map[string]interface{}{
+			"success": true,
+			"action":  action,
+		}
+	}
+
+	return json.MarshalIndent(resultsData, "", "  ")
+}
@@ -0,0 +1,303 @@
+package identityprotection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/identityprotection"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// defaultLimit is the number of risky users or risk detections returned when no limit is given.
+const defaultLimit = 100
+
+// riskLevels are the risk levels entries can be filtered on.
+var riskLevels = []string{"low", "medium", "high", "hidden", "none"}
+
+// maxPageSize is the largest page size accepted by the identity protection APIs.
+const maxPageSize = 500
+
+func init() {
+	// Risky Users Tool is a tool that interacts with microsoft for identity protection APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "risky_users",
+			Tool: mcp.NewTool("risky_users",
+				mcp.WithDescription("Read Entra ID Protection signals: the users flagged as risky or the individual risk detections. Requires IdentityRiskyUser.Read.All, and IdentityRiskEvent.Read.All for the detections."),
+				mcp.WithString("mode",
+					mcp.Enum("users", "detections"),
+					mcp.DefaultString("users"),
+					mcp.Description("The operation to run. 'users' returns the risky users, 'detections' returns the risk detections."),
+				),
+				mcp.WithString("risk_level",
+					mcp.Enum(riskLevels...),
+					mcp.Description("Only return entries with this risk level."),
+				),
+				mcp.WithNumber("limit",
+					mcp.Description(fmt.Sprintf("The maximum number of entries to return. Defaults to %d.", defaultLimit)),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"IdentityRiskyUser.Read.All", "IdentityRiskEvent.Read.All"},
+			OutputSchema:   riskSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := riskyUsersArgs{Mode: "users", Limit: defaultLimit}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				var filter *string
+				if a.RiskLevel != "" {
+					filter = to.Ptr(odata.Eq("riskLevel", a.RiskLevel))
+				}
+
+				if a.Mode == "users" {
+					jsonData, err := GetRiskyUsers(ctx, client, filter, a.Limit)
+					if err != nil {
+						return mcp.NewToolResultError("failed to get risky users"), err
+					}
+					return mcp.NewToolResultText(string(jsonData)), nil
+				}
+
+				jsonData, err := GetRiskDetections(ctx, client, filter, a.Limit)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get risk detections"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// riskSchema describes the result of the risky_users tool in both modes.
+var riskSchema = schema.OneOf(
+	schema.Map(schema.Object(map[string]schema.Schema{
+		"id":                      schema.String(),
+		"userDisplayName":         schema.String(),
+		"userPrincipalName":       schema.String(),
+		"riskLevel":               schema.String(),
+		"riskState":               schema.String(),
+		"riskDetail":              schema.String(),
+		"riskLastUpdatedDateTime": schema.DateTime(),
+		"isDeleted":               schema.Boolean(),
+		"isProcessing":            schema.Boolean(),
+	})),
+	schema.Map(schema.Object(map[string]schema.Schema{
+		"id":                schema.String(),
+		"userId":            schema.String(),
+		"userPrincipalName": schema.String(),
+		"riskEventType":     schema.String(),
+		"riskLevel":         schema.String(),
+		"riskState":         schema.String(),
+		"riskDetail":        schema.String(),
+		"source":            schema.String(),
+		"ipAddress":         schema.String(),
+		"location": schema.Object(map[string]schema.Schema{
+			"city":            schema.String(),
+			"state":           schema.String(),
+			"countryOrRegion": schema.String(),
+		}),
+		"detectedDateTime":    schema.DateTime(),
+		"lastUpdatedDateTime": schema.DateTime(),
+	})),
+)
+
+// riskyUsersArgs are the arguments of the risky_users tool.
+type riskyUsersArgs struct {
+	Mode      string `json:"mode"`
+	RiskLevel string `json:"risk_level"`
+	Limit     int    `json:"limit"`
+}
+
+// Validate checks the mode, the risk level and the limit.
+func (a *riskyUsersArgs) Validate() error {
+
+	if a.Limit <= 0 {
+		return fmt.Errorf("limit must be a positive number")
+	}
+	if a.RiskLevel != "" && !slices.Contains(riskLevels, a.RiskLevel) {
+		return fmt.Errorf("invalid risk_level '%s', expected one of %s", a.RiskLevel, strings.Join(riskLevels, ", "))
+	}
+	if a.Mode != "users" && a.Mode != "detections" {
+		return fmt.Errorf("unsupported mode '%s'", a.Mode)
+	}
+
+	return nil
+}
+
+// GetRiskyUsers retrieves up to limit risky users matching the optional filter.
+func GetRiskyUsers(ctx context.Context, client *msgraphsdk.GraphServiceClient, filter *string, limit int) ([]byte, error) {
+
+	result, err := client.IdentityProtection().RiskyUsers().Get(ctx, &identityprotection.RiskyUsersRequestBuilderGetRequestConfiguration{
+		QueryParameters: &identityprotection.RiskyUsersRequestBuilderGetQueryParameters{
+			Filter: filter,
+			Top:    to.Ptr(int32(min(limit, maxPageSize))),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching risky users: %v", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	usersData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateRiskyUserCollectionResponseFromDiscriminatorValue, func(user models.RiskyUserable) bool {
+		id, userData := convertRiskyUserToMap(user)
+		usersData[id] = userData
+		return len(usersData) < limit
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through risky users: %v", err)
+	}
+
+	return json.MarshalIndent(usersData, "", "  ")
+}
+
+// GetRiskDetections retrieves up to limit risk detections matching the optional filter.
+func GetRiskDetections(ctx context.Context, client *msgraphsdk.GraphServiceClient, filter *string, limit int) ([]byte, error) {
+
+	result, err := client.IdentityProtection().RiskDetections().Get(ctx, &identityprotection.RiskDetectionsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &identityprotection.RiskDetectionsRequestBuilderGetQueryParameters{
+			Filter: filter,
+			Top:    to.Ptr(int32(min(limit, maxPageSize))),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching risk detections: %v", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	detectionsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateRiskDetectionCollectionResponseFromDiscriminatorValue, func(detection models.RiskDetectionable) bool {
+		id, detectionData := convertRiskDetectionToMap(detection)
+		detectionsData[id] = detectionData
+		return len(detectionsData) < limit
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through risk detections: %v", err)
+	}
+
+	return json.MarshalIndent(detectionsData, "", "  ")
+}
+
+// convertRiskyUserToMap converts a risky user model to a map with its risk attributes
+func convertRiskyUserToMap(user models.RiskyUserable) (string, map[string]interface{}) {
+
+	userId := ""
+	userData := make(map[string]interface{})
+
+	if id := user.GetId(); id != nil {
+		userId = *id
+		userData["id"] = userId
+	}
+	if userDisplayName := user.GetUserDisplayName(); userDisplayName != nil {
+		userData["userDisplayName"] = *userDisplayName
+	}
+	if userPrincipalName := user.GetUserPrincipalName(); userPrincipalName != nil {
+		userData["userPrincipalName"] = *userPrincipalName
+	}
+	if riskLevel := user.GetRiskLevel(); riskLevel != nil {
+		userData["riskLevel"] = riskLevel.String()
+	}
+	if riskState := user.GetRiskState(); riskState != nil {
+		userData["riskState"] = riskState.String()
+	}
+	if riskDetail := user.GetRiskDetail(); riskDetail != nil {
+		userData["riskDetail"] = riskDetail.String()
+	}
+	if riskLastUpdatedDateTime := user.GetRiskLastUpdatedDateTime(); riskLastUpdatedDateTime != nil {
+		userData["riskLastUpdatedDateTime"] = riskLastUpdatedDateTime.Format(time.RFC3339)
+	}
+	if isDeleted := user.GetIsDeleted(); isDeleted != nil {
+		userData["isDeleted"] = *isDeleted
+	}
+	if isProcessing := user.GetIsProcessing(); isProcessing != nil {
+		userData["isProcessing"] = *isProcessing
+	}
+
+	return userId, userData
+}
+
+// convertRiskDetectionToMap converts a risk detection model to a map with its risk attributes
+func convertRiskDetectionToMap(detection models.RiskDetectionable) (string, map[string]interface{}) {
+
+	detectionId := ""
+	detectionData := make(map[string]interface{})
+
+	if id := detection.GetId(); id != nil {
+		detectionId = *id
+		detectionData["id"] = detectionId
+	}
+	if userId := detection.GetUserId(); userId != nil {
+		detectionData["userId"] = *userId
+	}
+	if userPrincipalName := detection.GetUserPrincipalName(); userPrincipalName != nil {
+		detectionData["userPrincipalName"] = *userPrincipalName
+	}
+	if riskEventType := detection.GetRiskEventType(); riskEventType != nil {
+		detectionData["riskEventType"] = *riskEventType
+	}
+	if riskLevel := detection.GetRiskLevel(); riskLevel != nil {
+		detectionData["riskLevel"] = riskLevel.String()
+	}
+	if riskState := detection.GetRiskState(); riskState != nil {
+		detectionData["riskState"] = riskState.String()
+	}
+	if riskDetail := detection.GetRiskDetail(); riskDetail != nil {
+		detectionData["riskDetail"] = riskDetail.String()
+	}
+	if source := detection.GetSource(); source != nil {
+		detectionData["source"] = *source
+	}
+	if ipAddress := detection.GetIpAddress(); ipAddress != nil {
+		detectionData["ipAddress"] = *ipAddress
+	}
+	if location := detection.GetLocation(); location != nil {
+		locationData := make(map[string]interface{})
+		if city := location.GetCity(); city != nil {
+			locationData["city"] = *city
+		}
+		if state := location.GetState(); state != nil {
+			locationData["state"] = *state
+		}
+		if countryOrRegion := location.GetCountryOrRegion(); countryOrRegion != nil {
+			locationData["countryOrRegion"] = *countryOrRegion
+		}
+		detectionData["location"] = locationData
+	}
+	if detectedDateTime := detection.GetDetectedDateTime(); detectedDateTime != nil {
+		detectionData["detectedDateTime"] = detectedDateTime.Format(time.RFC3339)
+	}
+	if lastUpdatedDateTime := detection.GetLastUpdatedDateTime(); lastUpdatedDateTime != nil {
+		detectionData["lastUpdatedDateTime"] = lastUpdatedDateTime.Format(time.RFC3339)
+	}
+
+	return detectionId, detectionData
+}
@@ -0,0 +1,34 @@
+package identityprotection
+
+import (
+	"testing"
+)
+
+func TestRiskyUsersArgsValidate(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		args    riskyUsersArgs
+		wantErr string
+	}{
+		{"no risk level", riskyUsersArgs{Mode: "users", Limit: 1}, ""},
+		{"known risk level", riskyUsersArgs{Mode: "detections", RiskLevel: "high", Limit: 1}, ""},
+		{"unknown risk level", riskyUsersArgs{Mode: "users", RiskLevel: "critical", Limit: 1}, "invalid risk_level 'critical', expected one of low, medium, high, hidden, none"},
+		{"injected risk level", riskyUsersArgs{Mode: "users", RiskLevel: "high' or 1 eq 1", Limit: 1}, "invalid risk_level 'high' or 1 eq 1', expected one of low, medium, high, hidden, none"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.args.Validate()
+			if test.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("got error %v, want %s", err, test.wantErr)
+			}
+		})
+	}
+}
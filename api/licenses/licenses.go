@@ -0,0 +1,168 @@
+package licenses
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Licenses Tool is a tool that interacts with microsoft for subscribed SKU APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "licenses",
+			Tool: mcp.NewTool("licenses",
+				mcp.WithDescription("List the license SKUs the tenant is subscribed to, with the units consumed, the prepaid units by state (enabled, suspended, warning, locked out), the units still available and the service plans included. Use it to reconcile license usage. Requires Organization.Read.All."),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Organization.Read.All"},
+			OutputSchema:   licenseSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				jsonData, err := Get(ctx, client)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get licenses"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// licenseSchema describes the result of the licenses tool.
+var licenseSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"skuId":            schema.String(),
+	"skuPartNumber":    schema.String(),
+	"appliesTo":        schema.String(),
+	"capabilityStatus": schema.String(),
+	"consumedUnits":    schema.Integer(),
+	"availableUnits":   schema.Integer(),
+	"prepaidUnits": schema.Object(map[string]schema.Schema{
+		"enabled":   schema.Integer(),
+		"suspended": schema.Integer(),
+		"warning":   schema.Integer(),
+		"lockedOut": schema.Integer(),
+	}),
+	"servicePlans": schema.Array(schema.Object(map[string]schema.Schema{
+		"servicePlanId":      schema.String(),
+		"servicePlanName":    schema.String(),
+		"provisioningStatus": schema.String(),
+		"appliesTo":          schema.String(),
+	})),
+}))
+
+// Get retrieves the SKUs the tenant is subscribed to.
+func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient) ([]byte, error) {
+
+	result, err := client.SubscribedSkus().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching subscribed skus: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	licensesData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateSubscribedSkuCollectionResponseFromDiscriminatorValue, func(sku models.SubscribedSkuable) bool {
+		id, skuData := convertSkuToMap(sku)
+		licensesData[id] = skuData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through subscribed skus: %v", err)
+	}
+
+	return json.MarshalIndent(licensesData, "", "  ")
+}
+
+// convertSkuToMap converts a subscribed SKU model to a map with its unit counts and service plans.
+// Missing counts are reported as 0 so that they can be summed.
+func convertSkuToMap(sku models.SubscribedSkuable) (string, map[string]interface{}) {
+
+	skuId := ""
+	skuData := make(map[string]interface{})
+
+	if id := sku.GetSkuId(); id != nil {
+		skuId = id.String()
+		skuData["skuId"] = skuId
+	}
+	if skuPartNumber := sku.GetSkuPartNumber(); skuPartNumber != nil {
+		skuData["skuPartNumber"] = *skuPartNumber
+	}
+	if appliesTo := sku.GetAppliesTo(); appliesTo != nil {
+		skuData["appliesTo"] = *appliesTo
+	}
+	if capabilityStatus := sku.GetCapabilityStatus(); capabilityStatus != nil {
+		skuData["capabilityStatus"] = *capabilityStatus
+	}
+
+	consumed := count(sku.GetConsumedUnits())
+	skuData["consumedUnits"] = consumed
+
+	prepaidData := map[string]interface{}{
+		"enabled":   int32(0),
+		"suspended": int32(0),
+		"warning":   int32(0),
+		"lockedOut": int32(0),
+	}
+	enabled := int32(0)
+	if prepaid := sku.GetPrepaidUnits(); prepaid != nil {
+		enabled = count(prepaid.GetEnabled())
+		prepaidData["enabled"] = enabled
+		prepaidData["suspended"] = count(prepaid.GetSuspended())
+		prepaidData["warning"] = count(prepaid.GetWarning())
+		prepaidData["lockedOut"] = count(prepaid.GetLockedOut())
+	}
+	skuData["prepaidUnits"] = prepaidData
+	skuData["availableUnits"] = enabled - consumed
+
+	plansData := []interface{}{}
+	for _, plan := range sku.GetServicePlans() {
+		planData := make(map[string]interface{})
+		if servicePlanId := plan.GetServicePlanId(); servicePlanId != nil {
+			planData["servicePlanId"] = servicePlanId.String()
+		}
+		if servicePlanName := plan.GetServicePlanName(); servicePlanName != nil {
+			planData["servicePlanName"] = *servicePlanName
+		}
+		if provisioningStatus := plan.GetProvisioningStatus(); provisioningStatus != nil {
+			planData["provisioningStatus"] = *provisioningStatus
+		}
+		if appliesTo := plan.GetAppliesTo(); appliesTo != nil {
+			planData["appliesTo"] = *appliesTo
+		}
+		plansData = append(plansData, planData)
+	}
+	skuData["servicePlans"] = plansData
+
+	return skuId, skuData
+}
+
+// count returns the value of a unit count, or 0 if it is not set.
+func count(units *int32) int32 {
+
+	if units == nil {
+		return 0
+	}
+
+	return *units
+}
@@ -0,0 +1,308 @@
+package lists
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/sites"
+)
+
+// maxScannedItems is the number of items read at most when filtering on the client side.
+// It matches the SharePoint list view threshold.
+const maxScannedItems = 5000
+
+func init() {
+	// List Items Tool is a tool that lists the items of a SharePoint list.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "list_items",
+			Tool: mcp.NewTool("list_items",
+				mcp.WithDescription("List the items of a SharePoint list, with their field values. Use the lists tool to find the lists of a site, or to query the items by field value."),
+				mcp.WithString("site_id",
+					mcp.Required(),
+					mcp.Description("The id of the site containing the list."),
+				),
+				mcp.WithString("list_id",
+					mcp.Required(),
+					mcp.Description("The id of the list."),
+				),
+				mcp.WithBoolean("with_fields",
+					mcp.Description("Return the field values of the items. Defaults to true, set it to false to list very large lists faster."),
+				),
+				paginate.WithLimit(),
+				trace.WithDebug(),
+			),
+			OutputSchema: itemsSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := listItemsArgs{WithFields: true}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				limit, capped, err := paginate.Limit(request, a.Limit, false)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				jsonData, err := GetItems(ctx, client, a.SiteId, a.ListId, a.WithFields, limit)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the list '%s' does not exist in the site '%s'", a.ListId, a.SiteId)), nil
+					}
+					return mcp.NewToolResultError("failed to get list items"), err
+				}
+				if capped {
+					return paginate.CappedNote(mcp.NewToolResultText(string(jsonData)), limit), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// listItemsArgs are the arguments of the list_items tool.
+type listItemsArgs struct {
+	SiteId     string `json:"site_id"`
+	ListId     string `json:"list_id"`
+	WithFields bool   `json:"with_fields"`
+	Limit      int    `json:"limit"`
+}
+
+// Validate checks that the list is given.
+func (a *listItemsArgs) Validate() error {
+
+	if a.SiteId == "" {
+		return fmt.Errorf("site_id is required")
+	}
+	if a.ListId == "" {
+		return fmt.Errorf("list_id is required")
+	}
+
+	return nil
+}
+
+// itemSchema describes a list item.
+var itemSchema = schema.Object(map[string]schema.Schema{
+	"id":                   schema.String(),
+	"webUrl":               schema.String(),
+	"createdDateTime":      schema.DateTime(),
+	"lastModifiedDateTime": schema.DateTime(),
+	"createdBy":            schema.String(),
+	"lastModifiedBy":       schema.String(),
+	"fields":               schema.Map(schema.Schema{}),
+})
+
+// itemsSchema describes the result of the list_items tool.
+var itemsSchema = schema.Map(itemSchema)
+
+// querySchema describes the result of the lists tool in 'query' mode.
+var querySchema = schema.Object(map[string]schema.Schema{
+	"items":   schema.Map(itemSchema),
+	"warning": schema.String(),
+})
+
+// GetItems retrieves the items of a list, up to limit if it is not zero, with their field values
+// if withFields is set.
+func GetItems(ctx context.Context, client *msgraphsdk.GraphServiceClient, siteId string, listId string, withFields bool, limit int) ([]byte, error) {
+
+	params := &sites.ItemListsItemItemsRequestBuilderGetQueryParameters{}
+	if withFields {
+		params.Expand = []string{"fields"}
+	}
+
+	result, err := client.Sites().BySiteId(siteId).Lists().ByListId(listId).Items().Get(ctx, &sites.ItemListsItemItemsRequestBuilderGetRequestConfiguration{
+		QueryParameters: params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching list items: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	itemsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateListItemCollectionResponseFromDiscriminatorValue, func(item models.ListItemable) bool {
+		id, itemData := convertItemToMap(item)
+		itemsData[id] = itemData
+		return limit == 0 || len(itemsData) < limit
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through list items: %v", err)
+	}
+
+	return json.MarshalIndent(itemsData, "", "  ")
+}
+
+// QueryItems retrieves up to limit items of a list whose field equals the value. Filtering on a
+// column that is not indexed is only allowed by SharePoint on small lists: when Microsoft Graph
+// rejects the filter, the items are filtered on the client side instead and a warning is returned.
+func QueryItems(ctx context.Context, client *msgraphsdk.GraphServiceClient, siteId string, listId string, field string, value string, limit int) ([]byte, error) {
+
+	builder := client.Sites().BySiteId(siteId).Lists().ByListId(listId).Items()
+
+	headers := abstractions.NewRequestHeaders()
+	headers.Add("Prefer", "HonorNonIndexedQueriesWarningMayFailRandomly")
+
+	// Create a map to store the JSON-friendly data
+	itemsData := make(map[string]interface{})
+	queryData := map[string]interface{}{
+		"items": itemsData,
+	}
+
+	result, err := builder.Get(ctx, &sites.ItemListsItemItemsRequestBuilderGetRequestConfiguration{
+		Headers: headers,
+		QueryParameters: &sites.ItemListsItemItemsRequestBuilderGetQueryParameters{
+			Filter: to.Ptr(fieldFilter(field, value)),
+			Expand: []string{"fields"},
+		},
+	})
+	if err == nil {
+		err = paginate.Iterate(ctx, client, result, models.CreateListItemCollectionResponseFromDiscriminatorValue, func(item models.ListItemable) bool {
+			id, itemData := convertItemToMap(item)
+			itemsData[id] = itemData
+			return len(itemsData) < limit
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error iterating through list items: %v", err)
+		}
+		return json.MarshalIndent(queryData, "", "  ")
+	}
+	if odata.StatusCode(err) != http.StatusBadRequest {
+		return nil, fmt.Errorf("error fetching list items: %v", err)
+	}
+
+	// The filter was rejected, typically because the column is not indexed on a large list
+	reason := odata.ErrorMessage(err)
+	result, err = builder.Get(ctx, &sites.ItemListsItemItemsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &sites.ItemListsItemItemsRequestBuilderGetQueryParameters{
+			Expand: []string{"fields"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching list items: %v", err)
+	}
+
+	scanned := 0
+	err = paginate.Iterate(ctx, client, result, models.CreateListItemCollectionResponseFromDiscriminatorValue, func(item models.ListItemable) bool {
+		scanned++
+		if fields := item.GetFields(); fields != nil && fmt.Sprint(fieldValue(fields.GetAdditionalData()[field])) == value {
+			id, itemData := convertItemToMap(item)
+			itemsData[id] = itemData
+		}
+		return len(itemsData) < limit && scanned < maxScannedItems
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through list items: %v", err)
+	}
+
+	warning := fmt.Sprintf("Microsoft Graph rejected the filter (%s), the items were filtered on the client side. Index the column %s to query it directly.", reason, field)
+	if scanned >= maxScannedItems {
+		warning += fmt.Sprintf(" Only the first %d items of the list were read, matching items may be missing.", maxScannedItems)
+	}
+	queryData["warning"] = warning
+
+	return json.MarshalIndent(queryData, "", "  ")
+}
+
+// literalRegex matches the values compared to number and boolean columns, sent unquoted.
+var literalRegex = regexp.MustCompile(`^(-?\d+(\.\d+)?|true|false)$`)
+
+// fieldFilter returns the equality filter on the field of the items. Numbers and booleans are
+// sent as literals, as quoting them makes Microsoft Graph reject the filter on number and yes/no
+// columns. A text column holding such a value rejects the literal instead, and is then filtered
+// on the client side.
+func fieldFilter(field string, value string) string {
+
+	if literalRegex.MatchString(value) {
+		return "fields/" + field + " eq " + value
+	}
+
+	return odata.Eq("fields/"+field, value)
+}
+
+// fieldValue dereferences the value of a field as parsed by the SDK, e.g. *string or *float64.
+func fieldValue(value interface{}) interface{} {
+
+	switch v := value.(type) {
+	case *string:
+		if v != nil {
+			return *v
+		}
+	case *float64:
+		if v != nil {
+			return *v
+		}
+	case *bool:
+		if v != nil {
+			return *v
+		}
+	case *int64:
+		if v != nil {
+			return *v
+		}
+	case *int32:
+		if v != nil {
+			return *v
+		}
+	default:
+		return v
+	}
+	return nil
+}
+
+// convertItemToMap converts a list item to a map with its metadata and field values
+func convertItemToMap(item models.ListItemable) (string, map[string]interface{}) {
+
+	itemId := ""
+	itemData := make(map[string]interface{})
+
+	if id := item.GetId(); id != nil {
+		itemId = *id
+		itemData["id"] = itemId
+	}
+	if webUrl := item.GetWebUrl(); webUrl != nil {
+		itemData["webUrl"] = *webUrl
+	}
+	if createdDateTime := item.GetCreatedDateTime(); createdDateTime != nil {
+		itemData["createdDateTime"] = createdDateTime.Format(time.RFC3339)
+	}
+	if lastModifiedDateTime := item.GetLastModifiedDateTime(); lastModifiedDateTime != nil {
+		itemData["lastModifiedDateTime"] = lastModifiedDateTime.Format(time.RFC3339)
+	}
+	if createdBy := item.GetCreatedBy(); createdBy != nil && createdBy.GetUser() != nil && createdBy.GetUser().GetDisplayName() != nil {
+		itemData["createdBy"] = *createdBy.GetUser().GetDisplayName()
+	}
+	if lastModifiedBy := item.GetLastModifiedBy(); lastModifiedBy != nil && lastModifiedBy.GetUser() != nil && lastModifiedBy.GetUser().GetDisplayName() != nil {
+		itemData["lastModifiedBy"] = *lastModifiedBy.GetUser().GetDisplayName()
+	}
+	if fields := item.GetFields(); fields != nil {
+		fieldsData := make(map[string]interface{})
+		for name, value := range fields.GetAdditionalData() {
+			fieldsData[name] = fieldValue(value)
+		}
+		itemData["fields"] = fieldsData
+	}
+
+	return itemId, itemData
+}
@@ -0,0 +1,125 @@
+package lists
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const listPath = "/v1.0/sites/site-id/lists/list-id"
+
+// pagedItems answers the items of a list in two pages, and checks whether the fields are expanded.
+func pagedItems(t *testing.T, wantFields bool) func(r *http.Request) interface{} {
+	return func(r *http.Request) interface{} {
+
+		// The next pages are requested with the link returned by the first one
+		if r.URL.Query().Get("$skiptoken") == "" {
+			if got := r.URL.Query().Get("$expand") == "fields"; got != wantFields {
+				t.Errorf("got fields expanded %t, want %t", got, wantFields)
+			}
+			return map[string]interface{}{
+				"value":           []interface{}{map[string]interface{}{"id": "1"}, map[string]interface{}{"id": "2"}},
+				"@odata.nextLink": "https://graph.microsoft.com" + listPath + "/items?$skiptoken=2",
+			}
+		}
+		return map[string]interface{}{
+			"value": []interface{}{map[string]interface{}{"id": "3"}},
+		}
+	}
+}
+
+func TestListItems(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		arguments    map[string]interface{}
+		defaultLimit int
+		wantFields   bool
+		want         []string
+		wantCapped   bool
+	}{
+		{"all pages", map[string]interface{}{}, 0, true, []string{"1", "2", "3"}, false},
+		{"without fields", map[string]interface{}{"with_fields": false}, 0, false, []string{"1", "2", "3"}, false},
+		{"explicit limit", map[string]interface{}{"limit": 2}, 0, true, []string{"1", "2"}, false},
+		{"default limit", map[string]interface{}{}, 2, true, []string{"1", "2"}, true},
+		{"default limit not reached", map[string]interface{}{}, 5, true, []string{"1", "2", "3"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func(limit int) { paginate.DefaultLimit = limit }(paginate.DefaultLimit)
+			paginate.DefaultLimit = test.defaultLimit
+
+			arguments := map[string]interface{}{"site_id": "site-id", "list_id": "list-id"}
+			for name, value := range test.arguments {
+				arguments[name] = value
+			}
+			result := graphtest.CallTool(t, "list_items", arguments, graphtest.Routes{
+				"GET " + listPath + "/items": pagedItems(t, test.wantFields),
+			})
+			if result.IsError || len(result.Content) == 0 {
+				t.Fatalf("unexpected result: %+v", result)
+			}
+
+			text, _ := mcp.AsTextContent(result.Content[0])
+			var itemsData map[string]interface{}
+			if err := json.Unmarshal([]byte(text.Text), &itemsData); err != nil {
+				t.Fatalf("decoding result: %v", err)
+			}
+			got := []string{}
+			for id := range itemsData {
+				got = append(got, id)
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(test.want, ",") {
+				t.Errorf("got items %v, want %v", got, test.want)
+			}
+
+			if capped := len(result.Content) > 1; capped != test.wantCapped {
+				t.Errorf("got capped note %t, want %t: %+v", capped, test.wantCapped, result.Content)
+			}
+		})
+	}
+}
+
+func TestQueryItemsFilter(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		field      string
+		value      string
+		wantFilter string
+	}{
+		{"text", "Status", "Open", "fields/Status eq 'Open'"},
+		{"quote", "Status", "O'Brien", "fields/Status eq 'O''Brien'"},
+		{"number", "Quantity", "42", "fields/Quantity eq 42"},
+		{"decimal", "Price", "-4.5", "fields/Price eq -4.5"},
+		{"boolean", "Approved", "true", "fields/Approved eq true"},
+		{"not a number", "Version", "1.2.3", "fields/Version eq '1.2.3'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Microsoft Graph rejects a filter whose value does not match the type of the column
+			result := graphtest.CheckTool(t, "lists", map[string]interface{}{"site_id": "site-id", "list_id": "list-id", "mode": "query", "field": test.field, "value": test.value}, graphtest.Routes{
+				"GET " + listPath + "/items": func(r *http.Request) interface{} {
+					if got := r.URL.Query().Get("$filter"); got != test.wantFilter {
+						return graphtest.Error{Status: http.StatusBadRequest, Code: "invalidRequest", Message: "Invalid filter clause: " + got}
+					}
+					return map[string]interface{}{"value": []interface{}{map[string]interface{}{"id": "1"}}}
+				},
+			})
+
+			queryData, _ := result.(map[string]interface{})
+			if warning, ok := queryData["warning"]; ok {
+				t.Errorf("the items were filtered on the client side: %v", warning)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package lists
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// defaultQueryLimit is the number of items returned by a query when no limit is given.
+const defaultQueryLimit = 100
+
+// fieldName matches the internal name of a list column.
+var fieldName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func init() {
+	// Lists Tool is a tool that interacts with microsoft for SharePoint list APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "lists",
+			Tool: mcp.NewTool("lists",
+				mcp.WithDescription("Interact with Microsoft Graph API for SharePoint list operations: enumerate the lists of a site, read the columns of a list or query its items. The list_items tool lists all the items of a list."),
+				mcp.WithString("site_id",
+					mcp.Required(),
+					mcp.Description("The id of the site containing the list."),
+				),
+				mcp.WithString("list_id",
+					mcp.Description("The id of the list. Required by all modes but 'lists'."),
+				),
+				mcp.WithString("mode",
+					mcp.Enum("lists", "columns", "query"),
+					mcp.Description("The operation to run. 'lists' returns the lists of the site, 'columns' returns the column definitions of the list, 'query' returns the items whose field equals the value. Defaults to 'columns' when list_id is given, 'lists' otherwise."),
+				),
+				mcp.WithString("field",
+					mcp.Description("The internal name of the column to match in 'query' mode, e.g. Status."),
+				),
+				mcp.WithString("value",
+					mcp.Description("The value the field must equal in 'query' mode, e.g. Open. Numbers and true or false are matched against number and yes/no columns."),
+				),
+				mcp.WithNumber("limit",
+					mcp.Description(fmt.Sprintf("The maximum number of items to return in 'query' mode. Defaults to %d.", defaultQueryLimit)),
+				),
+				mcp.WithBoolean("include_hidden",
+					mcp.Description("Include hidden and system columns. Defaults to false."),
+				),
+				trace.WithDebug(),
+			),
+			OutputSchema: schema.OneOf(listSchema, columnSchema, querySchema),
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := listsArgs{Limit: defaultQueryLimit}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				switch a.Mode {
+				case "lists":
+					jsonData, err := GetLists(ctx, client, a.SiteId)
+					if err != nil {
+						if odata.StatusCode(err) == http.StatusNotFound {
+							return mcp.NewToolResultError(fmt.Sprintf("the site '%s' does not exist", a.SiteId)), nil
+						}
+						return mcp.NewToolResultError("failed to get lists"), err
+					}
+					return mcp.NewToolResultText(string(jsonData)), nil
+				case "columns":
+					jsonData, err := GetColumns(ctx, client, a.SiteId, a.ListId, a.IncludeHidden)
+					if err != nil {
+						return mcp.NewToolResultError("failed to get list columns"), err
+					}
+					return mcp.NewToolResultText(string(jsonData)), nil
+				default:
+					jsonData, err := QueryItems(ctx, client, a.SiteId, a.ListId, a.Field, a.Value, a.Limit)
+					if err != nil {
+						return mcp.NewToolResultError("failed to query list items"), err
+					}
+					return mcp.NewToolResultText(string(jsonData)), nil
+				}
+			},
+		},
+	)
+}
+
+// listsArgs are the arguments of the lists tool.
+type listsArgs struct {
+	SiteId        string `json:"site_id"`
+	ListId        string `json:"list_id"`
+	Mode          string `json:"mode"`
+	IncludeHidden bool   `json:"include_hidden"`
+	Field         string `json:"field"`
+	Value         string `json:"value"`
+	Limit         int    `json:"limit"`
+}
+
+// Validate defaults the mode from the list given, and checks that the arguments of the mode are
+// given.
+func (a *listsArgs) Validate() error {
+
+	if a.SiteId == "" {
+		return fmt.Errorf("site_id is required")
+	}
+
+	if a.Mode == "" {
+		a.Mode = "lists"
+		if a.ListId != "" {
+			a.Mode = "columns"
+		}
+	}
+
+	switch a.Mode {
+	case "lists":
+	case "columns", "query":
+		if a.ListId == "" {
+			return fmt.Errorf("list_id is required in %s mode", a.Mode)
+		}
+	default:
+		return fmt.Errorf("unsupported mode '%s'", a.Mode)
+	}
+
+	if a.Mode == "query" && !fieldName.MatchString(a.Field) {
+		return fmt.Errorf("invalid field '%s', expected the internal name of a column", a.Field)
+	}
+	if a.Mode == "query" && a.Limit <= 0 {
+		return fmt.Errorf("limit must be a positive number")
+	}
+
+	return nil
+}
+
+// listSchema describes the result of the lists tool in 'lists' mode.
+var listSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":              schema.String(),
+	"name":            schema.String(),
+	"displayName":     schema.String(),
+	"description":     schema.String(),
+	"webUrl":          schema.String(),
+	"template":        schema.String(),
+	"hidden":          schema.Boolean(),
+	"createdDateTime": schema.DateTime(),
+}))
+
+// GetLists retrieves the lists of a site, document libraries included.
+func GetLists(ctx context.Context, client *msgraphsdk.GraphServiceClient, siteId string) ([]byte, error) {
+
+	result, err := client.Sites().BySiteId(siteId).Lists().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching lists: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	listsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateListCollectionResponseFromDiscriminatorValue, func(list models.Listable) bool {
+		id, listData := convertListToMap(list)
+		listsData[id] = listData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through lists: %v", err)
+	}
+
+	return json.MarshalIndent(listsData, "", "  ")
+}
+
+// convertListToMap converts a list model to a map with its names and template
+func convertListToMap(list models.Listable) (string, map[string]interface{}) {
+
+	listId := ""
+	listData := make(map[string]interface{})
+
+	if id := list.GetId(); id != nil {
+		listId = *id
+		listData["id"] = listId
+	}
+	if name := list.GetName(); name != nil {
+		listData["name"] = *name
+	}
+	if displayName := list.GetDisplayName(); displayName != nil {
+		listData["displayName"] = *displayName
+	}
+	if description := list.GetDescription(); description != nil && *description != "" {
+		listData["description"] = *description
+	}
+	if webUrl := list.GetWebUrl(); webUrl != nil {
+		listData["webUrl"] = *webUrl
+	}
+	if info := list.GetList(); info != nil {
+		if template := info.GetTemplate(); template != nil {
+			listData["template"] = *template
+		}
+		if hidden := info.GetHidden(); hidden != nil {
+			listData["hidden"] = *hidden
+		}
+	}
+	if createdDateTime := list.GetCreatedDateTime(); createdDateTime != nil {
+		listData["createdDateTime"] = createdDateTime.Format(time.RFC3339)
+	}
+
+	return listId, listData
+}
+
+// GetColumns retrieves the column definitions of a list.
+// Hidden and system columns are skipped unless includeHidden is set.
+func GetColumns(ctx context.Context, client *msgraphsdk.GraphServiceClient, siteId string, listId string, includeHidden bool) ([]byte, error) {
+
+	result, err := client.Sites().BySiteId(siteId).Lists().ByListId(listId).Columns().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching columns: %v", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	columnsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateColumnDefinitionCollectionResponseFromDiscriminatorValue, func(column models.ColumnDefinitionable) bool {
+		if !includeHidden && isHiddenColumn(column) {
+			return true
+		}
+		id, columnData := convertColumnToMap(column)
+		columnsData[id] = columnData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through columns: %v", err)
+	}
+
+	return json.MarshalIndent(columnsData, "", "  ")
+}
+
+// isHiddenColumn reports whether the column is hidden or belongs to the system column group.
+func isHiddenColumn(column models.ColumnDefinitionable) bool {
+
+	if hidden := column.GetHidden(); hidden != nil && *hidden {
+		return true
+	}
+	if group := column.GetColumnGroup(); group != nil && *group == "_Hidden" {
+		return true
+	}
+	return false
+}
+
+// columnSchema describes the result of the lists tool.
+var columnSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":               schema.String(),
+	"name":             schema.String(),
+	"displayName":      schema.String(),
+	"description":      schema.String(),
+	"hidden":           schema.Boolean(),
+	"readOnly":         schema.Boolean(),
+	"required":         schema.Boolean(),
+	"indexed":          schema.Boolean(),
+	"columnGroup":      schema.String(),
+	"type":             schema.String(),
+	"choices":          schema.Array(schema.String()),
+	"lookupListId":     schema.String(),
+	"lookupColumnName": schema.String(),
+}))
+
+// convertColumnToMap converts a column definition to a map with its name and type
+func convertColumnToMap(column models.ColumnDefinitionable) (string, map[string]interface{}) {
+
+	columnId := ""
+	columnData := make(map[string]interface{})
+
+	if id := column.GetId(); id != nil {
+		columnId = *id
+		columnData["id"] = columnId
+	}
+	if name := column.GetName(); name != nil {
+		columnData["name"] = *name
+	}
+	if displayName := column.GetDisplayName(); displayName != nil {
+		columnData["displayName"] = *displayName
+	}
+	if description := column.GetDescription(); description != nil && *description != "" {
+		columnData["description"] = *description
+	}
+	if hidden := column.GetHidden(); hidden != nil {
+		columnData["hidden"] = *hidden
+	}
+	if readOnly := column.GetReadOnly(); readOnly != nil {
+		columnData["readOnly"] = *readOnly
+	}
+	if required := column.GetRequired(); required != nil {
+		columnData["required"] = *required
+	}
+	if indexed := column.GetIndexed(); indexed != nil {
+		columnData["indexed"] = *indexed
+	}
+	if group := column.GetColumnGroup(); group != nil {
+		columnData["columnGroup"] = *group
+	}
+
+	columnData["type"] = columnType(column)
+
+	if choice := column.GetChoice(); choice != nil {
+		columnData["choices"] = choice.GetChoices()
+	}
+	if lookup := column.GetLookup(); lookup != nil {
+		if listId := lookup.GetListId(); listId != nil {
+			columnData["lookupListId"] = *listId
+		}
+		if columnName := lookup.GetColumnName(); columnName != nil {
+			columnData["lookupColumnName"] = *columnName
+		}
+	}
+
+	return columnId, columnData
+}
+
+// columnType returns the type of a column based on the facet that is set.
+func columnType(column models.ColumnDefinitionable) string {
+
+	switch {
+	case column.GetText() != nil:
+		return "text"
+	case column.GetNumber() != nil:
+		return "number"
+	case column.GetDateTime() != nil:
+		return "dateTime"
+	case column.GetLookup() != nil:
+		return "lookup"
+	case column.GetChoice() != nil:
+		return "choice"
+	case column.GetBoolean() != nil:
+		return "boolean"
+	case column.GetCurrency() != nil:
+		return "currency"
+	case column.GetPersonOrGroup() != nil:
+		return "personOrGroup"
+	case column.GetCalculated() != nil:
+		return "calculated"
+	case column.GetHyperlinkOrPicture() != nil:
+		return "hyperlinkOrPicture"
+	case column.GetTerm() != nil:
+		return "term"
+	case column.GetGeolocation() != nil:
+		return "geolocation"
+	case column.GetThumbnail() != nil:
+		return "thumbnail"
+	case column.GetContentApprovalStatus() != nil:
+		return "contentApprovalStatus"
+	case column.GetTypeEscaped() != nil:
+		return column.GetTypeEscaped().String()
+	}
+	return "unknown"
+}
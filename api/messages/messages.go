@@ -0,0 +1,194 @@
+package messages
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/markdown"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+)
+
+const (
+	// defaultTop is the number of messages returned when no top is given.
+	defaultTop = 25
+	// maxTop is the largest number of messages returned in one call.
+	maxTop = 250
+	// defaultFolder is the mail folder read when no folder is given.
+	defaultFolder = "inbox"
+)
+
+// messageFields are the attributes of a message read by the messages tool.
+var messageFields = []string{"id", "subject", "from", "receivedDateTime", "bodyPreview", "body", "isRead", "hasAttachments"}
+
+func init() {
+	// Messages Tool is a tool that interacts with microsoft for mail APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "messages",
+			Tool: mcp.NewTool("messages",
+				mcp.WithDescription("Read the most recent mail messages of a user in a mail folder, with their sender, preview and body, HTML bodies being converted to Markdown. Requires Mail.Read."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The id or user principal name of the user."),
+				),
+				mcp.WithString("folder",
+					mcp.Description("The id or well-known name (inbox, sentitems, drafts, deleteditems, archive, junkemail) of the mail folder. Defaults to inbox."),
+				),
+				mcp.WithNumber("top",
+					mcp.Description(fmt.Sprintf("The number of messages to return, most recent first, at most %d. Defaults to %d.", maxTop, defaultTop)),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Mail.Read"},
+			OutputSchema:   messageSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := messagesArgs{Top: defaultTop, Folder: defaultFolder}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := Get(ctx, client, a.UserId, a.Folder, a.Top)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the user '%s' or the mail folder '%s' does not exist", a.UserId, a.Folder)), nil
+					}
+					return mcp.NewToolResultError("failed to get messages"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// messageSchema describes the result of the messages tool.
+var messageSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":               schema.String(),
+	"subject":          schema.String(),
+	"from":             schema.String(),
+	"fromName":         schema.String(),
+	"receivedDateTime": schema.DateTime(),
+	"bodyPreview":      schema.String(),
+	"body":             schema.String(),
+	"isRead":           schema.Boolean(),
+	"hasAttachments":   schema.Boolean(),
+}))
+
+// messagesArgs are the arguments of the messages tool.
+type messagesArgs struct {
+	UserId string `json:"user_id"`
+	Top    int    `json:"top"`
+	Folder string `json:"folder"`
+}
+
+// Validate checks that the user is given and the bounds of the number of messages.
+func (a *messagesArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	if a.Top <= 0 || a.Top > maxTop {
+		return fmt.Errorf("top must be between 1 and %d", maxTop)
+	}
+
+	return nil
+}
+
+// Get retrieves the top most recent messages of a mail folder of the user.
+func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, folder string, top int) ([]byte, error) {
+
+	pageSize := int32(top)
+	result, err := client.Users().ByUserId(userId).MailFolders().ByMailFolderId(folder).Messages().Get(ctx, &users.ItemMailFoldersItemMessagesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemMailFoldersItemMessagesRequestBuilderGetQueryParameters{
+			Select:  messageFields,
+			Orderby: []string{"receivedDateTime desc"},
+			Top:     &pageSize,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching messages: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	messagesData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateMessageCollectionResponseFromDiscriminatorValue, func(message models.Messageable) bool {
+		id, messageData := convertMessageToMap(message)
+		messagesData[id] = messageData
+		return len(messagesData) < top
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through messages: %v", err)
+	}
+
+	return json.MarshalIndent(messagesData, "", "  ")
+}
+
+// convertMessageToMap converts a message model to a map with its sender and its body, as
+// Markdown when it is HTML
+func convertMessageToMap(message models.Messageable) (string, map[string]interface{}) {
+
+	messageId := ""
+	messageData := make(map[string]interface{})
+
+	if id := message.GetId(); id != nil {
+		messageId = *id
+		messageData["id"] = messageId
+	}
+	if subject := message.GetSubject(); subject != nil {
+		messageData["subject"] = *subject
+	}
+	if from := message.GetFrom(); from != nil && from.GetEmailAddress() != nil {
+		if address := from.GetEmailAddress().GetAddress(); address != nil {
+			messageData["from"] = *address
+		}
+		if name := from.GetEmailAddress().GetName(); name != nil {
+			messageData["fromName"] = *name
+		}
+	}
+	if receivedDateTime := message.GetReceivedDateTime(); receivedDateTime != nil {
+		messageData["receivedDateTime"] = receivedDateTime.Format(time.RFC3339)
+	}
+	if bodyPreview := message.GetBodyPreview(); bodyPreview != nil {
+		messageData["bodyPreview"] = *bodyPreview
+	}
+	if body := message.GetBody(); body != nil && body.GetContent() != nil {
+		content := *body.GetContent()
+		if contentType := body.GetContentType(); contentType != nil && *contentType == models.HTML_BODYTYPE {
+			content = markdown.FromHTML(content)
+		}
+		messageData["body"] = content
+	}
+	if isRead := message.GetIsRead(); isRead != nil {
+		messageData["isRead"] = *isRead
+	}
+	if hasAttachments := message.GetHasAttachments(); hasAttachments != nil {
+		messageData["hasAttachments"] = *hasAttachments
+	}
+
+	return messageId, messageData
+}
@@ -0,0 +1,167 @@
+package organization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/organization"
+)
+
+// organizationFields are the attributes of the organization read by the organization tool.
+var organizationFields = []string{"id", "displayName", "verifiedDomains", "city", "country", "countryLetterCode", "tenantType", "assignedPlans", "createdDateTime"}
+
+func init() {
+	// Organization Tool is a tool that interacts with microsoft for organization APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "organization",
+			Tool: mcp.NewTool("organization",
+				mcp.WithDescription("Get the details of the tenant: its id and name, its verified domains with the default and initial ones, its location, its type and a summary of the service plans assigned to it, counted by service and status. Requires Organization.Read.All."),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Organization.Read.All"},
+			OutputSchema:   organizationSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				org, err := Get(ctx, client)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get organization"), err
+				}
+				if org == nil {
+					return mcp.NewToolResultError("no organization was returned for the tenant: the signed-in identity may not be able to read it"), nil
+				}
+
+				jsonData, err := json.MarshalIndent(convertOrganizationToMap(org), "", "  ")
+				if err != nil {
+					return mcp.NewToolResultError("failed to get organization"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// organizationSchema describes the result of the organization tool.
+var organizationSchema = schema.Object(map[string]schema.Schema{
+	"id":                schema.String(),
+	"displayName":       schema.String(),
+	"city":              schema.String(),
+	"country":           schema.String(),
+	"countryLetterCode": schema.String(),
+	"tenantType":        schema.String(),
+	"createdDateTime":   schema.DateTime(),
+	"verifiedDomains": schema.Map(schema.Object(map[string]schema.Schema{
+		"name":         schema.String(),
+		"type":         schema.String(),
+		"capabilities": schema.String(),
+		"isDefault":    schema.Boolean(),
+		"isInitial":    schema.Boolean(),
+	})),
+	"assignedPlans": schema.Map(schema.Map(schema.Integer())),
+})
+
+// Get retrieves the organization of the tenant, or nil if none is returned.
+func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient) (models.Organizationable, error) {
+
+	result, err := client.Organization().Get(ctx, &organization.OrganizationRequestBuilderGetRequestConfiguration{
+		QueryParameters: &organization.OrganizationRequestBuilderGetQueryParameters{
+			Select: organizationFields,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching organization: %w", err)
+	}
+
+	// A tenant has a single organization
+	if len(result.GetValue()) == 0 {
+		return nil, nil
+	}
+
+	return result.GetValue()[0], nil
+}
+
+// convertOrganizationToMap converts an organization model to a map, with its assigned plans
+// counted by service and capability status.
+func convertOrganizationToMap(org models.Organizationable) map[string]interface{} {
+
+	organizationData := make(map[string]interface{})
+
+	if id := org.GetId(); id != nil {
+		organizationData["id"] = *id
+	}
+	if displayName := org.GetDisplayName(); displayName != nil {
+		organizationData["displayName"] = *displayName
+	}
+	if city := org.GetCity(); city != nil {
+		organizationData["city"] = *city
+	}
+	if country := org.GetCountry(); country != nil {
+		organizationData["country"] = *country
+	}
+	if countryLetterCode := org.GetCountryLetterCode(); countryLetterCode != nil {
+		organizationData["countryLetterCode"] = *countryLetterCode
+	}
+	if tenantType := org.GetTenantType(); tenantType != nil {
+		organizationData["tenantType"] = *tenantType
+	}
+	if createdDateTime := org.GetCreatedDateTime(); createdDateTime != nil {
+		organizationData["createdDateTime"] = createdDateTime.Format(time.RFC3339)
+	}
+
+	domainsData := make(map[string]interface{})
+	for _, domain := range org.GetVerifiedDomains() {
+		if domain.GetName() == nil {
+			continue
+		}
+		domainData := map[string]interface{}{
+			"name": *domain.GetName(),
+		}
+		if domainType := domain.GetTypeEscaped(); domainType != nil {
+			domainData["type"] = *domainType
+		}
+		if capabilities := domain.GetCapabilities(); capabilities != nil {
+			domainData["capabilities"] = *capabilities
+		}
+		if isDefault := domain.GetIsDefault(); isDefault != nil {
+			domainData["isDefault"] = *isDefault
+		}
+		if isInitial := domain.GetIsInitial(); isInitial != nil {
+			domainData["isInitial"] = *isInitial
+		}
+		domainsData[*domain.GetName()] = domainData
+	}
+	organizationData["verifiedDomains"] = domainsData
+
+	plansData := make(map[string]map[string]int)
+	for _, plan := range org.GetAssignedPlans() {
+		service, status := "unknown", "unknown"
+		if plan.GetService() != nil {
+			service = *plan.GetService()
+		}
+		if plan.GetCapabilityStatus() != nil {
+			status = *plan.GetCapabilityStatus()
+		}
+		if plansData[service] == nil {
+			plansData[service] = make(map[string]int)
+		}
+		plansData[service][status]++
+	}
+	organizationData["assignedPlans"] = plansData
+
+	return organizationData
+}
@@ -0,0 +1,141 @@
+package paging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+)
+
+func init() {
+	// Next Page Tool is a tool that interacts with microsoft for any paged API.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "next_page",
+			Tool: mcp.NewTool("next_page",
+				mcp.WithDescription("Fetch the page of a Microsoft Graph listing pointed to by an @odata.nextLink obtained elsewhere, and return its raw items with the link to the following page. The link must target Microsoft Graph. The permissions required are the ones of the listing the link belongs to."),
+				mcp.WithString("next_link",
+					mcp.Required(),
+					mcp.Description("The full @odata.nextLink URL, e.g. https://graph.microsoft.com/v1.0/users?$skiptoken=..."),
+				),
+				trace.WithDebug(),
+			),
+			OutputSchema: nextPageSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a nextPageArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetNextPage(ctx, client, a.NextLink)
+				if err != nil {
+					switch odata.StatusCode(err) {
+					case 0:
+						return mcp.NewToolResultError(err.Error()), nil
+					case http.StatusBadRequest, http.StatusNotFound, http.StatusGone:
+						return mcp.NewToolResultError(fmt.Sprintf("the link is invalid or has expired, restart the listing: %s", odata.ErrorMessage(err))), nil
+					default:
+						return mcp.NewToolResultError(fmt.Sprintf("failed to get next page: %s", odata.ErrorMessage(err))), nil
+					}
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// nextPageSchema describes the result of the next_page tool. The items are returned as Graph
+// sent them, their schema depends on the listing.
+var nextPageSchema = schema.Object(map[string]schema.Schema{
+	"value":     schema.Array(schema.Object(map[string]schema.Schema{})),
+	"nextLink":  schema.String(),
+	"deltaLink": schema.String(),
+})
+
+// nextPageArgs are the arguments of the next_page tool.
+type nextPageArgs struct {
+	NextLink string `json:"next_link"`
+}
+
+// Validate checks that the link is given.
+func (a *nextPageArgs) Validate() error {
+
+	if a.NextLink == "" {
+		return fmt.Errorf("next_link is required")
+	}
+
+	return nil
+}
+
+// GetNextPage validates that the link targets the Microsoft Graph host of the client, and
+// retrieves the page it points to, with the links to the following page.
+func GetNextPage(ctx context.Context, client *msgraphsdk.GraphServiceClient, nextLink string) ([]byte, error) {
+
+	adapter := client.GetAdapter()
+
+	graphURL, err := url.Parse(adapter.GetBaseUrl())
+	if err != nil {
+		return nil, err
+	}
+	link, err := url.Parse(nextLink)
+	if err != nil || link.Scheme != "https" || link.Host != graphURL.Host {
+		return nil, fmt.Errorf("the link must be an https URL on %s", graphURL.Host)
+	}
+
+	info := abstractions.NewRequestInformation()
+	info.Method = abstractions.GET
+	info.SetUri(*link)
+	info.Headers.TryAdd("Accept", "application/json")
+
+	raw, err := adapter.SendPrimitive(ctx, info, "[]byte", abstractions.ErrorMappings{
+		"XXX": odataerrors.CreateODataErrorFromDiscriminatorValue,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching page: %w", err)
+	}
+
+	var page struct {
+		Value     []json.RawMessage `json:"value"`
+		NextLink  string            `json:"@odata.nextLink"`
+		DeltaLink string            `json:"@odata.deltaLink"`
+	}
+	if content, ok := raw.([]byte); ok {
+		if err := json.Unmarshal(content, &page); err != nil {
+			return nil, fmt.Errorf("the link does not point to a page of a listing: %v", err)
+		}
+	}
+
+	pageData := map[string]interface{}{
+		"value": page.Value,
+	}
+	if page.Value == nil {
+		pageData["value"] = []json.RawMessage{}
+	}
+	if page.NextLink != "" {
+		pageData["nextLink"] = page.NextLink
+	}
+	if page.DeltaLink != "" {
+		pageData["deltaLink"] = page.DeltaLink
+	}
+
+	return json.MarshalIndent(pageData, "", "  ")
+}
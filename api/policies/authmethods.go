@@ -0,0 +1,242 @@
+package policies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Authentication Methods Tool is a tool that interacts with microsoft for authentication methods policy APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "authentication_methods_policy",
+			Tool: mcp.NewTool("authentication_methods_policy",
+				mcp.WithDescription("Read the tenant-wide authentication methods policy: for each method (FIDO2, Microsoft Authenticator, SMS, voice, email, temporary access pass, software OATH, certificate) whether it is enabled, the groups it targets or excludes, and its method specific settings. Requires Policy.Read.All."),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Policy.Read.All"},
+			OutputSchema:   authMethodsSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				jsonData, err := GetAuthenticationMethods(ctx, client)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get authentication methods policy"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// authMethodsSchema describes the result of the authentication_methods_policy tool.
+var authMethodsSchema = schema.Object(map[string]schema.Schema{
+	"displayName":          schema.String(),
+	"policyVersion":        schema.String(),
+	"policyMigrationState": schema.String(),
+	"lastModifiedDateTime": schema.DateTime(),
+	"reconfirmationInDays": schema.Integer(),
+	"enabledMethods":       schema.Array(schema.String()),
+	"methods": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":    schema.String(),
+		"state": schema.Enum("enabled", "disabled"),
+		"includeTargets": schema.Array(schema.Object(map[string]schema.Schema{
+			"id":                     schema.String(),
+			"targetType":             schema.String(),
+			"isRegistrationRequired": schema.Boolean(),
+			"authenticationMode":     schema.String(),
+			"isUsableForSignIn":      schema.Boolean(),
+		})),
+		"excludeTargets": schema.Array(schema.Object(map[string]schema.Schema{
+			"id":         schema.String(),
+			"targetType": schema.String(),
+		})),
+		"settings": schema.Map(schema.Schema{}),
+	})),
+})
+
+// GetAuthenticationMethods retrieves the authentication methods policy and the configuration of
+// each method.
+func GetAuthenticationMethods(ctx context.Context, client *msgraphsdk.GraphServiceClient) ([]byte, error) {
+
+	policy, err := client.Policies().AuthenticationMethodsPolicy().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching authentication methods policy: %v", err)
+	}
+
+	policyData := make(map[string]interface{})
+	if displayName := policy.GetDisplayName(); displayName != nil {
+		policyData["displayName"] = *displayName
+	}
+	if policyVersion := policy.GetPolicyVersion(); policyVersion != nil {
+		policyData["policyVersion"] = *policyVersion
+	}
+	if migrationState := policy.GetPolicyMigrationState(); migrationState != nil {
+		policyData["policyMigrationState"] = migrationState.String()
+	}
+	if lastModified := policy.GetLastModifiedDateTime(); lastModified != nil {
+		policyData["lastModifiedDateTime"] = lastModified.Format(time.RFC3339)
+	}
+	if reconfirmationInDays := policy.GetReconfirmationInDays(); reconfirmationInDays != nil {
+		policyData["reconfirmationInDays"] = *reconfirmationInDays
+	}
+
+	methodsData := make(map[string]interface{})
+	enabled := []string{}
+	for _, configuration := range policy.GetAuthenticationMethodConfigurations() {
+		id, methodData := convertMethodConfigurationToMap(configuration)
+		methodsData[id] = methodData
+		if methodData["state"] == "enabled" {
+			enabled = append(enabled, id)
+		}
+	}
+	sort.Strings(enabled)
+	policyData["methods"] = methodsData
+	policyData["enabledMethods"] = enabled
+
+	return json.MarshalIndent(policyData, "", "  ")
+}
+
+// convertMethodConfigurationToMap converts the configuration of an authentication method to a
+// map with its targets and the settings specific to its type
+func convertMethodConfigurationToMap(configuration models.AuthenticationMethodConfigurationable) (string, map[string]interface{}) {
+
+	methodId := ""
+	methodData := make(map[string]interface{})
+
+	if id := configuration.GetId(); id != nil {
+		methodId = *id
+		methodData["id"] = methodId
+	}
+	if state := configuration.GetState(); state != nil {
+		methodData["state"] = state.String()
+	}
+
+	excludeTargets := []interface{}{}
+	for _, target := range configuration.GetExcludeTargets() {
+		targetData := make(map[string]interface{})
+		if id := target.GetId(); id != nil {
+			targetData["id"] = *id
+		}
+		if targetType := target.GetTargetType(); targetType != nil {
+			targetData["targetType"] = targetType.String()
+		}
+		excludeTargets = append(excludeTargets, targetData)
+	}
+	methodData["excludeTargets"] = excludeTargets
+
+	// The include targets and settings are specific to each method
+	var includeTargets []models.AuthenticationMethodTargetable
+	settings := make(map[string]interface{})
+	switch c := configuration.(type) {
+	case models.Fido2AuthenticationMethodConfigurationable:
+		includeTargets = c.GetIncludeTargets()
+		if isAttestationEnforced := c.GetIsAttestationEnforced(); isAttestationEnforced != nil {
+			settings["isAttestationEnforced"] = *isAttestationEnforced
+		}
+		if isSelfServiceRegistrationAllowed := c.GetIsSelfServiceRegistrationAllowed(); isSelfServiceRegistrationAllowed != nil {
+			settings["isSelfServiceRegistrationAllowed"] = *isSelfServiceRegistrationAllowed
+		}
+		if restrictions := c.GetKeyRestrictions(); restrictions != nil {
+			if isEnforced := restrictions.GetIsEnforced(); isEnforced != nil {
+				settings["keyRestrictionsEnforced"] = *isEnforced
+			}
+			if enforcementType := restrictions.GetEnforcementType(); enforcementType != nil {
+				settings["keyRestrictionsEnforcementType"] = enforcementType.String()
+			}
+			settings["keyRestrictionsAaGuids"] = restrictions.GetAaGuids()
+		}
+	case models.MicrosoftAuthenticatorAuthenticationMethodConfigurationable:
+		for _, target := range c.GetIncludeTargets() {
+			includeTargets = append(includeTargets, target)
+		}
+		if isSoftwareOathEnabled := c.GetIsSoftwareOathEnabled(); isSoftwareOathEnabled != nil {
+			settings["isSoftwareOathEnabled"] = *isSoftwareOathEnabled
+		}
+	case models.SmsAuthenticationMethodConfigurationable:
+		for _, target := range c.GetIncludeTargets() {
+			includeTargets = append(includeTargets, target)
+		}
+	case models.TemporaryAccessPassAuthenticationMethodConfigurationable:
+		includeTargets = c.GetIncludeTargets()
+		if defaultLifetime := c.GetDefaultLifetimeInMinutes(); defaultLifetime != nil {
+			settings["defaultLifetimeInMinutes"] = *defaultLifetime
+		}
+		if minimumLifetime := c.GetMinimumLifetimeInMinutes(); minimumLifetime != nil {
+			settings["minimumLifetimeInMinutes"] = *minimumLifetime
+		}
+		if maximumLifetime := c.GetMaximumLifetimeInMinutes(); maximumLifetime != nil {
+			settings["maximumLifetimeInMinutes"] = *maximumLifetime
+		}
+		if defaultLength := c.GetDefaultLength(); defaultLength != nil {
+			settings["defaultLength"] = *defaultLength
+		}
+		if isUsableOnce := c.GetIsUsableOnce(); isUsableOnce != nil {
+			settings["isUsableOnce"] = *isUsableOnce
+		}
+	case models.EmailAuthenticationMethodConfigurationable:
+		includeTargets = c.GetIncludeTargets()
+		if allowExternalId := c.GetAllowExternalIdToUseEmailOtp(); allowExternalId != nil {
+			settings["allowExternalIdToUseEmailOtp"] = allowExternalId.String()
+		}
+	case models.VoiceAuthenticationMethodConfigurationable:
+		includeTargets = c.GetIncludeTargets()
+		if isOfficePhoneAllowed := c.GetIsOfficePhoneAllowed(); isOfficePhoneAllowed != nil {
+			settings["isOfficePhoneAllowed"] = *isOfficePhoneAllowed
+		}
+	case models.SoftwareOathAuthenticationMethodConfigurationable:
+		includeTargets = c.GetIncludeTargets()
+	case models.X509CertificateAuthenticationMethodConfigurationable:
+		includeTargets = c.GetIncludeTargets()
+		if modeConfiguration := c.GetAuthenticationModeConfiguration(); modeConfiguration != nil && modeConfiguration.GetX509CertificateAuthenticationDefaultMode() != nil {
+			settings["defaultAuthenticationMode"] = modeConfiguration.GetX509CertificateAuthenticationDefaultMode().String()
+		}
+	}
+
+	targetsData := []interface{}{}
+	for _, target := range includeTargets {
+		targetData := make(map[string]interface{})
+		if id := target.GetId(); id != nil {
+			targetData["id"] = *id
+		}
+		if targetType := target.GetTargetType(); targetType != nil {
+			targetData["targetType"] = targetType.String()
+		}
+		if isRegistrationRequired := target.GetIsRegistrationRequired(); isRegistrationRequired != nil {
+			targetData["isRegistrationRequired"] = *isRegistrationRequired
+		}
+		switch t := target.(type) {
+		case models.MicrosoftAuthenticatorAuthenticationMethodTargetable:
+			if authenticationMode := t.GetAuthenticationMode(); authenticationMode != nil {
+				targetData["authenticationMode"] = authenticationMode.String()
+			}
+		case models.SmsAuthenticationMethodTargetable:
+			if isUsableForSignIn := t.GetIsUsableForSignIn(); isUsableForSignIn != nil {
+				targetData["isUsableForSignIn"] = *isUsableForSignIn
+			}
+		}
+		targetsData = append(targetsData, targetData)
+	}
+	methodData["includeTargets"] = targetsData
+	if len(settings) > 0 {
+		methodData["settings"] = settings
+	}
+
+	return methodId, methodData
+}
@@ -0,0 +1,232 @@
+package policies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Cross Tenant Access Tool is a tool that interacts with microsoft for cross-tenant access policy APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "cross_tenant_access",
+			Tool: mcp.NewTool("cross_tenant_access",
+				mcp.WithDescription("Read the cross-tenant access settings: the default inbound/outbound B2B collaboration and direct connect configuration, inbound trust, and the per partner tenant overrides. Requires Policy.Read.All."),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Policy.Read.All"},
+			OutputSchema:   crossTenantSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				jsonData, err := GetCrossTenantAccess(ctx, client)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get cross-tenant access settings"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// targetConfigurationSchema describes the access type and the targets of a B2B setting.
+var targetConfigurationSchema = schema.Object(map[string]schema.Schema{
+	"accessType": schema.String(),
+	"targets": schema.Array(schema.Object(map[string]schema.Schema{
+		"target":     schema.String(),
+		"targetType": schema.String(),
+	})),
+})
+
+// b2bSettingSchema describes a B2B collaboration or direct connect setting.
+var b2bSettingSchema = schema.Object(map[string]schema.Schema{
+	"usersAndGroups": targetConfigurationSchema,
+	"applications":   targetConfigurationSchema,
+})
+
+// configurationProperties are the settings shared by the default and the partner configurations.
+var configurationProperties = map[string]schema.Schema{
+	"b2bCollaborationInbound":  b2bSettingSchema,
+	"b2bCollaborationOutbound": b2bSettingSchema,
+	"b2bDirectConnectInbound":  b2bSettingSchema,
+	"b2bDirectConnectOutbound": b2bSettingSchema,
+	"inboundTrust": schema.Object(map[string]schema.Schema{
+		"isMfaAccepted":                       schema.Boolean(),
+		"isCompliantDeviceAccepted":           schema.Boolean(),
+		"isHybridAzureADJoinedDeviceAccepted": schema.Boolean(),
+	}),
+}
+
+// crossTenantSchema describes the result of the cross_tenant_access tool.
+var crossTenantSchema = schema.Object(map[string]schema.Schema{
+	"default": schema.Object(schema.Merge(configurationProperties, map[string]schema.Schema{
+		"isServiceDefault": schema.Boolean(),
+	})),
+	"partners": schema.Map(schema.Object(schema.Merge(configurationProperties, map[string]schema.Schema{
+		"tenantId":                    schema.String(),
+		"isServiceProvider":           schema.Boolean(),
+		"isInMultiTenantOrganization": schema.Boolean(),
+	}))),
+	"message": schema.String(),
+})
+
+// GetCrossTenantAccess retrieves the default cross-tenant access settings and the partner configurations.
+func GetCrossTenantAccess(ctx context.Context, client *msgraphsdk.GraphServiceClient) ([]byte, error) {
+
+	policy := client.Policies().CrossTenantAccessPolicy()
+
+	defaults, err := policy.DefaultEscaped().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching default cross-tenant access settings: %v", err)
+	}
+
+	partners, err := policy.Partners().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching cross-tenant access partners: %v", err)
+	}
+
+	partnersData := make(map[string]interface{})
+	err = paginate.Iterate(ctx, client, partners, models.CreateCrossTenantAccessPolicyConfigurationPartnerCollectionResponseFromDiscriminatorValue, func(partner models.CrossTenantAccessPolicyConfigurationPartnerable) bool {
+		id, partnerData := convertPartnerToMap(partner)
+		partnersData[id] = partnerData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through cross-tenant access partners: %v", err)
+	}
+
+	accessData := map[string]interface{}{
+		"default":  convertDefaultToMap(defaults),
+		"partners": partnersData,
+	}
+	if len(partnersData) == 0 {
+		accessData["message"] = "No partner tenant is configured: the default settings apply to all external tenants."
+	}
+
+	return json.MarshalIndent(accessData, "", "  ")
+}
+
+// convertDefaultToMap converts the default cross-tenant access configuration to a map
+func convertDefaultToMap(defaults models.CrossTenantAccessPolicyConfigurationDefaultable) map[string]interface{} {
+
+	defaultData := make(map[string]interface{})
+
+	if isServiceDefault := defaults.GetIsServiceDefault(); isServiceDefault != nil {
+		defaultData["isServiceDefault"] = *isServiceDefault
+	}
+	addB2BSettings(defaultData, "b2bCollaborationInbound", defaults.GetB2bCollaborationInbound())
+	addB2BSettings(defaultData, "b2bCollaborationOutbound", defaults.GetB2bCollaborationOutbound())
+	addB2BSettings(defaultData, "b2bDirectConnectInbound", defaults.GetB2bDirectConnectInbound())
+	addB2BSettings(defaultData, "b2bDirectConnectOutbound", defaults.GetB2bDirectConnectOutbound())
+	addInboundTrust(defaultData, defaults.GetInboundTrust())
+
+	return defaultData
+}
+
+// convertPartnerToMap converts a partner cross-tenant access configuration to a map
+func convertPartnerToMap(partner models.CrossTenantAccessPolicyConfigurationPartnerable) (string, map[string]interface{}) {
+
+	tenantId := ""
+	partnerData := make(map[string]interface{})
+
+	if id := partner.GetTenantId(); id != nil {
+		tenantId = *id
+		partnerData["tenantId"] = tenantId
+	}
+	if isServiceProvider := partner.GetIsServiceProvider(); isServiceProvider != nil {
+		partnerData["isServiceProvider"] = *isServiceProvider
+	}
+	if inMultiTenantOrganization := partner.GetIsInMultiTenantOrganization(); inMultiTenantOrganization != nil {
+		partnerData["isInMultiTenantOrganization"] = *inMultiTenantOrganization
+	}
+	addB2BSettings(partnerData, "b2bCollaborationInbound", partner.GetB2bCollaborationInbound())
+	addB2BSettings(partnerData, "b2bCollaborationOutbound", partner.GetB2bCollaborationOutbound())
+	addB2BSettings(partnerData, "b2bDirectConnectInbound", partner.GetB2bDirectConnectInbound())
+	addB2BSettings(partnerData, "b2bDirectConnectOutbound", partner.GetB2bDirectConnectOutbound())
+	addInboundTrust(partnerData, partner.GetInboundTrust())
+
+	return tenantId, partnerData
+}
+
+// addB2BSettings adds the users/groups and applications targets of a B2B setting under the key.
+// Settings that are not set (inherited from the defaults for partners) are omitted.
+func addB2BSettings(data map[string]interface{}, key string, setting models.CrossTenantAccessPolicyB2BSettingable) {
+
+	if setting == nil {
+		return
+	}
+
+	settingData := make(map[string]interface{})
+	if usersAndGroups := convertTargetConfigurationToMap(setting.GetUsersAndGroups()); usersAndGroups != nil {
+		settingData["usersAndGroups"] = usersAndGroups
+	}
+	if applications := convertTargetConfigurationToMap(setting.GetApplications()); applications != nil {
+		settingData["applications"] = applications
+	}
+
+	data[key] = settingData
+}
+
+// convertTargetConfigurationToMap converts a target configuration (access type and targets) to a map
+func convertTargetConfigurationToMap(configuration models.CrossTenantAccessPolicyTargetConfigurationable) map[string]interface{} {
+
+	if configuration == nil {
+		return nil
+	}
+
+	configurationData := make(map[string]interface{})
+	if accessType := configuration.GetAccessType(); accessType != nil {
+		configurationData["accessType"] = accessType.String()
+	}
+
+	targets := []interface{}{}
+	for _, target := range configuration.GetTargets() {
+		targetData := make(map[string]interface{})
+		if value := target.GetTarget(); value != nil {
+			targetData["target"] = *value
+		}
+		if targetType := target.GetTargetType(); targetType != nil {
+			targetData["targetType"] = targetType.String()
+		}
+		targets = append(targets, targetData)
+	}
+	configurationData["targets"] = targets
+
+	return configurationData
+}
+
+// addInboundTrust adds the inbound trust settings, if any.
+func addInboundTrust(data map[string]interface{}, trust models.CrossTenantAccessPolicyInboundTrustable) {
+
+	if trust == nil {
+		return
+	}
+
+	trustData := make(map[string]interface{})
+	if mfa := trust.GetIsMfaAccepted(); mfa != nil {
+		trustData["isMfaAccepted"] = *mfa
+	}
+	if compliant := trust.GetIsCompliantDeviceAccepted(); compliant != nil {
+		trustData["isCompliantDeviceAccepted"] = *compliant
+	}
+	if hybrid := trust.GetIsHybridAzureADJoinedDeviceAccepted(); hybrid != nil {
+		trustData["isHybridAzureADJoinedDeviceAccepted"] = *hybrid
+	}
+
+	data["inboundTrust"] = trustData
+}
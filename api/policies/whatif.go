@@ -0,0 +1,295 @@
+package policies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/beta"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+)
+
+// whatIfPath is the beta conditional access evaluation endpoint, it has no v1.0 equivalent.
+const whatIfPath = "/identity/conditionalAccess/evaluate"
+
+func init() {
+	// Conditional Access What If Tool is a tool that interacts with microsoft for conditional access evaluation APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "conditional_access_what_if",
+			Tool: mcp.NewTool("conditional_access_what_if",
+				mcp.WithDescription("Simulate a sign-in of a user to an application under the given conditions and report which conditional access policies would apply, their grant and session controls, and the resulting access decision. Report-only policies are listed but do not weigh in the decision. Uses the beta conditional access evaluation (What If) API of Microsoft Graph, which may change. Requires Policy.Read.All."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The object id of the user signing in."),
+				),
+				mcp.WithString("app_id",
+					mcp.Required(),
+					mcp.Description("The application (client) id of the application signed in to, e.g. 00000003-0000-0ff1-ce00-000000000000 for SharePoint Online."),
+				),
+				mcp.WithString("device_platform",
+					mcp.Enum("android", "iOS", "windows", "windowsPhone", "macOS", "linux"),
+					mcp.Description("The platform of the device signing in."),
+				),
+				mcp.WithString("client_app_type",
+					mcp.Enum("browser", "mobileAppsAndDesktopClients", "exchangeActiveSync", "other"),
+					mcp.Description("The type of client signing in."),
+				),
+				mcp.WithString("sign_in_risk",
+					mcp.Enum("none", "low", "medium", "high"),
+					mcp.Description("The risk level of the sign-in."),
+				),
+				mcp.WithString("user_risk",
+					mcp.Enum("none", "low", "medium", "high"),
+					mcp.Description("The risk level of the user."),
+				),
+				mcp.WithString("country",
+					mcp.Description("The two-letter code of the country signed in from, e.g. US."),
+				),
+				mcp.WithString("ip_address",
+					mcp.Description("The IP address signed in from."),
+				),
+				mcp.WithBoolean("applied_only",
+					mcp.Description("Only return the policies applying to the sign-in. Defaults to true, set it to false to also get the policies not applying and why."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Policy.Read.All"},
+			OutputSchema:   whatIfSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := whatIfArgs{AppliedOnly: true}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := EvaluateWhatIf(ctx, client, a.UserId, a.AppId, a.conditions(), a.AppliedOnly)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to evaluate conditional access policies: %s", odata.ErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// whatIfSchema describes the result of the conditional_access_what_if tool.
+var whatIfSchema = schema.Object(map[string]schema.Schema{
+	"policies": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":              schema.String(),
+		"displayName":     schema.String(),
+		"state":           schema.String(),
+		"policyApplies":   schema.Boolean(),
+		"analysisReasons": schema.String(),
+		"grantControls": schema.Object(map[string]schema.Schema{
+			"operator":               schema.String(),
+			"builtInControls":        schema.Array(schema.String()),
+			"termsOfUse":             schema.Array(schema.String()),
+			"authenticationStrength": schema.String(),
+		}),
+		"sessionControls": schema.Array(schema.String()),
+	})),
+	"decision": schema.Object(map[string]schema.Schema{
+		"access":           schema.Enum("granted", "grantedWithControls", "blocked"),
+		"requiredControls": schema.Array(schema.String()),
+		"blockedBy":        schema.Array(schema.String()),
+	}),
+})
+
+// whatIfArgs are the arguments of the conditional_access_what_if tool.
+type whatIfArgs struct {
+	UserId         string `json:"user_id"`
+	AppId          string `json:"app_id"`
+	DevicePlatform string `json:"device_platform"`
+	ClientAppType  string `json:"client_app_type"`
+	SignInRisk     string `json:"sign_in_risk"`
+	UserRisk       string `json:"user_risk"`
+	Country        string `json:"country"`
+	IpAddress      string `json:"ip_address"`
+	AppliedOnly    bool   `json:"applied_only"`
+}
+
+// Validate checks that the user and the application are given.
+func (a *whatIfArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	if a.AppId == "" {
+		return fmt.Errorf("app_id is required")
+	}
+
+	return nil
+}
+
+// conditions returns the sign-in conditions given, by Graph attribute name. Only the conditions
+// given are sent, the others are left to the service defaults.
+func (a *whatIfArgs) conditions() map[string]interface{} {
+
+	conditions := map[string]interface{}{}
+	for field, value := range map[string]string{
+		"devicePlatform":  a.DevicePlatform,
+		"clientAppType":   a.ClientAppType,
+		"signInRiskLevel": a.SignInRisk,
+		"userRiskLevel":   a.UserRisk,
+		"country":         a.Country,
+		"ipAddress":       a.IpAddress,
+	} {
+		if value != "" {
+			conditions[field] = value
+		}
+	}
+
+	return conditions
+}
+
+// whatIfResult is the part of a beta whatIfAnalysisResult read by the tool.
+type whatIfResult struct {
+	Id              string `json:"id"`
+	DisplayName     string `json:"displayName"`
+	State           string `json:"state"`
+	PolicyApplies   bool   `json:"policyApplies"`
+	AnalysisReasons string `json:"analysisReasons"`
+	GrantControls   *struct {
+		Operator               string   `json:"operator"`
+		BuiltInControls        []string `json:"builtInControls"`
+		TermsOfUse             []string `json:"termsOfUse"`
+		AuthenticationStrength *struct {
+			DisplayName string `json:"displayName"`
+		} `json:"authenticationStrength"`
+	} `json:"grantControls"`
+	SessionControls map[string]interface{} `json:"sessionControls"`
+}
+
+// EvaluateWhatIf evaluates the conditional access policies against a sign-in of the user to the
+// application under the given conditions, and derives the access decision from the enforced ones.
+func EvaluateWhatIf(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, appId string, conditions map[string]interface{}, appliedOnly bool) ([]byte, error) {
+
+	content, err := beta.Do(ctx, client, abstractions.POST, whatIfPath, map[string]interface{}{
+		"signInIdentity": map[string]interface{}{
+			"@odata.type": "#microsoft.graph.userSignIn",
+			"userId":      userId,
+		},
+		"signInContext": map[string]interface{}{
+			"@odata.type":         "#microsoft.graph.applicationContext",
+			"includeApplications": []string{appId},
+		},
+		"signInConditions":    conditions,
+		"appliedPoliciesOnly": appliedOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating conditional access policies: %w", err)
+	}
+
+	var response struct {
+		Value []whatIfResult `json:"value"`
+	}
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("error decoding conditional access evaluation: %v", err)
+	}
+
+	policiesData := make(map[string]interface{})
+	decision := map[string]interface{}{
+		"access":           "granted",
+		"requiredControls": []string{},
+		"blockedBy":        []string{},
+	}
+	for _, result := range response.Value {
+		policiesData[result.Id] = convertWhatIfToMap(result)
+
+		// Report-only and disabled policies are not enforced
+		if !result.PolicyApplies || result.State != "enabled" || result.GrantControls == nil {
+			continue
+		}
+		controls := result.GrantControls.BuiltInControls
+		if result.GrantControls.AuthenticationStrength != nil {
+			controls = append(controls, "authenticationStrength '"+result.GrantControls.AuthenticationStrength.DisplayName+"'")
+		}
+		if len(result.GrantControls.TermsOfUse) > 0 {
+			controls = append(controls, "termsOfUse")
+		}
+		switch {
+		case len(controls) == 0:
+		case slices.Contains(controls, "block"):
+			decision["blockedBy"] = append(decision["blockedBy"].([]string), result.DisplayName)
+			decision["access"] = "blocked"
+		default:
+			operator := " AND "
+			if strings.EqualFold(result.GrantControls.Operator, "OR") {
+				operator = " OR "
+			}
+			decision["requiredControls"] = append(decision["requiredControls"].([]string), fmt.Sprintf("%s: %s", result.DisplayName, strings.Join(controls, operator)))
+			if decision["access"] == "granted" {
+				decision["access"] = "grantedWithControls"
+			}
+		}
+	}
+
+	whatIfData := map[string]interface{}{
+		"policies": policiesData,
+		"decision": decision,
+	}
+
+	return json.MarshalIndent(whatIfData, "", "  ")
+}
+
+// convertWhatIfToMap converts a policy evaluation to a map, listing the session controls set
+func convertWhatIfToMap(result whatIfResult) map[string]interface{} {
+
+	policyData := map[string]interface{}{
+		"id":            result.Id,
+		"displayName":   result.DisplayName,
+		"state":         result.State,
+		"policyApplies": result.PolicyApplies,
+	}
+	if result.AnalysisReasons != "" {
+		policyData["analysisReasons"] = result.AnalysisReasons
+	}
+
+	if grant := result.GrantControls; grant != nil {
+		grantData := map[string]interface{}{
+			"operator":        grant.Operator,
+			"builtInControls": []string{},
+		}
+		if grant.BuiltInControls != nil {
+			grantData["builtInControls"] = grant.BuiltInControls
+		}
+		if len(grant.TermsOfUse) > 0 {
+			grantData["termsOfUse"] = grant.TermsOfUse
+		}
+		if grant.AuthenticationStrength != nil {
+			grantData["authenticationStrength"] = grant.AuthenticationStrength.DisplayName
+		}
+		policyData["grantControls"] = grantData
+	}
+
+	sessionControls := []string{}
+	for name, value := range result.SessionControls {
+		if value != nil && name != "@odata.type" {
+			sessionControls = append(sessionControls, name)
+		}
+	}
+	if len(sessionControls) > 0 {
+		sort.Strings(sessionControls)
+		policyData["sessionControls"] = sessionControls
+	}
+
+	return policyData
+}
@@ -0,0 +1,140 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+)
+
+// activityColumns are the columns of the OneDrive and SharePoint activity user detail reports
+// returned per user. Visited pages are only reported by SharePoint.
+var activityColumns = []column{
+	{"User Principal Name", "userPrincipalName", "string"},
+	{"Is Deleted", "isDeleted", "boolean"},
+	{"Last Activity Date", "lastActivityDate", "string"},
+	{"Viewed Or Edited File Count", "viewedEditedFileCount", "integer"},
+	{"Synced File Count", "syncedFileCount", "integer"},
+	{"Shared Internally File Count", "sharedInternallyFileCount", "integer"},
+	{"Shared Externally File Count", "sharedExternallyFileCount", "integer"},
+	{"Visited Page Count", "visitedPageCount", "integer"},
+}
+
+func init() {
+	// File Activity Tool is a tool that interacts with microsoft for usage report APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "file_activity",
+			Tool: mcp.NewTool("file_activity",
+				mcp.WithDescription("Read the OneDrive or SharePoint activity report: per user the number of files viewed or edited, synced, shared internally and externally, and the date of the last activity over the period. When the tenant conceals user names in reports, the names are hashes and the result says so. Requires Reports.Read.All."),
+				mcp.WithString("service",
+					mcp.Enum("onedrive", "sharepoint"),
+					mcp.DefaultString("onedrive"),
+					mcp.Description("The service to report the activity of: 'onedrive' (default) or 'sharepoint'."),
+				),
+				withPeriod(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Reports.Read.All"},
+			OutputSchema:   activitySchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := activityArgs{periodArgs: newPeriodArgs(), Service: "onedrive"}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetFileActivity(ctx, client, a.Service, a.Period)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get file activity report"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// activitySchema describes the result of the file_activity tool.
+var activitySchema = schema.Object(map[string]schema.Schema{
+	"service":           schema.Enum("onedrive", "sharepoint"),
+	"period":            schema.String(),
+	"reportRefreshDate": schema.String(),
+	"anonymized":        schema.Boolean(),
+	"users": schema.Map(schema.Object(map[string]schema.Schema{
+		"userPrincipalName":         schema.String(),
+		"isDeleted":                 schema.Boolean(),
+		"lastActivityDate":          schema.String(),
+		"viewedEditedFileCount":     schema.Integer(),
+		"syncedFileCount":           schema.Integer(),
+		"sharedInternallyFileCount": schema.Integer(),
+		"sharedExternallyFileCount": schema.Integer(),
+		"visitedPageCount":          schema.Integer(),
+	})),
+	"message": schema.String(),
+})
+
+// activityArgs are the arguments of the file_activity tool.
+type activityArgs struct {
+	periodArgs
+	Service string `json:"service"`
+}
+
+// Validate checks the period and the service.
+func (a *activityArgs) Validate() error {
+
+	if err := a.periodArgs.Validate(); err != nil {
+		return err
+	}
+	if a.Service != "onedrive" && a.Service != "sharepoint" {
+		return fmt.Errorf("unsupported service '%s'", a.Service)
+	}
+
+	return nil
+}
+
+// GetFileActivity retrieves the activity user detail report of OneDrive or SharePoint over the
+// period, keyed by user principal name.
+func GetFileActivity(ctx context.Context, client *msgraphsdk.GraphServiceClient, service string, period string) ([]byte, error) {
+
+	var content []byte
+	var err error
+	if service == "sharepoint" {
+		content, err = client.Reports().GetSharePointActivityUserDetailWithPeriod(&period).Get(ctx, nil)
+	} else {
+		content, err = client.Reports().GetOneDriveActivityUserDetailWithPeriod(&period).Get(ctx, nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s activity report: %v", service, err)
+	}
+
+	usersData, refreshDate, anonymized, err := parseReport(content, "userPrincipalName", activityColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	reportData := map[string]interface{}{
+		"service":           service,
+		"period":            period,
+		"reportRefreshDate": refreshDate,
+		"anonymized":        anonymized,
+		"users":             usersData,
+	}
+	if anonymized {
+		reportData["message"] = anonymizedMessage
+	}
+
+	return json.MarshalIndent(reportData, "", "  ")
+}
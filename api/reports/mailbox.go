@@ -0,0 +1,113 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+)
+
+// mailboxColumns are the columns of the mailbox usage detail report returned per user.
+var mailboxColumns = []column{
+	{"User Principal Name", "userPrincipalName", "string"},
+	{"Display Name", "displayName", "string"},
+	{"Is Deleted", "isDeleted", "boolean"},
+	{"Created Date", "createdDate", "string"},
+	{"Last Activity Date", "lastActivityDate", "string"},
+	{"Item Count", "itemCount", "integer"},
+	{"Storage Used (Byte)", "storageUsed", "integer"},
+	{"Issue Warning Quota (Byte)", "issueWarningQuota", "integer"},
+	{"Prohibit Send Quota (Byte)", "prohibitSendQuota", "integer"},
+	{"Prohibit Send/Receive Quota (Byte)", "prohibitSendReceiveQuota", "integer"},
+	{"Has Archive", "hasArchive", "boolean"},
+}
+
+func init() {
+	// Mailbox Usage Tool is a tool that interacts with microsoft for usage report APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "mailbox_usage",
+			Tool: mcp.NewTool("mailbox_usage",
+				mcp.WithDescription("Read the mailbox usage report: per user the storage used, the item count, the quotas and the date of the last activity over the period. When the tenant conceals user names in reports, the names are hashes and the result says so. Requires Reports.Read.All."),
+				withPeriod(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Reports.Read.All"},
+			OutputSchema:   mailboxSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := newPeriodArgs()
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetMailboxUsage(ctx, client, a.Period)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get mailbox usage report"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// mailboxSchema describes the result of the mailbox_usage tool.
+var mailboxSchema = schema.Object(map[string]schema.Schema{
+	"period":            schema.String(),
+	"reportRefreshDate": schema.String(),
+	"anonymized":        schema.Boolean(),
+	"users": schema.Map(schema.Object(map[string]schema.Schema{
+		"userPrincipalName":        schema.String(),
+		"displayName":              schema.String(),
+		"isDeleted":                schema.Boolean(),
+		"createdDate":              schema.String(),
+		"lastActivityDate":         schema.String(),
+		"itemCount":                schema.Integer(),
+		"storageUsed":              schema.Integer(),
+		"issueWarningQuota":        schema.Integer(),
+		"prohibitSendQuota":        schema.Integer(),
+		"prohibitSendReceiveQuota": schema.Integer(),
+		"hasArchive":               schema.Boolean(),
+	})),
+	"message": schema.String(),
+})
+
+// GetMailboxUsage retrieves the mailbox usage detail report over the period, keyed by user
+// principal name. Graph redirects to the CSV content of the report, which the client follows.
+func GetMailboxUsage(ctx context.Context, client *msgraphsdk.GraphServiceClient, period string) ([]byte, error) {
+
+	content, err := client.Reports().GetMailboxUsageDetailWithPeriod(&period).Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching mailbox usage report: %v", err)
+	}
+
+	usersData, refreshDate, anonymized, err := parseReport(content, "userPrincipalName", mailboxColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	reportData := map[string]interface{}{
+		"period":            period,
+		"reportRefreshDate": refreshDate,
+		"anonymized":        anonymized,
+		"users":             usersData,
+	}
+	if anonymized {
+		reportData["message"] = anonymizedMessage
+	}
+
+	return json.MarshalIndent(reportData, "", "  ")
+}
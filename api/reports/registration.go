@@ -0,0 +1,160 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/beta"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+)
+
+func init() {
+	// Registration Trends Tool is a tool that interacts with microsoft for authentication methods report APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "registration_trends",
+			Tool: mcp.NewTool("registration_trends",
+				mcp.WithDescription("Report on the adoption of strong authentication: the current share of users registered for or capable of MFA, self-service password reset (SSPR) and passwordless, and the daily share of sign-ins using MFA over the period, to follow the trend. Requires Reports.Read.All and AuditLog.Read.All."),
+				withPeriod(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Reports.Read.All", "AuditLog.Read.All"},
+			OutputSchema:   registrationSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := newPeriodArgs()
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetRegistrationTrends(ctx, client, a.Period)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get registration trends"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// registrationSchema describes the result of the registration_trends tool.
+var registrationSchema = schema.Object(map[string]schema.Schema{
+	"period":         schema.String(),
+	"totalUserCount": schema.Integer(),
+	"registration": schema.Map(schema.Object(map[string]schema.Schema{
+		"userCount": schema.Integer(),
+		"percent":   schema.Number(),
+	})),
+	"mfaSignIns": schema.Array(schema.Object(map[string]schema.Schema{
+		"date":                schema.String(),
+		"totalSignIns":        schema.Integer(),
+		"multiFactorSignIns":  schema.Integer(),
+		"singleFactorSignIns": schema.Integer(),
+		"multiFactorPercent":  schema.Number(),
+	})),
+	"message": schema.String(),
+})
+
+// mfaSignInSummary is the daily count of sign-ins by number of factors, only reported by the beta endpoint.
+type mfaSignInSummary struct {
+	CreatedDateTime     time.Time `json:"createdDateTime"`
+	TotalSignIns        int64     `json:"totalSignIns"`
+	MultiFactorSignIns  int64     `json:"multiFactorSignIns"`
+	SingleFactorSignIns int64     `json:"singleFactorSignIns"`
+}
+
+// GetRegistrationTrends retrieves the current registration of users per authentication feature
+// and the daily share of MFA sign-ins over the period. The daily summary is only available in
+// beta: when it cannot be read, the registration is returned alone with the reason.
+func GetRegistrationTrends(ctx context.Context, client *msgraphsdk.GraphServiceClient, period string) ([]byte, error) {
+
+	summary, err := client.Reports().AuthenticationMethods().UsersRegisteredByFeature().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching users registered by feature: %w", err)
+	}
+
+	var total int64
+	if summary.GetTotalUserCount() != nil {
+		total = *summary.GetTotalUserCount()
+	}
+
+	registrationData := make(map[string]interface{})
+	for _, count := range summary.GetUserRegistrationFeatureCounts() {
+		if count.GetFeature() == nil || count.GetUserCount() == nil {
+			continue
+		}
+		registrationData[count.GetFeature().String()] = map[string]interface{}{
+			"userCount": *count.GetUserCount(),
+			"percent":   percent(*count.GetUserCount(), total),
+		}
+	}
+
+	trendData := map[string]interface{}{
+		"period":         period,
+		"totalUserCount": total,
+		"registration":   registrationData,
+	}
+
+	days, _ := strconv.Atoi(strings.TrimPrefix(period, "D"))
+	since := time.Now().UTC().AddDate(0, 0, -days)
+
+	query := url.Values{}
+	query.Set("$filter", fmt.Sprintf("createdDateTime ge %s", since.Format(time.RFC3339)))
+
+	signIns := []map[string]interface{}{}
+	err = beta.Iterate(ctx, client, "/reports/authenticationMethods/userMfaSignInSummary?"+query.Encode(), func(day mfaSignInSummary) bool {
+		// The filter is applied again in case the endpoint ignores it
+		if day.CreatedDateTime.Before(since) {
+			return true
+		}
+		signIns = append(signIns, map[string]interface{}{
+			"date":                day.CreatedDateTime.Format(time.DateOnly),
+			"totalSignIns":        day.TotalSignIns,
+			"multiFactorSignIns":  day.MultiFactorSignIns,
+			"singleFactorSignIns": day.SingleFactorSignIns,
+			"multiFactorPercent":  percent(day.MultiFactorSignIns, day.TotalSignIns),
+		})
+		return true
+	})
+	if err != nil {
+		trendData["message"] = fmt.Sprintf("The daily MFA sign-in summary is unavailable: %s", odata.ErrorMessage(err))
+		return json.MarshalIndent(trendData, "", "  ")
+	}
+
+	sort.Slice(signIns, func(i, j int) bool {
+		return signIns[i]["date"].(string) < signIns[j]["date"].(string)
+	})
+	trendData["mfaSignIns"] = signIns
+
+	return json.MarshalIndent(trendData, "", "  ")
+}
+
+// percent returns count as a percentage of total, rounded to one decimal, or 0 if total is 0.
+func percent(count int64, total int64) float64 {
+
+	if total == 0 {
+		return 0
+	}
+
+	return math.Round(float64(count)*1000/float64(total)) / 10
+}
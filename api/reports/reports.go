@@ -0,0 +1,114 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// periods are the periods the usage reports can be computed over.
+var periods = []string{"D7", "D30", "D90", "D180"}
+
+// concealedName matches the hashes replacing user names when reports are anonymized.
+var concealedName = regexp.MustCompile(`^[0-9A-F]{32}$`)
+
+// anonymizedMessage explains how to get the real user names in the reports.
+const anonymizedMessage = "The user names are concealed: the tenant anonymizes the usage reports. Disable 'Display concealed user, group, and site names in all reports' in the Microsoft 365 admin center (Settings > Org settings > Reports) to get them."
+
+// column maps a column of a report to an attribute of a row.
+type column struct {
+	header string
+	key    string
+	kind   string // "string", "integer" or "boolean"
+}
+
+// withPeriod adds the period argument of a usage report tool.
+func withPeriod() mcp.ToolOption {
+	return mcp.WithString("period",
+		mcp.Enum(periods...),
+		mcp.DefaultString("D30"),
+		mcp.Description("The number of days the report covers: D7, D30 (default), D90 or D180."),
+	)
+}
+
+// periodArgs are the arguments of the usage report tools taking only the period.
+type periodArgs struct {
+	Period string `json:"period"`
+}
+
+// newPeriodArgs returns the arguments with the default period.
+func newPeriodArgs() periodArgs {
+	return periodArgs{Period: "D30"}
+}
+
+// Validate checks that the period is supported.
+func (a *periodArgs) Validate() error {
+
+	if !slices.Contains(periods, a.Period) {
+		return fmt.Errorf("unsupported period '%s', use one of %v", a.Period, periods)
+	}
+
+	return nil
+}
+
+// parseReport converts the CSV content of a report to rows keyed by the key column, keeping the
+// given columns. It returns the refresh date of the report and whether the names are concealed.
+func parseReport(content []byte, key string, columns []column) (rows map[string]interface{}, refreshDate string, anonymized bool, err error) {
+
+	// Reports are UTF-8 with a byte order mark
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, "", false, fmt.Errorf("error parsing report: %v", err)
+	}
+
+	rows = make(map[string]interface{})
+	if len(records) == 0 {
+		return rows, "", false, nil
+	}
+
+	indexes := make(map[string]int, len(records[0]))
+	for i, header := range records[0] {
+		indexes[header] = i
+	}
+
+	for _, record := range records[1:] {
+		row := make(map[string]interface{})
+		if i, ok := indexes["Report Refresh Date"]; ok && i < len(record) {
+			refreshDate = record[i]
+		}
+		for _, c := range columns {
+			i, ok := indexes[c.header]
+			if !ok || i >= len(record) || record[i] == "" {
+				continue
+			}
+			switch c.kind {
+			case "integer":
+				if value, err := strconv.ParseInt(record[i], 10, 64); err == nil {
+					row[c.key] = value
+				}
+			case "boolean":
+				if value, err := strconv.ParseBool(record[i]); err == nil {
+					row[c.key] = value
+				}
+			default:
+				row[c.key] = record[i]
+			}
+		}
+		id, _ := row[key].(string)
+		if id == "" {
+			continue
+		}
+		if concealedName.MatchString(id) {
+			anonymized = true
+		}
+		rows[id] = row
+	}
+
+	return rows, refreshDate, anonymized, nil
+}
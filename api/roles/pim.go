@@ -0,0 +1,207 @@
+package roles
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/rolemanagement"
+)
+
+func init() {
+	// Principal Roles Tool is a tool that interacts with microsoft for Privileged Identity Management APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "principal_roles",
+			Tool: mcp.NewTool("principal_roles",
+				mcp.WithDescription("Show the directory roles a principal is eligible for and the ones actively assigned to it (permanently or through a Privileged Identity Management activation), with their scope and schedule. Requires RoleManagement.Read.Directory."),
+				mcp.WithString("principal_id",
+					mcp.Required(),
+					mcp.Description("The object id of the user, group or service principal."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"RoleManagement.Read.Directory"},
+			OutputSchema:   principalRolesSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a principalRolesArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetPrincipalRoles(ctx, client, a.PrincipalId)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get principal roles"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// scheduleInstance is what eligibility and assignment schedule instances have in common.
+type scheduleInstance interface {
+	GetId() *string
+	GetRoleDefinitionId() *string
+	GetRoleDefinition() models.UnifiedRoleDefinitionable
+	GetDirectoryScopeId() *string
+	GetAppScopeId() *string
+	GetMemberType() *string
+	GetStartDateTime() *time.Time
+	GetEndDateTime() *time.Time
+}
+
+// scheduleInstanceProperties are the attributes of an eligibility or assignment schedule instance.
+var scheduleInstanceProperties = map[string]schema.Schema{
+	"id":               schema.String(),
+	"roleDefinitionId": schema.String(),
+	"roleDisplayName":  schema.String(),
+	"directoryScopeId": schema.String(),
+	"appScopeId":       schema.String(),
+	"memberType":       schema.String(),
+	"startDateTime":    schema.DateTime(),
+	"endDateTime":      schema.String(),
+	"assignmentType":   schema.String(),
+}
+
+// principalRolesSchema describes the result of the principal_roles tool.
+var principalRolesSchema = schema.Object(map[string]schema.Schema{
+	"principalId": schema.String(),
+	"eligible":    schema.Map(schema.Object(scheduleInstanceProperties)),
+	"active":      schema.Map(schema.Object(scheduleInstanceProperties)),
+	"message":     schema.String(),
+})
+
+// principalRolesArgs are the arguments of the principal_roles tool.
+type principalRolesArgs struct {
+	PrincipalId string `json:"principal_id"`
+}
+
+// Validate checks that the principal is given.
+func (a *principalRolesArgs) Validate() error {
+
+	if a.PrincipalId == "" {
+		return fmt.Errorf("principal_id is required")
+	}
+
+	return nil
+}
+
+// GetPrincipalRoles retrieves the role eligibilities and active role assignments of a principal.
+func GetPrincipalRoles(ctx context.Context, client *msgraphsdk.GraphServiceClient, principalId string) ([]byte, error) {
+
+	directory := client.RoleManagement().Directory()
+	filter := to.Ptr(odata.Eq("principalId", principalId))
+
+	eligibilities, err := directory.RoleEligibilityScheduleInstances().Get(ctx, &rolemanagement.DirectoryRoleEligibilityScheduleInstancesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &rolemanagement.DirectoryRoleEligibilityScheduleInstancesRequestBuilderGetQueryParameters{
+			Filter: filter,
+			Expand: []string{"roleDefinition"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching role eligibility schedule instances: %v", err)
+	}
+
+	eligibleData := make(map[string]interface{})
+	err = paginate.Iterate(ctx, client, eligibilities, models.CreateUnifiedRoleEligibilityScheduleInstanceCollectionResponseFromDiscriminatorValue, func(instance models.UnifiedRoleEligibilityScheduleInstanceable) bool {
+		id, instanceData := convertScheduleInstanceToMap(instance)
+		eligibleData[id] = instanceData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through role eligibility schedule instances: %v", err)
+	}
+
+	assignments, err := directory.RoleAssignmentScheduleInstances().Get(ctx, &rolemanagement.DirectoryRoleAssignmentScheduleInstancesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &rolemanagement.DirectoryRoleAssignmentScheduleInstancesRequestBuilderGetQueryParameters{
+			Filter: filter,
+			Expand: []string{"roleDefinition"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching role assignment schedule instances: %v", err)
+	}
+
+	activeData := make(map[string]interface{})
+	err = paginate.Iterate(ctx, client, assignments, models.CreateUnifiedRoleAssignmentScheduleInstanceCollectionResponseFromDiscriminatorValue, func(instance models.UnifiedRoleAssignmentScheduleInstanceable) bool {
+		id, instanceData := convertScheduleInstanceToMap(instance)
+		// Assigned is a permanent or time bound assignment, Activated comes from an eligibility
+		if assignmentType := instance.GetAssignmentType(); assignmentType != nil {
+			instanceData["assignmentType"] = *assignmentType
+		}
+		activeData[id] = instanceData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through role assignment schedule instances: %v", err)
+	}
+
+	rolesData := map[string]interface{}{
+		"principalId": principalId,
+		"eligible":    eligibleData,
+		"active":      activeData,
+	}
+	if len(eligibleData) == 0 && len(activeData) == 0 {
+		rolesData["message"] = "The principal has no eligible or active directory role."
+	}
+
+	return json.MarshalIndent(rolesData, "", "  ")
+}
+
+// convertScheduleInstanceToMap converts a role schedule instance to a map with its role, scope and schedule
+func convertScheduleInstanceToMap(instance scheduleInstance) (string, map[string]interface{}) {
+
+	instanceId := ""
+	instanceData := make(map[string]interface{})
+
+	if id := instance.GetId(); id != nil {
+		instanceId = *id
+		instanceData["id"] = instanceId
+	}
+	if roleDefinitionId := instance.GetRoleDefinitionId(); roleDefinitionId != nil {
+		instanceData["roleDefinitionId"] = *roleDefinitionId
+	}
+	if roleDefinition := instance.GetRoleDefinition(); roleDefinition != nil && roleDefinition.GetDisplayName() != nil {
+		instanceData["roleDisplayName"] = *roleDefinition.GetDisplayName()
+	}
+	if directoryScopeId := instance.GetDirectoryScopeId(); directoryScopeId != nil {
+		// "/" is the whole tenant, otherwise the scope is an administrative unit or an object
+		instanceData["directoryScopeId"] = *directoryScopeId
+	}
+	if appScopeId := instance.GetAppScopeId(); appScopeId != nil {
+		instanceData["appScopeId"] = *appScopeId
+	}
+	if memberType := instance.GetMemberType(); memberType != nil {
+		instanceData["memberType"] = *memberType
+	}
+	if startDateTime := instance.GetStartDateTime(); startDateTime != nil {
+		instanceData["startDateTime"] = startDateTime.Format(time.RFC3339)
+	}
+	if endDateTime := instance.GetEndDateTime(); endDateTime != nil {
+		instanceData["endDateTime"] = endDateTime.Format(time.RFC3339)
+	} else {
+		instanceData["endDateTime"] = "permanent"
+	}
+
+	return instanceId, instanceData
+}
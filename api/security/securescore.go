@@ -0,0 +1,213 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/security"
+)
+
+// defaultTopActions is the number of improvement actions returned when no top is given.
+const defaultTopActions = 10
+
+func init() {
+	// Secure Score Tool is a tool that interacts with microsoft for secure score APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "secure_score",
+			Tool: mcp.NewTool("secure_score",
+				mcp.WithDescription("Read the latest Microsoft Secure Score of the tenant (current and max score) and the improvement actions bringing the most points. Requires SecurityEvents.Read.All."),
+				mcp.WithNumber("top",
+					mcp.Description(fmt.Sprintf("The number of improvement actions to return. Defaults to %d.", defaultTopActions)),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"SecurityEvents.Read.All"},
+			OutputSchema:   secureScoreSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := secureScoreArgs{Top: defaultTopActions}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetSecureScore(ctx, client, a.Top)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get secure score"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// secureScoreSchema describes the result of the secure_score tool.
+var secureScoreSchema = schema.Object(map[string]schema.Schema{
+	"currentScore":    schema.Number(),
+	"maxScore":        schema.Number(),
+	"createdDateTime": schema.DateTime(),
+	"enabledServices": schema.Array(schema.String()),
+	"improvementActions": schema.Array(schema.Object(map[string]schema.Schema{
+		"id":                 schema.String(),
+		"title":              schema.String(),
+		"controlCategory":    schema.String(),
+		"service":            schema.String(),
+		"rank":               schema.Integer(),
+		"userImpact":         schema.String(),
+		"implementationCost": schema.String(),
+		"threats":            schema.Array(schema.String()),
+		"actionUrl":          schema.String(),
+		"maxScore":           schema.Number(),
+		"score":              schema.Number(),
+		"potentialGain":      schema.Number(),
+	})),
+	"message": schema.String(),
+})
+
+// secureScoreArgs are the arguments of the secure_score tool.
+type secureScoreArgs struct {
+	Top int `json:"top"`
+}
+
+// Validate checks that the number of actions is positive.
+func (a *secureScoreArgs) Validate() error {
+
+	if a.Top <= 0 {
+		return fmt.Errorf("top must be a positive number")
+	}
+
+	return nil
+}
+
+// GetSecureScore retrieves the latest secure score and the top improvement actions, ranked by
+// the points they would still bring.
+func GetSecureScore(ctx context.Context, client *msgraphsdk.GraphServiceClient, top int) ([]byte, error) {
+
+	scores, err := client.Security().SecureScores().Get(ctx, &security.SecureScoresRequestBuilderGetRequestConfiguration{
+		QueryParameters: &security.SecureScoresRequestBuilderGetQueryParameters{
+			Top: to.Ptr(int32(1)),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching secure scores: %v", err)
+	}
+
+	// The secure score is computed daily, a new tenant may not have one yet
+	if len(scores.GetValue()) == 0 {
+		return json.MarshalIndent(map[string]interface{}{
+			"message": "No secure score has been computed for this tenant yet.",
+		}, "", "  ")
+	}
+	score := scores.GetValue()[0]
+
+	// Points already earned per control
+	earned := map[string]float64{}
+	for _, control := range score.GetControlScores() {
+		if control.GetControlName() != nil && control.GetScore() != nil {
+			earned[*control.GetControlName()] = *control.GetScore()
+		}
+	}
+
+	profiles, err := client.Security().SecureScoreControlProfiles().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching secure score control profiles: %v", err)
+	}
+
+	var actions []map[string]interface{}
+	err = paginate.Iterate(ctx, client, profiles, models.CreateSecureScoreControlProfileCollectionResponseFromDiscriminatorValue, func(profile models.SecureScoreControlProfileable) bool {
+		if profile.GetId() == nil || profile.GetMaxScore() == nil || (profile.GetDeprecated() != nil && *profile.GetDeprecated()) {
+			return true
+		}
+		gain := *profile.GetMaxScore() - earned[*profile.GetId()]
+		if gain <= 0 {
+			return true
+		}
+		actions = append(actions, convertControlProfileToMap(profile, earned[*profile.GetId()], gain))
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through secure score control profiles: %v", err)
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i]["potentialGain"].(float64) > actions[j]["potentialGain"].(float64)
+	})
+	if len(actions) > top {
+		actions = actions[:top]
+	}
+
+	scoreData := map[string]interface{}{
+		"improvementActions": actions,
+	}
+	if currentScore := score.GetCurrentScore(); currentScore != nil {
+		scoreData["currentScore"] = *currentScore
+	}
+	if maxScore := score.GetMaxScore(); maxScore != nil {
+		scoreData["maxScore"] = *maxScore
+	}
+	if createdDateTime := score.GetCreatedDateTime(); createdDateTime != nil {
+		scoreData["createdDateTime"] = createdDateTime.Format(time.RFC3339)
+	}
+	if enabledServices := score.GetEnabledServices(); enabledServices != nil {
+		scoreData["enabledServices"] = enabledServices
+	}
+
+	return json.MarshalIndent(scoreData, "", "  ")
+}
+
+// convertControlProfileToMap converts a secure score control profile to an improvement action
+func convertControlProfileToMap(profile models.SecureScoreControlProfileable, score float64, gain float64) map[string]interface{} {
+
+	actionData := map[string]interface{}{
+		"id":            *profile.GetId(),
+		"maxScore":      *profile.GetMaxScore(),
+		"score":         score,
+		"potentialGain": gain,
+	}
+
+	if title := profile.GetTitle(); title != nil {
+		actionData["title"] = *title
+	}
+	if controlCategory := profile.GetControlCategory(); controlCategory != nil {
+		actionData["controlCategory"] = *controlCategory
+	}
+	if service := profile.GetService(); service != nil {
+		actionData["service"] = *service
+	}
+	if rank := profile.GetRank(); rank != nil {
+		actionData["rank"] = *rank
+	}
+	if userImpact := profile.GetUserImpact(); userImpact != nil {
+		actionData["userImpact"] = *userImpact
+	}
+	if implementationCost := profile.GetImplementationCost(); implementationCost != nil {
+		actionData["implementationCost"] = *implementationCost
+	}
+	if threats := profile.GetThreats(); threats != nil {
+		actionData["threats"] = threats
+	}
+	if actionUrl := profile.GetActionUrl(); actionUrl != nil {
+		actionData["actionUrl"] = *actionUrl
+	}
+
+	return actionData
+}
@@ -0,0 +1,87 @@
+package security
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// scoreRoutes answer a secure score with points earned on some controls, and the profiles of
+// controls with all, part or none of their points earned, and a deprecated one.
+var scoreRoutes = graphtest.Routes{
+	"GET /v1.0/security/secureScores": map[string]interface{}{
+		"value": []interface{}{
+			map[string]interface{}{
+				"id":           "score-id",
+				"currentScore": 15,
+				"maxScore":     50,
+				"controlScores": []interface{}{
+					map[string]interface{}{"controlName": "partial", "score": 5},
+					map[string]interface{}{"controlName": "complete", "score": 10},
+				},
+			},
+		},
+	},
+	"GET /v1.0/security/secureScoreControlProfiles": map[string]interface{}{
+		"value": []interface{}{
+			map[string]interface{}{"id": "partial", "maxScore": 9},
+			map[string]interface{}{"id": "complete", "maxScore": 10},
+			map[string]interface{}{"id": "small", "maxScore": 3},
+			map[string]interface{}{"id": "deprecated", "maxScore": 20, "deprecated": true},
+			map[string]interface{}{"id": "none", "maxScore": 8},
+		},
+	},
+}
+
+func TestSecureScoreRanking(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		arguments map[string]interface{}
+		want      []string
+	}{
+		{"ranked by potential gain", nil, []string{"none", "partial", "small"}},
+		{"top", map[string]interface{}{"top": 2}, []string{"none", "partial"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := graphtest.CheckTool(t, "secure_score", test.arguments, scoreRoutes)
+
+			actions, _ := result.(map[string]interface{})["improvementActions"].([]interface{})
+			got := []string{}
+			for _, action := range actions {
+				got = append(got, action.(map[string]interface{})["id"].(string))
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got actions %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSecureScorePotentialGain(t *testing.T) {
+
+	result := graphtest.CheckTool(t, "secure_score", nil, scoreRoutes)
+
+	actions, _ := result.(map[string]interface{})["improvementActions"].([]interface{})
+	if len(actions) == 0 {
+		t.Fatalf("no improvement action returned: %v", result)
+	}
+	partial := actions[1].(map[string]interface{})
+	if partial["id"] != "partial" || partial["score"] != float64(5) || partial["potentialGain"] != float64(4) {
+		t.Errorf("unexpected action: %v", partial)
+	}
+}
+
+func TestSecureScoreNotComputed(t *testing.T) {
+
+	result := graphtest.CheckTool(t, "secure_score", nil, graphtest.Routes{
+		"GET /v1.0/security/secureScores": map[string]interface{}{"value": []interface{}{}},
+	})
+
+	if message, _ := result.(map[string]interface{})["message"].(string); message == "" {
+		t.Errorf("missing message: %v", result)
+	}
+}
@@ -0,0 +1,204 @@
+package serviceprincipals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/serviceprincipals"
+)
+
+// graphAppId is the application id of Microsoft Graph, the resource described by default.
+const graphAppId = "00000003-0000-0000-c000-000000000000"
+
+func init() {
+	// API Permissions Tool is a tool that interacts with microsoft for service principal APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "api_permissions",
+			Tool: mcp.NewTool("api_permissions",
+				mcp.WithDescription("List the permissions an API exposes before granting them: the delegated scopes of its service principal, with whether they require admin consent, and its application roles, which always do, with their descriptions. Requires Application.Read.All."),
+				mcp.WithString("app_id",
+					mcp.Description(fmt.Sprintf("The application (client) id of the resource API. Defaults to Microsoft Graph (%s).", graphAppId)),
+				),
+				mcp.WithString("permission",
+					mcp.Description("Only return the permissions whose name contains this text, case-insensitively, like 'User.' or 'Mail'."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Application.Read.All"},
+			OutputSchema:   apiPermissionsSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := apiPermissionsArgs{AppId: graphAppId}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				servicePrincipal, err := getByAppId(ctx, client, a.AppId)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get resource service principal"), err
+				}
+				if servicePrincipal == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("no service principal found for the resource with app id '%s': the API is unknown or not available in this tenant", a.AppId)), nil
+				}
+
+				jsonData, err := json.MarshalIndent(convertAPIPermissionsToMap(servicePrincipal, a.Permission), "", "  ")
+				if err != nil {
+					return mcp.NewToolResultError("failed to get api permissions"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// apiPermissionsSchema describes the result of the api_permissions tool.
+var apiPermissionsSchema = schema.Object(map[string]schema.Schema{
+	"id":          schema.String(),
+	"appId":       schema.String(),
+	"displayName": schema.String(),
+	"delegatedScopes": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":                   schema.String(),
+		"value":                schema.String(),
+		"displayName":          schema.String(),
+		"description":          schema.String(),
+		"userConsentName":      schema.String(),
+		"adminConsentRequired": schema.Boolean(),
+		"isEnabled":            schema.Boolean(),
+	})),
+	"applicationRoles": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":                   schema.String(),
+		"value":                schema.String(),
+		"displayName":          schema.String(),
+		"description":          schema.String(),
+		"allowedMemberTypes":   schema.Array(schema.String()),
+		"adminConsentRequired": schema.Boolean(),
+		"isEnabled":            schema.Boolean(),
+	})),
+})
+
+// apiPermissionsArgs are the arguments of the api_permissions tool.
+type apiPermissionsArgs struct {
+	AppId      string `json:"app_id"`
+	Permission string `json:"permission"`
+}
+
+// getByAppId returns the service principal of the application with the given app id, with the
+// permissions it exposes, or nil if it has none in the tenant.
+func getByAppId(ctx context.Context, client *msgraphsdk.GraphServiceClient, appId string) (models.ServicePrincipalable, error) {
+
+	result, err := client.ServicePrincipals().Get(ctx, &serviceprincipals.ServicePrincipalsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &serviceprincipals.ServicePrincipalsRequestBuilderGetQueryParameters{
+			Filter: to.Ptr(odata.Eq("appId", appId)),
+			Select: []string{"id", "appId", "displayName", "oauth2PermissionScopes", "appRoles"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching service principal of '%s': %w", appId, err)
+	}
+	if len(result.GetValue()) == 0 {
+		return nil, nil
+	}
+
+	return result.GetValue()[0], nil
+}
+
+// convertAPIPermissionsToMap converts the delegated scopes and the application roles exposed by
+// a service principal to maps keyed by permission name, keeping those whose name contains filter.
+func convertAPIPermissionsToMap(servicePrincipal models.ServicePrincipalable, filter string) map[string]interface{} {
+
+	filter = strings.ToLower(filter)
+	matches := func(value *string) bool {
+		return value != nil && strings.Contains(strings.ToLower(*value), filter)
+	}
+
+	permissionsData := make(map[string]interface{})
+
+	if id := servicePrincipal.GetId(); id != nil {
+		permissionsData["id"] = *id
+	}
+	if appId := servicePrincipal.GetAppId(); appId != nil {
+		permissionsData["appId"] = *appId
+	}
+	if displayName := servicePrincipal.GetDisplayName(); displayName != nil {
+		permissionsData["displayName"] = *displayName
+	}
+
+	scopesData := make(map[string]interface{})
+	for _, scope := range servicePrincipal.GetOauth2PermissionScopes() {
+		if !matches(scope.GetValue()) {
+			continue
+		}
+		scopeData := map[string]interface{}{
+			"value": *scope.GetValue(),
+			// Scopes of type User can be consented to by the users themselves
+			"adminConsentRequired": scope.GetTypeEscaped() == nil || *scope.GetTypeEscaped() != "User",
+		}
+		if id := scope.GetId(); id != nil {
+			scopeData["id"] = id.String()
+		}
+		if displayName := scope.GetAdminConsentDisplayName(); displayName != nil {
+			scopeData["displayName"] = *displayName
+		}
+		if description := scope.GetAdminConsentDescription(); description != nil {
+			scopeData["description"] = *description
+		}
+		if userConsentName := scope.GetUserConsentDisplayName(); userConsentName != nil {
+			scopeData["userConsentName"] = *userConsentName
+		}
+		if isEnabled := scope.GetIsEnabled(); isEnabled != nil {
+			scopeData["isEnabled"] = *isEnabled
+		}
+		scopesData[*scope.GetValue()] = scopeData
+	}
+	permissionsData["delegatedScopes"] = scopesData
+
+	rolesData := make(map[string]interface{})
+	for _, role := range servicePrincipal.GetAppRoles() {
+		if !matches(role.GetValue()) {
+			continue
+		}
+		roleData := map[string]interface{}{
+			"value":                *role.GetValue(),
+			"allowedMemberTypes":   []string{},
+			"adminConsentRequired": true,
+		}
+		if allowedMemberTypes := role.GetAllowedMemberTypes(); allowedMemberTypes != nil {
+			roleData["allowedMemberTypes"] = allowedMemberTypes
+		}
+		if id := role.GetId(); id != nil {
+			roleData["id"] = id.String()
+		}
+		if displayName := role.GetDisplayName(); displayName != nil {
+			roleData["displayName"] = *displayName
+		}
+		if description := role.GetDescription(); description != nil {
+			roleData["description"] = *description
+		}
+		if isEnabled := role.GetIsEnabled(); isEnabled != nil {
+			roleData["isEnabled"] = *isEnabled
+		}
+		rolesData[*role.GetValue()] = roleData
+	}
+	permissionsData["applicationRoles"] = rolesData
+
+	return permissionsData
+}
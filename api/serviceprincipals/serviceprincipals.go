@@ -0,0 +1,177 @@
+package serviceprincipals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/serviceprincipals"
+)
+
+// servicePrincipalFields are the attributes of a service principal returned by the service_principals tool.
+var servicePrincipalFields = []string{"id", "appId", "displayName", "servicePrincipalType", "accountEnabled", "appRoleAssignmentRequired", "appOwnerOrganizationId", "tags"}
+
+func init() {
+	// Service Principals Tool is a tool that interacts with microsoft for service principal APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "service_principals",
+			Tool: mcp.NewTool("service_principals",
+				mcp.WithDescription("List the service principals of the tenant, the enterprise applications and managed identities, or find them by name, with their type, whether they are enabled and whether users need an assignment to sign in. Requires Application.Read.All."),
+				mcp.WithString("name",
+					mcp.Description("The display name of the service principal. If not provided, all service principals will be returned."),
+				),
+				odata.WithMatchMode(),
+				paginate.WithLimit(),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Application.Read.All"},
+			OutputSchema:   servicePrincipalSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a servicePrincipalsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				params := &serviceprincipals.ServicePrincipalsRequestBuilderGetQueryParameters{}
+				if a.Name != "" {
+					filter, search, err := odata.Match("displayName", a.Name, a.MatchMode)
+					if err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+					params.Filter, params.Search = filter, search
+				}
+				limit, capped, err := paginate.Limit(request, a.Limit, params.Filter != nil || params.Search != nil)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				// Get the list of service principals
+				jsonData, err := Get(ctx, client, params, limit)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get service principals"), err
+				}
+				if capped {
+					return paginate.CappedNote(mcp.NewToolResultText(string(jsonData)), limit), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// servicePrincipalSchema describes the result of the service_principals tool.
+var servicePrincipalSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":                        schema.String(),
+	"appId":                     schema.String(),
+	"displayName":               schema.String(),
+	"servicePrincipalType":      schema.String(),
+	"accountEnabled":            schema.Boolean(),
+	"appRoleAssignmentRequired": schema.Boolean(),
+	"appOwnerOrganizationId":    schema.String(),
+	"tags":                      schema.Array(schema.String()),
+}))
+
+// servicePrincipalsArgs are the arguments of the service_principals tool.
+type servicePrincipalsArgs struct {
+	Name      string `json:"name"`
+	MatchMode string `json:"matchMode"`
+	Limit     int    `json:"limit"`
+}
+
+// Get retrieves the service principals of the tenant, or the first limit ones if it is not zero.
+func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *serviceprincipals.ServicePrincipalsRequestBuilderGetQueryParameters, limit int) ([]byte, error) {
+
+	if params == nil {
+		params = &serviceprincipals.ServicePrincipalsRequestBuilderGetQueryParameters{}
+	}
+	if len(params.Select) == 0 {
+		params.Select = servicePrincipalFields
+	}
+
+	requestConfig := &serviceprincipals.ServicePrincipalsRequestBuilderGetRequestConfiguration{
+		QueryParameters: params,
+	}
+	// $search is an advanced query, it requires the ConsistencyLevel header
+	if params.Search != nil {
+		requestConfig.Headers = abstractions.NewRequestHeaders()
+		requestConfig.Headers.Add("ConsistencyLevel", "eventual")
+	}
+
+	result, err := client.ServicePrincipals().Get(ctx, requestConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching service principals: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	servicePrincipalsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateServicePrincipalCollectionResponseFromDiscriminatorValue, func(servicePrincipal models.ServicePrincipalable) bool {
+		id, servicePrincipalData := convertServicePrincipalToMap(servicePrincipal)
+		servicePrincipalsData[id] = servicePrincipalData
+		return limit == 0 || len(servicePrincipalsData) < limit
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through service principals: %v", err)
+	}
+
+	return json.MarshalIndent(servicePrincipalsData, "", "  ")
+}
+
+// convertServicePrincipalToMap converts a service principal model to a map with its identity and sign-in settings
+func convertServicePrincipalToMap(servicePrincipal models.ServicePrincipalable) (string, map[string]interface{}) {
+
+	servicePrincipalId := ""
+	servicePrincipalData := make(map[string]interface{})
+
+	if id := servicePrincipal.GetId(); id != nil {
+		servicePrincipalId = *id
+		servicePrincipalData["id"] = servicePrincipalId
+	}
+	if appId := servicePrincipal.GetAppId(); appId != nil {
+		servicePrincipalData["appId"] = *appId
+	}
+	if displayName := servicePrincipal.GetDisplayName(); displayName != nil {
+		servicePrincipalData["displayName"] = *displayName
+	}
+	if servicePrincipalType := servicePrincipal.GetServicePrincipalType(); servicePrincipalType != nil {
+		servicePrincipalData["servicePrincipalType"] = *servicePrincipalType
+	}
+	if accountEnabled := servicePrincipal.GetAccountEnabled(); accountEnabled != nil {
+		servicePrincipalData["accountEnabled"] = *accountEnabled
+	}
+	if appRoleAssignmentRequired := servicePrincipal.GetAppRoleAssignmentRequired(); appRoleAssignmentRequired != nil {
+		servicePrincipalData["appRoleAssignmentRequired"] = *appRoleAssignmentRequired
+	}
+	if appOwnerOrganizationId := servicePrincipal.GetAppOwnerOrganizationId(); appOwnerOrganizationId != nil {
+		servicePrincipalData["appOwnerOrganizationId"] = appOwnerOrganizationId.String()
+	}
+	servicePrincipalData["tags"] = []string{}
+	if tags := servicePrincipal.GetTags(); tags != nil {
+		servicePrincipalData["tags"] = tags
+	}
+
+	return servicePrincipalId, servicePrincipalData
+}
@@ -0,0 +1,162 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Directory Settings Tool is a tool that interacts with microsoft for tenant-wide directory settings.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "directory_settings",
+			Tool: mcp.NewTool("directory_settings",
+				mcp.WithDescription("Read the tenant-wide directory settings (group creation restrictions, guest access, naming policy...) as name/value pairs. Settings that are not customized are reported with their template default values."),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Directory.Read.All"},
+			OutputSchema:   settingSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				jsonData, err := Get(ctx, client)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get directory settings"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// settingSchema describes the result of the directory_settings tool.
+var settingSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":          schema.String(),
+	"templateId":  schema.String(),
+	"displayName": schema.String(),
+	"description": schema.String(),
+	"source":      schema.Enum("custom", "template default"),
+	"values":      schema.Map(schema.Nullable(schema.String())),
+}))
+
+// Get retrieves the directory settings of the tenant. Templates without a
+// customized setting are reported with their default values.
+func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient) ([]byte, error) {
+
+	settings, err := client.GroupSettings().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching directory settings: %v", err)
+	}
+
+	templates, err := client.GroupSettingTemplates().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching directory setting templates: %v", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	settingsData := make(map[string]interface{})
+
+	configured := map[string]bool{}
+	for _, setting := range settings.GetValue() {
+		id, settingData := convertSettingToMap(setting)
+		settingsData[id] = settingData
+		if templateId := setting.GetTemplateId(); templateId != nil {
+			configured[*templateId] = true
+		}
+	}
+
+	// Fall back to the template defaults for the settings that are not customized
+	for _, template := range templates.GetValue() {
+		if template.GetId() == nil || configured[*template.GetId()] {
+			continue
+		}
+		id, templateData := convertTemplateToMap(template)
+		settingsData[id] = templateData
+	}
+
+	return json.MarshalIndent(settingsData, "", "  ")
+}
+
+// convertSettingToMap converts a customized directory setting to a map of name/value pairs
+func convertSettingToMap(setting models.GroupSettingable) (string, map[string]interface{}) {
+
+	settingId := ""
+	settingData := map[string]interface{}{
+		"source": "custom",
+	}
+
+	if id := setting.GetId(); id != nil {
+		settingId = *id
+		settingData["id"] = settingId
+	}
+	if displayName := setting.GetDisplayName(); displayName != nil {
+		settingData["displayName"] = *displayName
+	}
+	if templateId := setting.GetTemplateId(); templateId != nil {
+		settingData["templateId"] = *templateId
+	}
+
+	values := map[string]interface{}{}
+	for _, value := range setting.GetValues() {
+		if value.GetName() == nil {
+			continue
+		}
+		if value.GetValue() != nil {
+			values[*value.GetName()] = *value.GetValue()
+		} else {
+			values[*value.GetName()] = nil
+		}
+	}
+	settingData["values"] = values
+
+	return settingId, settingData
+}
+
+// convertTemplateToMap converts a directory setting template to a map of name/default value pairs
+func convertTemplateToMap(template models.GroupSettingTemplateable) (string, map[string]interface{}) {
+
+	templateId := ""
+	templateData := map[string]interface{}{
+		"source": "template default",
+	}
+
+	if id := template.GetId(); id != nil {
+		templateId = *id
+		templateData["templateId"] = templateId
+	}
+	if displayName := template.GetDisplayName(); displayName != nil {
+		templateData["displayName"] = *displayName
+	}
+	if description := template.GetDescription(); description != nil {
+		templateData["description"] = *description
+	}
+
+	values := map[string]interface{}{}
+	for _, value := range template.GetValues() {
+		if value.GetName() == nil {
+			continue
+		}
+		if value.GetDefaultValue() != nil {
+			values[*value.GetName()] = *value.GetDefaultValue()
+		} else {
+			values[*value.GetName()] = nil
+		}
+	}
+	templateData["values"] = values
+
+	return templateId, templateData
+}
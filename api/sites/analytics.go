@@ -0,0 +1,196 @@
+package sites
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Site Analytics Tool is a tool that interacts with microsoft for site analytics APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "site_analytics",
+			Tool: mcp.NewTool("site_analytics",
+				mcp.WithDescription("Read the usage of a SharePoint site: page views and unique viewers, along with edits, over all time and the last seven days, with the time range each covers. Sites without analytics data are reported as such. Requires Sites.Read.All."),
+				mcp.WithString("site_id",
+					mcp.Required(),
+					mcp.Description("The id of the site."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Sites.Read.All"},
+			OutputSchema:   analyticsSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a analyticsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetAnalytics(ctx, client, a.SiteId)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get site analytics"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// activityStatSchema describes the activity of a site over a time range.
+var activityStatSchema = schema.Object(map[string]schema.Schema{
+	"startDateTime":        schema.DateTime(),
+	"endDateTime":          schema.DateTime(),
+	"views":                schema.Integer(),
+	"uniqueViewers":        schema.Integer(),
+	"edits":                schema.Integer(),
+	"uniqueEditors":        schema.Integer(),
+	"isTrending":           schema.Boolean(),
+	"incompleteDataBefore": schema.DateTime(),
+	"incompleteThrottled":  schema.Boolean(),
+})
+
+// analyticsSchema describes the result of the site_analytics tool.
+var analyticsSchema = schema.Object(map[string]schema.Schema{
+	"siteId":        schema.String(),
+	"available":     schema.Boolean(),
+	"allTime":       activityStatSchema,
+	"lastSevenDays": activityStatSchema,
+	"message":       schema.String(),
+})
+
+// analyticsArgs are the arguments of the site_analytics tool.
+type analyticsArgs struct {
+	SiteId string `json:"site_id"`
+}
+
+// Validate checks that the site is given.
+func (a *analyticsArgs) Validate() error {
+
+	if a.SiteId == "" {
+		return fmt.Errorf("site_id is required")
+	}
+
+	return nil
+}
+
+// GetAnalytics retrieves the activity of a site over all time and the last seven days. A site
+// Graph holds no analytics for is reported as unavailable rather than as an error.
+func GetAnalytics(ctx context.Context, client *msgraphsdk.GraphServiceClient, siteId string) ([]byte, error) {
+
+	analytics := client.Sites().BySiteId(siteId).Analytics()
+	analyticsData := map[string]interface{}{
+		"siteId":    siteId,
+		"available": false,
+	}
+
+	allTime, err := analytics.AllTime().Get(ctx, nil)
+	if err != nil {
+		if odata.StatusCode(err) != http.StatusNotFound {
+			return nil, fmt.Errorf("error fetching site analytics: %w", err)
+		}
+		analyticsData["message"] = fmt.Sprintf("no analytics data for the site: %s", odata.ErrorMessage(err))
+		return json.MarshalIndent(analyticsData, "", "  ")
+	}
+	lastSevenDays, err := analytics.LastSevenDays().Get(ctx, nil)
+	if err != nil && odata.StatusCode(err) != http.StatusNotFound {
+		return nil, fmt.Errorf("error fetching site analytics: %w", err)
+	}
+
+	if allTimeData := convertActivityStatToMap(allTime); allTimeData != nil {
+		analyticsData["allTime"] = allTimeData
+		analyticsData["available"] = true
+	}
+	if lastSevenDaysData := convertActivityStatToMap(lastSevenDays); lastSevenDaysData != nil {
+		analyticsData["lastSevenDays"] = lastSevenDaysData
+		analyticsData["available"] = true
+	}
+	if analyticsData["available"] == false {
+		analyticsData["message"] = "no analytics data for the site"
+	}
+
+	return json.MarshalIndent(analyticsData, "", "  ")
+}
+
+// convertAnalyticsToMap converts the analytics of a site to a map of its activity per time
+// range, or nil if it holds none
+func convertAnalyticsToMap(analytics models.ItemAnalyticsable) map[string]interface{} {
+
+	analyticsData := make(map[string]interface{})
+	if allTime := convertActivityStatToMap(analytics.GetAllTime()); allTime != nil {
+		analyticsData["allTime"] = allTime
+	}
+	if lastSevenDays := convertActivityStatToMap(analytics.GetLastSevenDays()); lastSevenDays != nil {
+		analyticsData["lastSevenDays"] = lastSevenDays
+	}
+	if len(analyticsData) == 0 {
+		return nil
+	}
+
+	return analyticsData
+}
+
+// convertActivityStatToMap converts the activity over a time range to a map of views and edits
+// with their unique actors, or nil if there is no activity data
+func convertActivityStatToMap(stat models.ItemActivityStatable) map[string]interface{} {
+
+	if stat == nil || (stat.GetAccess() == nil && stat.GetEdit() == nil) {
+		return nil
+	}
+
+	statData := make(map[string]interface{})
+	if startDateTime := stat.GetStartDateTime(); startDateTime != nil {
+		statData["startDateTime"] = startDateTime.Format(time.RFC3339)
+	}
+	if endDateTime := stat.GetEndDateTime(); endDateTime != nil {
+		statData["endDateTime"] = endDateTime.Format(time.RFC3339)
+	}
+	if access := stat.GetAccess(); access != nil {
+		if actionCount := access.GetActionCount(); actionCount != nil {
+			statData["views"] = *actionCount
+		}
+		if actorCount := access.GetActorCount(); actorCount != nil {
+			statData["uniqueViewers"] = *actorCount
+		}
+	}
+	if edit := stat.GetEdit(); edit != nil {
+		if actionCount := edit.GetActionCount(); actionCount != nil {
+			statData["edits"] = *actionCount
+		}
+		if actorCount := edit.GetActorCount(); actorCount != nil {
+			statData["uniqueEditors"] = *actorCount
+		}
+	}
+	if isTrending := stat.GetIsTrending(); isTrending != nil {
+		statData["isTrending"] = *isTrending
+	}
+	if incompleteData := stat.GetIncompleteData(); incompleteData != nil {
+		if missingBefore := incompleteData.GetMissingDataBeforeDateTime(); missingBefore != nil {
+			statData["incompleteDataBefore"] = missingBefore.Format(time.RFC3339)
+		}
+		if wasThrottled := incompleteData.GetWasThrottled(); wasThrottled != nil {
+			statData["incompleteThrottled"] = *wasThrottled
+		}
+	}
+
+	return statData
+}
@@ -0,0 +1,239 @@
+package sites
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/sites"
+)
+
+// contentTypeFields are the attributes of a content type read by the site_content_types tool.
+var contentTypeFields = []string{"id", "name", "description", "group", "parentId", "isBuiltIn", "hidden", "readOnly", "sealed", "documentTemplate"}
+
+func init() {
+	// Site Content Types Tool is a tool that interacts with microsoft for SharePoint content type APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "site_content_types",
+			Tool: mcp.NewTool("site_content_types",
+				mcp.WithDescription("Map how the content of a SharePoint site is structured: the content types of the site with their group, the chain of content types they derive from, and their document template if any. Sites only using the built-in content types are reported as such. Requires Sites.Read.All."),
+				mcp.WithString("site_id",
+					mcp.Required(),
+					mcp.Description("The id of the site."),
+				),
+				mcp.WithBoolean("include_built_in",
+					mcp.Description("Also return the built-in content types. Only the custom ones are returned by default."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Sites.Read.All"},
+			OutputSchema:   contentTypesSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a contentTypesArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetContentTypes(ctx, client, a.SiteId, a.IncludeBuiltIn)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the site '%s' does not exist", a.SiteId)), nil
+					}
+					return mcp.NewToolResultError("failed to get site content types"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// contentTypesSchema describes the result of the site_content_types tool.
+var contentTypesSchema = schema.Object(map[string]schema.Schema{
+	"siteId":             schema.String(),
+	"builtInCount":       schema.Integer(),
+	"customCount":        schema.Integer(),
+	"onlyBuiltInContent": schema.Boolean(),
+	"message":            schema.String(),
+	"contentTypes": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":          schema.String(),
+		"name":        schema.String(),
+		"description": schema.String(),
+		"group":       schema.String(),
+		"parentId":    schema.String(),
+		"baseTypes":   schema.Array(schema.String()),
+		"isBuiltIn":   schema.Boolean(),
+		"hidden":      schema.Boolean(),
+		"readOnly":    schema.Boolean(),
+		"sealed":      schema.Boolean(),
+		"documentTemplate": schema.Object(map[string]schema.Schema{
+			"fileName":   schema.String(),
+			"folderName": schema.String(),
+		}),
+	})),
+})
+
+// contentTypesArgs are the arguments of the site_content_types tool.
+type contentTypesArgs struct {
+	SiteId         string `json:"site_id"`
+	IncludeBuiltIn bool   `json:"include_built_in"`
+}
+
+// Validate checks that the site is given.
+func (a *contentTypesArgs) Validate() error {
+
+	if a.SiteId == "" {
+		return fmt.Errorf("site_id is required")
+	}
+
+	return nil
+}
+
+// GetContentTypes retrieves the content types of a site, only the custom ones unless includeBuiltIn is set.
+func GetContentTypes(ctx context.Context, client *msgraphsdk.GraphServiceClient, siteId string, includeBuiltIn bool) ([]byte, error) {
+
+	result, err := client.Sites().BySiteId(siteId).ContentTypes().Get(ctx, &sites.ItemContentTypesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &sites.ItemContentTypesRequestBuilderGetQueryParameters{
+			Select: contentTypeFields,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching content types: %w", err)
+	}
+
+	contentTypes := []models.ContentTypeable{}
+	err = paginate.Iterate(ctx, client, result, models.CreateContentTypeCollectionResponseFromDiscriminatorValue, func(contentType models.ContentTypeable) bool {
+		contentTypes = append(contentTypes, contentType)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through content types: %v", err)
+	}
+
+	// The parents are looked up among the content types of the site to name the base types
+	byId := make(map[string]models.ContentTypeable, len(contentTypes))
+	for _, contentType := range contentTypes {
+		if contentType.GetId() != nil {
+			byId[*contentType.GetId()] = contentType
+		}
+	}
+
+	// Create a map to store the JSON-friendly data
+	contentTypesData := make(map[string]interface{})
+	builtIn, custom := 0, 0
+
+	for _, contentType := range contentTypes {
+		isBuiltIn := contentType.GetIsBuiltIn() != nil && *contentType.GetIsBuiltIn()
+		if isBuiltIn {
+			builtIn++
+		} else {
+			custom++
+		}
+		if isBuiltIn && !includeBuiltIn {
+			continue
+		}
+		id, contentTypeData := convertContentTypeToMap(contentType)
+		contentTypeData["baseTypes"] = baseTypes(contentType, byId)
+		contentTypesData[id] = contentTypeData
+	}
+
+	contentTypesResult := map[string]interface{}{
+		"siteId":             siteId,
+		"builtInCount":       builtIn,
+		"customCount":        custom,
+		"onlyBuiltInContent": custom == 0,
+		"contentTypes":       contentTypesData,
+	}
+	if custom == 0 {
+		contentTypesResult["message"] = "The site only uses the built-in content types."
+	}
+
+	return json.MarshalIndent(contentTypesResult, "", "  ")
+}
+
+// baseTypes returns the names of the content types a content type derives from, closest first.
+func baseTypes(contentType models.ContentTypeable, byId map[string]models.ContentTypeable) []string {
+
+	names := []string{}
+	seen := map[string]bool{}
+
+	for parentId := contentType.GetParentId(); parentId != nil && !seen[*parentId]; {
+		seen[*parentId] = true
+		parent, ok := byId[*parentId]
+		if !ok {
+			names = append(names, *parentId)
+			break
+		}
+		if name := parent.GetName(); name != nil {
+			names = append(names, *name)
+		}
+		parentId = parent.GetParentId()
+	}
+
+	return names
+}
+
+// convertContentTypeToMap converts a content type model to a map with its document template
+func convertContentTypeToMap(contentType models.ContentTypeable) (string, map[string]interface{}) {
+
+	contentTypeId := ""
+	contentTypeData := make(map[string]interface{})
+
+	if id := contentType.GetId(); id != nil {
+		contentTypeId = *id
+		contentTypeData["id"] = contentTypeId
+	}
+	if name := contentType.GetName(); name != nil {
+		contentTypeData["name"] = *name
+	}
+	if description := contentType.GetDescription(); description != nil && *description != "" {
+		contentTypeData["description"] = *description
+	}
+	if group := contentType.GetGroup(); group != nil {
+		contentTypeData["group"] = *group
+	}
+	if parentId := contentType.GetParentId(); parentId != nil {
+		contentTypeData["parentId"] = *parentId
+	}
+	if isBuiltIn := contentType.GetIsBuiltIn(); isBuiltIn != nil {
+		contentTypeData["isBuiltIn"] = *isBuiltIn
+	}
+	if hidden := contentType.GetHidden(); hidden != nil {
+		contentTypeData["hidden"] = *hidden
+	}
+	if readOnly := contentType.GetReadOnly(); readOnly != nil {
+		contentTypeData["readOnly"] = *readOnly
+	}
+	if sealed := contentType.GetSealed(); sealed != nil {
+		contentTypeData["sealed"] = *sealed
+	}
+	if template := contentType.GetDocumentTemplate(); template != nil && template.GetFileName() != nil && *template.GetFileName() != "" {
+		templateData := map[string]interface{}{
+			"fileName": *template.GetFileName(),
+		}
+		if folderName := template.GetFolderName(); folderName != nil && *folderName != "" {
+			templateData["folderName"] = *folderName
+		}
+		contentTypeData["documentTemplate"] = templateData
+	}
+
+	return contentTypeId, contentTypeData
+}
@@ -0,0 +1,74 @@
+package sites
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+func TestExportPagesCollidingTitles(t *testing.T) {
+
+	titles := map[string]string{"page-1": "Intro", "page-2": "Intro", "page-3": "Intro 2"}
+
+	routes := graphtest.Routes{
+		"GET /v1.0/sites/site-id/pages/graph.sitePage": map[string]interface{}{
+			"value": []interface{}{
+				map[string]interface{}{"id": "page-1", "title": titles["page-1"]},
+				map[string]interface{}{"id": "page-2", "title": titles["page-2"]},
+				map[string]interface{}{"id": "page-3", "title": titles["page-3"]},
+			},
+		},
+	}
+	for id, title := range titles {
+		routes["GET /v1.0/sites/site-id/pages/"+id+"/graph.sitePage"] = func(r *http.Request) interface{} {
+			return map[string]interface{}{"id": id, "title": title}
+		}
+	}
+	cl, err := graphtest.NewClient(routes.Handler(t))
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+
+	dir := t.TempDir()
+	files, err := ExportPages(context.Background(), cl, "site-id", dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names := []string{}
+	for _, file := range files {
+		names = append(names, filepath.Base(file))
+	}
+	sort.Strings(names)
+	if want := "intro-2-2.md,intro-2.md,intro.md"; strings.Join(names, ",") != want {
+		t.Errorf("got files %v, want %s", names, want)
+	}
+
+	// Every page is kept, none overwrote another
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("reading output directory: %v", err)
+	}
+	if len(entries) != len(titles) {
+		t.Errorf("got %d files written, want %d", len(entries), len(titles))
+	}
+}
+
+func TestUniqueFileName(t *testing.T) {
+
+	used := map[string]bool{}
+	got := []string{}
+	for _, name := range []string{"intro", "intro", "intro-2", "intro"} {
+		got = append(got, uniqueFileName(name, used))
+	}
+
+	if want := "intro,intro-2,intro-2-2,intro-3"; strings.Join(got, ",") != want {
+		t.Errorf("got names %v, want %s", got, want)
+	}
+}
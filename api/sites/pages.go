@@ -0,0 +1,324 @@
+package sites
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/markdown"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	"github.com/microsoftgraph/msgraph-sdk-go/sites"
+)
+
+// publishedLevel is the publishing level of a published page.
+const publishedLevel = "published"
+
+func init() {
+	// Create Site Page Tool is a tool that creates a page in a site.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "create_site_page",
+			Tool: mcp.NewTool("create_site_page",
+				mcp.WithDescription("Create a page in a SharePoint site with a single text section holding the given content. The page is created as a draft, named after its title. Returns the id and url of the new page. Requires Sites.ReadWrite.All."),
+				mcp.WithString("site_id",
+					mcp.Required(),
+					mcp.Description("The id of the site."),
+				),
+				mcp.WithString("title",
+					mcp.Required(),
+					mcp.Description("The title of the page."),
+				),
+				mcp.WithString("content",
+					mcp.Required(),
+					mcp.Description("The body of the page, in the format given by content_format."),
+				),
+				mcp.WithString("content_format",
+					mcp.Description("The format of the content."),
+					mcp.Enum("markdown", "html"),
+					mcp.DefaultString("markdown"),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"Sites.ReadWrite.All"},
+			OutputSchema:   createPageSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := createPageArgs{ContentFormat: "markdown"}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := CreatePage(ctx, client, a.SiteId, a.Title, a.html())
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the site '%s' does not exist", a.SiteId)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to create site page: %s", odata.ErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+
+	// Publish Site Page Tool is a tool that publishes a page of a site.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "publish_site_page",
+			Tool: mcp.NewTool("publish_site_page",
+				mcp.WithDescription("Publish the current draft of a page of a SharePoint site, to make it visible to the readers of the site. A page that is already published is reported as such. Returns the publishing state of the page. Requires Sites.ReadWrite.All."),
+				mcp.WithString("site_id",
+					mcp.Required(),
+					mcp.Description("The id of the site."),
+				),
+				mcp.WithString("page_id",
+					mcp.Required(),
+					mcp.Description("The id of the page."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"Sites.ReadWrite.All"},
+			OutputSchema:   publishPageSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a publishPageArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := PublishPage(ctx, client, a.SiteId, a.PageId)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the page '%s' does not exist in the site '%s'", a.PageId, a.SiteId)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to publish site page: %s", odata.ErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// createPageSchema describes the result of the create_site_page tool.
+var createPageSchema = schema.Object(map[string]schema.Schema{
+	"id":              schema.String(),
+	"name":            schema.String(),
+	"title":           schema.String(),
+	"webUrl":          schema.String(),
+	"publishingState": schema.String(),
+	"success":         schema.Boolean(),
+})
+
+// publishPageSchema describes the result of the publish_site_page tool.
+var publishPageSchema = schema.Object(map[string]schema.Schema{
+	"id":               schema.String(),
+	"title":            schema.String(),
+	"webUrl":           schema.String(),
+	"publishingState":  schema.String(),
+	"alreadyPublished": schema.Boolean(),
+	"success":          schema.Boolean(),
+})
+
+// createPageArgs are the arguments of the create_site_page tool.
+type createPageArgs struct {
+	SiteId        string `json:"site_id"`
+	Title         string `json:"title"`
+	Content       string `json:"content"`
+	ContentFormat string `json:"content_format"`
+}
+
+// Validate checks that the site and the title are given, and the format of the content.
+func (a *createPageArgs) Validate() error {
+
+	if a.SiteId == "" {
+		return fmt.Errorf("site_id is required")
+	}
+	if a.Title = strings.TrimSpace(a.Title); a.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if a.ContentFormat != "markdown" && a.ContentFormat != "html" {
+		return fmt.Errorf("invalid content_format '%s', expected markdown or html", a.ContentFormat)
+	}
+
+	return nil
+}
+
+// html returns the content of the page as HTML.
+func (a *createPageArgs) html() string {
+
+	if a.ContentFormat == "markdown" {
+		return markdown.ToHTML(a.Content)
+	}
+
+	return a.Content
+}
+
+// publishPageArgs are the arguments of the publish_site_page tool.
+type publishPageArgs struct {
+	SiteId string `json:"site_id"`
+	PageId string `json:"page_id"`
+}
+
+// Validate checks that the page is given.
+func (a *publishPageArgs) Validate() error {
+
+	if a.SiteId == "" {
+		return fmt.Errorf("site_id is required")
+	}
+	if a.PageId == "" {
+		return fmt.Errorf("page_id is required")
+	}
+
+	return nil
+}
+
+// CreatePage creates an article page in the site, with a single one column section holding the
+// HTML content in a text web part.
+func CreatePage(ctx context.Context, client *msgraphsdk.GraphServiceClient, siteId string, title string, content string) ([]byte, error) {
+
+	webPart := models.NewTextWebPart()
+	webPart.SetInnerHtml(&content)
+
+	column := models.NewHorizontalSectionColumn()
+	column.SetWebparts([]models.WebPartable{webPart})
+
+	sectionLayout := models.ONECOLUMN_HORIZONTALSECTIONLAYOUTTYPE
+	section := models.NewHorizontalSection()
+	section.SetLayout(&sectionLayout)
+	section.SetColumns([]models.HorizontalSectionColumnable{column})
+
+	canvasLayout := models.NewCanvasLayout()
+	canvasLayout.SetHorizontalSections([]models.HorizontalSectionable{section})
+
+	name := pageFileName(title, "page") + ".aspx"
+	pageLayout := models.ARTICLE_PAGELAYOUTTYPE
+	page := models.NewSitePage()
+	page.SetName(&name)
+	page.SetTitle(&title)
+	page.SetPageLayout(&pageLayout)
+	page.SetCanvasLayout(canvasLayout)
+
+	created, err := client.Sites().BySiteId(siteId).Pages().Post(ctx, page, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating site page: %w", err)
+	}
+
+	pageData := map[string]interface{}{
+		"success": true,
+	}
+	if id := created.GetId(); id != nil {
+		pageData["id"] = *id
+	}
+	if name := created.GetName(); name != nil {
+		pageData["name"] = *name
+	}
+	if title := created.GetTitle(); title != nil {
+		pageData["title"] = *title
+	}
+	if webUrl := created.GetWebUrl(); webUrl != nil {
+		pageData["webUrl"] = *webUrl
+	}
+	if level := publishingLevel(created); level != "" {
+		pageData["publishingState"] = level
+	}
+
+	return json.MarshalIndent(pageData, "", "  ")
+}
+
+// PublishPage publishes the page, unless it is already published, and returns its publishing state.
+func PublishPage(ctx context.Context, client *msgraphsdk.GraphServiceClient, siteId string, pageId string) ([]byte, error) {
+
+	pageBuilder := client.Sites().BySiteId(siteId).Pages().ByBaseSitePageId(pageId).GraphSitePage()
+	getPage := func() (models.SitePageable, error) {
+		return pageBuilder.Get(ctx, &sites.ItemPagesItemGraphSitePageRequestBuilderGetRequestConfiguration{
+			QueryParameters: &sites.ItemPagesItemGraphSitePageRequestBuilderGetQueryParameters{
+				Select: []string{"id", "title", "webUrl", "publishingState"},
+			},
+		})
+	}
+
+	page, err := getPage()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching site page: %w", err)
+	}
+
+	alreadyPublished := publishingLevel(page) == publishedLevel
+	if !alreadyPublished {
+		// The SDK has no request builder for the publish action, its request is derived from the page one
+		requestInfo, err := pageBuilder.ToGetRequestInformation(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error building publish request: %v", err)
+		}
+		uri, err := requestInfo.GetUri()
+		if err != nil {
+			return nil, fmt.Errorf("error building publish request: %v", err)
+		}
+		uri = uri.JoinPath("publish")
+
+		publishInfo := abstractions.NewRequestInformation()
+		publishInfo.Method = abstractions.POST
+		publishInfo.SetUri(*uri)
+
+		errorMapping := abstractions.ErrorMappings{
+			"XXX": odataerrors.CreateODataErrorFromDiscriminatorValue,
+		}
+		if err := client.GetAdapter().SendNoContent(ctx, publishInfo, errorMapping); err != nil {
+			return nil, fmt.Errorf("error publishing site page: %w", err)
+		}
+
+		if page, err = getPage(); err != nil {
+			return nil, fmt.Errorf("error fetching published site page: %w", err)
+		}
+	}
+
+	pageData := map[string]interface{}{
+		"success":          true,
+		"alreadyPublished": alreadyPublished,
+	}
+	if id := page.GetId(); id != nil {
+		pageData["id"] = *id
+	}
+	if title := page.GetTitle(); title != nil {
+		pageData["title"] = *title
+	}
+	if webUrl := page.GetWebUrl(); webUrl != nil {
+		pageData["webUrl"] = *webUrl
+	}
+	if level := publishingLevel(page); level != "" {
+		pageData["publishingState"] = level
+	}
+
+	return json.MarshalIndent(pageData, "", "  ")
+}
+
+// publishingLevel returns the publishing level of the page, e.g. checkout or published, or an empty string if it is not set.
+func publishingLevel(page models.BaseSitePageable) string {
+
+	if publishingState := page.GetPublishingState(); publishingState != nil && publishingState.GetLevel() != nil {
+		return *publishingState.GetLevel()
+	}
+
+	return ""
+}
@@ -4,16 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"html"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
 	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
 	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/markdown"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
 	"github.com/mark3labs/mcp-go/mcp"
 	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
-	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/microsoftgraph/msgraph-sdk-go/sites"
 )
@@ -28,7 +35,21 @@ func init() {
 				mcp.WithString("name",
 					mcp.Description("The name of the site. If not provided, all sites will be returned."),
 				),
+				odata.WithMatchMode(),
+				paginate.WithLimit(),
+				mcp.WithString("id",
+					mcp.Description("The id of a single site to return."),
+				),
+				mcp.WithString("etag",
+					mcp.Description("The etag returned by a previous lookup of the same site. If the site has not changed, it is reported as not modified instead of being returned again."),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
 			),
+			OutputSchema: siteSchema,
 			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 
 				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
@@ -36,15 +57,43 @@ func init() {
 					return mcp.NewToolResultError("client not found"), nil
 				}
 
+				var a sitesArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				if a.Id != "" {
+					jsonData, err := GetById(ctx, client, a.Id, a.ETag)
+					if err != nil {
+						return mcp.NewToolResultError("failed to get site"), err
+					}
+					return mcp.NewToolResultText(string(jsonData)), nil
+				}
+
 				params := &sites.SitesRequestBuilderGetQueryParameters{}
-				if name, ok := request.Params.Arguments["name"]; ok {
-					params.Filter = to.Ptr("displayName eq '" + name.(string) + "'")
+				if a.Name != "" {
+					filter, _, err := odata.Match("displayName", a.Name, a.MatchMode)
+					if err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+					params.Filter = filter
+					// Sites are searched by keyword rather than on a property
+					if a.MatchMode == odata.MatchContains {
+						params.Search = to.Ptr(odata.SearchTerm(a.Name))
+					}
+				}
+				limit, capped, err := paginate.Limit(request, a.Limit, params.Filter != nil || params.Search != nil)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
 				}
 				// Get the list of sites
-				jsonData, err := Get(ctx, client, params)
+				jsonData, err := Get(ctx, client, params, limit)
 				if err != nil {
 					return mcp.NewToolResultError("failed to get sites"), err
 				}
+				if capped {
+					return paginate.CappedNote(mcp.NewToolResultText(string(jsonData)), limit), nil
+				}
 
 				return mcp.NewToolResultText(string(jsonData)), nil
 			},
@@ -52,8 +101,57 @@ func init() {
 	)
 }
 
-// Get retrieves all sites from Microsoft Graph and returns their preferred names or IDs.
-func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *sites.SitesRequestBuilderGetQueryParameters) ([]byte, error) {
+// siteProperties are the attributes of a site and of its subsites. Sites also carry the
+// additional data returned by Microsoft Graph beyond the attributes of the SDK model.
+var siteProperties = map[string]schema.Schema{
+	"id":             schema.String(),
+	"displayName":    schema.String(),
+	"isPersonalSite": schema.Boolean(),
+	"analytics": schema.Object(map[string]schema.Schema{
+		"allTime":       activityStatSchema,
+		"lastSevenDays": activityStatSchema,
+	}),
+	"error": schema.Object(map[string]schema.Schema{
+		"code":    schema.String(),
+		"message": schema.String(),
+	}),
+	"sharepointIds": schema.Object(map[string]schema.Schema{
+		"siteId":  schema.String(),
+		"siteUrl": schema.String(),
+		"webId":   schema.String(),
+	}),
+	"siteCollection": schema.Object(map[string]schema.Schema{
+		"hostname":         schema.String(),
+		"dataLocationCode": schema.String(),
+	}),
+}
+
+// siteSchema describes the result of the sites tool.
+var siteSchema = schema.Map(schema.Object(schema.Merge(siteProperties, map[string]schema.Schema{
+	"subsites": schema.Map(schema.Object(siteProperties)),
+	"pages": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":              schema.String(),
+		"title":           schema.String(),
+		"pageLayout":      schema.String(),
+		"publishingState": schema.String(),
+		"content":         schema.String(),
+	})),
+	"etag":        schema.String(),
+	"notModified": schema.Boolean(),
+})))
+
+// sitesArgs are the arguments of the sites tool.
+type sitesArgs struct {
+	Id        string `json:"id"`
+	ETag      string `json:"etag"`
+	Name      string `json:"name"`
+	MatchMode string `json:"matchMode"`
+	Limit     int    `json:"limit"`
+}
+
+// Get retrieves all sites from Microsoft Graph, or the first limit ones if it is not zero,
+// and returns their preferred names or IDs.
+func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *sites.SitesRequestBuilderGetQueryParameters, limit int) ([]byte, error) {
 
 	if params == nil {
 		params = &sites.SitesRequestBuilderGetQueryParameters{
@@ -71,40 +169,17 @@ func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *sit
 		return nil, err
 	}
 
-	// Get the sites from the result
-	sites := result.GetValue()
-	if sites == nil {
-		return nil, err
-	}
-
 	// Create a map to store the JSON-friendly data
 	sitesData := make(map[string]interface{})
 
-	// Convert each site to a map of attributes
-	for _, site := range sites {
+	// Convert each site of every page to a map of attributes
+	err = paginate.Iterate(ctx, client, result, models.CreateSiteCollectionResponseFromDiscriminatorValue, func(site models.Siteable) bool {
 		id, siteData := convertSiteToMap(site)
 		sitesData[id] = siteData
-	}
-
-	// Use PageIterator to handle pagination if there are more sites
-	if result.GetOdataNextLink() != nil && *result.GetOdataNextLink() != "" {
-		pageIterator, err := msgraphcore.NewPageIterator[models.Siteable](
-			result,
-			client.GetAdapter(),
-			models.CreateSiteCollectionResponseFromDiscriminatorValue,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("error creating page iterator: %v", err)
-		}
-
-		err = pageIterator.Iterate(context.Background(), func(site models.Siteable) bool {
-			id, siteData := convertSiteToMap(site)
-			sitesData[id] = siteData
-			return true // Continue iteration
-		})
-		if err != nil {
-			return nil, fmt.Errorf("error iterating over sites: %v", err)
-		}
+		return limit == 0 || len(sitesData) < limit
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating over sites: %v", err)
 	}
 
 	for id, site := range sitesData {
@@ -147,6 +222,41 @@ func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *sit
 	return json.MarshalIndent(sitesData, "", "  ")
 }
 
+// GetById retrieves a single site. If etag is set and the site has not changed since,
+// Graph answers 304 and the site is reported as not modified.
+func GetById(ctx context.Context, client *msgraphsdk.GraphServiceClient, id string, etag string) ([]byte, error) {
+
+	conditional := odata.NewConditional(etag)
+
+	site, err := client.Sites().BySiteId(id).Get(ctx, &sites.SiteItemRequestBuilderGetRequestConfiguration{
+		Headers: conditional.Headers,
+		Options: conditional.Options,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Graph answered 304 Not Modified
+	if site == nil {
+		return json.MarshalIndent(map[string]interface{}{
+			id: map[string]interface{}{
+				"id":          id,
+				"etag":        etag,
+				"notModified": true,
+			},
+		}, "", "  ")
+	}
+
+	siteID, siteData := convertSiteToMap(site)
+	if responseETag := conditional.ETag(); responseETag != "" {
+		siteData["etag"] = responseETag
+	} else if siteETag := site.GetETag(); siteETag != nil {
+		siteData["etag"] = *siteETag
+	}
+
+	return json.MarshalIndent(map[string]interface{}{siteID: siteData}, "", "  ")
+}
+
 // You can also create a function to get a specific site's details and subsites
 func GetSubsites(ctx context.Context, client *msgraphsdk.GraphServiceClient, siteId string) ([]models.Siteable, error) {
 
@@ -156,28 +266,14 @@ func GetSubsites(ctx context.Context, client *msgraphsdk.GraphServiceClient, sit
 		return nil, fmt.Errorf("error fetching subsites: %v", err)
 	}
 
-	subsites := subsitesResponse.GetValue()
-
-	// Handle pagination for subsites if necessary
-	if subsitesResponse.GetOdataNextLink() != nil {
-		pageIterator, err := msgraphcore.NewPageIterator[models.Siteable](
-			subsitesResponse,
-			client.GetAdapter(),
-			models.CreateSiteCollectionResponseFromDiscriminatorValue,
-		)
-
-		if err != nil {
-			return subsites, fmt.Errorf("error creating page iterator for subsites: %v", err)
-		}
-
-		err = pageIterator.Iterate(ctx, func(subsite models.Siteable) bool {
-			subsites = append(subsites, subsite)
-			return true
-		})
-
-		if err != nil {
-			return subsites, fmt.Errorf("error iterating through subsites: %v", err)
-		}
+	// Handle pagination for subsites
+	var subsites []models.Siteable
+	err = paginate.Iterate(ctx, client, subsitesResponse, models.CreateSiteCollectionResponseFromDiscriminatorValue, func(subsite models.Siteable) bool {
+		subsites = append(subsites, subsite)
+		return true
+	})
+	if err != nil {
+		return subsites, fmt.Errorf("error iterating through subsites: %v", err)
 	}
 
 	return subsites, nil
@@ -192,28 +288,14 @@ func GetPages(ctx context.Context, client *msgraphsdk.GraphServiceClient, siteId
 		return nil, fmt.Errorf("error fetching pages: %v", err)
 	}
 
-	pages := pagesResponse.GetValue()
-
-	// Handle pagination for subsites if necessary
-	if pagesResponse.GetOdataNextLink() != nil {
-		pageIterator, err := msgraphcore.NewPageIterator[models.SitePageable](
-			pagesResponse,
-			client.GetAdapter(),
-			models.CreateSitePageCollectionResponseFromDiscriminatorValue,
-		)
-
-		if err != nil {
-			return pages, fmt.Errorf("error creating page iterator for pages: %v", err)
-		}
-
-		err = pageIterator.Iterate(ctx, func(page models.SitePageable) bool {
-			pages = append(pages, page)
-			return true
-		})
-
-		if err != nil {
-			return pages, fmt.Errorf("error iterating through pages: %v", err)
-		}
+	// Handle pagination for pages
+	var pages []models.SitePageable
+	err = paginate.Iterate(ctx, client, pagesResponse, models.CreateSitePageCollectionResponseFromDiscriminatorValue, func(page models.SitePageable) bool {
+		pages = append(pages, page)
+		return true
+	})
+	if err != nil {
+		return pages, fmt.Errorf("error iterating through pages: %v", err)
 	}
 
 	return pages, nil
@@ -239,19 +321,45 @@ func convertSiteToMap(site models.Siteable) (string, map[string]interface{}) {
 	}
 
 	if analytics := site.GetAnalytics(); analytics != nil {
-		siteMap["analytics"] = analytics // optionally convert to map if needed
+		if analyticsData := convertAnalyticsToMap(analytics); analyticsData != nil {
+			siteMap["analytics"] = analyticsData
+		}
 	}
 
 	if errorInfo := site.GetError(); errorInfo != nil {
-		siteMap["error"] = errorInfo // optionally convert to map if needed
+		errorData := make(map[string]interface{})
+		if code := errorInfo.GetCode(); code != nil {
+			errorData["code"] = *code
+		}
+		if message := errorInfo.GetMessage(); message != nil {
+			errorData["message"] = *message
+		}
+		siteMap["error"] = errorData
 	}
 
 	if sharepointIds := site.GetSharepointIds(); sharepointIds != nil {
-		siteMap["sharepointIds"] = sharepointIds // optionally convert to map if needed
+		idsData := make(map[string]interface{})
+		if siteId := sharepointIds.GetSiteId(); siteId != nil {
+			idsData["siteId"] = *siteId
+		}
+		if siteUrl := sharepointIds.GetSiteUrl(); siteUrl != nil {
+			idsData["siteUrl"] = *siteUrl
+		}
+		if webId := sharepointIds.GetWebId(); webId != nil {
+			idsData["webId"] = *webId
+		}
+		siteMap["sharepointIds"] = idsData
 	}
 
 	if siteCollection := site.GetSiteCollection(); siteCollection != nil {
-		siteMap["siteCollection"] = siteCollection // optionally convert to map if needed
+		collectionData := make(map[string]interface{})
+		if hostname := siteCollection.GetHostname(); hostname != nil {
+			collectionData["hostname"] = *hostname
+		}
+		if dataLocationCode := siteCollection.GetDataLocationCode(); dataLocationCode != nil {
+			collectionData["dataLocationCode"] = *dataLocationCode
+		}
+		siteMap["siteCollection"] = collectionData
 	}
 
 	// Add AdditionalData last to allow overrides
@@ -274,11 +382,11 @@ func convertSitePageToMap(page models.SitePageable) (string, map[string]interfac
 
 	// From BaseSitePageable
 	if layout := page.GetPageLayout(); layout != nil {
-		siteMap["pageLayout"] = *layout
+		siteMap["pageLayout"] = layout.String()
 	}
 
-	if publishingState := page.GetPublishingState(); publishingState != nil {
-		siteMap["publishingState"] = publishingState
+	if level := publishingLevel(page); level != "" {
+		siteMap["publishingState"] = level
 	}
 
 	if title := page.GetTitle(); title != nil {
@@ -450,7 +558,7 @@ func getPageContent(client *msgraphsdk.GraphServiceClient, siteId string, pageId
 									if err == nil && innerHtml != nil {
 										if htmlStr, ok := innerHtml.(*string); ok {
 											if format == "markdown" {
-												markdownContent := htmlToMarkdown(*htmlStr)
+												markdownContent := markdown.FromHTML(*htmlStr)
 												contentBuilder.WriteString(markdownContent)
 												contentBuilder.WriteString("\n\n")
 												contentFound = true
@@ -471,7 +579,7 @@ func getPageContent(client *msgraphsdk.GraphServiceClient, siteId string, pageId
 										if innerHtml, ok := data["innerHtml"]; ok {
 											if htmlStr, ok := innerHtml.(string); ok {
 												if format == "markdown" {
-													markdownContent := htmlToMarkdown(htmlStr)
+													markdownContent := markdown.FromHTML(htmlStr)
 													contentBuilder.WriteString(markdownContent)
 													contentBuilder.WriteString("\n\n")
 													contentFound = true
@@ -510,7 +618,7 @@ func getPageContent(client *msgraphsdk.GraphServiceClient, siteId string, pageId
 														if strVal, ok := fieldVal.(string); ok && strVal != "" {
 															if format == "markdown" {
 																if field == "html" {
-																	contentBuilder.WriteString(htmlToMarkdown(strVal))
+																	contentBuilder.WriteString(markdown.FromHTML(strVal))
 																} else {
 																	contentBuilder.WriteString(strVal)
 																}
@@ -605,7 +713,7 @@ func getPageContent(client *msgraphsdk.GraphServiceClient, siteId string, pageId
 						if innerHtml, ok := data["innerHtml"]; ok {
 							if htmlStr, ok := innerHtml.(string); ok {
 								if format == "markdown" {
-									markdownContent := htmlToMarkdown(htmlStr)
+									markdownContent := markdown.FromHTML(htmlStr)
 									contentBuilder.WriteString(markdownContent)
 									contentBuilder.WriteString("\n\n")
 									contentFound = true
@@ -643,7 +751,7 @@ func getPageContent(client *msgraphsdk.GraphServiceClient, siteId string, pageId
 											if strVal, ok := fieldVal.(string); ok && strVal != "" {
 												if format == "markdown" {
 													if field == "html" {
-														contentBuilder.WriteString(htmlToMarkdown(strVal))
+														contentBuilder.WriteString(markdown.FromHTML(strVal))
 													} else {
 														contentBuilder.WriteString(strVal)
 													}
@@ -707,170 +815,89 @@ func getPageContent(client *msgraphsdk.GraphServiceClient, siteId string, pageId
 	return content, nil
 }
 
-// Convert HTML content to Markdown
-func htmlToMarkdown(htmlContent string) string {
-	// Unescape HTML entities
-	unescaped := html.UnescapeString(htmlContent)
-
-	// Create a string builder for the result
-	var result strings.Builder
-
-	// Basic HTML to Markdown conversions - these are simplified and won't handle all HTML
-
-	// Replace headings
-	h1Regex := regexp.MustCompile(`<h1[^>]*>(.*?)</h1>`)
-	unescaped = h1Regex.ReplaceAllString(unescaped, "# $1\n\n")
-
-	h2Regex := regexp.MustCompile(`<h2[^>]*>(.*?)</h2>`)
-	unescaped = h2Regex.ReplaceAllString(unescaped, "## $1\n\n")
-
-	h3Regex := regexp.MustCompile(`<h3[^>]*>(.*?)</h3>`)
-	unescaped = h3Regex.ReplaceAllString(unescaped, "### $1\n\n")
-
-	h4Regex := regexp.MustCompile(`<h4[^>]*>(.*?)</h4>`)
-	unescaped = h4Regex.ReplaceAllString(unescaped, "#### $1\n\n")
-
-	// Replace paragraph tags
-	pRegex := regexp.MustCompile(`<p[^>]*>(.*?)</p>`)
-	unescaped = pRegex.ReplaceAllString(unescaped, "$1\n\n")
-
-	// Replace bold tags
-	boldRegex := regexp.MustCompile(`<(b|strong)[^>]*>(.*?)</\\1>`)
-	unescaped = boldRegex.ReplaceAllString(unescaped, "**$2**")
-
-	// Replace italic tags
-	italicRegex := regexp.MustCompile(`<(i|em)[^>]*>(.*?)</\\1>`)
-	unescaped = italicRegex.ReplaceAllString(unescaped, "*$2*")
-
-	// Replace links
-	linkRegex := regexp.MustCompile(`<a[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
-	unescaped = linkRegex.ReplaceAllString(unescaped, "[$2]($1)")
-
-	// Replace unordered lists
-	unescaped = strings.Replace(unescaped, "<ul>", "\n", -1)
-	unescaped = strings.Replace(unescaped, "</ul>", "\n", -1)
-	liRegex := regexp.MustCompile(`<li[^>]*>(.*?)</li>`)
-	unescaped = liRegex.ReplaceAllString(unescaped, "- $1\n")
-
-	// Replace ordered lists
-	unescaped = strings.Replace(unescaped, "<ol>", "\n", -1)
-	unescaped = strings.Replace(unescaped, "</ol>", "\n", -1)
-	olLiRegex := regexp.MustCompile(`<li[^>]*>(.*?)</li>`)
-	unescaped = olLiRegex.ReplaceAllString(unescaped, "1. $1\n")
-
-	// Replace images
-	imgRegex := regexp.MustCompile(`<img[^>]*src="([^"]*)"[^>]*alt="([^"]*)"[^>]*>`)
-	unescaped = imgRegex.ReplaceAllString(unescaped, "![$2]($1)")
-
-	// Handle tables
-	tableRegex := regexp.MustCompile(`<table[^>]*>(.*?)</table>`)
-	tableMatches := tableRegex.FindAllStringSubmatch(unescaped, -1)
-	for _, match := range tableMatches {
-		fullTableHTML := match[0]
-		tableContent := match[1]
-
-		var mdTable strings.Builder
-
-		// Extract rows
-		trRegex := regexp.MustCompile(`<tr[^>]*>(.*?)</tr>`)
-		rows := trRegex.FindAllStringSubmatch(tableContent, -1)
-
-		// Process header row
-		if len(rows) > 0 {
-			thRegex := regexp.MustCompile(`<th[^>]*>(.*?)</th>`)
-			headerCells := thRegex.FindAllStringSubmatch(rows[0][1], -1)
-
-			if len(headerCells) > 0 {
-				// This is a header row
-				for _, cell := range headerCells {
-					mdTable.WriteString("| ")
-					mdTable.WriteString(strings.TrimSpace(cell[1]))
-					mdTable.WriteString(" ")
-				}
-				mdTable.WriteString("|\n")
+// Helper function to convert int32 to pointer
+func Int32Ptr(i int32) *int32 {
+	return &i
+}
 
-				// Add separator row
-				for i := 0; i < len(headerCells); i++ {
-					mdTable.WriteString("| --- ")
-				}
-				mdTable.WriteString("|\n")
-			} else {
-				// No header cells, check for data cells in the first row for table structure
-				tdRegex := regexp.MustCompile(`<td[^>]*>(.*?)</td>`)
-				firstRowCells := tdRegex.FindAllStringSubmatch(rows[0][1], -1)
-
-				// Create header based on number of columns
-				for i := 0; i < len(firstRowCells); i++ {
-					mdTable.WriteString("| Column ")
-					mdTable.WriteString(fmt.Sprintf("%d", i+1))
-					mdTable.WriteString(" ")
-				}
-				mdTable.WriteString("|\n")
+// ExportPages writes the Markdown content of every page of a site to a separate file
+// in the output directory, with a front-matter header holding the page title and url.
+// It returns the paths of the written files.
+func ExportPages(ctx context.Context, client *msgraphsdk.GraphServiceClient, siteId string, outputDir string) ([]string, error) {
 
-				// Add separator row
-				for i := 0; i < len(firstRowCells); i++ {
-					mdTable.WriteString("| --- ")
-				}
-				mdTable.WriteString("|\n")
-			}
-		}
+	pages, err := GetPages(ctx, client, siteId)
+	if err != nil {
+		return nil, err
+	}
 
-		// Process data rows
-		for _, row := range rows {
-			tdRegex := regexp.MustCompile(`<td[^>]*>(.*?)</td>`)
-			cells := tdRegex.FindAllStringSubmatch(row[1], -1)
+	if err := os.MkdirAll(outputDir, 0o750); err != nil {
+		return nil, fmt.Errorf("error creating output directory: %v", err)
+	}
 
-			for _, cell := range cells {
-				mdTable.WriteString("| ")
-				mdTable.WriteString(strings.TrimSpace(cell[1]))
-				mdTable.WriteString(" ")
-			}
-			mdTable.WriteString("|\n")
-		}
+	files := []string{}
+	used := map[string]bool{}
 
-		// Replace the HTML table with the Markdown table
-		unescaped = strings.Replace(unescaped, fullTableHTML, mdTable.String(), 1)
-	}
+	for _, page := range pages {
+		pageId, pageInfo := convertSitePageToMap(page)
 
-	// Handle code blocks
-	preRegex := regexp.MustCompile(`<pre[^>]*>(.*?)</pre>`)
-	unescaped = preRegex.ReplaceAllString(unescaped, "```\n$1\n```\n\n")
+		content, err := getPageContent(client, siteId, pageId, "markdown")
+		if err != nil {
+			return files, fmt.Errorf("error fetching content of page '%s': %v", pageId, err)
+		}
 
-	codeRegex := regexp.MustCompile(`<code[^>]*>(.*?)</code>`)
-	unescaped = codeRegex.ReplaceAllString(unescaped, "`$1`")
+		title, _ := pageInfo["title"].(string)
+		webUrl := ""
+		if page.GetWebUrl() != nil {
+			webUrl = *page.GetWebUrl()
+		}
 
-	// Replace blockquotes
-	blockquoteRegex := regexp.MustCompile(`<blockquote[^>]*>(.*?)</blockquote>`)
-	unescaped = blockquoteRegex.ReplaceAllString(unescaped, "> $1\n\n")
+		name := uniqueFileName(pageFileName(title, pageId), used)
 
-	// Replace horizontal rules
-	hrRegex := regexp.MustCompile(`<hr[^>]*>`)
-	unescaped = hrRegex.ReplaceAllString(unescaped, "---\n\n")
+		var fileBuilder strings.Builder
+		fileBuilder.WriteString("---\n")
+		fileBuilder.WriteString(fmt.Sprintf("title: %q\n", title))
+		fileBuilder.WriteString(fmt.Sprintf("webUrl: %q\n", webUrl))
+		fileBuilder.WriteString("---\n\n")
+		fileBuilder.WriteString(content)
+		fileBuilder.WriteString("\n")
 
-	// Replace divs and spans with their content
-	divRegex := regexp.MustCompile(`<(div|span)[^>]*>(.*?)</\\1>`)
-	for divRegex.MatchString(unescaped) {
-		unescaped = divRegex.ReplaceAllString(unescaped, "$2")
+		path := filepath.Join(outputDir, name+".md")
+		if err := os.WriteFile(path, []byte(fileBuilder.String()), 0o600); err != nil {
+			return files, fmt.Errorf("error writing page '%s': %v", path, err)
+		}
+		files = append(files, path)
 	}
 
-	// Replace breaks with newlines
-	brRegex := regexp.MustCompile(`<br[^>]*>`)
-	unescaped = brRegex.ReplaceAllString(unescaped, "\n")
+	return files, nil
+}
 
-	// Remove other HTML tags
-	tagRegex := regexp.MustCompile(`<[^>]*>`)
-	unescaped = tagRegex.ReplaceAllString(unescaped, "")
+// uniqueFileName returns the name, suffixed with the first counter giving a name not used yet
+// when it is already, and marks it as used. The suffixed name is checked too, as another page may
+// be titled like it.
+func uniqueFileName(name string, used map[string]bool) string {
 
-	// Clean up extra whitespace
-	unescaped = strings.TrimSpace(unescaped)
-	spaceRegex := regexp.MustCompile(`\n{3,}`)
-	unescaped = spaceRegex.ReplaceAllString(unescaped, "\n\n")
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s-%d", name, i)
+	}
+	used[unique] = true
 
-	result.WriteString(unescaped)
-	return result.String()
+	return unique
 }
 
-// Helper function to convert int32 to pointer
-func Int32Ptr(i int32) *int32 {
-	return &i
+// fileNameRegex matches the characters replaced in exported page file names.
+var fileNameRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// pageFileName returns a file system safe name for a page, based on its title or its id.
+func pageFileName(title string, pageId string) string {
+
+	name := strings.Trim(fileNameRegex.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if name == "" {
+		name = strings.Trim(fileNameRegex.ReplaceAllString(strings.ToLower(pageId), "-"), "-")
+	}
+	if len(name) > 100 {
+		name = strings.Trim(name[:100], "-")
+	}
+
+	return name
 }
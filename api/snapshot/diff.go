@@ -0,0 +1,118 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// document is the part of a snapshot compared by Diff.
+type document struct {
+	SnapshotDateTime string                                       `json:"snapshotDateTime"`
+	TenantId         string                                       `json:"tenantId"`
+	Sections         map[string]map[string]map[string]interface{} `json:"sections"`
+}
+
+// Diff compares two snapshots taken by Take and returns, per section, the objects added, the
+// objects removed and the attributes modified on the objects found in both, with their old and
+// new values. Objects are matched by id, and the JSON encoding sorts the keys so the diff of two
+// snapshots is always the same.
+func Diff(oldSnapshot []byte, newSnapshot []byte) ([]byte, error) {
+
+	var from, to document
+	if err := json.Unmarshal(oldSnapshot, &from); err != nil {
+		return nil, fmt.Errorf("error decoding old snapshot: %v", err)
+	}
+	if err := json.Unmarshal(newSnapshot, &to); err != nil {
+		return nil, fmt.Errorf("error decoding new snapshot: %v", err)
+	}
+	if from.TenantId != "" && to.TenantId != "" && from.TenantId != to.TenantId {
+		return nil, fmt.Errorf("the snapshots are of different tenants: %s and %s", from.TenantId, to.TenantId)
+	}
+
+	warnings := []string{}
+	sectionsData := make(map[string]interface{})
+
+	for _, s := range sections {
+		oldObjects, inOld := from.Sections[s.name]
+		newObjects, inNew := to.Sections[s.name]
+		if !inOld || !inNew {
+			warnings = append(warnings, fmt.Sprintf("%s: not compared, the section is missing from a snapshot", s.name))
+			continue
+		}
+		sectionsData[s.name] = diffSection(oldObjects, newObjects)
+	}
+
+	diffData := map[string]interface{}{
+		"from":     from.SnapshotDateTime,
+		"to":       to.SnapshotDateTime,
+		"tenantId": to.TenantId,
+		"sections": sectionsData,
+		"warnings": warnings,
+	}
+
+	return json.MarshalIndent(diffData, "", "  ")
+}
+
+// diffSection compares the objects of a section keyed by id.
+func diffSection(oldObjects map[string]map[string]interface{}, newObjects map[string]map[string]interface{}) map[string]interface{} {
+
+	added := make(map[string]interface{})
+	removed := make(map[string]interface{})
+	modified := make(map[string]interface{})
+
+	for id, newObject := range newObjects {
+		oldObject, ok := oldObjects[id]
+		if !ok {
+			added[id] = newObject
+			continue
+		}
+		if changes := diffObject(oldObject, newObject); len(changes) > 0 {
+			modifiedData := map[string]interface{}{
+				"changes": changes,
+			}
+			if displayName, ok := newObject["displayName"]; ok {
+				modifiedData["displayName"] = displayName
+			}
+			modified[id] = modifiedData
+		}
+	}
+	for id, oldObject := range oldObjects {
+		if _, ok := newObjects[id]; !ok {
+			removed[id] = oldObject
+		}
+	}
+
+	return map[string]interface{}{
+		"added":    added,
+		"removed":  removed,
+		"modified": modified,
+	}
+}
+
+// diffObject returns the old and new values of the attributes which differ between two versions
+// of an object. An attribute missing from one version has a null value in it.
+func diffObject(oldObject map[string]interface{}, newObject map[string]interface{}) map[string]interface{} {
+
+	fields := make([]string, 0, len(oldObject)+len(newObject))
+	for field := range oldObject {
+		fields = append(fields, field)
+	}
+	for field := range newObject {
+		if _, ok := oldObject[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+
+	changes := make(map[string]interface{})
+	for _, field := range fields {
+		if !reflect.DeepEqual(oldObject[field], newObject[field]) {
+			changes[field] = map[string]interface{}{
+				"old": oldObject[field],
+				"new": newObject[field],
+			}
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,262 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/api/applications"
+	"github.com/acuvity/mcp-server-microsoft-graph/api/groups"
+	"github.com/acuvity/mcp-server-microsoft-graph/api/serviceprincipals"
+	"github.com/acuvity/mcp-server-microsoft-graph/api/users"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/sites"
+)
+
+const (
+	// defaultMaxItems is the number of objects per section of a snapshot taken by the tool.
+	defaultMaxItems = 1000
+	// maxMaxItems is the largest number of objects per section of a snapshot taken by the tool.
+	maxMaxItems = 10000
+	// defaultTimeout is the time given to the tool to take a snapshot.
+	defaultTimeout = 5 * time.Minute
+	// defaultMaxBytes is the size of the sections of a snapshot taken by the tool.
+	defaultMaxBytes = 20 << 20
+	// parallelism is the number of sections enumerated at the same time.
+	parallelism = 3
+)
+
+// Options bounds the size and the duration of a snapshot.
+type Options struct {
+	// MaxItems is the number of objects kept per section, zero for all of them.
+	MaxItems int
+	// MaxBytes is the total size of the sections, zero for no limit. The sections not fitting
+	// are left out with a warning.
+	MaxBytes int
+	// Timeout is the time given to the enumerations, zero for no limit.
+	Timeout time.Duration
+}
+
+// section enumerates one kind of directory object, keyed by id.
+type section struct {
+	name      string
+	enumerate func(ctx context.Context, client *msgraphsdk.GraphServiceClient, limit int) ([]byte, error)
+}
+
+// sections are the enumerations assembled in a snapshot, in the order they are added to it.
+var sections = []section{
+	{"users", func(ctx context.Context, client *msgraphsdk.GraphServiceClient, limit int) ([]byte, error) {
+		return users.Get(ctx, client, nil, limit)
+	}},
+	{"groups", func(ctx context.Context, client *msgraphsdk.GraphServiceClient, limit int) ([]byte, error) {
+		return groups.Get(ctx, client, nil, limit)
+	}},
+	{"applications", func(ctx context.Context, client *msgraphsdk.GraphServiceClient, limit int) ([]byte, error) {
+		return applications.Get(ctx, client, nil, limit)
+	}},
+	{"servicePrincipals", func(ctx context.Context, client *msgraphsdk.GraphServiceClient, limit int) ([]byte, error) {
+		return serviceprincipals.Get(ctx, client, nil, limit)
+	}},
+	{"sites", getSites},
+}
+
+func init() {
+	// Directory Snapshot Tool is a tool that interacts with microsoft for directory APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "directory_snapshot",
+			Tool: mcp.NewTool("directory_snapshot",
+				mcp.WithDescription("Export a point-in-time snapshot of the directory: the users, groups, applications, service principals and sites of the tenant in a single document stamped with the tenant id and the time it was taken. Sections which fail, time out or are capped are reported in warnings. Requires Directory.Read.All and Sites.Read.All."),
+				mcp.WithNumber("max_items",
+					mcp.Description(fmt.Sprintf("The maximum number of objects per section, at most %d. Defaults to %d.", maxMaxItems, defaultMaxItems)),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Directory.Read.All", "Sites.Read.All"},
+			OutputSchema:   snapshotSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := snapshotArgs{MaxItems: defaultMaxItems}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := Take(ctx, client, Options{
+					MaxItems: a.MaxItems,
+					MaxBytes: defaultMaxBytes,
+					Timeout:  defaultTimeout,
+				})
+				if err != nil {
+					return mcp.NewToolResultError("failed to take directory snapshot"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// snapshotSchema describes the result of the directory_snapshot tool.
+var snapshotSchema = schema.Object(map[string]schema.Schema{
+	"snapshotDateTime":  schema.DateTime(),
+	"tenantId":          schema.String(),
+	"tenantDisplayName": schema.String(),
+	"sections": schema.Object(map[string]schema.Schema{
+		"users":             schema.Map(schema.Object(map[string]schema.Schema{})),
+		"groups":            schema.Map(schema.Object(map[string]schema.Schema{})),
+		"applications":      schema.Map(schema.Object(map[string]schema.Schema{})),
+		"servicePrincipals": schema.Map(schema.Object(map[string]schema.Schema{})),
+		"sites":             schema.Map(schema.Object(map[string]schema.Schema{})),
+	}),
+	"warnings": schema.Array(schema.String()),
+})
+
+// snapshotArgs are the arguments of the directory_snapshot tool.
+type snapshotArgs struct {
+	MaxItems int `json:"max_items"`
+}
+
+// Validate checks the bounds of the number of items.
+func (a *snapshotArgs) Validate() error {
+
+	if a.MaxItems <= 0 || a.MaxItems > maxMaxItems {
+		return fmt.Errorf("max_items must be between 1 and %d", maxMaxItems)
+	}
+
+	return nil
+}
+
+// Take enumerates the sections of the directory concurrently, with bounded parallelism, and
+// assembles them in a snapshot. A failing section does not fail the snapshot, it is reported in
+// the warnings.
+func Take(ctx context.Context, client *msgraphsdk.GraphServiceClient, options Options) ([]byte, error) {
+
+	snapshotData := map[string]interface{}{
+		"snapshotDateTime": time.Now().UTC().Format(time.RFC3339),
+	}
+	warnings := []string{}
+
+	organizations, err := client.Organization().Get(ctx, nil)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("tenant: %v", err))
+	} else if value := organizations.GetValue(); len(value) > 0 {
+		if id := value[0].GetId(); id != nil {
+			snapshotData["tenantId"] = *id
+		}
+		if displayName := value[0].GetDisplayName(); displayName != nil {
+			snapshotData["tenantDisplayName"] = *displayName
+		}
+	}
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	results := make([][]byte, len(sections))
+	errs := make([]error, len(sections))
+	limiter := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+
+	for i, s := range sections {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limiter <- struct{}{}
+			defer func() { <-limiter }()
+			results[i], errs[i] = s.enumerate(ctx, client, options.MaxItems)
+		}()
+	}
+	wg.Wait()
+
+	sectionsData := make(map[string]json.RawMessage)
+	size := 0
+	for i, s := range sections {
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded) && errs[i] != nil:
+			warnings = append(warnings, fmt.Sprintf("%s: timed out after %s", s.name, options.Timeout))
+			continue
+		case errs[i] != nil:
+			warnings = append(warnings, fmt.Sprintf("%s: %v", s.name, errs[i]))
+			continue
+		case options.MaxBytes > 0 && size+len(results[i]) > options.MaxBytes:
+			warnings = append(warnings, fmt.Sprintf("%s: left out, the snapshot would exceed %d bytes", s.name, options.MaxBytes))
+			continue
+		}
+
+		if options.MaxItems > 0 {
+			var items map[string]json.RawMessage
+			if err := json.Unmarshal(results[i], &items); err == nil && len(items) >= options.MaxItems {
+				warnings = append(warnings, fmt.Sprintf("%s: capped to %d items", s.name, options.MaxItems))
+			}
+		}
+		size += len(results[i])
+		sectionsData[s.name] = results[i]
+	}
+
+	sort.Strings(warnings)
+	snapshotData["sections"] = sectionsData
+	snapshotData["warnings"] = warnings
+
+	return json.MarshalIndent(snapshotData, "", "  ")
+}
+
+// getSites enumerates the sites, without their subsites and pages.
+func getSites(ctx context.Context, client *msgraphsdk.GraphServiceClient, limit int) ([]byte, error) {
+
+	result, err := client.Sites().Get(ctx, &sites.SitesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &sites.SitesRequestBuilderGetQueryParameters{
+			Select: []string{"id", "displayName", "webUrl", "createdDateTime", "isPersonalSite"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching sites: %v", err)
+	}
+
+	sitesData := make(map[string]interface{})
+	err = paginate.Iterate(ctx, client, result, models.CreateSiteCollectionResponseFromDiscriminatorValue, func(site models.Siteable) bool {
+		if site.GetId() == nil {
+			return true
+		}
+		siteData := map[string]interface{}{
+			"id": *site.GetId(),
+		}
+		if displayName := site.GetDisplayName(); displayName != nil {
+			siteData["displayName"] = *displayName
+		}
+		if webUrl := site.GetWebUrl(); webUrl != nil {
+			siteData["webUrl"] = *webUrl
+		}
+		if createdDateTime := site.GetCreatedDateTime(); createdDateTime != nil {
+			siteData["createdDateTime"] = createdDateTime.Format(time.RFC3339)
+		}
+		if isPersonalSite := site.GetIsPersonalSite(); isPersonalSite != nil {
+			siteData["isPersonalSite"] = *isPersonalSite
+		}
+		sitesData[*site.GetId()] = siteData
+		return limit == 0 || len(sitesData) < limit
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through sites: %v", err)
+	}
+
+	return json.MarshalIndent(sitesData, "", "  ")
+}
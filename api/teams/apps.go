@@ -0,0 +1,163 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/appcatalogs"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// distributionMethods are the distribution methods apps can be filtered on.
+var distributionMethods = []string{"store", "organization", "sideloaded"}
+
+func init() {
+	// Teams Apps Tool is a tool that interacts with microsoft for the Teams app catalog APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "teams_apps",
+			Tool: mcp.NewTool("teams_apps",
+				mcp.WithDescription("List the apps of the Teams app catalog with their distribution method (store, organization or sideloaded) and publishing state. Requires AppCatalog.Read.All."),
+				mcp.WithString("distribution_method",
+					mcp.Enum(distributionMethods...),
+					mcp.Description("Only return apps distributed this way."),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"AppCatalog.Read.All"},
+			OutputSchema:   appSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a appsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				params := &appcatalogs.TeamsAppsRequestBuilderGetQueryParameters{
+					Expand: []string{"appDefinitions"},
+				}
+				if a.DistributionMethod != "" {
+					params.Filter = to.Ptr(odata.Eq("distributionMethod", a.DistributionMethod))
+				}
+
+				jsonData, err := GetApps(ctx, client, params)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get Teams apps"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// appSchema describes the result of the teams_apps tool.
+var appSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":                 schema.String(),
+	"displayName":        schema.String(),
+	"externalId":         schema.String(),
+	"distributionMethod": schema.String(),
+	"publishingState":    schema.String(),
+	"version":            schema.String(),
+	"description":        schema.String(),
+}))
+
+// appsArgs are the arguments of the teams_apps tool.
+type appsArgs struct {
+	DistributionMethod string `json:"distribution_method"`
+}
+
+// Validate checks that the distribution method, when given, is one Graph knows.
+func (a *appsArgs) Validate() error {
+
+	if a.DistributionMethod != "" && !slices.Contains(distributionMethods, a.DistributionMethod) {
+		return fmt.Errorf("invalid distribution_method '%s', expected one of %s", a.DistributionMethod, strings.Join(distributionMethods, ", "))
+	}
+
+	return nil
+}
+
+// GetApps retrieves the apps of the Teams app catalog.
+func GetApps(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *appcatalogs.TeamsAppsRequestBuilderGetQueryParameters) ([]byte, error) {
+
+	result, err := client.AppCatalogs().TeamsApps().Get(ctx, &appcatalogs.TeamsAppsRequestBuilderGetRequestConfiguration{
+		QueryParameters: params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching Teams apps: %v", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	appsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateTeamsAppCollectionResponseFromDiscriminatorValue, func(app models.TeamsAppable) bool {
+		id, appData := convertAppToMap(app)
+		appsData[id] = appData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through Teams apps: %v", err)
+	}
+
+	return json.MarshalIndent(appsData, "", "  ")
+}
+
+// convertAppToMap converts a Teams app model to a map with its distribution and publishing attributes
+func convertAppToMap(app models.TeamsAppable) (string, map[string]interface{}) {
+
+	appId := ""
+	appData := make(map[string]interface{})
+
+	if id := app.GetId(); id != nil {
+		appId = *id
+		appData["id"] = appId
+	}
+	if displayName := app.GetDisplayName(); displayName != nil {
+		appData["displayName"] = *displayName
+	}
+	if externalId := app.GetExternalId(); externalId != nil {
+		appData["externalId"] = *externalId
+	}
+	if distributionMethod := app.GetDistributionMethod(); distributionMethod != nil {
+		appData["distributionMethod"] = distributionMethod.String()
+	}
+
+	// The last definition is the latest version of the app
+	if definitions := app.GetAppDefinitions(); len(definitions) > 0 {
+		definition := definitions[len(definitions)-1]
+		if publishingState := definition.GetPublishingState(); publishingState != nil {
+			appData["publishingState"] = publishingState.String()
+		}
+		if version := definition.GetVersion(); version != nil {
+			appData["version"] = *version
+		}
+		if description := definition.GetDescription(); description != nil {
+			appData["description"] = *description
+		}
+	}
+
+	return appId, appData
+}
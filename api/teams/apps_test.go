@@ -0,0 +1,33 @@
+package teams
+
+import (
+	"testing"
+)
+
+func TestAppsArgsValidate(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		args    appsArgs
+		wantErr string
+	}{
+		{"no distribution method", appsArgs{}, ""},
+		{"known distribution method", appsArgs{DistributionMethod: "organization"}, ""},
+		{"unknown distribution method", appsArgs{DistributionMethod: "marketplace"}, "invalid distribution_method 'marketplace', expected one of store, organization, sideloaded"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.args.Validate()
+			if test.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("got error %v, want %s", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/communications"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/callrecords"
+)
+
+const (
+	// defaultMaxSessions is the number of sessions summarized when no max_sessions is given.
+	defaultMaxSessions = 20
+	// maxMaxSessions is the largest number of sessions summarized in one call.
+	maxMaxSessions = 100
+)
+
+// The thresholds above which a media stream is considered poor, as used by the Teams Call
+// Quality Dashboard.
+const (
+	poorPacketLossRate   = 0.1
+	poorJitterMs         = 30
+	poorRoundTripTimeMs  = 500
+	poorAudioDegradation = 1.0
+)
+
+func init() {
+	// Call Record Tool is a tool that interacts with microsoft for Teams call record APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "call_record",
+			Tool: mcp.NewTool("call_record",
+				mcp.WithDescription("Summarize the quality of a Teams call or meeting from its call record: participants, modalities, start and end times, and for each session the caller, callee, failure if any and the worst packet loss, jitter, round trip time and audio degradation of its media streams, with the streams above the Call Quality Dashboard thresholds counted as poor. The quality totals cover the summarized sessions. Requires CallRecords.Read.All."),
+				mcp.WithString("call_id",
+					mcp.Required(),
+					mcp.Description("The id of the call record."),
+				),
+				mcp.WithNumber("max_sessions",
+					mcp.Description(fmt.Sprintf("The maximum number of sessions to summarize, at most %d. Defaults to %d.", maxMaxSessions, defaultMaxSessions)),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"CallRecords.Read.All"},
+			OutputSchema:   callRecordSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := callRecordArgs{MaxSessions: defaultMaxSessions}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetCallRecord(ctx, client, a.CallId, a.MaxSessions)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the call record '%s' does not exist", a.CallId)), nil
+					}
+					return mcp.NewToolResultError("failed to get call record"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// qualityProperties are the quality indicators of a session and of the whole call.
+var qualityProperties = map[string]schema.Schema{
+	"streams":             schema.Integer(),
+	"poorStreams":         schema.Integer(),
+	"maxPacketLossRate":   schema.Number(),
+	"maxJitterMs":         schema.Integer(),
+	"maxRoundTripTimeMs":  schema.Integer(),
+	"maxAudioDegradation": schema.Number(),
+}
+
+// callRecordSchema describes the result of the call_record tool.
+var callRecordSchema = schema.Object(map[string]schema.Schema{
+	"id":                schema.String(),
+	"type":              schema.String(),
+	"modalities":        schema.Array(schema.String()),
+	"startDateTime":     schema.DateTime(),
+	"endDateTime":       schema.DateTime(),
+	"durationSeconds":   schema.Integer(),
+	"organizer":         schema.String(),
+	"participants":      schema.Array(schema.String()),
+	"joinWebUrl":        schema.String(),
+	"sessionsTruncated": schema.Boolean(),
+	"failedSessions":    schema.Integer(),
+	"quality":           schema.Object(qualityProperties),
+	"sessions": schema.Map(schema.Object(schema.Merge(qualityProperties, map[string]schema.Schema{
+		"id":            schema.String(),
+		"modalities":    schema.Array(schema.String()),
+		"startDateTime": schema.DateTime(),
+		"endDateTime":   schema.DateTime(),
+		"caller":        schema.String(),
+		"callee":        schema.String(),
+		"segments":      schema.Integer(),
+		"failureReason": schema.String(),
+		"failureStage":  schema.String(),
+	}))),
+})
+
+// callRecordArgs are the arguments of the call_record tool.
+type callRecordArgs struct {
+	CallId      string `json:"call_id"`
+	MaxSessions int    `json:"max_sessions"`
+}
+
+// Validate checks that the call is given and the bounds of the number of sessions.
+func (a *callRecordArgs) Validate() error {
+
+	if a.CallId == "" {
+		return fmt.Errorf("call_id is required")
+	}
+	if a.MaxSessions <= 0 || a.MaxSessions > maxMaxSessions {
+		return fmt.Errorf("max_sessions must be between 1 and %d", maxMaxSessions)
+	}
+
+	return nil
+}
+
+// streamQuality accumulates the worst quality indicators of media streams.
+type streamQuality struct {
+	streams             int
+	poorStreams         int
+	maxPacketLossRate   float32
+	maxJitterMs         int64
+	maxRoundTripTimeMs  int64
+	maxAudioDegradation float32
+}
+
+// GetCallRecord retrieves a call record and summarizes the quality of at most maxSessions of its sessions.
+func GetCallRecord(ctx context.Context, client *msgraphsdk.GraphServiceClient, callId string, maxSessions int) ([]byte, error) {
+
+	record, err := client.Communications().CallRecords().ByCallRecordId(callId).Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching call record: %w", err)
+	}
+
+	recordData := convertCallRecordToMap(record)
+
+	// The media streams are only returned within the segments of the sessions
+	result, err := client.Communications().CallRecords().ByCallRecordId(callId).Sessions().Get(ctx, &communications.CallRecordsItemSessionsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &communications.CallRecordsItemSessionsRequestBuilderGetQueryParameters{
+			Expand: []string{"segments"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching call sessions: %w", err)
+	}
+
+	sessionsData := make(map[string]interface{})
+	callQuality := &streamQuality{}
+	failedSessions := 0
+	truncated := false
+
+	err = paginate.Iterate(ctx, client, result, callrecords.CreateSessionCollectionResponseFromDiscriminatorValue, func(session callrecords.Sessionable) bool {
+		if len(sessionsData) == maxSessions {
+			truncated = true
+			return false
+		}
+		id, sessionData, quality := convertSessionToMap(session)
+		if _, failed := sessionData["failureReason"]; failed {
+			failedSessions++
+		}
+		callQuality.merge(quality)
+		sessionsData[id] = sessionData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through call sessions: %v", err)
+	}
+
+	recordData["sessions"] = sessionsData
+	recordData["sessionsTruncated"] = truncated
+	recordData["failedSessions"] = failedSessions
+	recordData["quality"] = callQuality.toMap()
+
+	return json.MarshalIndent(recordData, "", "  ")
+}
+
+// convertCallRecordToMap converts a call record to a map with its participants and time range
+func convertCallRecordToMap(record callrecords.CallRecordable) map[string]interface{} {
+
+	recordData := make(map[string]interface{})
+
+	if id := record.GetId(); id != nil {
+		recordData["id"] = *id
+	}
+	if callType := record.GetTypeEscaped(); callType != nil {
+		recordData["type"] = callType.String()
+	}
+	modalities := []string{}
+	for _, modality := range record.GetModalities() {
+		modalities = append(modalities, modality.String())
+	}
+	recordData["modalities"] = modalities
+	start, end := record.GetStartDateTime(), record.GetEndDateTime()
+	if start != nil {
+		recordData["startDateTime"] = start.Format(time.RFC3339)
+	}
+	if end != nil {
+		recordData["endDateTime"] = end.Format(time.RFC3339)
+	}
+	if start != nil && end != nil {
+		recordData["durationSeconds"] = int64(end.Sub(*start).Seconds())
+	}
+	if organizer := identityName(record.GetOrganizer()); organizer != "" {
+		recordData["organizer"] = organizer
+	}
+	participants := []string{}
+	for _, participant := range record.GetParticipants() {
+		if name := identityName(participant); name != "" {
+			participants = append(participants, name)
+		}
+	}
+	recordData["participants"] = participants
+	if joinWebUrl := record.GetJoinWebUrl(); joinWebUrl != nil {
+		recordData["joinWebUrl"] = *joinWebUrl
+	}
+
+	return recordData
+}
+
+// convertSessionToMap converts a session to a map summarizing its segments, along with the
+// quality of its media streams
+func convertSessionToMap(session callrecords.Sessionable) (string, map[string]interface{}, *streamQuality) {
+
+	sessionId := ""
+	sessionData := make(map[string]interface{})
+
+	if id := session.GetId(); id != nil {
+		sessionId = *id
+		sessionData["id"] = sessionId
+	}
+	modalities := []string{}
+	for _, modality := range session.GetModalities() {
+		modalities = append(modalities, modality.String())
+	}
+	sessionData["modalities"] = modalities
+	if start := session.GetStartDateTime(); start != nil {
+		sessionData["startDateTime"] = start.Format(time.RFC3339)
+	}
+	if end := session.GetEndDateTime(); end != nil {
+		sessionData["endDateTime"] = end.Format(time.RFC3339)
+	}
+	if caller := endpointName(session.GetCaller()); caller != "" {
+		sessionData["caller"] = caller
+	}
+	if callee := endpointName(session.GetCallee()); callee != "" {
+		sessionData["callee"] = callee
+	}
+	addFailure(sessionData, session.GetFailureInfo())
+
+	quality := &streamQuality{}
+	sessionData["segments"] = len(session.GetSegments())
+	for _, segment := range session.GetSegments() {
+		// A session failing in one of its segments is reported with the first failure found
+		if _, failed := sessionData["failureReason"]; !failed {
+			addFailure(sessionData, segment.GetFailureInfo())
+		}
+		for _, media := range segment.GetMedia() {
+			for _, stream := range media.GetStreams() {
+				quality.add(stream)
+			}
+		}
+	}
+	for key, value := range quality.toMap() {
+		sessionData[key] = value
+	}
+
+	return sessionId, sessionData, quality
+}
+
+// addFailure adds the reason and stage of a failure, if any.
+func addFailure(data map[string]interface{}, failure callrecords.FailureInfoable) {
+
+	if failure == nil || failure.GetReason() == nil || *failure.GetReason() == "" {
+		return
+	}
+	data["failureReason"] = *failure.GetReason()
+	if stage := failure.GetStage(); stage != nil {
+		data["failureStage"] = stage.String()
+	}
+}
+
+// add records the indicators of a media stream, and whether any of them is above its poor threshold.
+func (q *streamQuality) add(stream callrecords.MediaStreamable) {
+
+	q.streams++
+	poor := false
+
+	if packetLossRate := stream.GetMaxPacketLossRate(); packetLossRate != nil {
+		q.maxPacketLossRate = max(q.maxPacketLossRate, *packetLossRate)
+		poor = poor || *packetLossRate > poorPacketLossRate
+	}
+	if jitter := stream.GetMaxJitter(); jitter != nil {
+		q.maxJitterMs = max(q.maxJitterMs, durationMs(jitter))
+		poor = poor || durationMs(jitter) > poorJitterMs
+	}
+	if roundTripTime := stream.GetMaxRoundTripTime(); roundTripTime != nil {
+		q.maxRoundTripTimeMs = max(q.maxRoundTripTimeMs, durationMs(roundTripTime))
+		poor = poor || durationMs(roundTripTime) > poorRoundTripTimeMs
+	}
+	if audioDegradation := stream.GetAverageAudioDegradation(); audioDegradation != nil {
+		q.maxAudioDegradation = max(q.maxAudioDegradation, *audioDegradation)
+		poor = poor || *audioDegradation > poorAudioDegradation
+	}
+
+	if poor {
+		q.poorStreams++
+	}
+}
+
+// merge accumulates the indicators of another set of streams.
+func (q *streamQuality) merge(other *streamQuality) {
+
+	q.streams += other.streams
+	q.poorStreams += other.poorStreams
+	q.maxPacketLossRate = max(q.maxPacketLossRate, other.maxPacketLossRate)
+	q.maxJitterMs = max(q.maxJitterMs, other.maxJitterMs)
+	q.maxRoundTripTimeMs = max(q.maxRoundTripTimeMs, other.maxRoundTripTimeMs)
+	q.maxAudioDegradation = max(q.maxAudioDegradation, other.maxAudioDegradation)
+}
+
+// toMap converts the quality indicators to a map.
+func (q *streamQuality) toMap() map[string]interface{} {
+	return map[string]interface{}{
+		"streams":             q.streams,
+		"poorStreams":         q.poorStreams,
+		"maxPacketLossRate":   q.maxPacketLossRate,
+		"maxJitterMs":         q.maxJitterMs,
+		"maxRoundTripTimeMs":  q.maxRoundTripTimeMs,
+		"maxAudioDegradation": q.maxAudioDegradation,
+	}
+}
+
+// durationMs converts an ISO 8601 duration to milliseconds.
+func durationMs(duration *serialization.ISODuration) int64 {
+
+	days := duration.GetWeeks()*7 + duration.GetDays()
+	d := time.Duration(days*24+duration.GetHours())*time.Hour +
+		time.Duration(duration.GetMinutes())*time.Minute +
+		time.Duration(duration.GetSeconds())*time.Second +
+		time.Duration(duration.GetMilliSeconds())*time.Millisecond
+
+	return d.Milliseconds()
+}
+
+// endpointName returns the name of the user, application or device behind a session endpoint.
+func endpointName(endpoint callrecords.Endpointable) string {
+
+	participant, ok := endpoint.(callrecords.ParticipantEndpointable)
+	if !ok {
+		return ""
+	}
+	if name := identityName(participant.GetIdentity()); name != "" {
+		return name
+	}
+	if name := participant.GetName(); name != nil {
+		return *name
+	}
+
+	return ""
+}
+
+// identityName returns the display name, or the id, of the user, application or device of an identity set.
+func identityName(identitySet models.IdentitySetable) string {
+
+	if identitySet == nil {
+		return ""
+	}
+	for _, identity := range []models.Identityable{identitySet.GetUser(), identitySet.GetApplication(), identitySet.GetDevice()} {
+		if identity == nil {
+			continue
+		}
+		if displayName := identity.GetDisplayName(); displayName != nil && *displayName != "" {
+			return *displayName
+		}
+		if id := identity.GetId(); id != nil && *id != "" {
+			return *id
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,182 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Channel Members Tool is a tool that interacts with microsoft for Teams channel APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "channel_members",
+			Tool: mcp.NewTool("channel_members",
+				mcp.WithDescription("Read the members of a Teams channel with their email and role (owner, member or guest). Private and shared channels have their own membership, standard channels inherit the membership of the team, which is then returned. Requires Channel.ReadBasic.All and ChannelMember.Read.All."),
+				mcp.WithString("team_id",
+					mcp.Required(),
+					mcp.Description("The id of the team."),
+				),
+				mcp.WithString("channel_id",
+					mcp.Required(),
+					mcp.Description("The id of the channel."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Channel.ReadBasic.All", "ChannelMember.Read.All"},
+			OutputSchema:   channelMembersSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a channelMembersArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetChannelMembers(ctx, client, a.TeamId, a.ChannelId)
+				if err != nil {
+					switch odata.StatusCode(err) {
+					case http.StatusForbidden, http.StatusNotFound:
+						return mcp.NewToolResultError(fmt.Sprintf("the channel '%s' cannot be read: %s", a.ChannelId, odata.ErrorMessage(err))), nil
+					}
+					return mcp.NewToolResultError("failed to get channel members"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// channelMembersSchema describes the result of the channel_members tool.
+var channelMembersSchema = schema.Object(map[string]schema.Schema{
+	"id":                     schema.String(),
+	"displayName":            schema.String(),
+	"membershipType":         schema.String(),
+	"inheritsTeamMembership": schema.Boolean(),
+	"members": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":          schema.String(),
+		"userId":      schema.String(),
+		"displayName": schema.String(),
+		"email":       schema.String(),
+		"role":        schema.Enum("owner", "member", "guest"),
+	})),
+	"message": schema.String(),
+})
+
+// channelMembersArgs are the arguments of the channel_members tool.
+type channelMembersArgs struct {
+	TeamId    string `json:"team_id"`
+	ChannelId string `json:"channel_id"`
+}
+
+// Validate checks that the team and the channel are given.
+func (a *channelMembersArgs) Validate() error {
+
+	if a.TeamId == "" {
+		return fmt.Errorf("team_id is required")
+	}
+	if a.ChannelId == "" {
+		return fmt.Errorf("channel_id is required")
+	}
+
+	return nil
+}
+
+// GetChannelMembers retrieves the membership type of a channel and its members.
+func GetChannelMembers(ctx context.Context, client *msgraphsdk.GraphServiceClient, teamId string, channelId string) ([]byte, error) {
+
+	channel, err := client.Teams().ByTeamId(teamId).Channels().ByChannelId(channelId).Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching channel: %w", err)
+	}
+
+	channelData := map[string]interface{}{
+		"id": channelId,
+	}
+	if displayName := channel.GetDisplayName(); displayName != nil {
+		channelData["displayName"] = *displayName
+	}
+	standard := true
+	if membershipType := channel.GetMembershipType(); membershipType != nil {
+		channelData["membershipType"] = membershipType.String()
+		standard = *membershipType == models.STANDARD_CHANNELMEMBERSHIPTYPE
+	}
+	channelData["inheritsTeamMembership"] = standard
+	if standard {
+		channelData["message"] = "Standard channels inherit the membership of the team: the members returned are the members of the team."
+	}
+
+	result, err := client.Teams().ByTeamId(teamId).Channels().ByChannelId(channelId).Members().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching channel members: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	membersData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateConversationMemberCollectionResponseFromDiscriminatorValue, func(member models.ConversationMemberable) bool {
+		id, memberData := convertMemberToMap(member)
+		membersData[id] = memberData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through channel members: %v", err)
+	}
+
+	channelData["members"] = membersData
+
+	return json.MarshalIndent(channelData, "", "  ")
+}
+
+// convertMemberToMap converts a conversation member to a map with its single role: Graph lists
+// the owner and guest roles, members have none
+func convertMemberToMap(member models.ConversationMemberable) (string, map[string]interface{}) {
+
+	memberId := ""
+	memberData := make(map[string]interface{})
+
+	if id := member.GetId(); id != nil {
+		memberId = *id
+		memberData["id"] = memberId
+	}
+	if displayName := member.GetDisplayName(); displayName != nil {
+		memberData["displayName"] = *displayName
+	}
+	if user, ok := member.(models.AadUserConversationMemberable); ok {
+		if userId := user.GetUserId(); userId != nil {
+			memberData["userId"] = *userId
+		}
+		if email := user.GetEmail(); email != nil {
+			memberData["email"] = *email
+		}
+	}
+
+	switch roles := member.GetRoles(); {
+	case slices.Contains(roles, "owner"):
+		memberData["role"] = "owner"
+	case slices.Contains(roles, "guest"):
+		memberData["role"] = "guest"
+	default:
+		memberData["role"] = "member"
+	}
+
+	return memberId, memberData
+}
@@ -0,0 +1,195 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+)
+
+func init() {
+	// User Channels Tool is a tool that interacts with microsoft for Teams channel APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "user_channels",
+			Tool: mcp.NewTool("user_channels",
+				mcp.WithDescription("List the channels a user participates in across all the teams they joined, with the team and channel names: every standard channel of their teams, and the private and shared channels they are a member of. Users without Teams activity get an empty result. Requires User.Read.All, Team.ReadBasic.All, Channel.ReadBasic.All and ChannelMember.Read.All."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The id or user principal name of the user."),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"User.Read.All", "Team.ReadBasic.All", "Channel.ReadBasic.All", "ChannelMember.Read.All"},
+			OutputSchema:   userChannelsSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a userChannelsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetUserChannels(ctx, client, a.UserId)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the user '%s' does not exist", a.UserId)), nil
+					}
+					return mcp.NewToolResultError("failed to get user channels"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// userChannelsSchema describes the result of the user_channels tool, keyed by channel id.
+var userChannelsSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":             schema.String(),
+	"displayName":    schema.String(),
+	"description":    schema.String(),
+	"membershipType": schema.String(),
+	"webUrl":         schema.String(),
+	"teamId":         schema.String(),
+	"teamName":       schema.String(),
+}))
+
+// userChannelsArgs are the arguments of the user_channels tool.
+type userChannelsArgs struct {
+	UserId string `json:"user_id"`
+}
+
+// Validate checks that the user is given.
+func (a *userChannelsArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+
+	return nil
+}
+
+// GetUserChannels retrieves the channels of the teams joined by the user that the user takes
+// part in: all the standard channels, and the other ones the user is a member of.
+func GetUserChannels(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string) ([]byte, error) {
+
+	// The channel members are matched on the object id of the user
+	user, err := client.Users().ByUserId(userId).Get(ctx, &users.UserItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.UserItemRequestBuilderGetQueryParameters{
+			Select: []string{"id"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching user: %w", err)
+	}
+	if user.GetId() == nil {
+		return nil, fmt.Errorf("user '%s' has no id", userId)
+	}
+	objectId := *user.GetId()
+
+	result, err := client.Users().ByUserId(objectId).JoinedTeams().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching joined teams: %w", err)
+	}
+
+	teams := []models.Teamable{}
+	err = paginate.Iterate(ctx, client, result, models.CreateTeamCollectionResponseFromDiscriminatorValue, func(team models.Teamable) bool {
+		teams = append(teams, team)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through joined teams: %v", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	channelsData := make(map[string]interface{})
+
+	for _, team := range teams {
+		if team.GetId() == nil {
+			continue
+		}
+		teamId := *team.GetId()
+
+		channels, err := client.Teams().ByTeamId(teamId).Channels().Get(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching channels: %w", err)
+		}
+		var memberErr error
+		err = paginate.Iterate(ctx, client, channels, models.CreateChannelCollectionResponseFromDiscriminatorValue, func(channel models.Channelable) bool {
+			if channel.GetId() == nil {
+				return true
+			}
+			// Private and shared channels have their own membership
+			if membershipType := channel.GetMembershipType(); membershipType != nil && *membershipType != models.STANDARD_CHANNELMEMBERSHIPTYPE {
+				var member bool
+				member, memberErr = isChannelMember(ctx, client, teamId, *channel.GetId(), objectId)
+				if memberErr != nil {
+					return false
+				}
+				if !member {
+					return true
+				}
+			}
+			id, channelData := convertChannelToMap(channel)
+			channelData["teamId"] = teamId
+			if displayName := team.GetDisplayName(); displayName != nil {
+				channelData["teamName"] = *displayName
+			}
+			channelsData[id] = channelData
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error iterating through channels: %v", err)
+		}
+		if memberErr != nil {
+			return nil, memberErr
+		}
+	}
+
+	return json.MarshalIndent(channelsData, "", "  ")
+}
+
+// isChannelMember reports whether the user with the given object id is a member of the channel.
+func isChannelMember(ctx context.Context, client *msgraphsdk.GraphServiceClient, teamId string, channelId string, userId string) (bool, error) {
+
+	result, err := client.Teams().ByTeamId(teamId).Channels().ByChannelId(channelId).Members().Get(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("error fetching channel members: %w", err)
+	}
+
+	member := false
+	err = paginate.Iterate(ctx, client, result, models.CreateConversationMemberCollectionResponseFromDiscriminatorValue, func(conversationMember models.ConversationMemberable) bool {
+		if user, ok := conversationMember.(models.AadUserConversationMemberable); ok && user.GetUserId() != nil && *user.GetUserId() == userId {
+			member = true
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return false, fmt.Errorf("error iterating through channel members: %v", err)
+	}
+
+	return member, nil
+}
@@ -0,0 +1,331 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/teams"
+)
+
+func init() {
+	// Team Settings Tool is a tool that interacts with microsoft for Teams configuration APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "team_settings",
+			Tool: mcp.NewTool("team_settings",
+				mcp.WithDescription("Read the settings of a team (member, messaging and fun settings) and the tabs pinned in each of its channels with the app or url they point to. Requires TeamSettings.Read.All, Channel.ReadBasic.All and TeamsTab.Read.All."),
+				mcp.WithString("team_id",
+					mcp.Required(),
+					mcp.Description("The id of the team."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"TeamSettings.Read.All", "Channel.ReadBasic.All", "TeamsTab.Read.All"},
+			OutputSchema:   teamSettingsSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a teamSettingsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetSettings(ctx, client, a.TeamId)
+				if err != nil {
+					switch odata.StatusCode(err) {
+					case http.StatusForbidden, http.StatusNotFound:
+						return mcp.NewToolResultError(fmt.Sprintf("the team '%s' cannot be read: %s", a.TeamId, odata.ErrorMessage(err))), nil
+					}
+					return mcp.NewToolResultError("failed to get team settings"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// teamSettingsSchema describes the result of the team_settings tool.
+var teamSettingsSchema = schema.Object(map[string]schema.Schema{
+	"id":          schema.String(),
+	"displayName": schema.String(),
+	"isArchived":  schema.Boolean(),
+	"visibility":  schema.String(),
+	"memberSettings": schema.Object(map[string]schema.Schema{
+		"allowCreateUpdateChannels":         schema.Boolean(),
+		"allowCreatePrivateChannels":        schema.Boolean(),
+		"allowDeleteChannels":               schema.Boolean(),
+		"allowAddRemoveApps":                schema.Boolean(),
+		"allowCreateUpdateRemoveTabs":       schema.Boolean(),
+		"allowCreateUpdateRemoveConnectors": schema.Boolean(),
+	}),
+	"messagingSettings": schema.Object(map[string]schema.Schema{
+		"allowUserEditMessages":    schema.Boolean(),
+		"allowUserDeleteMessages":  schema.Boolean(),
+		"allowOwnerDeleteMessages": schema.Boolean(),
+		"allowTeamMentions":        schema.Boolean(),
+		"allowChannelMentions":     schema.Boolean(),
+	}),
+	"funSettings": schema.Object(map[string]schema.Schema{
+		"allowGiphy":            schema.Boolean(),
+		"giphyContentRating":    schema.String(),
+		"allowStickersAndMemes": schema.Boolean(),
+		"allowCustomMemes":      schema.Boolean(),
+	}),
+	"channels": schema.Map(schema.Object(map[string]schema.Schema{
+		"id":             schema.String(),
+		"displayName":    schema.String(),
+		"membershipType": schema.String(),
+		"error":          schema.String(),
+		"tabs": schema.Map(schema.Object(map[string]schema.Schema{
+			"id":          schema.String(),
+			"displayName": schema.String(),
+			"webUrl":      schema.String(),
+			"contentUrl":  schema.String(),
+			"websiteUrl":  schema.String(),
+			"teamsApp": schema.Object(map[string]schema.Schema{
+				"id":                 schema.String(),
+				"displayName":        schema.String(),
+				"distributionMethod": schema.String(),
+			}),
+		})),
+	})),
+})
+
+// teamSettingsArgs are the arguments of the team_settings tool.
+type teamSettingsArgs struct {
+	TeamId string `json:"team_id"`
+}
+
+// Validate checks that the team is given.
+func (a *teamSettingsArgs) Validate() error {
+
+	if a.TeamId == "" {
+		return fmt.Errorf("team_id is required")
+	}
+
+	return nil
+}
+
+// GetSettings retrieves the settings of a team and the tabs of each of its channels.
+// A channel whose tabs cannot be read is reported with the error instead of failing the whole call.
+func GetSettings(ctx context.Context, client *msgraphsdk.GraphServiceClient, teamId string) ([]byte, error) {
+
+	team, err := client.Teams().ByTeamId(teamId).Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching team: %w", err)
+	}
+
+	teamData := map[string]interface{}{
+		"id": teamId,
+	}
+	if displayName := team.GetDisplayName(); displayName != nil {
+		teamData["displayName"] = *displayName
+	}
+	if isArchived := team.GetIsArchived(); isArchived != nil {
+		teamData["isArchived"] = *isArchived
+	}
+	if visibility := team.GetVisibility(); visibility != nil {
+		teamData["visibility"] = visibility.String()
+	}
+	if memberSettings := team.GetMemberSettings(); memberSettings != nil {
+		teamData["memberSettings"] = convertMemberSettingsToMap(memberSettings)
+	}
+	if messagingSettings := team.GetMessagingSettings(); messagingSettings != nil {
+		teamData["messagingSettings"] = convertMessagingSettingsToMap(messagingSettings)
+	}
+	if funSettings := team.GetFunSettings(); funSettings != nil {
+		teamData["funSettings"] = convertFunSettingsToMap(funSettings)
+	}
+
+	channels, err := client.Teams().ByTeamId(teamId).Channels().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching channels: %w", err)
+	}
+
+	channelsData := make(map[string]interface{})
+	err = paginate.Iterate(ctx, client, channels, models.CreateChannelCollectionResponseFromDiscriminatorValue, func(channel models.Channelable) bool {
+		if channel.GetId() == nil {
+			return true
+		}
+		channelData := map[string]interface{}{
+			"id": *channel.GetId(),
+		}
+		if displayName := channel.GetDisplayName(); displayName != nil {
+			channelData["displayName"] = *displayName
+		}
+		if membershipType := channel.GetMembershipType(); membershipType != nil {
+			channelData["membershipType"] = membershipType.String()
+		}
+
+		tabs, err := getTabs(ctx, client, teamId, *channel.GetId())
+		if err != nil {
+			channelData["error"] = odata.ErrorMessage(err)
+		} else {
+			channelData["tabs"] = tabs
+		}
+
+		channelsData[*channel.GetId()] = channelData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through channels: %w", err)
+	}
+	teamData["channels"] = channelsData
+
+	return json.MarshalIndent(teamData, "", "  ")
+}
+
+// getTabs returns the tabs of a channel keyed by id.
+func getTabs(ctx context.Context, client *msgraphsdk.GraphServiceClient, teamId string, channelId string) (map[string]interface{}, error) {
+
+	result, err := client.Teams().ByTeamId(teamId).Channels().ByChannelId(channelId).Tabs().Get(ctx, &teams.ItemChannelsItemTabsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &teams.ItemChannelsItemTabsRequestBuilderGetQueryParameters{
+			Expand: []string{"teamsApp"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tabsData := make(map[string]interface{})
+	err = paginate.Iterate(ctx, client, result, models.CreateTeamsTabCollectionResponseFromDiscriminatorValue, func(tab models.TeamsTabable) bool {
+		id, tabData := convertTabToMap(tab)
+		tabsData[id] = tabData
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tabsData, nil
+}
+
+// convertTabToMap converts a tab model to a map with the app or url it points to
+func convertTabToMap(tab models.TeamsTabable) (string, map[string]interface{}) {
+
+	tabId := ""
+	tabData := make(map[string]interface{})
+
+	if id := tab.GetId(); id != nil {
+		tabId = *id
+		tabData["id"] = tabId
+	}
+	if displayName := tab.GetDisplayName(); displayName != nil {
+		tabData["displayName"] = *displayName
+	}
+	if webUrl := tab.GetWebUrl(); webUrl != nil {
+		tabData["webUrl"] = *webUrl
+	}
+	if app := tab.GetTeamsApp(); app != nil {
+		appData := make(map[string]interface{})
+		if id := app.GetId(); id != nil {
+			appData["id"] = *id
+		}
+		if displayName := app.GetDisplayName(); displayName != nil {
+			appData["displayName"] = *displayName
+		}
+		if distributionMethod := app.GetDistributionMethod(); distributionMethod != nil {
+			appData["distributionMethod"] = distributionMethod.String()
+		}
+		tabData["teamsApp"] = appData
+	}
+	if configuration := tab.GetConfiguration(); configuration != nil {
+		if contentUrl := configuration.GetContentUrl(); contentUrl != nil {
+			tabData["contentUrl"] = *contentUrl
+		}
+		if websiteUrl := configuration.GetWebsiteUrl(); websiteUrl != nil {
+			tabData["websiteUrl"] = *websiteUrl
+		}
+	}
+
+	return tabId, tabData
+}
+
+// convertMemberSettingsToMap converts the member settings of a team to a map
+func convertMemberSettingsToMap(settings models.TeamMemberSettingsable) map[string]interface{} {
+
+	settingsData := make(map[string]interface{})
+
+	if value := settings.GetAllowCreateUpdateChannels(); value != nil {
+		settingsData["allowCreateUpdateChannels"] = *value
+	}
+	if value := settings.GetAllowCreatePrivateChannels(); value != nil {
+		settingsData["allowCreatePrivateChannels"] = *value
+	}
+	if value := settings.GetAllowDeleteChannels(); value != nil {
+		settingsData["allowDeleteChannels"] = *value
+	}
+	if value := settings.GetAllowAddRemoveApps(); value != nil {
+		settingsData["allowAddRemoveApps"] = *value
+	}
+	if value := settings.GetAllowCreateUpdateRemoveTabs(); value != nil {
+		settingsData["allowCreateUpdateRemoveTabs"] = *value
+	}
+	if value := settings.GetAllowCreateUpdateRemoveConnectors(); value != nil {
+		settingsData["allowCreateUpdateRemoveConnectors"] = *value
+	}
+
+	return settingsData
+}
+
+// convertMessagingSettingsToMap converts the messaging settings of a team to a map
+func convertMessagingSettingsToMap(settings models.TeamMessagingSettingsable) map[string]interface{} {
+
+	settingsData := make(map[string]interface{})
+
+	if value := settings.GetAllowUserEditMessages(); value != nil {
+		settingsData["allowUserEditMessages"] = *value
+	}
+	if value := settings.GetAllowUserDeleteMessages(); value != nil {
+		settingsData["allowUserDeleteMessages"] = *value
+	}
+	if value := settings.GetAllowOwnerDeleteMessages(); value != nil {
+		settingsData["allowOwnerDeleteMessages"] = *value
+	}
+	if value := settings.GetAllowTeamMentions(); value != nil {
+		settingsData["allowTeamMentions"] = *value
+	}
+	if value := settings.GetAllowChannelMentions(); value != nil {
+		settingsData["allowChannelMentions"] = *value
+	}
+
+	return settingsData
+}
+
+// convertFunSettingsToMap converts the fun settings of a team to a map
+func convertFunSettingsToMap(settings models.TeamFunSettingsable) map[string]interface{} {
+
+	settingsData := make(map[string]interface{})
+
+	if value := settings.GetAllowGiphy(); value != nil {
+		settingsData["allowGiphy"] = *value
+	}
+	if value := settings.GetGiphyContentRating(); value != nil {
+		settingsData["giphyContentRating"] = value.String()
+	}
+	if value := settings.GetAllowStickersAndMemes(); value != nil {
+		settingsData["allowStickersAndMemes"] = *value
+	}
+	if value := settings.GetAllowCustomMemes(); value != nil {
+		settingsData["allowCustomMemes"] = *value
+	}
+
+	return settingsData
+}
@@ -0,0 +1,214 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// teamFilter selects the groups backing a team.
+const teamFilter = "resourceProvisioningOptions/Any(x:x eq 'Team')"
+
+func init() {
+	// Teams Tool is a tool that interacts with microsoft for Teams APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "teams",
+			Tool: mcp.NewTool("teams",
+				mcp.WithDescription("Interact with Microsoft Graph API for Teams operations: list the teams of the organization, or the channels of a team with their membership type. Requires Team.ReadBasic.All and Channel.ReadBasic.All."),
+				mcp.WithString("team_id",
+					mcp.Description("The id of the team whose channels are returned. If not provided, the teams are returned."),
+				),
+				paginate.WithLimit(),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"Team.ReadBasic.All", "Channel.ReadBasic.All"},
+			OutputSchema:   teamsSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a teamsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				if a.TeamId != "" {
+					jsonData, err := GetChannels(ctx, client, a.TeamId)
+					if err != nil {
+						if odata.StatusCode(err) == http.StatusNotFound {
+							return mcp.NewToolResultError(fmt.Sprintf("the team '%s' does not exist", a.TeamId)), nil
+						}
+						return mcp.NewToolResultError("failed to get channels"), err
+					}
+					return mcp.NewToolResultText(string(jsonData)), nil
+				}
+
+				limit, capped, err := paginate.Limit(request, a.Limit, false)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				// Get the list of teams
+				jsonData, err := Get(ctx, client, limit)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get teams"), err
+				}
+				if capped {
+					return paginate.CappedNote(mcp.NewToolResultText(string(jsonData)), limit), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// teamsSchema describes the result of the teams tool: the teams, or the channels of a team.
+var teamsSchema = schema.OneOf(
+	schema.Map(schema.Object(map[string]schema.Schema{
+		"id":          schema.String(),
+		"displayName": schema.String(),
+		"description": schema.String(),
+		"mail":        schema.String(),
+		"visibility":  schema.String(),
+	})),
+	schema.Map(schema.Object(map[string]schema.Schema{
+		"id":             schema.String(),
+		"displayName":    schema.String(),
+		"description":    schema.String(),
+		"membershipType": schema.String(),
+		"webUrl":         schema.String(),
+	})),
+)
+
+// teamsArgs are the arguments of the teams tool.
+type teamsArgs struct {
+	TeamId string `json:"team_id"`
+	Limit  int    `json:"limit"`
+}
+
+// Get retrieves the teams of the organization, as the groups backing them, or the first limit
+// ones if it is not zero.
+func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, limit int) ([]byte, error) {
+
+	result, err := client.Groups().Get(ctx, &groups.GroupsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.GroupsRequestBuilderGetQueryParameters{
+			Filter: to.Ptr(teamFilter),
+			Select: []string{"id", "displayName", "description", "mail", "visibility"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching teams: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	teamsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateGroupCollectionResponseFromDiscriminatorValue, func(group models.Groupable) bool {
+		id, teamData := convertTeamToMap(group)
+		teamsData[id] = teamData
+		return limit == 0 || len(teamsData) < limit
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through teams: %v", err)
+	}
+
+	return json.MarshalIndent(teamsData, "", "  ")
+}
+
+// GetChannels retrieves the channels of a team.
+func GetChannels(ctx context.Context, client *msgraphsdk.GraphServiceClient, teamId string) ([]byte, error) {
+
+	result, err := client.Teams().ByTeamId(teamId).Channels().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching channels: %w", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	channelsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateChannelCollectionResponseFromDiscriminatorValue, func(channel models.Channelable) bool {
+		id, channelData := convertChannelToMap(channel)
+		channelsData[id] = channelData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through channels: %v", err)
+	}
+
+	return json.MarshalIndent(channelsData, "", "  ")
+}
+
+// convertTeamToMap converts the group backing a team to a map of its attributes
+func convertTeamToMap(group models.Groupable) (string, map[string]interface{}) {
+
+	teamId := ""
+	teamData := make(map[string]interface{})
+
+	if id := group.GetId(); id != nil {
+		teamId = *id
+		teamData["id"] = teamId
+	}
+	if displayName := group.GetDisplayName(); displayName != nil {
+		teamData["displayName"] = *displayName
+	}
+	if description := group.GetDescription(); description != nil {
+		teamData["description"] = *description
+	}
+	if mail := group.GetMail(); mail != nil {
+		teamData["mail"] = *mail
+	}
+	if visibility := group.GetVisibility(); visibility != nil {
+		teamData["visibility"] = *visibility
+	}
+
+	return teamId, teamData
+}
+
+// convertChannelToMap converts a channel model to a map of its attributes
+func convertChannelToMap(channel models.Channelable) (string, map[string]interface{}) {
+
+	channelId := ""
+	channelData := make(map[string]interface{})
+
+	if id := channel.GetId(); id != nil {
+		channelId = *id
+		channelData["id"] = channelId
+	}
+	if displayName := channel.GetDisplayName(); displayName != nil {
+		channelData["displayName"] = *displayName
+	}
+	if description := channel.GetDescription(); description != nil {
+		channelData["description"] = *description
+	}
+	if membershipType := channel.GetMembershipType(); membershipType != nil {
+		channelData["membershipType"] = membershipType.String()
+	}
+	if webUrl := channel.GetWebUrl(); webUrl != nil {
+		channelData["webUrl"] = *webUrl
+	}
+
+	return channelId, channelData
+}
@@ -0,0 +1,172 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// attributeSetters are the user attributes that can be updated, with how to set them on a user.
+// Identity, account and security attributes are deliberately not part of the list.
+var attributeSetters = map[string]func(user models.Userable, value *string){
+	"department":        models.Userable.SetDepartment,
+	"jobTitle":          models.Userable.SetJobTitle,
+	"officeLocation":    models.Userable.SetOfficeLocation,
+	"companyName":       models.Userable.SetCompanyName,
+	"employeeId":        models.Userable.SetEmployeeId,
+	"employeeType":      models.Userable.SetEmployeeType,
+	"givenName":         models.Userable.SetGivenName,
+	"surname":           models.Userable.SetSurname,
+	"displayName":       models.Userable.SetDisplayName,
+	"mobilePhone":       models.Userable.SetMobilePhone,
+	"streetAddress":     models.Userable.SetStreetAddress,
+	"city":              models.Userable.SetCity,
+	"state":             models.Userable.SetState,
+	"postalCode":        models.Userable.SetPostalCode,
+	"country":           models.Userable.SetCountry,
+	"usageLocation":     models.Userable.SetUsageLocation,
+	"preferredLanguage": models.Userable.SetPreferredLanguage,
+}
+
+func init() {
+	// Update User Attributes Tool is a tool that updates the profile attributes of a user.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "update_user_attributes",
+			Tool: mcp.NewTool("update_user_attributes",
+				mcp.WithDescription(fmt.Sprintf("Update profile attributes of a user, for example to sync them from an HR system. Only the given attributes are changed, a null value clears the attribute. Accepted attributes: %s. Requires User.ReadWrite.All.", strings.Join(attributeNames(), ", "))),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The id or user principal name of the user."),
+				),
+				mcp.WithObject("attributes",
+					mcp.Required(),
+					mcp.Description("The attributes to update and their new value, e.g. {\"department\": \"Sales\", \"jobTitle\": \"Account Manager\"}."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"User.ReadWrite.All"},
+			OutputSchema:   attributesSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a attributesArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := UpdateAttributes(ctx, client, a.UserId, a.user, a.Attributes)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to update user: %s", odata.ErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// attributesSchema describes the result of the update_user_attributes tool.
+var attributesSchema = schema.Object(map[string]schema.Schema{
+	"userId":  schema.String(),
+	"success": schema.Boolean(),
+	"updated": schema.Map(schema.Nullable(schema.String())),
+})
+
+// attributesArgs are the arguments of the update_user_attributes tool.
+type attributesArgs struct {
+	UserId     string                 `json:"user_id"`
+	Attributes map[string]interface{} `json:"attributes"`
+
+	user models.Userable
+}
+
+// Validate checks that the user and the attributes are given, and builds the update.
+func (a *attributesArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	if len(a.Attributes) == 0 {
+		return fmt.Errorf("attributes is required")
+	}
+
+	user, err := newUserUpdate(a.Attributes)
+	if err != nil {
+		return err
+	}
+	a.user = user
+
+	return nil
+}
+
+// attributeNames returns the sorted names of the attributes that can be updated.
+func attributeNames() []string {
+
+	names := make([]string, 0, len(attributeSetters))
+	for name := range attributeSetters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// newUserUpdate builds a user with only the given attributes set. It rejects the attributes
+// that are not allowed and the values that are not strings or null.
+func newUserUpdate(attributes map[string]any) (models.Userable, error) {
+
+	user := models.NewUser()
+	cleared := make(map[string]interface{})
+
+	for name, value := range attributes {
+		setter, ok := attributeSetters[name]
+		if !ok {
+			return nil, fmt.Errorf("attribute '%s' cannot be updated, accepted attributes are: %s", name, strings.Join(attributeNames(), ", "))
+		}
+		switch v := value.(type) {
+		case nil:
+			// Unset properties are not serialized, send an explicit null instead
+			cleared[name] = nil
+		case string:
+			setter(user, &v)
+		default:
+			return nil, fmt.Errorf("attribute '%s' must be a string or null", name)
+		}
+	}
+	if len(cleared) > 0 {
+		user.SetAdditionalData(cleared)
+	}
+
+	return user, nil
+}
+
+// UpdateAttributes patches a user with the given update and returns the updated attributes.
+// Microsoft Graph validation errors, such as an invalid usageLocation, are returned as is.
+func UpdateAttributes(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, user models.Userable, attributes map[string]any) ([]byte, error) {
+
+	if _, err := client.Users().ByUserId(userId).Patch(ctx, user, nil); err != nil {
+		return nil, fmt.Errorf("error updating user: %w", err)
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"userId":  userId,
+		"success": true,
+		"updated": attributes,
+	}, "", "  ")
+}
@@ -0,0 +1,67 @@
+package users
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+func TestUpdateAttributesBody(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		attributes map[string]interface{}
+	}{
+		{"single attribute", map[string]interface{}{"department": "Sales"}},
+		{"several attributes", map[string]interface{}{"usageLocation": "FR", "jobTitle": "Retail Manager", "city": "Paris"}},
+		{"cleared attribute", map[string]interface{}{"employeeType": nil, "officeLocation": "18/2111"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var body map[string]interface{}
+			graphtest.CheckTool(t, "update_user_attributes", map[string]interface{}{"user_id": "user-id", "attributes": test.attributes}, graphtest.Routes{
+				"PATCH /v1.0/users/user-id": func(r *http.Request) interface{} {
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Errorf("decoding body: %v", err)
+					}
+					return http.StatusNoContent
+				},
+			})
+
+			// The type of the object is the only property sent besides the requested attributes
+			if body["@odata.type"] != "#microsoft.graph.user" {
+				t.Errorf("unexpected @odata.type in %v", body)
+			}
+			delete(body, "@odata.type")
+			if !reflect.DeepEqual(body, test.attributes) {
+				t.Errorf("sent %v, want %v", body, test.attributes)
+			}
+		})
+	}
+}
+
+func TestNewUserUpdateInvalid(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		attributes map[string]interface{}
+		wantErr    string
+	}{
+		{"identity attribute", map[string]interface{}{"userPrincipalName": "adele@contoso.com"}, "'userPrincipalName' cannot be updated"},
+		{"security attribute", map[string]interface{}{"accountEnabled": "false"}, "'accountEnabled' cannot be updated"},
+		{"not a string", map[string]interface{}{"department": 12}, "'department' must be a string or null"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := newUserUpdate(test.attributes); err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("got error %v, want %q", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Create User Tool is a tool that creates a user.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "create_user",
+			Tool: mcp.NewTool("create_user",
+				mcp.WithDescription("Create a user account in Microsoft Entra ID, to onboard a new employee. The domain of the user principal name must be verified in the tenant and the password must meet the password policy. The password is never returned. Requires User.ReadWrite.All."),
+				mcp.WithString("display_name",
+					mcp.Required(),
+					mcp.Description("The name displayed for the user, e.g. Adele Vance."),
+				),
+				mcp.WithString("user_principal_name",
+					mcp.Required(),
+					mcp.Description("The sign-in name of the user, e.g. adelev@contoso.com."),
+				),
+				mcp.WithString("mail_nickname",
+					mcp.Description("The mail alias of the user. Defaults to the part of the user principal name before the @."),
+				),
+				mcp.WithString("password",
+					mcp.Required(),
+					mcp.Description("The initial password of the user."),
+				),
+				mcp.WithBoolean("force_change_password",
+					mcp.Description("Require the user to change the password at the next sign-in. Defaults to true."),
+				),
+				mcp.WithBoolean("account_enabled",
+					mcp.Description("Whether the account can sign in. Defaults to true."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"User.ReadWrite.All"},
+			OutputSchema:   createUserSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := createUserArgs{AccountEnabled: true, ForceChangePassword: true}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := CreateUser(ctx, client, a.user())
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to create user: %s", userErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// createUserSchema describes the result of the create_user tool.
+var createUserSchema = schema.Object(map[string]schema.Schema{
+	"id":                schema.String(),
+	"userPrincipalName": schema.String(),
+	"displayName":       schema.String(),
+	"success":           schema.Boolean(),
+})
+
+// createUserArgs are the arguments of the create_user tool.
+type createUserArgs struct {
+	DisplayName         string `json:"display_name"`
+	UserPrincipalName   string `json:"user_principal_name"`
+	Password            string `json:"password"`
+	MailNickname        string `json:"mail_nickname"`
+	AccountEnabled      bool   `json:"account_enabled"`
+	ForceChangePassword bool   `json:"force_change_password"`
+}
+
+// Validate checks the required arguments, and defaults the mail nickname to the alias of the
+// user principal name.
+func (a *createUserArgs) Validate() error {
+
+	if a.DisplayName == "" {
+		return fmt.Errorf("display_name is required")
+	}
+	alias, _, found := strings.Cut(a.UserPrincipalName, "@")
+	if !found || alias == "" {
+		return fmt.Errorf("invalid user_principal_name '%s', expected alias@domain", a.UserPrincipalName)
+	}
+	if a.Password == "" {
+		return fmt.Errorf("password is required")
+	}
+	if a.MailNickname == "" {
+		a.MailNickname = alias
+	}
+
+	return nil
+}
+
+// user builds the user to create.
+func (a *createUserArgs) user() models.Userable {
+
+	user := models.NewUser()
+	user.SetDisplayName(&a.DisplayName)
+	user.SetUserPrincipalName(&a.UserPrincipalName)
+	user.SetMailNickname(&a.MailNickname)
+	user.SetAccountEnabled(&a.AccountEnabled)
+	user.SetPasswordProfile(newPasswordProfile(a.Password, a.ForceChangePassword))
+
+	return user
+}
+
+// newPasswordProfile returns the password profile setting the password of a user.
+func newPasswordProfile(password string, forceChange bool) models.PasswordProfileable {
+
+	profile := models.NewPasswordProfile()
+	profile.SetPassword(&password)
+	profile.SetForceChangePasswordNextSignIn(&forceChange)
+
+	return profile
+}
+
+// CreateUser creates the user and returns its id and user principal name, never its password.
+func CreateUser(ctx context.Context, client *msgraphsdk.GraphServiceClient, user models.Userable) ([]byte, error) {
+
+	created, err := client.Users().Post(ctx, user, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating user: %w", err)
+	}
+
+	userData := map[string]interface{}{
+		"success": true,
+	}
+	if id := created.GetId(); id != nil {
+		userData["id"] = *id
+	}
+	if userPrincipalName := created.GetUserPrincipalName(); userPrincipalName != nil {
+		userData["userPrincipalName"] = *userPrincipalName
+	}
+	if displayName := created.GetDisplayName(); displayName != nil {
+		userData["displayName"] = *displayName
+	}
+
+	return json.MarshalIndent(userData, "", "  ")
+}
+
+// userErrorMessage returns the message of an error returned by Microsoft Graph when writing a
+// user, with a hint on how to fix the most common validation errors.
+func userErrorMessage(err error) string {
+
+	message := odata.ErrorMessage(err)
+	lower := strings.ToLower(message)
+
+	switch {
+	case strings.Contains(lower, "password complexity") || strings.Contains(lower, "password policy"):
+		return message + " The password must be at least 8 characters long and combine 3 of: lowercase letters, uppercase letters, digits and symbols. It cannot contain the user name."
+	case strings.Contains(lower, "domain portion"):
+		return message + " Use one of the verified domains of the tenant, see the organization tool."
+	case strings.Contains(lower, "another object with the same value"):
+		return message + " A user with this user principal name or mail nickname already exists."
+	default:
+		return message
+	}
+}
@@ -0,0 +1,114 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+)
+
+func init() {
+	// Delete User Tool is a tool that deletes a user.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "delete_user",
+			Tool: mcp.NewTool("delete_user",
+				mcp.WithDescription("Delete a user. The user is moved to the deleted items of the directory, where it can be restored with restore_user for 30 days, unless permanent is set. To avoid deleting the wrong account, the user must be given by its object id, not by its user principal name. Requires User.ReadWrite.All."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The object id of the user, a GUID. User principal names are rejected."),
+				),
+				mcp.WithBoolean("permanent",
+					mcp.Description("Also remove the user from the deleted items, so that it cannot be restored. Defaults to false."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"User.ReadWrite.All"},
+			OutputSchema:   deleteUserSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a deleteUserArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := DeleteUser(ctx, client, a.UserId, a.Permanent)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the user '%s' does not exist", a.UserId)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to delete user: %s", odata.ErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// deleteUserSchema describes the result of the delete_user tool.
+var deleteUserSchema = schema.Object(map[string]schema.Schema{
+	"userId":    schema.String(),
+	"success":   schema.Boolean(),
+	"permanent": schema.Boolean(),
+	"message":   schema.String(),
+})
+
+// deleteUserArgs are the arguments of the delete_user tool.
+type deleteUserArgs struct {
+	UserId    string `json:"user_id"`
+	Permanent bool   `json:"permanent"`
+}
+
+// Validate checks that the user is given by its object id.
+func (a *deleteUserArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	if !odata.IsGUID(a.UserId) {
+		return fmt.Errorf("user_id must be the object id of the user, not '%s': look it up with the users tool first", a.UserId)
+	}
+
+	return nil
+}
+
+// DeleteUser deletes a user and, if permanent is set, removes it from the deleted items.
+func DeleteUser(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, permanent bool) ([]byte, error) {
+
+	if err := client.Users().ByUserId(userId).Delete(ctx, nil); err != nil {
+		return nil, fmt.Errorf("error deleting user: %w", err)
+	}
+
+	deleteData := map[string]interface{}{
+		"userId":    userId,
+		"success":   true,
+		"permanent": permanent,
+		"message":   "The user was deleted, it can be restored with restore_user for 30 days.",
+	}
+
+	if permanent {
+		if err := client.Directory().DeletedItems().ByDirectoryObjectId(userId).Delete(ctx, nil); err != nil {
+			// The user is deleted all the same, only the hard delete failed
+			deleteData["permanent"] = false
+			deleteData["message"] = fmt.Sprintf("The user was deleted but could not be removed from the deleted items: %s. It can still be restored for 30 days.", odata.ErrorMessage(err))
+			return json.MarshalIndent(deleteData, "", "  ")
+		}
+		deleteData["message"] = "The user was permanently deleted."
+	}
+
+	return json.MarshalIndent(deleteData, "", "  ")
+}
@@ -0,0 +1,57 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+)
+
+// GetDelta retrieves the users changed since the given delta link, or all the users if it is empty,
+// along with the delta link to use on the next call. Deleted users are flagged as removed.
+func GetDelta(ctx context.Context, client *msgraphsdk.GraphServiceClient, deltaLink string) ([]byte, error) {
+
+	builder := client.Users().Delta()
+	if deltaLink != "" {
+		builder = builder.WithUrl(deltaLink)
+	}
+
+	result, err := builder.GetAsDeltaGetResponse(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching users delta: %v", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	usersData := make(map[string]interface{})
+
+	// Delta pages end with a delta link instead of a next link, follow them by hand
+	for {
+		for _, user := range result.GetValue() {
+			id, userData := convertUserToMap(user)
+			if _, removed := user.GetAdditionalData()["@removed"]; removed {
+				delete(userData, "@removed")
+				userData["removed"] = true
+			}
+			usersData[id] = userData
+		}
+
+		nextLink := result.GetOdataNextLink()
+		if nextLink == nil || *nextLink == "" {
+			break
+		}
+		result, err = client.Users().Delta().WithUrl(*nextLink).GetAsDeltaGetResponse(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching users delta page: %v", err)
+		}
+	}
+
+	deltaData := map[string]interface{}{
+		"users": usersData,
+	}
+	if nextDeltaLink := result.GetOdataDeltaLink(); nextDeltaLink != nil {
+		deltaData["deltaLink"] = *nextDeltaLink
+	}
+
+	return json.MarshalIndent(deltaData, "", "  ")
+}
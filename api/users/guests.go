@@ -0,0 +1,333 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+)
+
+// defaultRedirectUrl is where guests land after redeeming an invitation when no redirect url is given.
+const defaultRedirectUrl = "https://myapps.microsoft.com"
+
+// pendingAcceptance is the external user state of guests who have not redeemed their invitation yet.
+const pendingAcceptance = "PendingAcceptance"
+
+func init() {
+	// Guests Tool is a tool that interacts with microsoft for B2B guest user APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "guests",
+			Tool: mcp.NewTool("guests",
+				mcp.WithDescription("List the B2B guest users of the tenant with the state of their invitation (externalUserState) and how long ago they were created, optionally with their sponsors, the users or groups responsible for them."),
+				mcp.WithString("state",
+					mcp.Enum("Accepted", pendingAcceptance),
+					mcp.Description("Only return guests whose invitation is in this state."),
+				),
+				mcp.WithBoolean("with_sponsors",
+					mcp.Description("Also return the sponsors of each guest. Guests without a recorded sponsor have an empty list."),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			OutputSchema: guestSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a guestsArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetGuests(ctx, client, a.State, a.WithSponsors)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get guests"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+
+	// Resend Invitation Tool is a tool that resends the invitation of pending B2B guests.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "resend_invitation",
+			Tool: mcp.NewTool("resend_invitation",
+				mcp.WithDescription("Resend the invitation email to B2B guests whose invitation is still pending acceptance. Returns the redeem url of each new invitation. Requires User.Invite.All and User.Read.All."),
+				mcp.WithString("user_ids",
+					mcp.Required(),
+					mcp.Description("Comma separated list of guest user ids."),
+				),
+				mcp.WithString("redirect_url",
+					mcp.Description(fmt.Sprintf("The url guests are redirected to once the invitation is redeemed. Defaults to %s.", defaultRedirectUrl)),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"User.Invite.All", "User.Read.All"},
+			OutputSchema:   invitationSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := invitationArgs{RedirectUrl: defaultRedirectUrl}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := ResendInvitations(ctx, client, a.userIds, a.RedirectUrl)
+				if err != nil {
+					return mcp.NewToolResultError("failed to resend invitations"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// guestSchema describes the result of the guests tool.
+var guestSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":                              schema.String(),
+	"displayName":                     schema.String(),
+	"mail":                            schema.String(),
+	"userPrincipalName":               schema.String(),
+	"createdDateTime":                 schema.DateTime(),
+	"externalUserState":               schema.String(),
+	"externalUserStateChangeDateTime": schema.DateTime(),
+	"daysSinceCreated":                schema.Integer(),
+	"sponsors": schema.Array(schema.Object(map[string]schema.Schema{
+		"id":                schema.String(),
+		"type":              schema.Enum("user", "group", "directoryObject"),
+		"displayName":       schema.String(),
+		"userPrincipalName": schema.String(),
+	})),
+}))
+
+// guestsArgs are the arguments of the guests tool.
+type guestsArgs struct {
+	State        string `json:"state"`
+	WithSponsors bool   `json:"with_sponsors"`
+}
+
+// invitationSchema describes the result of the resend_invitation tool, keyed by user id.
+var invitationSchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"success":         schema.Boolean(),
+	"error":           schema.String(),
+	"mail":            schema.String(),
+	"status":          schema.String(),
+	"inviteRedeemUrl": schema.String(),
+}))
+
+// invitationArgs are the arguments of the resend_invitation tool.
+type invitationArgs struct {
+	UserIds     string `json:"user_ids"`
+	RedirectUrl string `json:"redirect_url"`
+
+	userIds []string
+}
+
+// Validate splits the comma separated user ids and checks that there is at least one.
+func (a *invitationArgs) Validate() error {
+
+	for _, userId := range strings.Split(a.UserIds, ",") {
+		if userId = strings.TrimSpace(userId); userId != "" {
+			a.userIds = append(a.userIds, userId)
+		}
+	}
+	if len(a.userIds) == 0 {
+		return fmt.Errorf("user_ids is required")
+	}
+
+	return nil
+}
+
+// GetGuests retrieves the guest users of the tenant, optionally only those whose invitation is in the given state,
+// along with their sponsors when withSponsors is set.
+func GetGuests(ctx context.Context, client *msgraphsdk.GraphServiceClient, state string, withSponsors bool) ([]byte, error) {
+
+	params := &users.UsersRequestBuilderGetQueryParameters{
+		Filter: to.Ptr(odata.Eq("userType", "Guest")),
+		Select: []string{"id", "displayName", "mail", "userPrincipalName", "createdDateTime", "externalUserState", "externalUserStateChangeDateTime"},
+	}
+	if withSponsors {
+		params.Expand = []string{"sponsors($select=id,displayName,userPrincipalName)"}
+	}
+
+	result, err := client.Users().Get(ctx, &users.UsersRequestBuilderGetRequestConfiguration{
+		QueryParameters: params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching guests: %v", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	guestsData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateUserCollectionResponseFromDiscriminatorValue, func(user models.Userable) bool {
+		if state != "" && (user.GetExternalUserState() == nil || *user.GetExternalUserState() != state) {
+			return true
+		}
+		id, guestData := convertGuestToMap(user)
+		if withSponsors {
+			sponsors := []interface{}{}
+			for _, sponsor := range user.GetSponsors() {
+				sponsors = append(sponsors, convertSponsorToMap(sponsor))
+			}
+			guestData["sponsors"] = sponsors
+		}
+		guestsData[id] = guestData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through guests: %v", err)
+	}
+
+	return json.MarshalIndent(guestsData, "", "  ")
+}
+
+// ResendInvitations sends a new invitation to each guest still pending acceptance.
+// Guests are processed one by one so that a failure only affects the guest it concerns.
+func ResendInvitations(ctx context.Context, client *msgraphsdk.GraphServiceClient, userIds []string, redirectUrl string) ([]byte, error) {
+
+	resultsData := make(map[string]interface{})
+
+	for _, userId := range userIds {
+
+		user, err := client.Users().ByUserId(userId).Get(ctx, &users.UserItemRequestBuilderGetRequestConfiguration{
+			QueryParameters: &users.UserItemRequestBuilderGetQueryParameters{
+				Select: []string{"id", "mail", "userType", "externalUserState"},
+			},
+		})
+		if err != nil {
+			resultsData[userId] = map[string]interface{}{"success": false, "error": odata.ErrorMessage(err)}
+			continue
+		}
+
+		if user.GetExternalUserState() == nil || *user.GetExternalUserState() != pendingAcceptance {
+			resultsData[userId] = map[string]interface{}{"success": false, "error": "the user is not a guest pending acceptance"}
+			continue
+		}
+		if user.GetMail() == nil || *user.GetMail() == "" {
+			resultsData[userId] = map[string]interface{}{"success": false, "error": "the guest has no email address"}
+			continue
+		}
+
+		invitedUser := models.NewUser()
+		invitedUser.SetId(to.Ptr(userId))
+
+		body := models.NewInvitation()
+		body.SetInvitedUser(invitedUser)
+		body.SetInvitedUserEmailAddress(user.GetMail())
+		body.SetInviteRedirectUrl(to.Ptr(redirectUrl))
+		body.SetSendInvitationMessage(to.Ptr(true))
+
+		invitation, err := client.Invitations().Post(ctx, body, nil)
+		if err != nil {
+			resultsData[userId] = map[string]interface{}{"success": false, "error": odata.ErrorMessage(err)}
+			continue
+		}
+
+		invitationData := map[string]interface{}{
+			"success": true,
+			"mail":    *user.GetMail(),
+		}
+		if status := invitation.GetStatus(); status != nil {
+			invitationData["status"] = *status
+		}
+		if inviteRedeemUrl := invitation.GetInviteRedeemUrl(); inviteRedeemUrl != nil {
+			invitationData["inviteRedeemUrl"] = *inviteRedeemUrl
+		}
+		resultsData[userId] = invitationData
+	}
+
+	return json.MarshalIndent(resultsData, "", "  ")
+}
+
+// convertGuestToMap converts a guest user model to a map with its invitation attributes
+func convertGuestToMap(user models.Userable) (string, map[string]interface{}) {
+
+	userId := ""
+	guestData := make(map[string]interface{})
+
+	if id := user.GetId(); id != nil {
+		userId = *id
+		guestData["id"] = userId
+	}
+	if displayName := user.GetDisplayName(); displayName != nil {
+		guestData["displayName"] = *displayName
+	}
+	if mail := user.GetMail(); mail != nil {
+		guestData["mail"] = *mail
+	}
+	if userPrincipalName := user.GetUserPrincipalName(); userPrincipalName != nil {
+		guestData["userPrincipalName"] = *userPrincipalName
+	}
+	if createdDateTime := user.GetCreatedDateTime(); createdDateTime != nil {
+		guestData["createdDateTime"] = createdDateTime.Format(time.RFC3339)
+		guestData["daysSinceCreated"] = int(time.Since(*createdDateTime).Hours() / 24)
+	}
+	if externalUserState := user.GetExternalUserState(); externalUserState != nil {
+		guestData["externalUserState"] = *externalUserState
+	}
+	if externalUserStateChangeDateTime := user.GetExternalUserStateChangeDateTime(); externalUserStateChangeDateTime != nil {
+		guestData["externalUserStateChangeDateTime"] = externalUserStateChangeDateTime.Format(time.RFC3339)
+	}
+
+	return userId, guestData
+}
+
+// convertSponsorToMap converts the sponsor of a guest, a user or a group, to a map with its type and names
+func convertSponsorToMap(sponsor models.DirectoryObjectable) map[string]interface{} {
+
+	sponsorData := make(map[string]interface{})
+
+	if id := sponsor.GetId(); id != nil {
+		sponsorData["id"] = *id
+	}
+
+	switch s := sponsor.(type) {
+	case models.Userable:
+		sponsorData["type"] = "user"
+		if displayName := s.GetDisplayName(); displayName != nil {
+			sponsorData["displayName"] = *displayName
+		}
+		if userPrincipalName := s.GetUserPrincipalName(); userPrincipalName != nil {
+			sponsorData["userPrincipalName"] = *userPrincipalName
+		}
+	case models.Groupable:
+		sponsorData["type"] = "group"
+		if displayName := s.GetDisplayName(); displayName != nil {
+			sponsorData["displayName"] = *displayName
+		}
+	default:
+		sponsorData["type"] = "directoryObject"
+	}
+
+	return sponsorData
+}
@@ -0,0 +1,165 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+)
+
+func init() {
+	// Update User Manager Tool is a tool that sets or removes the manager of a user.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "update_user_manager",
+			Tool: mcp.NewTool("update_user_manager",
+				mcp.WithDescription("Set the manager of a user, replacing the current one, or remove it. The manager must be an existing user. Requires User.ReadWrite.All."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The id or user principal name of the user."),
+				),
+				mcp.WithString("action",
+					mcp.Required(),
+					mcp.Enum("set", "remove"),
+					mcp.Description("'set' makes manager_id the manager of the user, 'remove' leaves the user without manager."),
+				),
+				mcp.WithString("manager_id",
+					mcp.Description("set: the id or user principal name of the manager."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"User.ReadWrite.All"},
+			OutputSchema:   managerSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a managerArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				switch a.Action {
+				case "set":
+
+					// Graph only reports a reference to a missing object as a bad request
+					manager, err := client.Users().ByUserId(a.ManagerId).Get(ctx, &users.UserItemRequestBuilderGetRequestConfiguration{
+						QueryParameters: &users.UserItemRequestBuilderGetQueryParameters{
+							Select: []string{"id"},
+						},
+					})
+					if err != nil {
+						if odata.StatusCode(err) == http.StatusNotFound {
+							return mcp.NewToolResultError(fmt.Sprintf("the manager '%s' does not exist", a.ManagerId)), nil
+						}
+						return mcp.NewToolResultError(fmt.Sprintf("failed to look up the manager: %s", odata.ErrorMessage(err))), nil
+					}
+					if manager.GetId() == nil {
+						return mcp.NewToolResultError(fmt.Sprintf("the manager '%s' has no id", a.ManagerId)), nil
+					}
+
+					jsonData, err := SetManager(ctx, client, a.UserId, *manager.GetId())
+					if err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("failed to set manager: %s", odata.ErrorMessage(err))), nil
+					}
+					return mcp.NewToolResultText(string(jsonData)), nil
+
+				case "remove":
+					jsonData, err := RemoveManager(ctx, client, a.UserId)
+					if err != nil {
+						if odata.StatusCode(err) == http.StatusNotFound {
+							return mcp.NewToolResultError(fmt.Sprintf("the user '%s' does not exist or has no manager", a.UserId)), nil
+						}
+						return mcp.NewToolResultError(fmt.Sprintf("failed to remove manager: %s", odata.ErrorMessage(err))), nil
+					}
+					return mcp.NewToolResultText(string(jsonData)), nil
+
+				default:
+					return mcp.NewToolResultError(fmt.Sprintf("unsupported action '%s'", a.Action)), nil
+				}
+			},
+		},
+	)
+}
+
+// managerSchema describes the result of the update_user_manager tool.
+var managerSchema = schema.Object(map[string]schema.Schema{
+	"userId":    schema.String(),
+	"success":   schema.Boolean(),
+	"action":    schema.Enum("set", "remove"),
+	"managerId": schema.String(),
+})
+
+// managerArgs are the arguments of the update_user_manager tool.
+type managerArgs struct {
+	UserId    string `json:"user_id"`
+	Action    string `json:"action"`
+	ManagerId string `json:"manager_id"`
+}
+
+// Validate checks that the user is given, and the manager when it is set.
+func (a *managerArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	if a.Action == "set" && a.ManagerId == "" {
+		return fmt.Errorf("manager_id is required to set the manager")
+	}
+
+	return nil
+}
+
+// NewManagerReference returns the reference to the user who is the manager, as sent to the
+// manager $ref of a user.
+func NewManagerReference(baseUrl string, managerId string) models.ReferenceUpdateable {
+
+	odataId := fmt.Sprintf("%s/users/%s", baseUrl, managerId)
+	reference := models.NewReferenceUpdate()
+	reference.SetOdataId(&odataId)
+
+	return reference
+}
+
+// SetManager makes the user with the given id the manager of the user.
+func SetManager(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, managerId string) ([]byte, error) {
+
+	reference := NewManagerReference(client.GetAdapter().GetBaseUrl(), managerId)
+	if err := client.Users().ByUserId(userId).Manager().Ref().Put(ctx, reference, nil); err != nil {
+		return nil, fmt.Errorf("error setting manager: %w", err)
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"userId":    userId,
+		"success":   true,
+		"action":    "set",
+		"managerId": managerId,
+	}, "", "  ")
+}
+
+// RemoveManager removes the manager of the user.
+func RemoveManager(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string) ([]byte, error) {
+
+	if err := client.Users().ByUserId(userId).Manager().Ref().Delete(ctx, nil); err != nil {
+		return nil, fmt.Errorf("error removing manager: %w", err)
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"userId":  userId,
+		"success": true,
+		"action":  "remove",
+	}, "", "  ")
+}
@@ -0,0 +1,30 @@
+package users
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+func TestSetManagerBody(t *testing.T) {
+
+	var body map[string]interface{}
+	graphtest.CheckTool(t, "update_user_manager", map[string]interface{}{"user_id": "user-id", "action": "set", "manager_id": "megan@contoso.com"}, graphtest.Routes{
+		"GET /v1.0/users/megan@contoso.com": map[string]interface{}{"id": "manager-id"},
+		"PUT /v1.0/users/user-id/manager/$ref": func(r *http.Request) interface{} {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decoding body: %v", err)
+			}
+			return http.StatusNoContent
+		},
+	})
+
+	// The reference is made to the object id of the manager, not the id it was given by
+	want := map[string]interface{}{"@odata.id": "https://graph.microsoft.com/v1.0/users/manager-id"}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("sent %v, want %v", body, want)
+	}
+}
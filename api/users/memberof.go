@@ -0,0 +1,59 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+)
+
+// GetMemberOf retrieves the groups the user is a direct member of, with their id and display name.
+// The directory roles and administrative units the user is a member of are left out.
+func GetMemberOf(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string) ([]interface{}, error) {
+
+	result, err := client.Users().ByUserId(userId).MemberOf().Get(ctx, &users.ItemMemberOfRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemMemberOfRequestBuilderGetQueryParameters{
+			Select: []string{"id", "displayName"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching groups of user '%s': %w", userId, err)
+	}
+
+	groupsData := []interface{}{}
+	err = paginate.Iterate(ctx, client, result, models.CreateDirectoryObjectCollectionResponseFromDiscriminatorValue, func(member models.DirectoryObjectable) bool {
+		group, ok := member.(models.Groupable)
+		if !ok {
+			return true
+		}
+		groupData := make(map[string]interface{})
+		if id := group.GetId(); id != nil {
+			groupData["id"] = *id
+		}
+		if displayName := group.GetDisplayName(); displayName != nil {
+			groupData["displayName"] = *displayName
+		}
+		groupsData = append(groupsData, groupData)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through groups of user '%s': %v", userId, err)
+	}
+
+	return groupsData, nil
+}
+
+// WithMemberOf is the expansion attaching the groups the user is a direct member of as memberOf.
+func WithMemberOf(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, userData map[string]interface{}) error {
+
+	groupsData, err := GetMemberOf(ctx, client, userId)
+	if err != nil {
+		return err
+	}
+	userData["memberOf"] = groupsData
+
+	return nil
+}
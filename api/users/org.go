@@ -0,0 +1,77 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+)
+
+// WithOrg is the expansion attaching the reporting relationships of the user: its manager, left
+// out if it has none, and its direct reports.
+func WithOrg(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, userData map[string]interface{}) error {
+
+	manager, err := client.Users().ByUserId(userId).Manager().Get(ctx, &users.ItemManagerRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemManagerRequestBuilderGetQueryParameters{
+			Select: []string{"id", "displayName"},
+		},
+	})
+	switch {
+	// Graph answers 404 for a user without manager
+	case odata.StatusCode(err) == http.StatusNotFound:
+	case err != nil:
+		return fmt.Errorf("error fetching manager of user '%s': %w", userId, err)
+	case manager != nil:
+		userData["manager"] = convertRelatedToMap(manager)
+	}
+
+	result, err := client.Users().ByUserId(userId).DirectReports().Get(ctx, &users.ItemDirectReportsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemDirectReportsRequestBuilderGetQueryParameters{
+			Select: []string{"id", "displayName"},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error fetching direct reports of user '%s': %w", userId, err)
+	}
+
+	reportsData := []interface{}{}
+	err = paginate.Iterate(ctx, client, result, models.CreateDirectoryObjectCollectionResponseFromDiscriminatorValue, func(report models.DirectoryObjectable) bool {
+		reportsData = append(reportsData, convertRelatedToMap(report))
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("error iterating through direct reports of user '%s': %v", userId, err)
+	}
+	userData["directReports"] = reportsData
+
+	return nil
+}
+
+// convertRelatedToMap converts a directory object related to a user to a map of its id and
+// display name. Managers and direct reports are users, but can also be org contacts.
+func convertRelatedToMap(object models.DirectoryObjectable) map[string]interface{} {
+
+	objectData := make(map[string]interface{})
+
+	if id := object.GetId(); id != nil {
+		objectData["id"] = *id
+	}
+
+	switch o := object.(type) {
+	case models.Userable:
+		if displayName := o.GetDisplayName(); displayName != nil {
+			objectData["displayName"] = *displayName
+		}
+	case models.OrgContactable:
+		if displayName := o.GetDisplayName(); displayName != nil {
+			objectData["displayName"] = *displayName
+		}
+	}
+
+	return objectData
+}
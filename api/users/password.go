@@ -0,0 +1,112 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Reset Password Tool is a tool that sets a new password for a user.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "reset_password",
+			Tool: mcp.NewTool("reset_password",
+				mcp.WithDescription("Reset the password of a user. By default the user must change it at the next sign-in, set force_change to false to issue a permanent password, for example for a service account. The password is never returned. Requires User-PasswordProfile.ReadWrite.All."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The id or user principal name of the user."),
+				),
+				mcp.WithString("new_password",
+					mcp.Required(),
+					mcp.Description("The new password. It must meet the password policy of the tenant."),
+				),
+				mcp.WithBoolean("force_change",
+					mcp.Description("Require the user to change the password at the next sign-in. Defaults to true."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"User-PasswordProfile.ReadWrite.All"},
+			OutputSchema:   resetPasswordSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				a := resetPasswordArgs{ForceChange: true}
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := ResetPassword(ctx, client, a.UserId, a.NewPassword, a.ForceChange)
+				if err != nil {
+					switch odata.StatusCode(err) {
+					case http.StatusNotFound:
+						return mcp.NewToolResultError(fmt.Sprintf("the user '%s' does not exist", a.UserId)), nil
+					case http.StatusForbidden:
+						return mcp.NewToolResultError(fmt.Sprintf("failed to reset password: %s Resetting the password of an administrator requires a privileged role.", odata.ErrorMessage(err))), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to reset password: %s", userErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// resetPasswordSchema describes the result of the reset_password tool.
+var resetPasswordSchema = schema.Object(map[string]schema.Schema{
+	"userId":                        schema.String(),
+	"success":                       schema.Boolean(),
+	"forceChangePasswordNextSignIn": schema.Boolean(),
+})
+
+// resetPasswordArgs are the arguments of the reset_password tool.
+type resetPasswordArgs struct {
+	UserId      string `json:"user_id"`
+	NewPassword string `json:"new_password"`
+	ForceChange bool   `json:"force_change"`
+}
+
+// Validate checks that the user and the new password are given.
+func (a *resetPasswordArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	if a.NewPassword == "" {
+		return fmt.Errorf("new_password is required")
+	}
+
+	return nil
+}
+
+// ResetPassword sets the password of a user, never returning it.
+func ResetPassword(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, password string, forceChange bool) ([]byte, error) {
+
+	user := models.NewUser()
+	user.SetPasswordProfile(newPasswordProfile(password, forceChange))
+
+	if _, err := client.Users().ByUserId(userId).Patch(ctx, user, nil); err != nil {
+		return nil, fmt.Errorf("error resetting password: %w", err)
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"userId":                        userId,
+		"success":                       true,
+		"forceChangePasswordNextSignIn": forceChange,
+	}, "", "  ")
+}
@@ -0,0 +1,247 @@
+package users
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+)
+
+// maxPhotoSize is the largest profile photo accepted by Microsoft Graph.
+const maxPhotoSize = 4 * 1024 * 1024
+
+// photoContentTypes are the image formats accepted for profile photos.
+var photoContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/bmp":  true,
+}
+
+// photoSizes are the sizes profile photos are available in, besides the original one.
+var photoSizes = []string{"48x48", "64x64", "96x96", "120x120", "240x240", "360x360", "432x432", "504x504", "648x648"}
+
+func init() {
+	// User Photo Tool is a tool that interacts with microsoft for profile photo APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "user_photo",
+			Tool: mcp.NewTool("user_photo",
+				mcp.WithDescription("Get the profile photo of a user, base64 encoded along with its content type, in its original size or one of the smaller sizes Microsoft Graph provides. Requires User.Read.All."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The id or user principal name of the user."),
+				),
+				mcp.WithString("size",
+					mcp.Enum(photoSizes...),
+					mcp.Description("The size of the photo. The largest available photo is returned by default."),
+				),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"User.Read.All"},
+			OutputSchema:   getPhotoSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a getPhotoArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetPhoto(ctx, client, a.UserId, a.Size)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the user '%s' has no profile photo, or does not exist", a.UserId)), nil
+					}
+					return mcp.NewToolResultError("failed to get photo"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+
+	// Upload User Photo Tool is a tool that sets the profile photo of a user.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "upload_user_photo",
+			Tool: mcp.NewTool("upload_user_photo",
+				mcp.WithDescription("Set the profile photo of a user from a base64 encoded JPEG, PNG, GIF or BMP image of at most 4MB. Requires User.ReadWrite.All."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The id or user principal name of the user."),
+				),
+				mcp.WithString("image",
+					mcp.Required(),
+					mcp.Description("The base64 encoded image."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"User.ReadWrite.All"},
+			OutputSchema:   photoSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a uploadPhotoArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := UploadPhoto(ctx, client, a.UserId, a.image, a.contentType)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to upload photo: %s", odata.ErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// photoSchema describes the result of the upload_user_photo tool.
+var photoSchema = schema.Object(map[string]schema.Schema{
+	"userId":      schema.String(),
+	"success":     schema.Boolean(),
+	"contentType": schema.String(),
+	"size":        schema.Integer(),
+})
+
+// getPhotoSchema describes the result of the user_photo tool.
+var getPhotoSchema = schema.Object(map[string]schema.Schema{
+	"userId":      schema.String(),
+	"size":        schema.String(),
+	"contentType": schema.String(),
+	"bytes":       schema.Integer(),
+	"content":     schema.String(),
+})
+
+// getPhotoArgs are the arguments of the user_photo tool.
+type getPhotoArgs struct {
+	UserId string `json:"user_id"`
+	Size   string `json:"size"`
+}
+
+// Validate checks that the user is given, and the size when it is.
+func (a *getPhotoArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	if a.Size != "" && !slices.Contains(photoSizes, a.Size) {
+		return fmt.Errorf("unsupported size '%s', expected one of %s", a.Size, strings.Join(photoSizes, ", "))
+	}
+
+	return nil
+}
+
+// uploadPhotoArgs are the arguments of the upload_user_photo tool.
+type uploadPhotoArgs struct {
+	UserId string `json:"user_id"`
+	Image  string `json:"image"`
+
+	image       []byte
+	contentType string
+}
+
+// Validate checks that the user is given, and decodes the image and checks its size and format.
+func (a *uploadPhotoArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+
+	image, err := base64.StdEncoding.DecodeString(a.Image)
+	if err != nil {
+		return fmt.Errorf("image is not valid base64: %v", err)
+	}
+	if len(image) == 0 {
+		return fmt.Errorf("image is required")
+	}
+	if len(image) > maxPhotoSize {
+		return fmt.Errorf("image is %d bytes, the maximum is %d", len(image), maxPhotoSize)
+	}
+
+	a.contentType = http.DetectContentType(image)
+	if !photoContentTypes[a.contentType] {
+		return fmt.Errorf("unsupported image format '%s', expected JPEG, PNG, GIF or BMP", a.contentType)
+	}
+	a.image = image
+
+	return nil
+}
+
+// GetPhoto retrieves the profile photo of a user in the given size, or the largest one if size is empty.
+func GetPhoto(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, size string) ([]byte, error) {
+
+	var image []byte
+	var err error
+	if size == "" {
+		image, err = client.Users().ByUserId(userId).Photo().Content().Get(ctx, nil)
+	} else {
+		image, err = client.Users().ByUserId(userId).Photos().ByProfilePhotoId(size).Content().Get(ctx, nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching photo: %w", err)
+	}
+
+	photoData := map[string]interface{}{
+		"userId":      userId,
+		"contentType": http.DetectContentType(image),
+		"bytes":       len(image),
+		"content":     base64.StdEncoding.EncodeToString(image),
+	}
+	if size != "" {
+		photoData["size"] = size
+	}
+
+	return json.MarshalIndent(photoData, "", "  ")
+}
+
+// UploadPhoto sets the profile photo of a user.
+func UploadPhoto(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, image []byte, contentType string) ([]byte, error) {
+
+	requestInfo, err := client.Users().ByUserId(userId).Photo().Content().ToPutRequestInformation(ctx, image, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error building photo request: %v", err)
+	}
+
+	// The request builder always sends application/octet-stream, Graph expects the image type
+	requestInfo.Headers.Remove("Content-Type")
+	requestInfo.Headers.Add("Content-Type", contentType)
+
+	errorMapping := abstractions.ErrorMappings{
+		"XXX": odataerrors.CreateODataErrorFromDiscriminatorValue,
+	}
+	if err := client.GetAdapter().SendNoContent(ctx, requestInfo, errorMapping); err != nil {
+		return nil, fmt.Errorf("error uploading photo: %w", err)
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"userId":      userId,
+		"success":     true,
+		"contentType": contentType,
+		"size":        len(image),
+	}, "", "  ")
+}
@@ -0,0 +1,108 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Restore User Tool is a tool that restores a deleted user.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "restore_user",
+			Tool: mcp.NewTool("restore_user",
+				mcp.WithDescription("Restore a user deleted in the last 30 days from the deleted items of the directory. Users deleted with permanent set cannot be restored. Requires User.ReadWrite.All."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The object id of the deleted user, a GUID."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"User.ReadWrite.All"},
+			OutputSchema:   restoreUserSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a restoreUserArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := RestoreUser(ctx, client, a.UserId)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the user '%s' is not in the deleted items", a.UserId)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to restore user: %s", odata.ErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// restoreUserSchema describes the result of the restore_user tool.
+var restoreUserSchema = schema.Object(map[string]schema.Schema{
+	"userId":            schema.String(),
+	"displayName":       schema.String(),
+	"userPrincipalName": schema.String(),
+	"success":           schema.Boolean(),
+})
+
+// restoreUserArgs are the arguments of the restore_user tool.
+type restoreUserArgs struct {
+	UserId string `json:"user_id"`
+}
+
+// Validate checks that the user is given by its object id.
+func (a *restoreUserArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	if !odata.IsGUID(a.UserId) {
+		return fmt.Errorf("user_id must be the object id of the deleted user, not '%s'", a.UserId)
+	}
+
+	return nil
+}
+
+// RestoreUser restores a user from the deleted items of the directory.
+func RestoreUser(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string) ([]byte, error) {
+
+	restored, err := client.Directory().DeletedItems().ByDirectoryObjectId(userId).Restore().Post(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error restoring user: %w", err)
+	}
+
+	restoreData := map[string]interface{}{
+		"userId":  userId,
+		"success": true,
+	}
+	if user, ok := restored.(models.Userable); ok {
+		if displayName := user.GetDisplayName(); displayName != nil {
+			restoreData["displayName"] = *displayName
+		}
+		if userPrincipalName := user.GetUserPrincipalName(); userPrincipalName != nil {
+			restoreData["userPrincipalName"] = *userPrincipalName
+		}
+	}
+
+	return json.MarshalIndent(restoreData, "", "  ")
+}
@@ -0,0 +1,34 @@
+package users
+
+import (
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+func TestRestoreUser(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		userId  string
+		wantErr bool
+	}{
+		{"object id", "9f4b6f2c-3b4a-4a8e-8f4e-2a8f1e6c7d10", false},
+		{"user principal name", "adelev@contoso.com", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := graphtest.CallTool(t, "restore_user", map[string]interface{}{"user_id": tt.userId}, graphtest.Routes{
+				"POST /v1.0/directory/deletedItems/9f4b6f2c-3b4a-4a8e-8f4e-2a8f1e6c7d10/restore": map[string]interface{}{
+					"@odata.type":       "#microsoft.graph.user",
+					"id":                "9f4b6f2c-3b4a-4a8e-8f4e-2a8f1e6c7d10",
+					"userPrincipalName": "adelev@contoso.com",
+				},
+			})
+			if result.IsError != tt.wantErr {
+				t.Errorf("IsError = %v, want %v: %+v", result.IsError, tt.wantErr, result.Content)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+)
+
+func init() {
+	// Sign In Activity Tool is a tool that interacts with microsoft for user sign-in activity APIs.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "user_sign_in_activity",
+			Tool: mcp.NewTool("user_sign_in_activity",
+				mcp.WithDescription("List the last interactive and non-interactive sign-in of users, optionally only those who have not signed in since a date, to find stale accounts. Requires AuditLog.Read.All and User.Read.All."),
+				mcp.WithString("inactive_since",
+					mcp.Description("Only return users whose last sign-in is before this date (YYYY-MM-DD or RFC 3339). Users with no recorded sign-in are never matched by Microsoft Graph: list all users and use the clientFilter \"lastSignInDateTime eq null\" to find them."),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
+			),
+			RequiredScopes: []string{"AuditLog.Read.All", "User.Read.All"},
+			OutputSchema:   signInActivitySchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a signInActivityArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				jsonData, err := GetSignInActivity(ctx, client, a.since)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get user sign-in activity"), err
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// signInActivitySchema describes the result of the user_sign_in_activity tool.
+var signInActivitySchema = schema.Map(schema.Object(map[string]schema.Schema{
+	"id":                               schema.String(),
+	"displayName":                      schema.String(),
+	"userPrincipalName":                schema.String(),
+	"accountEnabled":                   schema.Boolean(),
+	"userType":                         schema.String(),
+	"createdDateTime":                  schema.DateTime(),
+	"lastSignInDateTime":               schema.Nullable(schema.DateTime()),
+	"lastNonInteractiveSignInDateTime": schema.Nullable(schema.DateTime()),
+	"lastSuccessfulSignInDateTime":     schema.Nullable(schema.DateTime()),
+}))
+
+// signInActivityArgs are the arguments of the user_sign_in_activity tool.
+type signInActivityArgs struct {
+	InactiveSince string `json:"inactive_since"`
+
+	since *time.Time
+}
+
+// Validate parses the date the users must have been inactive since, when given.
+func (a *signInActivityArgs) Validate() error {
+
+	if a.InactiveSince != "" {
+		since, err := parseDate(a.InactiveSince)
+		if err != nil {
+			return fmt.Errorf("invalid inactive_since '%s', expected YYYY-MM-DD or RFC 3339", a.InactiveSince)
+		}
+		a.since = &since
+	}
+
+	return nil
+}
+
+// GetSignInActivity retrieves the sign-in activity of users, only the ones who have not signed in
+// since the given date if set. Filtering on signInActivity is an advanced query, it requires the
+// ConsistencyLevel header and $count.
+func GetSignInActivity(ctx context.Context, client *msgraphsdk.GraphServiceClient, since *time.Time) ([]byte, error) {
+
+	params := &users.UsersRequestBuilderGetQueryParameters{
+		Select: []string{"id", "displayName", "userPrincipalName", "accountEnabled", "userType", "createdDateTime", "signInActivity"},
+	}
+	if since != nil {
+		params.Filter = to.Ptr(fmt.Sprintf("signInActivity/lastSignInDateTime le %s", since.UTC().Format(time.RFC3339)))
+		params.Count = to.Ptr(true)
+	}
+
+	headers := abstractions.NewRequestHeaders()
+	headers.Add("ConsistencyLevel", "eventual")
+
+	result, err := client.Users().Get(ctx, &users.UsersRequestBuilderGetRequestConfiguration{
+		Headers:         headers,
+		QueryParameters: params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching users sign-in activity: %v", err)
+	}
+
+	// Create a map to store the JSON-friendly data
+	activityData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateUserCollectionResponseFromDiscriminatorValue, func(user models.Userable) bool {
+		id, userData := convertSignInActivityToMap(user)
+		activityData[id] = userData
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through users sign-in activity: %v", err)
+	}
+
+	return json.MarshalIndent(activityData, "", "  ")
+}
+
+// parseDate parses a date given as YYYY-MM-DD or RFC 3339.
+func parseDate(value string) (time.Time, error) {
+
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// convertSignInActivityToMap converts a user model to a map with its sign-in activity.
+// Users with no recorded activity have null sign-in dates.
+func convertSignInActivityToMap(user models.Userable) (string, map[string]interface{}) {
+
+	userId := ""
+	userData := map[string]interface{}{
+		"lastSignInDateTime":               nil,
+		"lastNonInteractiveSignInDateTime": nil,
+		"lastSuccessfulSignInDateTime":     nil,
+	}
+
+	if id := user.GetId(); id != nil {
+		userId = *id
+		userData["id"] = userId
+	}
+	if displayName := user.GetDisplayName(); displayName != nil {
+		userData["displayName"] = *displayName
+	}
+	if userPrincipalName := user.GetUserPrincipalName(); userPrincipalName != nil {
+		userData["userPrincipalName"] = *userPrincipalName
+	}
+	if accountEnabled := user.GetAccountEnabled(); accountEnabled != nil {
+		userData["accountEnabled"] = *accountEnabled
+	}
+	if userType := user.GetUserType(); userType != nil {
+		userData["userType"] = *userType
+	}
+	if createdDateTime := user.GetCreatedDateTime(); createdDateTime != nil {
+		userData["createdDateTime"] = createdDateTime.Format(time.RFC3339)
+	}
+	if activity := user.GetSignInActivity(); activity != nil {
+		if lastSignInDateTime := activity.GetLastSignInDateTime(); lastSignInDateTime != nil {
+			userData["lastSignInDateTime"] = lastSignInDateTime.Format(time.RFC3339)
+		}
+		if lastNonInteractiveSignInDateTime := activity.GetLastNonInteractiveSignInDateTime(); lastNonInteractiveSignInDateTime != nil {
+			userData["lastNonInteractiveSignInDateTime"] = lastNonInteractiveSignInDateTime.Format(time.RFC3339)
+		}
+		if lastSuccessfulSignInDateTime := activity.GetLastSuccessfulSignInDateTime(); lastSuccessfulSignInDateTime != nil {
+			userData["lastSuccessfulSignInDateTime"] = lastSuccessfulSignInDateTime.Format(time.RFC3339)
+		}
+	}
+
+	return userId, userData
+}
@@ -0,0 +1,155 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+func init() {
+	// Update User Tool is a tool that updates the job and account attributes of a user.
+	collection.RegisterTool(
+		collection.Tool{
+			Name: "update_user",
+			Tool: mcp.NewTool("update_user",
+				mcp.WithDescription("Update the job information, mobile phone or sign-in state of a user. Only the given fields are changed, the others keep their value. Use account_enabled=false to block the sign-in of a departing user. Other profile attributes are updated with update_user_attributes. Requires User.ReadWrite.All."),
+				mcp.WithString("user_id",
+					mcp.Required(),
+					mcp.Description("The id or user principal name of the user."),
+				),
+				mcp.WithString("job_title",
+					mcp.Description("The job title of the user."),
+				),
+				mcp.WithString("department",
+					mcp.Description("The department of the user."),
+				),
+				mcp.WithString("office_location",
+					mcp.Description("The office location of the user."),
+				),
+				mcp.WithString("mobile_phone",
+					mcp.Description("The mobile phone number of the user."),
+				),
+				mcp.WithBoolean("account_enabled",
+					mcp.Description("Whether the user can sign in."),
+				),
+			),
+			Write:          true,
+			RequiredScopes: []string{"User.ReadWrite.All"},
+			OutputSchema:   updateUserSchema,
+			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+				if client == nil {
+					return mcp.NewToolResultError("client not found"), nil
+				}
+
+				var a updateUserArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				user, updated := a.user()
+
+				jsonData, err := UpdateUser(ctx, client, a.UserId, user, updated)
+				if err != nil {
+					if odata.StatusCode(err) == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("the user '%s' does not exist", a.UserId)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to update user: %s", userErrorMessage(err))), nil
+				}
+
+				return mcp.NewToolResultText(string(jsonData)), nil
+			},
+		},
+	)
+}
+
+// updateUserSchema describes the result of the update_user tool.
+var updateUserSchema = schema.Object(map[string]schema.Schema{
+	"userId":  schema.String(),
+	"success": schema.Boolean(),
+	"updated": schema.Object(map[string]schema.Schema{
+		"jobTitle":       schema.String(),
+		"department":     schema.String(),
+		"officeLocation": schema.String(),
+		"mobilePhone":    schema.String(),
+		"accountEnabled": schema.Boolean(),
+	}),
+})
+
+// updateUserArgs are the arguments of the update_user tool. The fields not given are left nil.
+type updateUserArgs struct {
+	UserId         string  `json:"user_id"`
+	JobTitle       *string `json:"job_title"`
+	Department     *string `json:"department"`
+	OfficeLocation *string `json:"office_location"`
+	MobilePhone    *string `json:"mobile_phone"`
+	AccountEnabled *bool   `json:"account_enabled"`
+}
+
+// Validate checks that the user and at least one field to update are given.
+func (a *updateUserArgs) Validate() error {
+
+	if a.UserId == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	if a.JobTitle == nil && a.Department == nil && a.OfficeLocation == nil && a.MobilePhone == nil && a.AccountEnabled == nil {
+		return fmt.Errorf("no fields to update: give at least one of job_title, department, office_location, mobile_phone or account_enabled")
+	}
+
+	return nil
+}
+
+// user builds the user update with only the given fields set, and returns them by attribute name.
+func (a *updateUserArgs) user() (models.Userable, map[string]interface{}) {
+
+	user := models.NewUser()
+	updated := make(map[string]interface{})
+
+	if a.JobTitle != nil {
+		user.SetJobTitle(a.JobTitle)
+		updated["jobTitle"] = *a.JobTitle
+	}
+	if a.Department != nil {
+		user.SetDepartment(a.Department)
+		updated["department"] = *a.Department
+	}
+	if a.OfficeLocation != nil {
+		user.SetOfficeLocation(a.OfficeLocation)
+		updated["officeLocation"] = *a.OfficeLocation
+	}
+	if a.MobilePhone != nil {
+		user.SetMobilePhone(a.MobilePhone)
+		updated["mobilePhone"] = *a.MobilePhone
+	}
+	if a.AccountEnabled != nil {
+		user.SetAccountEnabled(a.AccountEnabled)
+		updated["accountEnabled"] = *a.AccountEnabled
+	}
+
+	return user, updated
+}
+
+// UpdateUser patches a user with the given update and returns the updated fields.
+func UpdateUser(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, user models.Userable, updated map[string]interface{}) ([]byte, error) {
+
+	if _, err := client.Users().ByUserId(userId).Patch(ctx, user, nil); err != nil {
+		return nil, fmt.Errorf("error updating user: %w", err)
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"userId":  userId,
+		"success": true,
+		"updated": updated,
+	}, "", "  ")
+}
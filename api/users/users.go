@@ -3,13 +3,24 @@ package users
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
 	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
 	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
 	"github.com/mark3labs/mcp-go/mcp"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	jsonserialization "github.com/microsoft/kiota-serialization-json-go"
 	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
-	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/microsoftgraph/msgraph-sdk-go/users"
 )
@@ -20,11 +31,55 @@ func init() {
 		collection.Tool{
 			Name: "users",
 			Tool: mcp.NewTool("users",
-				mcp.WithDescription("Interact with Microsoft Graph API for user operations"),
+				mcp.WithDescription("Read Microsoft Entra ID users. Pick the operation with 'mode'. Creating, updating or resetting the password of users are separate tools, exposed when write operations are enabled."),
+				mcp.WithString("mode",
+					mcp.Enum("list", "get", "search", "delta"),
+					mcp.DefaultString("list"),
+					mcp.Description("The operation to run. "+
+						"'list' returns all users, or the ones with the given 'name'. "+
+						"'get' returns the single user with the given 'id', and accepts the 'etag' of a previous lookup. "+
+						"'search' returns the users whose display name, mail or user principal name contains 'query'. "+
+						"'delta' returns the users created, updated or deleted since the 'delta_link' of a previous call, or all users and a first delta link if none is given."),
+				),
 				mcp.WithString("name",
-					mcp.Description("The name of the user. If not provided, all users will be returned."),
+					mcp.Description("list: the given name of the users to return. If not provided, all users will be returned."),
+				),
+				odata.WithMatchMode(),
+				paginate.WithLimit(),
+				mcp.WithNumber("top",
+					mcp.Description(fmt.Sprintf("list: the number of users to return, to get a sample of a large directory. It is also the page size asked to Graph, up to %d. Without top nor limit, all the users are returned, up to the server default limit when no name is given.", maxPageSize)),
+				),
+				mcp.WithString("id",
+					mcp.Description("get: the id or user principal name of the user to return."),
+				),
+				mcp.WithString("etag",
+					mcp.Description("get: the etag returned by a previous lookup of the same user. If the user has not changed, it is reported as not modified instead of being returned again."),
+				),
+				mcp.WithString("select",
+					mcp.Description("list, get, search: comma separated list of the properties to return, e.g. id,displayName,mail, instead of the default ones. The id is always returned. Properties Graph does not know are left out with a warning."),
+				),
+				mcp.WithBoolean("include_status",
+					mcp.Description("list, get: also return why an account may be disabled or blocked: accountEnabled, onPremisesSyncEnabled, creationType, externalUserState for guests and employeeLeaveDateTime. employeeLeaveDateTime requires User-LifeCycleInfo.Read.All."),
+				),
+				mcp.WithBoolean("with_groups",
+					mcp.Description("list, get, search: also return the groups each user is a direct member of, as memberOf. This makes one more request per user, avoid it on large listings. Requires GroupMember.Read.All."),
+				),
+				mcp.WithBoolean("expand_org",
+					mcp.Description("list, get, search: also return the reporting relationships of each user: its manager, left out if it has none, and its direct reports. This makes more requests per user, avoid it on large listings."),
+				),
+				mcp.WithString("query",
+					mcp.Description("search: the text to look for."),
 				),
+				mcp.WithString("delta_link",
+					mcp.Description("delta: the deltaLink returned by a previous delta call."),
+				),
+				output.WithKeyBy(),
+				output.WithGroupBy(),
+				output.WithClientFilter(),
+				output.WithClientSort(),
+				trace.WithDebug(),
 			),
+			OutputSchema: userSchema,
 			Processor: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 
 				client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
@@ -32,24 +87,250 @@ func init() {
 					return mcp.NewToolResultError("client not found"), nil
 				}
 
-				params := &users.UsersRequestBuilderGetQueryParameters{}
-				if name, ok := request.Params.Arguments["name"]; ok {
-					params.Filter = to.Ptr("givenName eq '" + name.(string) + "'")
+				var a usersArgs
+				if err := args.Decode(request, &a); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				fields := a.fields
+				if a.IncludeStatus {
+					if len(fields) == 0 {
+						fields = slices.Clone(defaultFields)
+					}
+					fields = append(fields, statusFields...)
+				}
+
+				var expansions []Expansion
+				if a.WithGroups {
+					expansions = append(expansions, WithMemberOf)
 				}
-				// Get the list of users
-				jsonData, err := Get(ctx, client, params)
-				if err != nil {
-					return mcp.NewToolResultError("failed to get users"), err
+				if a.ExpandOrg {
+					expansions = append(expansions, WithOrg)
 				}
 
-				return mcp.NewToolResultText(string(jsonData)), nil
+				switch a.Mode {
+				case "list":
+					params := &users.UsersRequestBuilderGetQueryParameters{}
+					if a.Top > 0 {
+						params.Top = to.Ptr(int32(min(a.Top, maxPageSize)))
+					}
+					if a.Name != "" {
+						filter, search, err := odata.Match("givenName", a.Name, a.MatchMode)
+						if err != nil {
+							return mcp.NewToolResultError(err.Error()), nil
+						}
+						params.Filter, params.Search = filter, search
+					}
+					limit, capped := paginate.Cap(a.Limit, params.Filter != nil || params.Search != nil || paginate.Aggregated(request))
+					// Get the list of users
+					var jsonData []byte
+					dropped, err := odata.SelectWithFallback(fields, func(fields []string) (err error) {
+						params.Select = fields
+						jsonData, err = Get(ctx, client, params, limit, expansions...)
+						return err
+					})
+					if err != nil {
+						return mcp.NewToolResultError("failed to get users"), err
+					}
+					result := odata.SelectNote(mcp.NewToolResultText(string(jsonData)), dropped)
+					if capped {
+						return paginate.CappedNote(result, limit), nil
+					}
+					return result, nil
+				case "get":
+					var jsonData []byte
+					dropped, err := odata.SelectWithFallback(fields, func(fields []string) (err error) {
+						jsonData, err = GetById(ctx, client, a.Id, a.ETag, fields, expansions...)
+						return err
+					})
+					if err != nil {
+						return mcp.NewToolResultError("failed to get user"), err
+					}
+					return odata.SelectNote(mcp.NewToolResultText(string(jsonData)), dropped), nil
+				case "search":
+					var jsonData []byte
+					dropped, err := odata.SelectWithFallback(fields, func(fields []string) (err error) {
+						jsonData, err = Search(ctx, client, a.Query, fields, expansions...)
+						return err
+					})
+					if err != nil {
+						return mcp.NewToolResultError("failed to search users"), err
+					}
+					return odata.SelectNote(mcp.NewToolResultText(string(jsonData)), dropped), nil
+				default:
+					jsonData, err := GetDelta(ctx, client, a.DeltaLink)
+					if err != nil {
+						return mcp.NewToolResultError("failed to get users delta"), err
+					}
+					return mcp.NewToolResultText(string(jsonData)), nil
+				}
 			},
 		},
 	)
 }
 
-// Get retrieves all users from Microsoft Graph and returns their preferred names or IDs.
-func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *users.UsersRequestBuilderGetQueryParameters) ([]byte, error) {
+// usersArgs are the arguments of the users tool.
+type usersArgs struct {
+	Mode          string `json:"mode"`
+	Name          string `json:"name"`
+	MatchMode     string `json:"matchMode"`
+	Limit         int    `json:"limit"`
+	Top           int    `json:"top"`
+	Id            string `json:"id"`
+	ETag          string `json:"etag"`
+	Select        string `json:"select"`
+	IncludeStatus bool   `json:"include_status"`
+	WithGroups    bool   `json:"with_groups"`
+	ExpandOrg     bool   `json:"expand_org"`
+	Query         string `json:"query"`
+	DeltaLink     string `json:"delta_link"`
+
+	fields []string
+}
+
+// Validate defaults the mode, an id alone being a single user lookup, and checks that the
+// arguments the mode needs are given.
+func (a *usersArgs) Validate() error {
+
+	if a.Mode == "" {
+		a.Mode = "list"
+		if a.Id != "" {
+			a.Mode = "get"
+		}
+	}
+	if a.Limit < 0 {
+		return fmt.Errorf("limit must be a positive number")
+	}
+	if a.Top < 0 {
+		return fmt.Errorf("top must be a positive number")
+	}
+	// The listing stops once top users are returned, whichever of top and limit is lower
+	if a.Top > 0 && (a.Limit == 0 || a.Top < a.Limit) {
+		a.Limit = a.Top
+	}
+
+	if a.Select != "" {
+		fields, err := odata.Fields(strings.Split(a.Select, ","), nil)
+		if err != nil {
+			return err
+		}
+		// Users are keyed by id, it is selected even if not asked for
+		if len(fields) > 0 && !slices.ContainsFunc(fields, func(field string) bool { return strings.EqualFold(field, "id") }) {
+			fields = append([]string{"id"}, fields...)
+		}
+		a.fields = fields
+	}
+
+	switch a.Mode {
+	case "list":
+		if a.MatchMode == "" {
+			a.MatchMode = odata.MatchExact
+		}
+	case "get":
+		if a.Id == "" {
+			return fmt.Errorf("id is required in get mode")
+		}
+	case "search":
+		if a.Query = odata.SearchTerm(a.Query); a.Query == "" {
+			return fmt.Errorf("query is required in search mode")
+		}
+	case "delta":
+	default:
+		return fmt.Errorf("unsupported mode '%s'", a.Mode)
+	}
+
+	return nil
+}
+
+// maxPageSize is the largest page of users Graph returns.
+const maxPageSize = 999
+
+// defaultFields are the attributes Graph returns for a user when none is selected.
+var defaultFields = []string{
+	"id", "displayName", "userPrincipalName", "mail", "givenName", "surname", "jobTitle",
+	"mobilePhone", "officeLocation", "businessPhones", "preferredLanguage",
+}
+
+// statusFields are the attributes explaining the state of an account, only returned when selected.
+var statusFields = []string{
+	"accountEnabled", "onPremisesSyncEnabled", "creationType", "externalUserState",
+	"externalUserStateChangeDateTime", "employeeLeaveDateTime",
+}
+
+// userProperties are the attributes of a user.
+var userProperties = map[string]schema.Schema{
+	"id":                              schema.String(),
+	"displayName":                     schema.String(),
+	"userPrincipalName":               schema.String(),
+	"mail":                            schema.String(),
+	"givenName":                       schema.String(),
+	"surname":                         schema.String(),
+	"jobTitle":                        schema.String(),
+	"mobilePhone":                     schema.String(),
+	"officeLocation":                  schema.String(),
+	"businessPhones":                  schema.Array(schema.String()),
+	"accountEnabled":                  schema.Boolean(),
+	"city":                            schema.String(),
+	"country":                         schema.String(),
+	"department":                      schema.String(),
+	"companyName":                     schema.String(),
+	"streetAddress":                   schema.String(),
+	"postalCode":                      schema.String(),
+	"state":                           schema.String(),
+	"preferredLanguage":               schema.String(),
+	"employeeId":                      schema.String(),
+	"onPremisesSyncEnabled":           schema.Boolean(),
+	"creationType":                    schema.String(),
+	"externalUserState":               schema.String(),
+	"externalUserStateChangeDateTime": schema.DateTime(),
+	"employeeLeaveDateTime":           schema.DateTime(),
+	"etag":                            schema.String(),
+	"notModified":                     schema.Boolean(),
+	"removed":                         schema.Boolean(),
+	"memberOf": schema.Array(schema.Object(map[string]schema.Schema{
+		"id":          schema.String(),
+		"displayName": schema.String(),
+	})),
+	"manager": schema.Object(map[string]schema.Schema{
+		"id":          schema.String(),
+		"displayName": schema.String(),
+	}),
+	"directReports": schema.Array(schema.Object(map[string]schema.Schema{
+		"id":          schema.String(),
+		"displayName": schema.String(),
+	})),
+}
+
+// userSchema describes the result of the users tool: users keyed by id, or the users
+// and the next delta link in delta mode.
+var userSchema = schema.OneOf(
+	schema.Map(schema.Object(userProperties)),
+	schema.Object(map[string]schema.Schema{
+		"users":     schema.Map(schema.Object(userProperties)),
+		"deltaLink": schema.String(),
+	}),
+)
+
+// Expansion attaches the objects related to a user, looked up by its id, to the map of the user.
+type Expansion func(ctx context.Context, client *msgraphsdk.GraphServiceClient, userId string, userData map[string]interface{}) error
+
+// expand applies the expansions to each of the users, keyed by id.
+func expand(ctx context.Context, client *msgraphsdk.GraphServiceClient, usersData map[string]interface{}, expansions []Expansion) error {
+
+	for userId, userData := range usersData {
+		for _, expansion := range expansions {
+			if err := expansion(ctx, client, userId, userData.(map[string]interface{})); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Get retrieves all users from Microsoft Graph, or the first limit ones if it is not zero,
+// and returns their preferred names or IDs, with the expansions applied.
+func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *users.UsersRequestBuilderGetQueryParameters, limit int, expansions ...Expansion) ([]byte, error) {
 
 	if params == nil {
 		params = &users.UsersRequestBuilderGetQueryParameters{}
@@ -58,35 +339,67 @@ func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *use
 	requestConfig := &users.UsersRequestBuilderGetRequestConfiguration{
 		QueryParameters: params,
 	}
+	// $search is an advanced query, it requires the ConsistencyLevel header
+	if params.Search != nil {
+		requestConfig.Headers = abstractions.NewRequestHeaders()
+		requestConfig.Headers.Add("ConsistencyLevel", "eventual")
+	}
 
 	result, err := client.Users().Get(ctx, requestConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get the users from the result
-	users := result.GetValue()
-	if users == nil {
-		return nil, err
-	}
-
 	// Create a map to store the JSON-friendly data
 	usersData := make(map[string]interface{})
 
-	// Convert each user to a map of attributes
-	for _, user := range users {
-		id, userData := convertUserToMap(user)
+	// Convert each user of every page to a map of attributes
+	err = paginate.Iterate(ctx, client, result, models.CreateUserCollectionResponseFromDiscriminatorValue, func(user models.Userable) bool {
+		id, userData := convertUserToMap(user, params.Select...)
 		usersData[id] = userData
+		return limit == 0 || len(usersData) < limit
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := expand(ctx, client, usersData, expansions); err != nil {
+		return nil, err
 	}
 
-	// Use PageIterator to iterate through all users
-	pageIterator, err := msgraphcore.NewPageIterator[models.Userable](result, client.GetAdapter(), models.CreateUserCollectionResponseFromDiscriminatorValue)
+	// Convert the user data to JSON
+	return json.MarshalIndent(usersData, "", "  ")
+}
+
+// Search retrieves the users whose display name, mail or user principal name contains the query,
+// with the given fields or the default ones. $search is an advanced query, it requires the
+// ConsistencyLevel header.
+func Search(ctx context.Context, client *msgraphsdk.GraphServiceClient, query string, fields []string, expansions ...Expansion) ([]byte, error) {
+
+	headers := abstractions.NewRequestHeaders()
+	headers.Add("ConsistencyLevel", "eventual")
+
+	result, err := client.Users().Get(ctx, &users.UsersRequestBuilderGetRequestConfiguration{
+		Headers: headers,
+		QueryParameters: &users.UsersRequestBuilderGetQueryParameters{
+			Search: to.Ptr(strings.Join([]string{
+				odata.Search("displayName", query),
+				odata.Search("mail", query),
+				odata.Search("userPrincipalName", query),
+			}, " OR ")),
+			Count:  to.Ptr(true),
+			Select: fields,
+		},
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	err = pageIterator.Iterate(context.Background(), func(user models.Userable) bool {
-		id, userData := convertUserToMap(user)
+	// Create a map to store the JSON-friendly data
+	usersData := make(map[string]interface{})
+
+	err = paginate.Iterate(ctx, client, result, models.CreateUserCollectionResponseFromDiscriminatorValue, func(user models.Userable) bool {
+		id, userData := convertUserToMap(user, fields...)
 		usersData[id] = userData
 		return true
 	})
@@ -94,12 +407,59 @@ func Get(ctx context.Context, client *msgraphsdk.GraphServiceClient, params *use
 		return nil, err
 	}
 
-	// Convert the user data to JSON
+	if err := expand(ctx, client, usersData, expansions); err != nil {
+		return nil, err
+	}
+
 	return json.MarshalIndent(usersData, "", "  ")
 }
 
-// convertUserToMap converts a user model to a map with all attributes
-func convertUserToMap(user models.Userable) (string, map[string]interface{}) {
+// GetById retrieves a single user, with the given fields or the default ones. If etag is set
+// and the user has not changed since, Graph answers 304 and the user is reported as not modified.
+func GetById(ctx context.Context, client *msgraphsdk.GraphServiceClient, id string, etag string, fields []string, expansions ...Expansion) ([]byte, error) {
+
+	conditional := odata.NewConditional(etag)
+
+	user, err := client.Users().ByUserId(id).Get(ctx, &users.UserItemRequestBuilderGetRequestConfiguration{
+		Headers: conditional.Headers,
+		Options: conditional.Options,
+		QueryParameters: &users.UserItemRequestBuilderGetQueryParameters{
+			Select: fields,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Graph answered 304 Not Modified
+	if user == nil {
+		return json.MarshalIndent(map[string]interface{}{
+			id: map[string]interface{}{
+				"id":          id,
+				"etag":        etag,
+				"notModified": true,
+			},
+		}, "", "  ")
+	}
+
+	userId, userData := convertUserToMap(user, fields...)
+	if responseETag := conditional.ETag(); responseETag != "" {
+		userData["etag"] = responseETag
+	} else if odataETag, ok := user.GetAdditionalData()["@odata.etag"].(*string); ok && odataETag != nil {
+		userData["etag"] = *odataETag
+	}
+
+	usersData := map[string]interface{}{userId: userData}
+	if err := expand(ctx, client, usersData, expansions); err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(usersData, "", "  ")
+}
+
+// convertUserToMap converts a user model to a map with all attributes, or only the given fields
+// and the additional data if any is given.
+func convertUserToMap(user models.Userable, fields ...string) (string, map[string]interface{}) {
 
 	userId := ""
 	userData := make(map[string]interface{})
@@ -167,6 +527,23 @@ func convertUserToMap(user models.Userable) (string, map[string]interface{}) {
 		userData["employeeId"] = *employeeId
 	}
 
+	// Account status properties, only returned when selected
+	if onPremisesSyncEnabled := user.GetOnPremisesSyncEnabled(); onPremisesSyncEnabled != nil {
+		userData["onPremisesSyncEnabled"] = *onPremisesSyncEnabled
+	}
+	if creationType := user.GetCreationType(); creationType != nil {
+		userData["creationType"] = *creationType
+	}
+	if externalUserState := user.GetExternalUserState(); externalUserState != nil {
+		userData["externalUserState"] = *externalUserState
+	}
+	if externalUserStateChangeDateTime := user.GetExternalUserStateChangeDateTime(); externalUserStateChangeDateTime != nil {
+		userData["externalUserStateChangeDateTime"] = externalUserStateChangeDateTime.Format(time.RFC3339)
+	}
+	if employeeLeaveDateTime := user.GetEmployeeLeaveDateTime(); employeeLeaveDateTime != nil {
+		userData["employeeLeaveDateTime"] = employeeLeaveDateTime.Format(time.RFC3339)
+	}
+
 	// Add any additional properties available through the GetAdditionalData method
 	// This can include custom attributes
 	if additionalData := user.GetAdditionalData(); additionalData != nil {
@@ -175,5 +552,70 @@ func convertUserToMap(user models.Userable) (string, map[string]interface{}) {
 		}
 	}
 
+	if len(fields) > 0 {
+		userData = selectUserFields(user, userData, fields)
+	}
+
 	return userId, userData
 }
+
+// selectUserFields reduces the map of a user to the selected fields and the additional data.
+// The fields convertUserToMap does not convert are read from the JSON serialization of the user.
+func selectUserFields(user models.Userable, userData map[string]interface{}, fields []string) map[string]interface{} {
+
+	selected := make(map[string]interface{})
+
+	var serialized map[string]interface{}
+	for _, field := range fields {
+		if key, value, ok := lookupField(userData, field); ok {
+			selected[key] = value
+			continue
+		}
+		if serialized == nil {
+			serialized = serializeUser(user)
+		}
+		if key, value, ok := lookupField(serialized, field); ok {
+			selected[key] = value
+		}
+	}
+
+	for key, value := range user.GetAdditionalData() {
+		selected[key] = value
+	}
+
+	return selected
+}
+
+// serializeUser returns the JSON serialization of the user as a map, or an empty map if it cannot be serialized.
+func serializeUser(user models.Userable) map[string]interface{} {
+
+	serialized := make(map[string]interface{})
+
+	writer := jsonserialization.NewJsonSerializationWriter()
+	defer writer.Close()
+	if err := writer.WriteObjectValue("", user); err != nil {
+		return serialized
+	}
+	content, err := writer.GetSerializedContent()
+	if err != nil {
+		return serialized
+	}
+	_ = json.Unmarshal(content, &serialized)
+
+	return serialized
+}
+
+// lookupField returns the key and value of the field in the map, property names being case insensitive.
+func lookupField(data map[string]interface{}, field string) (string, interface{}, bool) {
+
+	if value, ok := data[field]; ok {
+		return field, value, true
+	}
+	for key, value := range data {
+		if strings.EqualFold(key, field) {
+			return key, value, true
+		}
+	}
+
+	return "", nil, false
+}
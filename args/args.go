@@ -0,0 +1,108 @@
+package args
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Validator is implemented by the argument structs checking their values once decoded.
+type Validator interface {
+	Validate() error
+}
+
+// Decode decodes the arguments of the request into v, a pointer to a struct whose fields are
+// tagged with the argument names. The fields of the arguments not given keep their value, so
+// defaults are set on v before decoding. If v is a Validator, it is validated once decoded.
+// The error is meant to be returned to the caller as is.
+//
+// Arguments without a field are ignored, as the output options are read by the middlewares, but
+// arguments matching a field in another case are rejected: JSON decoding is case insensitive, so
+// they would otherwise silently replace the argument with the exact name.
+func Decode(request mcp.CallToolRequest, v interface{}) error {
+
+	if len(request.Params.Arguments) > 0 {
+		names := fieldNames(reflect.TypeOf(v))
+		for argument := range request.Params.Arguments {
+			if slices.Contains(names, argument) {
+				continue
+			}
+			for _, name := range names {
+				if strings.EqualFold(name, argument) {
+					return fmt.Errorf("unknown argument '%s', did you mean '%s'?", argument, name)
+				}
+			}
+		}
+
+		data, err := json.Marshal(request.Params.Arguments)
+		if err != nil {
+			return fmt.Errorf("invalid arguments: %v", err)
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				return fmt.Errorf("argument '%s' must be a %s, not a %s", typeErr.Field, kind(typeErr.Type.Kind().String()), typeErr.Value)
+			}
+			return fmt.Errorf("invalid arguments: %v", err)
+		}
+	}
+
+	if validator, ok := v.(Validator); ok {
+		return validator.Validate()
+	}
+
+	return nil
+}
+
+// fieldNames returns the JSON names of the fields of a struct, or of the struct pointed to.
+func fieldNames(t reflect.Type) []string {
+
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	names := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch {
+		case name == "-":
+		case field.Anonymous && name == "":
+			names = append(names, fieldNames(field.Type)...)
+		case !field.IsExported():
+		case name == "":
+			names = append(names, field.Name)
+		default:
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// kind returns the JSON name of a Go kind.
+func kind(goKind string) string {
+
+	switch goKind {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return "whole number"
+	case "float32", "float64":
+		return "number"
+	case "bool":
+		return "boolean"
+	case "slice", "array":
+		return "list"
+	case "map", "struct":
+		return "object"
+	default:
+		return goKind
+	}
+}
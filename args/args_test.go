@@ -0,0 +1,112 @@
+package args
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// testArgs are sample arguments, checked once decoded.
+type testArgs struct {
+	Name      string   `json:"name"`
+	MatchMode string   `json:"matchMode"`
+	Limit     int      `json:"limit"`
+	Enabled   bool     `json:"enabled"`
+	Ids       []string `json:"ids"`
+}
+
+// Validate rejects a negative limit.
+func (a *testArgs) Validate() error {
+
+	if a.Limit < 0 {
+		return fmt.Errorf("limit must be a positive number")
+	}
+
+	return nil
+}
+
+func TestDecode(t *testing.T) {
+
+	defaults := testArgs{MatchMode: "exact", Limit: 100}
+
+	tests := []struct {
+		name      string
+		arguments map[string]interface{}
+		want      testArgs
+		wantErr   string
+	}{
+		{
+			name: "no arguments keeps the defaults",
+			want: testArgs{MatchMode: "exact", Limit: 100},
+		},
+		{
+			name:      "arguments override the defaults",
+			arguments: map[string]interface{}{"name": "Adele", "limit": float64(5), "enabled": true, "ids": []interface{}{"a", "b"}},
+			want:      testArgs{Name: "Adele", MatchMode: "exact", Limit: 5, Enabled: true, Ids: []string{"a", "b"}},
+		},
+		{
+			name:      "unknown arguments ignored",
+			arguments: map[string]interface{}{"name": "Adele", "keyBy": "mail"},
+			want:      testArgs{Name: "Adele", MatchMode: "exact", Limit: 100},
+		},
+		{
+			name:      "string instead of number",
+			arguments: map[string]interface{}{"limit": "5"},
+			wantErr:   "argument 'limit' must be a whole number, not a string",
+		},
+		{
+			name:      "number instead of boolean",
+			arguments: map[string]interface{}{"enabled": float64(1)},
+			wantErr:   "argument 'enabled' must be a boolean, not a number",
+		},
+		{
+			name:      "string instead of list",
+			arguments: map[string]interface{}{"ids": "a,b"},
+			wantErr:   "argument 'ids' must be a list, not a string",
+		},
+		{
+			name:      "fraction instead of whole number",
+			arguments: map[string]interface{}{"limit": 1.5},
+			wantErr:   "argument 'limit' must be a whole number, not a number 1.5",
+		},
+		{
+			name:      "argument in another case",
+			arguments: map[string]interface{}{"matchmode": "startswith"},
+			wantErr:   "unknown argument 'matchmode', did you mean 'matchMode'?",
+		},
+		{
+			name:      "arguments differing only in case",
+			arguments: map[string]interface{}{"matchMode": "exact", "matchmode": "startswith"},
+			wantErr:   "unknown argument 'matchmode', did you mean 'matchMode'?",
+		},
+		{
+			name:      "validated",
+			arguments: map[string]interface{}{"limit": float64(-1)},
+			wantErr:   "limit must be a positive number",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := mcp.CallToolRequest{}
+			request.Params.Arguments = test.arguments
+
+			got := defaults
+			err := Decode(request, &got)
+			if test.wantErr != "" {
+				if err == nil || err.Error() != test.wantErr {
+					t.Fatalf("got error %v, want %s", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package beta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+)
+
+// Do sends a request to the beta endpoint of Microsoft Graph, which has no model in the SDK,
+// with the JSON encoding of body if it is not nil, and returns the raw response. The path is
+// relative to the beta root, e.g. /identity/conditionalAccess/evaluate, and may carry a query.
+// Errors are OData errors, so odata.ErrorMessage and odata.StatusCode apply.
+func Do(ctx context.Context, client *msgraphsdk.GraphServiceClient, method abstractions.HttpMethod, path string, body interface{}) ([]byte, error) {
+
+	adapter := client.GetAdapter()
+	endpoint, err := url.Parse(strings.TrimSuffix(adapter.GetBaseUrl(), "/v1.0") + "/beta" + path)
+	if err != nil {
+		return nil, err
+	}
+
+	return DoURL(ctx, client, method, endpoint, body)
+}
+
+// DoURL is Do with the absolute URL of the request, e.g. the next link of a beta listing.
+func DoURL(ctx context.Context, client *msgraphsdk.GraphServiceClient, method abstractions.HttpMethod, endpoint *url.URL, body interface{}) ([]byte, error) {
+
+	info := abstractions.NewRequestInformation()
+	info.Method = method
+	info.SetUri(*endpoint)
+	info.Headers.TryAdd("Accept", "application/json")
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding request: %v", err)
+		}
+		info.SetStreamContentAndContentType(content, "application/json")
+	}
+
+	raw, err := client.GetAdapter().SendPrimitive(ctx, info, "[]byte", abstractions.ErrorMappings{
+		"XXX": odataerrors.CreateODataErrorFromDiscriminatorValue,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	content, _ := raw.([]byte)
+	return content, nil
+}
+
+// Iterate calls fn for each item of every page of a beta collection, decoded as T, following
+// the next links until fn returns false.
+func Iterate[T any](ctx context.Context, client *msgraphsdk.GraphServiceClient, path string, fn func(item T) bool) error {
+
+	content, err := Do(ctx, client, abstractions.GET, path, nil)
+	if err != nil {
+		return err
+	}
+
+	for {
+		var page struct {
+			Value    []T    `json:"value"`
+			NextLink string `json:"@odata.nextLink"`
+		}
+		if err := json.Unmarshal(content, &page); err != nil {
+			return fmt.Errorf("error decoding page: %v", err)
+		}
+		for _, item := range page.Value {
+			if !fn(item) {
+				return nil
+			}
+		}
+
+		if page.NextLink == "" {
+			return nil
+		}
+		next, err := url.Parse(page.NextLink)
+		if err != nil {
+			return fmt.Errorf("error parsing next link: %v", err)
+		}
+		content, err = DoURL(ctx, client, abstractions.GET, next, nil)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -4,9 +4,15 @@ import (
 	"fmt"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	auth "github.com/microsoft/kiota-authentication-azure-go"
 	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
 )
 
+// validHosts are the Microsoft Graph national clouds the token is sent to, as in the SDK.
+var validHosts = []string{"graph.microsoft.com", "graph.microsoft.us", "dod-graph.microsoft.us", "graph.microsoft.de", "microsoftgraph.chinacloudapi.cn", "canary.graph.microsoft.com"}
+
 // GetClient creates a new Microsoft Graph client using the provided credentials.
 func GetClient(tenant, client, clientSecret string) (*msgraphsdk.GraphServiceClient, error) {
 
@@ -21,5 +27,24 @@ func GetClient(tenant, client, clientSecret string) (*msgraphsdk.GraphServiceCli
 		return nil, fmt.Errorf("error creating credentials: %v", err)
 	}
 
-	return msgraphsdk.NewGraphServiceClientWithCredentials(cred, []string{"https://graph.microsoft.com/.default"})
+	authProvider, err := auth.NewAzureIdentityAuthenticationProviderWithScopesAndValidHosts(cred, []string{"https://graph.microsoft.com/.default"}, validHosts)
+	if err != nil {
+		return nil, fmt.Errorf("error creating authentication provider: %v", err)
+	}
+
+	// The default middlewares are kept, the requests are traced once they are final
+	options := msgraphsdk.GetDefaultClientOptions()
+	middlewares := append(msgraphcore.GetDefaultMiddlewaresWithOptions(&options), trace.Handler{})
+
+	adapter, err := msgraphsdk.NewGraphRequestAdapterWithParseNodeFactoryAndSerializationWriterFactoryAndHttpClient(
+		authProvider,
+		nil,
+		nil,
+		msgraphcore.GetDefaultClient(&options, middlewares...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request adapter: %v", err)
+	}
+
+	return msgraphsdk.NewGraphServiceClient(adapter), nil
 }
@@ -1,10 +1,15 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/acuvity/mcp-server-microsoft-graph/api/sites"
+	"github.com/acuvity/mcp-server-microsoft-graph/api/snapshot"
 	"github.com/acuvity/mcp-server-microsoft-graph/client"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -20,7 +25,7 @@ func Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error creating client: %v", err)
 	}
 
-	u, err := sites.Get(cmd.Context(), cl, nil)
+	u, err := sites.Get(cmd.Context(), cl, nil, 0)
 	if err != nil {
 		return fmt.Errorf("error getting sites: %v", err)
 	}
@@ -28,3 +33,122 @@ func Run(cmd *cobra.Command, args []string) error {
 	fmt.Println(string(u))
 	return nil
 }
+
+// ExportPages exports the pages of a site as Markdown files.
+func ExportPages(cmd *cobra.Command, args []string) error {
+
+	cl, err := client.GetClient(
+		viper.GetString("tenant-id"),     // Tenant ID
+		viper.GetString("client-id"),     // Client ID
+		viper.GetString("client-secret"), // Client Secret
+	)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	siteId := viper.GetString("site-id")
+	if siteId == "" {
+		return fmt.Errorf("--site-id is required")
+	}
+
+	files, err := sites.ExportPages(cmd.Context(), cl, siteId, viper.GetString("output-dir"))
+	if err != nil {
+		return fmt.Errorf("error exporting pages: %v", err)
+	}
+
+	for _, file := range files {
+		fmt.Println(file)
+	}
+	return nil
+}
+
+// Snapshot exports a snapshot of the directory to a file, or to the standard output.
+func Snapshot(cmd *cobra.Command, args []string) error {
+
+	cl, err := client.GetClient(
+		viper.GetString("tenant-id"),     // Tenant ID
+		viper.GetString("client-id"),     // Client ID
+		viper.GetString("client-secret"), // Client Secret
+	)
+	if err != nil {
+		return fmt.Errorf("error creating client: %v", err)
+	}
+
+	jsonData, err := snapshot.Take(cmd.Context(), cl, snapshot.Options{
+		MaxItems: viper.GetInt("max-items"),
+		MaxBytes: viper.GetInt("max-bytes"),
+		Timeout:  viper.GetDuration("timeout"),
+	})
+	if err != nil {
+		return fmt.Errorf("error taking snapshot: %v", err)
+	}
+
+	output := viper.GetString("output")
+	if output == "" {
+		fmt.Println(string(jsonData))
+		return nil
+	}
+
+	if err := os.WriteFile(output, jsonData, 0o600); err != nil {
+		return fmt.Errorf("error writing snapshot: %v", err)
+	}
+	fmt.Println(output)
+	return nil
+}
+
+// SnapshotDiff compares two snapshots exported by Snapshot and prints the objects added, removed
+// and modified between them.
+func SnapshotDiff(cmd *cobra.Command, args []string) error {
+
+	oldSnapshot, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("error reading snapshot: %v", err)
+	}
+	newSnapshot, err := os.ReadFile(args[1])
+	if err != nil {
+		return fmt.Errorf("error reading snapshot: %v", err)
+	}
+
+	jsonData, err := snapshot.Diff(oldSnapshot, newSnapshot)
+	if err != nil {
+		return fmt.Errorf("error comparing snapshots: %v", err)
+	}
+
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+// PrintSchema prints the JSON schema of the result of the given tools, or of all the tools.
+func PrintSchema(cmd *cobra.Command, args []string) error {
+
+	names := args
+	if len(names) == 0 {
+		for name := range collection.Tools {
+			names = append(names, name)
+		}
+	}
+
+	schemas := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		tool, ok := collection.Tools[name]
+		if !ok {
+			return fmt.Errorf("unknown tool '%s'", name)
+		}
+		toolSchema := schema.Schema{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"title":   name,
+		}
+		for k, v := range tool.OutputSchema {
+			toolSchema[k] = v
+		}
+		schemas[name] = toolSchema
+	}
+
+	jsonData, err := json.MarshalIndent(schemas, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding schemas: %v", err)
+	}
+
+	fmt.Println(string(jsonData))
+	return nil
+}
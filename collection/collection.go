@@ -3,6 +3,7 @@ package collection
 import (
 	"context"
 
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -11,6 +12,14 @@ type Tool struct {
 	Name      string
 	Tool      mcp.Tool
 	Processor func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+	// Write marks tools that modify the tenant. They are only exposed when --enable-write is set.
+	Write bool
+	// RequiredScopes lists the Microsoft Graph application permissions the tool needs.
+	// They are verified before running a write tool.
+	RequiredScopes []string
+	// OutputSchema is the JSON schema of the tool result, before any output option is applied.
+	OutputSchema schema.Schema
 }
 
 // toolsMap organizes tools in a map
@@ -0,0 +1,36 @@
+package collection
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Defaults maps a tool name to the default values of its arguments.
+type Defaults map[string]map[string]interface{}
+
+// DefaultsMiddleware fills the arguments a caller did not give with the defaults configured for
+// the tool. The arguments given by the caller always win.
+func DefaultsMiddleware(defaults Defaults) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+			toolDefaults := defaults[request.Params.Name]
+			if len(toolDefaults) == 0 {
+				return next(ctx, request)
+			}
+
+			arguments := make(map[string]interface{}, len(toolDefaults)+len(request.Params.Arguments))
+			for name, value := range toolDefaults {
+				arguments[name] = value
+			}
+			for name, value := range request.Params.Arguments {
+				arguments[name] = value
+			}
+			request.Params.Arguments = arguments
+
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package collection
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestDefaultsMiddleware(t *testing.T) {
+
+	defaults := Defaults{
+		"users": {"limit": float64(50), "matchMode": "startswith"},
+	}
+
+	tests := []struct {
+		name      string
+		tool      string
+		arguments map[string]interface{}
+		want      map[string]interface{}
+	}{
+		{"default applied", "users", map[string]interface{}{"name": "Adele"}, map[string]interface{}{"name": "Adele", "limit": float64(50), "matchMode": "startswith"}},
+		{"default applied without arguments", "users", nil, map[string]interface{}{"limit": float64(50), "matchMode": "startswith"}},
+		{"explicit argument wins", "users", map[string]interface{}{"limit": float64(5)}, map[string]interface{}{"limit": float64(5), "matchMode": "startswith"}},
+		{"other tool untouched", "groups", map[string]interface{}{"name": "Sales"}, map[string]interface{}{"name": "Sales"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got map[string]interface{}
+			handler := DefaultsMiddleware(defaults)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				got = request.Params.Arguments
+				return mcp.NewToolResultText(""), nil
+			})
+
+			request := mcp.CallToolRequest{}
+			request.Params.Name = test.tool
+			request.Params.Arguments = test.arguments
+			if _, err := handler(context.Background(), request); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got arguments %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	// The configured defaults are shared by every call, they must not be altered by one
+	if want := (map[string]interface{}{"limit": float64(50), "matchMode": "startswith"}); !reflect.DeepEqual(defaults["users"], want) {
+		t.Errorf("the defaults were modified: %v", defaults["users"])
+	}
+}
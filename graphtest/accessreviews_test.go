@@ -0,0 +1,78 @@
+package graphtest_test
+
+import (
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+const definitionPath = "/v1.0/identityGovernance/accessReviews/definitions/definition-id"
+
+// instance is a sample access review instance in progress.
+var instance = map[string]interface{}{
+	"id":            "instance-id",
+	"status":        "InProgress",
+	"startDateTime": "2024-01-01T00:00:00Z",
+	"endDateTime":   "2024-01-15T00:00:00Z",
+}
+
+// accessreviewsSamples are the sample calls of the tools of the accessreviews package.
+func accessreviewsSamples() []sample {
+
+	return []sample{
+		{
+			tool: "access_reviews",
+			routes: graphtest.Routes{
+				"GET /v1.0/identityGovernance/accessReviews/definitions": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "definition-id", "displayName": "Guests review", "status": "InProgress"},
+					},
+				},
+				"GET " + definitionPath + "/instances": map[string]interface{}{
+					"value": []interface{}{instance, map[string]interface{}{"id": "completed-id", "status": "Completed"}},
+				},
+				"GET " + definitionPath + "/instances/instance-id/decisions": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":             "decision-id",
+							"recommendation": "Deny",
+							"principal":      map[string]interface{}{"@odata.type": "#microsoft.graph.userIdentity", "id": "user-id", "displayName": "Adele Vance"},
+							"resource":       map[string]interface{}{"id": "group-id", "displayName": "Sales"},
+						},
+					},
+				},
+				"GET " + definitionPath + "/instances/instance-id/contactedReviewers": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "reviewer-id", "displayName": "Miriam Graham", "userPrincipalName": "miriam@contoso.com"},
+					},
+				},
+			},
+		},
+		{
+			tool: "access_review_decision",
+			arguments: map[string]interface{}{
+				"definition_id": "definition-id",
+				"instance_id":   "instance-id",
+				"decision_id":   "decision-id",
+				"decision":      "approve",
+				"justification": "Still needed",
+			},
+			routes: graphtest.Routes{
+				"GET " + definitionPath + "/instances/instance-id":                         instance,
+				"PATCH " + definitionPath + "/instances/instance-id/decisions/decision-id": http.StatusNoContent,
+			},
+		},
+		{
+			tool: "access_review_decision",
+			arguments: map[string]interface{}{
+				"definition_id": "definition-id",
+				"instance_id":   "completed-id",
+				"decision_id":   "decision-id",
+				"decision":      "deny",
+			},
+			routes: graphtest.Routes{
+				"GET " + definitionPath + "/instances/completed-id": map[string]interface{}{"id": "completed-id", "status": "Completed"},
+			},
+		},
+	}
+}
@@ -0,0 +1,258 @@
+package graphtest_test
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+	"github.com/spf13/viper"
+)
+
+const (
+	appObjectId           = "10000000-0000-0000-0000-000000000001"
+	appId                 = "10000000-0000-0000-0000-000000000002"
+	spId                  = "10000000-0000-0000-0000-000000000003"
+	applicationsGraphSpId = "10000000-0000-0000-0000-000000000004"
+	userReadAll           = "df021288-bdef-4463-88db-98f22de89214"
+	userRead              = "e1fe6dd8-ba31-4d61-89e7-88639da4683d"
+	secretKeyId           = "10000000-0000-0000-0000-000000000005"
+	certKeyId             = "10000000-0000-0000-0000-000000000006"
+	flowServiceId         = "7df0a125-d3be-4c96-aa54-591f83ff541c"
+	graphAppId            = "00000003-0000-0000-c000-000000000000"
+)
+
+// application is a sample application registration.
+var application = map[string]interface{}{
+	"id":                         appObjectId,
+	"appId":                      appId,
+	"displayName":                "Payroll",
+	"publisherDomain":            "contoso.com",
+	"createdDateTime":            "2024-01-01T00:00:00Z",
+	"applicationTemplateId":      "template-id",
+	"defaultRedirectUri":         "https://payroll.contoso.com",
+	"description":                "Payroll application",
+	"disabledByMicrosoftStatus":  "NotDisabled",
+	"groupMembershipClaims":      "SecurityGroup",
+	"isDeviceOnlyAuthSupported":  false,
+	"isFallbackPublicClient":     false,
+	"notes":                      "Owned by HR",
+	"oauth2RequirePostResponse":  false,
+	"samlMetadataUrl":            "https://payroll.contoso.com/saml",
+	"serviceManagementReference": "HR-1",
+	"signInAudience":             "AzureADMyOrg",
+	"tags":                       []interface{}{"hr"},
+	"tokenEncryptionKeyId":       certKeyId,
+	"uniqueName":                 "payroll",
+	"logo":                       "aGVsbG8=",
+	"api":                        map[string]interface{}{"requestedAccessTokenVersion": 2},
+	"web":                        map[string]interface{}{"redirectUris": []interface{}{"https://payroll.contoso.com/auth"}},
+	"spa":                        map[string]interface{}{"redirectUris": []interface{}{}},
+	"certification":              map[string]interface{}{"isPublisherAttested": true},
+	"info":                       map[string]interface{}{"privacyStatementUrl": "https://contoso.com/privacy"},
+	"verifiedPublisher":          map[string]interface{}{"displayName": "Contoso"},
+	"requiredResourceAccess": []interface{}{
+		map[string]interface{}{
+			"resourceAppId": graphAppId,
+			"resourceAccess": []interface{}{
+				map[string]interface{}{"id": userReadAll, "type": "Role"},
+				map[string]interface{}{"id": userRead, "type": "Scope"},
+			},
+		},
+	},
+	"passwordCredentials": []interface{}{
+		map[string]interface{}{"keyId": secretKeyId, "displayName": "CI secret", "startDateTime": "2020-01-01T00:00:00Z", "endDateTime": time.Now().AddDate(0, 0, 10).UTC().Format(time.RFC3339)},
+	},
+	"keyCredentials": []interface{}{
+		map[string]interface{}{"keyId": certKeyId, "displayName": "CN=payroll", "startDateTime": "2020-01-01T00:00:00Z", "endDateTime": "2020-12-31T00:00:00Z"},
+	},
+}
+
+// graphServicePrincipal is the service principal of Microsoft Graph, exposing the permissions.
+var graphServicePrincipal = map[string]interface{}{
+	"id":                     applicationsGraphSpId,
+	"appId":                  graphAppId,
+	"displayName":            "Microsoft Graph",
+	"appRoles":               []interface{}{map[string]interface{}{"id": userReadAll, "value": "User.Read.All"}},
+	"oauth2PermissionScopes": []interface{}{map[string]interface{}{"id": userRead, "value": "User.Read"}},
+}
+
+// servicePrincipals answers the lookups of service principals by app id.
+func servicePrincipals(r *http.Request) interface{} {
+
+	filter := r.URL.Query().Get("$filter")
+	switch {
+	case strings.Contains(filter, graphAppId):
+		return map[string]interface{}{"value": []interface{}{graphServicePrincipal}}
+	case strings.Contains(filter, appId):
+		return map[string]interface{}{"value": []interface{}{map[string]interface{}{"id": spId, "appId": appId, "displayName": "Payroll"}}}
+	case strings.Contains(filter, "ManagedIdentity"):
+		return map[string]interface{}{"value": []interface{}{
+			map[string]interface{}{"id": "mi-id", "appId": "mi-app-id", "displayName": "order-workflow", "alternativeNames": []interface{}{"isExplicit=False", "/subscriptions/s/resourcegroups/rg/providers/Microsoft.Logic/workflows/order-workflow"}},
+		}}
+	case strings.Contains(filter, flowServiceId):
+		return map[string]interface{}{"value": []interface{}{
+			map[string]interface{}{"id": "flow-sp-id", "appId": flowServiceId, "displayName": "Microsoft Flow Service", "accountEnabled": true},
+		}}
+	default:
+		return map[string]interface{}{"value": []interface{}{}}
+	}
+}
+
+// grants are the permissions granted to the service principal of the sample application.
+var grants = graphtest.Routes{
+	"GET /v1.0/servicePrincipals":                          servicePrincipals,
+	"GET /v1.0/servicePrincipals/" + applicationsGraphSpId: graphServicePrincipal,
+	"GET /v1.0/servicePrincipals/" + spId + "/appRoleAssignments": map[string]interface{}{
+		"value": []interface{}{map[string]interface{}{"id": "assignment-id", "resourceId": applicationsGraphSpId, "appRoleId": userReadAll}},
+	},
+	"GET /v1.0/oauth2PermissionGrants": map[string]interface{}{
+		"value": []interface{}{map[string]interface{}{"id": "grant-id", "clientId": spId, "resourceId": applicationsGraphSpId, "consentType": "AllPrincipals", "scope": "User.Read openid"}},
+	},
+}
+
+// merge returns the union of the routes.
+func merge(routes ...graphtest.Routes) graphtest.Routes {
+
+	merged := graphtest.Routes{}
+	for _, r := range routes {
+		for route, answer := range r {
+			merged[route] = answer
+		}
+	}
+
+	return merged
+}
+
+// applicationsSamples are the sample calls of the tools of the applications package.
+func applicationsSamples() []sample {
+
+	viper.Set("client-id", appId)
+
+	applications := map[string]interface{}{"value": []interface{}{application}}
+
+	samples := []sample{
+		{
+			tool:   "applications",
+			routes: graphtest.Routes{"GET /v1.0/applications": applications},
+		},
+		{
+			tool: "automation_connections",
+			routes: graphtest.Routes{
+				"GET /v1.0/servicePrincipals": servicePrincipals,
+				"GET /v1.0/servicePrincipals/flow-sp-id/oauth2PermissionGrants": map[string]interface{}{
+					"value": []interface{}{map[string]interface{}{"id": "grant-id"}},
+				},
+			},
+		},
+		{
+			name:   "automation_connections without connection",
+			tool:   "automation_connections",
+			routes: graphtest.Routes{"GET /v1.0/servicePrincipals": map[string]interface{}{"value": []interface{}{}}},
+		},
+		{
+			tool:      "create_application",
+			arguments: map[string]interface{}{"display_name": "Payroll", "redirect_uris": "https://payroll.contoso.com/auth"},
+			routes:    graphtest.Routes{"POST /v1.0/applications": application},
+		},
+		{
+			tool: "credential_usage",
+			routes: graphtest.Routes{
+				"GET /v1.0/applications": applications,
+				"GET /beta/reports/appCredentialSignInActivities": map[string]interface{}{
+					"value": []interface{}{map[string]interface{}{"keyId": secretKeyId, "signInActivity": map[string]interface{}{"lastSignInDateTime": "2024-01-01T00:00:00Z"}}},
+				},
+				"GET /beta/reports/servicePrincipalSignInActivities": map[string]interface{}{
+					"value": []interface{}{map[string]interface{}{"appId": appId, "lastSignInActivity": map[string]interface{}{"lastSignInDateTime": "2024-01-02T00:00:00Z"}}},
+				},
+			},
+		},
+		{
+			name: "credential_usage without credential activity",
+			tool: "credential_usage",
+			routes: graphtest.Routes{
+				"GET /v1.0/applications":                          applications,
+				"GET /beta/reports/appCredentialSignInActivities": graphtest.Error{Status: http.StatusForbidden, Code: "Authorization_RequestDenied", Message: "Insufficient privileges"},
+				"GET /beta/reports/servicePrincipalSignInActivities": map[string]interface{}{
+					"value": []interface{}{map[string]interface{}{"appId": appId, "lastSignInActivity": map[string]interface{}{"lastSignInDateTime": "2024-01-02T00:00:00Z"}}},
+				},
+			},
+		},
+		{
+			tool:      "delete_application",
+			arguments: map[string]interface{}{"object_id": appObjectId, "permanent": true},
+			routes: graphtest.Routes{
+				"DELETE /v1.0/applications/" + appObjectId:           http.StatusNoContent,
+				"DELETE /v1.0/directory/deletedItems/" + appObjectId: graphtest.Error{Status: http.StatusForbidden, Code: "Authorization_RequestDenied", Message: "Insufficient privileges"},
+			},
+		},
+		{
+			tool:      "effective_permissions",
+			arguments: map[string]interface{}{"refresh": true},
+			routes:    grants,
+		},
+		{
+			tool:      "application_permissions",
+			arguments: map[string]interface{}{"app_id": appId},
+			routes:    merge(grants, graphtest.Routes{"GET /v1.0/applications": applications}),
+		},
+		{
+			tool:      "rotate_expiring_secrets",
+			arguments: map[string]interface{}{"app_ids": appObjectId + ",missing-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/applications/" + appObjectId: application,
+				"GET /v1.0/applications/missing-id":     graphtest.Error{Status: http.StatusNotFound, Code: "Request_ResourceNotFound", Message: "Resource 'missing-id' does not exist"},
+				"POST /v1.0/applications/" + appObjectId + "/addPassword": map[string]interface{}{
+					"keyId": "10000000-0000-0000-0000-000000000007", "displayName": "Rotated secret", "endDateTime": "2030-01-01T00:00:00Z", "secretText": "secret",
+				},
+			},
+		},
+		{
+			name:      "rotate_expiring_secrets dry run",
+			tool:      "rotate_expiring_secrets",
+			arguments: map[string]interface{}{"dry_run": true},
+			routes:    graphtest.Routes{"GET /v1.0/applications": applications},
+		},
+	}
+
+	signIn := map[string]interface{}{
+		"id":                      "sign-in-id",
+		"createdDateTime":         "2024-01-01T00:00:00Z",
+		"userPrincipalName":       "adele@contoso.com",
+		"appDisplayName":          "Payroll",
+		"resourceDisplayName":     "Microsoft Graph",
+		"clientAppUsed":           "Browser",
+		"ipAddress":               "203.0.113.1",
+		"conditionalAccessStatus": "failure",
+		"status":                  map[string]interface{}{"errorCode": 50126, "failureReason": "Invalid username or password", "additionalDetails": "The user did not enter the right credentials."},
+	}
+	servicePrincipalSignIn := map[string]interface{}{
+		"id":                   "sp-sign-in-id",
+		"createdDateTime":      "2024-01-01T00:00:00Z",
+		"appDisplayName":       "Payroll",
+		"servicePrincipalName": "Payroll",
+		"resourceDisplayName":  "Microsoft Graph",
+		"ipAddress":            "203.0.113.2",
+		"signInEventTypes":     []interface{}{"servicePrincipal"},
+		"status":               map[string]interface{}{"errorCode": 7000215, "failureReason": "Invalid client secret provided."},
+	}
+	for _, mode := range []string{"summary", "signins"} {
+		samples = append(samples, sample{
+			name:      "application_sign_in_failures " + mode,
+			tool:      "application_sign_in_failures",
+			arguments: map[string]interface{}{"app_id": appId, "mode": mode},
+			routes:    graphtest.Routes{"GET /v1.0/auditLogs/signIns": map[string]interface{}{"value": []interface{}{signIn, signIn}}},
+		})
+		samples = append(samples, sample{
+			name:      "application_sign_in_failures " + mode + " with service principals",
+			tool:      "application_sign_in_failures",
+			arguments: map[string]interface{}{"app_id": appId, "mode": mode, "include_service_principals": true},
+			routes: graphtest.Routes{
+				"GET /v1.0/auditLogs/signIns": map[string]interface{}{"value": []interface{}{signIn}},
+				"GET /beta/auditLogs/signIns": map[string]interface{}{"value": []interface{}{servicePrincipalSignIn}},
+			},
+		})
+	}
+
+	return samples
+}
@@ -0,0 +1,75 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// consentAudit is a sample directory audit entry of an admin consent.
+var consentAudit = map[string]interface{}{
+	"id":                  "consent-id",
+	"activityDateTime":    "2024-01-02T03:04:05Z",
+	"activityDisplayName": "Consent to application",
+	"category":            "ApplicationManagement",
+	"operationType":       "Assign",
+	"loggedByService":     "Core Directory",
+	"result":              "success",
+	"initiatedBy": map[string]interface{}{
+		"user": map[string]interface{}{"id": "user-id", "displayName": "Adele Vance", "userPrincipalName": "adele@contoso.com"},
+	},
+	"targetResources": []interface{}{
+		map[string]interface{}{
+			"id":          "sp-id",
+			"type":        "ServicePrincipal",
+			"displayName": "Payroll",
+			"modifiedProperties": []interface{}{
+				map[string]interface{}{"displayName": "ConsentContext.IsAdminConsent", "oldValue": nil, "newValue": `"True"`},
+				map[string]interface{}{"displayName": "ConsentContext.OnBehalfOfAll", "oldValue": nil, "newValue": `"True"`},
+				map[string]interface{}{"displayName": "ConsentAction.Permissions", "oldValue": nil, "newValue": `"Scope: User.Read"`},
+			},
+		},
+	},
+}
+
+// appAudit is a sample directory audit entry initiated by an application.
+var appAudit = map[string]interface{}{
+	"id":                  "update-id",
+	"activityDateTime":    "2024-01-01T03:04:05Z",
+	"activityDisplayName": "Update user",
+	"category":            "UserManagement",
+	"operationType":       "Update",
+	"loggedByService":     "Core Directory",
+	"result":              "failure",
+	"resultReason":        "Insufficient privileges",
+	"initiatedBy": map[string]interface{}{
+		"app": map[string]interface{}{"servicePrincipalId": "sp-id", "displayName": "Provisioning"},
+	},
+	"targetResources": []interface{}{
+		map[string]interface{}{
+			"id":   "sp-id",
+			"type": "User",
+			"modifiedProperties": []interface{}{
+				map[string]interface{}{"displayName": "JobTitle", "oldValue": `["Engineer"]`, "newValue": `["Manager"]`},
+			},
+		},
+	},
+}
+
+// auditSamples are the sample calls of the tools of the audit package.
+func auditSamples() []sample {
+
+	return []sample{
+		{
+			tool: "consent_audits",
+			routes: graphtest.Routes{
+				"GET /v1.0/auditLogs/directoryAudits": map[string]interface{}{"value": []interface{}{consentAudit}},
+			},
+		},
+		{
+			tool:      "object_audit_history",
+			arguments: map[string]interface{}{"object_id": "sp-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/auditLogs/directoryAudits": map[string]interface{}{"value": []interface{}{consentAudit, appAudit}},
+			},
+		},
+	}
+}
@@ -0,0 +1,31 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// auditlogsSamples are the sample calls of the tools of the auditlogs package.
+func auditlogsSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "signin_logs",
+			arguments: map[string]interface{}{"user_principal_name": "adele@contoso.com", "top": 5},
+			routes: graphtest.Routes{
+				"GET /v1.0/auditLogs/signIns": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":                "signin-id",
+							"createdDateTime":   "2024-01-02T03:04:05Z",
+							"userPrincipalName": "adele@contoso.com",
+							"appDisplayName":    "Office 365",
+							"ipAddress":         "203.0.113.1",
+							"clientAppUsed":     "Browser",
+							"status":            map[string]interface{}{"errorCode": 50126, "failureReason": "Invalid username or password"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+const (
+	clientSpId        = "10000000-0000-0000-0000-000000000001"
+	consentsGraphSpId = "10000000-0000-0000-0000-000000000002"
+	userRWAll         = "741f803b-c850-494e-b5df-cde7c675a1ca"
+)
+
+// consentsSamples are the sample calls of the tools of the consents package.
+func consentsSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "consent_review",
+			arguments: map[string]interface{}{"risk_permissions": "Mail.Read,User.ReadWrite.All"},
+			routes: graphtest.Routes{
+				"GET /v1.0/servicePrincipals": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": clientSpId, "appId": "app-id", "displayName": "Mailer"},
+						map[string]interface{}{
+							"id":          consentsGraphSpId,
+							"appId":       "00000003-0000-0000-c000-000000000000",
+							"displayName": "Microsoft Graph",
+							"appRoles":    []interface{}{map[string]interface{}{"id": userRWAll, "value": "User.ReadWrite.All"}},
+						},
+					},
+				},
+				"GET /v1.0/oauth2PermissionGrants": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "grant-id", "clientId": clientSpId, "consentType": "Principal", "principalId": "user-id", "resourceId": consentsGraphSpId, "scope": "User.Read Mail.Read"},
+					},
+				},
+				"GET /v1.0/servicePrincipals/" + clientSpId + "/appRoleAssignments": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "assignment-id", "appRoleId": userRWAll, "resourceId": consentsGraphSpId, "principalId": clientSpId},
+					},
+				},
+				"GET /v1.0/servicePrincipals/" + consentsGraphSpId + "/appRoleAssignments": map[string]interface{}{"value": []interface{}{}},
+			},
+		},
+	}
+}
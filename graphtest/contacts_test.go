@@ -0,0 +1,33 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// contactsSamples are the sample calls of the tools of the contacts package.
+func contactsSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "contacts",
+			arguments: map[string]interface{}{"user_id": "adele@contoso.com"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/adele@contoso.com/contacts": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":             "contact-id",
+							"displayName":    "Alex Wilber",
+							"givenName":      "Alex",
+							"surname":        "Wilber",
+							"emailAddresses": []interface{}{map[string]interface{}{"name": "Alex", "address": "alex@fabrikam.com"}, map[string]interface{}{"name": "No address"}},
+							"businessPhones": []interface{}{"+1 425 555 0100"},
+							"mobilePhone":    "+1 425 555 0101",
+							"companyName":    "Fabrikam",
+							"jobTitle":       "Buyer",
+						},
+					},
+				},
+			},
+		},
+	}
+}
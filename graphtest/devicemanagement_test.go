@@ -0,0 +1,50 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// devicemanagementSamples are the sample calls of the tools of the devicemanagement package.
+func devicemanagementSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "intune_policies",
+			arguments: map[string]interface{}{"kind": "compliance"},
+			routes: graphtest.Routes{
+				"GET /v1.0/deviceManagement/deviceCompliancePolicies": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"@odata.type":          "#microsoft.graph.windows10CompliancePolicy",
+							"id":                   "compliance-id",
+							"displayName":          "Windows baseline",
+							"description":          "Requires BitLocker",
+							"version":              3,
+							"createdDateTime":      "2024-01-01T00:00:00Z",
+							"lastModifiedDateTime": "2024-02-01T00:00:00Z",
+							"assignments":          []interface{}{map[string]interface{}{"id": "assignment-id"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			tool:      "intune_policies",
+			arguments: map[string]interface{}{"kind": "configuration"},
+			routes: graphtest.Routes{
+				"GET /v1.0/deviceManagement/deviceConfigurations": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"@odata.type":          "#microsoft.graph.windows10GeneralConfiguration",
+							"id":                   "configuration-id",
+							"displayName":          "Windows restrictions",
+							"version":              1,
+							"createdDateTime":      "2024-01-01T00:00:00Z",
+							"lastModifiedDateTime": "2024-02-01T00:00:00Z",
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// device is a sample device joined to the directory.
+var device = map[string]interface{}{
+	"id":                            "device-id",
+	"displayName":                   "LAPTOP-01",
+	"operatingSystem":               "Windows",
+	"operatingSystemVersion":        "10.0.22631",
+	"deviceId":                      "10000000-0000-0000-0000-000000000001",
+	"isCompliant":                   true,
+	"isManaged":                     true,
+	"accountEnabled":                true,
+	"trustType":                     "AzureAd",
+	"approximateLastSignInDateTime": "2024-01-02T03:04:05Z",
+}
+
+// devicesSamples are the sample calls of the tools of the devices package.
+func devicesSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "devices",
+			arguments: map[string]interface{}{"name": "LAPTOP", "matchMode": "contains"},
+			routes: graphtest.Routes{
+				"GET /v1.0/devices": map[string]interface{}{"value": []interface{}{device}},
+			},
+		},
+		{
+			tool:      "devices",
+			arguments: map[string]interface{}{"limit": 1},
+			routes: graphtest.Routes{
+				"GET /v1.0/devices": map[string]interface{}{"value": []interface{}{device, map[string]interface{}{"id": "other-id"}}},
+			},
+		},
+	}
+}
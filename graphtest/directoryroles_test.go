@@ -0,0 +1,40 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// directoryrolesSamples are the sample calls of the tools of the directoryroles package.
+func directoryrolesSamples() []sample {
+
+	return []sample{
+		{
+			tool: "directory_roles",
+			routes: graphtest.Routes{
+				"GET /v1.0/directoryRoles": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":             "role-id",
+							"displayName":    "Global Administrator",
+							"description":    "Can manage all aspects of Microsoft Entra ID.",
+							"roleTemplateId": "62e90394-69f5-4237-9190-012177145e10",
+						},
+					},
+				},
+			},
+		},
+		{
+			tool:      "directory_roles",
+			arguments: map[string]interface{}{"role_id": "role-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/directoryRoles/role-id/members": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"@odata.type": "#microsoft.graph.user", "id": "user-id", "displayName": "Adele Vance", "userPrincipalName": "adele@contoso.com"},
+						map[string]interface{}{"@odata.type": "#microsoft.graph.group", "id": "group-id", "displayName": "Admins"},
+						map[string]interface{}{"@odata.type": "#microsoft.graph.servicePrincipal", "id": "sp-id", "displayName": "Automation"},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+const domainPath = "/v1.0/domains/contoso.com"
+
+// serviceRecords are sample service configuration records of each type.
+var serviceRecords = map[string]interface{}{
+	"value": []interface{}{
+		map[string]interface{}{"@odata.type": "#microsoft.graph.domainDnsMxRecord", "id": "mx-id", "recordType": "Mx", "label": "contoso.com", "ttl": 3600, "isOptional": false, "supportedService": "Email", "mailExchange": "contoso-com.mail.protection.outlook.com", "preference": 0},
+		map[string]interface{}{"@odata.type": "#microsoft.graph.domainDnsCnameRecord", "id": "cname-id", "recordType": "CName", "label": "autodiscover.contoso.com", "ttl": 3600, "isOptional": false, "supportedService": "Email", "canonicalName": "autodiscover.outlook.com"},
+		map[string]interface{}{"@odata.type": "#microsoft.graph.domainDnsSrvRecord", "id": "srv-id", "recordType": "Srv", "label": "contoso.com", "ttl": 3600, "isOptional": false, "supportedService": "OfficeCommunicationsOnline", "nameTarget": "sipdir.online.lync.com", "port": 443, "priority": 100, "protocol": "_tcp", "service": "_sip", "weight": 1},
+	},
+}
+
+// domainsSamples are the sample calls of the tools of the domains package.
+func domainsSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "domain_dns_records",
+			arguments: map[string]interface{}{"domain_id": "contoso.com"},
+			routes: graphtest.Routes{
+				"GET " + domainPath: map[string]interface{}{"id": "contoso.com", "isVerified": false},
+				"GET " + domainPath + "/verificationDnsRecords": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"@odata.type": "#microsoft.graph.domainDnsTxtRecord", "id": "txt-id", "recordType": "Txt", "label": "contoso.com", "ttl": 3600, "isOptional": false, "supportedService": "Email", "text": "MS=ms12345678"},
+					},
+				},
+				"GET " + domainPath + "/serviceConfigurationRecords": serviceRecords,
+			},
+		},
+		{
+			tool:      "domain_dns_records",
+			arguments: map[string]interface{}{"domain_id": "contoso.com"},
+			routes: graphtest.Routes{
+				"GET " + domainPath: map[string]interface{}{"id": "contoso.com", "isVerified": true},
+				"GET " + domainPath + "/serviceConfigurationRecords": serviceRecords,
+			},
+		},
+	}
+}
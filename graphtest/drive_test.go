@@ -0,0 +1,38 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// driveChildren are a sample folder and file of a drive.
+var driveChildren = map[string]interface{}{
+	"value": []interface{}{
+		map[string]interface{}{"id": "folder-id", "name": "Reports", "size": 2048, "webUrl": "https://contoso-my.sharepoint.com/Reports", "lastModifiedDateTime": "2024-01-02T03:04:05Z", "folder": map[string]interface{}{"childCount": 2}},
+		map[string]interface{}{"id": "file-id", "name": "budget.xlsx", "size": 1024, "webUrl": "https://contoso-my.sharepoint.com/budget.xlsx", "lastModifiedDateTime": "2024-01-02T03:04:05Z", "file": map[string]interface{}{"mimeType": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}},
+	},
+}
+
+// driveSamples are the sample calls of the tools of the drive package.
+func driveSamples() []sample {
+
+	return []sample{
+		{
+			name:      "root folder",
+			tool:      "drive_items",
+			arguments: map[string]interface{}{"user_id": "adele@contoso.com"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/adele@contoso.com/drive":       map[string]interface{}{"id": "drive-id"},
+				"GET /v1.0/drives/drive-id/items/root/children": driveChildren,
+			},
+		},
+		{
+			name:      "empty folder",
+			tool:      "drive_items",
+			arguments: map[string]interface{}{"user_id": "adele@contoso.com", "path": "Documents/Empty"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/adele@contoso.com/drive":                         map[string]interface{}{"id": "drive-id"},
+				"GET /v1.0/drives/drive-id/items/root:/Documents/Empty:/children": map[string]interface{}{"value": []interface{}{}},
+			},
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// quota is a sample drive quota.
+var quota = map[string]interface{}{"total": 1099511627776, "used": 1048576, "remaining": 1099510579200, "deleted": 0, "state": "normal"}
+
+// drivesChildren are a sample folder and file of a drive.
+var drivesChildren = map[string]interface{}{
+	"value": []interface{}{
+		map[string]interface{}{"id": "folder-id", "name": "Reports", "size": 2048, "webUrl": "https://contoso-my.sharepoint.com/Reports", "lastModifiedDateTime": "2024-01-02T03:04:05Z", "folder": map[string]interface{}{"childCount": 2}},
+		map[string]interface{}{"id": "file-id", "name": "budget.xlsx", "size": 1024, "webUrl": "https://contoso-my.sharepoint.com/budget.xlsx", "lastModifiedDateTime": "2024-01-02T03:04:05Z", "file": map[string]interface{}{"mimeType": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}},
+	},
+}
+
+// siteDrives are the sample document libraries of a site.
+var siteDrives = map[string]interface{}{
+	"value": []interface{}{
+		map[string]interface{}{"id": "drive-id", "name": "Documents", "driveType": "documentLibrary", "webUrl": "https://contoso.sharepoint.com/Shared Documents", "quota": quota},
+		map[string]interface{}{"id": "other-id", "name": "Archive", "driveType": "documentLibrary"},
+	},
+}
+
+// drivesSamples are the sample calls of the tools of the drives package.
+func drivesSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "drive_quota",
+			arguments: map[string]interface{}{"user_ids": "adele@contoso.com, alex@contoso.com"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/adele@contoso.com/drive": map[string]interface{}{"id": "drive-id", "webUrl": "https://contoso-my.sharepoint.com/personal/adele", "quota": quota},
+				"GET /v1.0/users/alex@contoso.com/drive":  graphtest.Error{Status: 404, Code: "ResourceNotFound", Message: "User's mysite not found."},
+			},
+		},
+		{
+			tool:      "drive_quota",
+			arguments: map[string]interface{}{"site_id": "site-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/sites/site-id/drives": siteDrives,
+			},
+		},
+		{
+			tool:      "site_drives",
+			arguments: map[string]interface{}{"site_id": "site-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/sites/site-id/drives": siteDrives,
+			},
+		},
+		{
+			tool:      "site_drives",
+			arguments: map[string]interface{}{"site_id": "site-id", "drive_id": "drive-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/sites/site-id/drives/drive-id":       map[string]interface{}{"id": "drive-id"},
+				"GET /v1.0/drives/drive-id/items/root/children": drivesChildren,
+			},
+		},
+	}
+}
@@ -0,0 +1,85 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+const eventPath = "/v1.0/users/adele@contoso.com/events/event-id"
+
+// event is a sample master event of a recurring series.
+var event = map[string]interface{}{
+	"id":        "event-id",
+	"subject":   "Weekly sync",
+	"type":      "seriesMaster",
+	"isAllDay":  false,
+	"start":     map[string]interface{}{"dateTime": "2024-01-02T09:00:00.0000000", "timeZone": "UTC"},
+	"end":       map[string]interface{}{"dateTime": "2024-01-02T09:30:00.0000000", "timeZone": "UTC"},
+	"location":  map[string]interface{}{"displayName": "Room 1"},
+	"organizer": map[string]interface{}{"emailAddress": map[string]interface{}{"name": "Adele Vance", "address": "adele@contoso.com"}},
+	"attendees": []interface{}{
+		map[string]interface{}{
+			"type":         "required",
+			"emailAddress": map[string]interface{}{"name": "Alex Wilber", "address": "alex@contoso.com"},
+			"status":       map[string]interface{}{"response": "accepted", "time": "2024-01-01T10:00:00Z"},
+		},
+		map[string]interface{}{
+			"type":         "optional",
+			"emailAddress": map[string]interface{}{"name": "Miriam Graham", "address": "miriam@contoso.com"},
+			"status":       map[string]interface{}{"response": "none", "time": "0001-01-01T00:00:00Z"},
+		},
+	},
+}
+
+// eventsSamples are the sample calls of the tools of the events package.
+func eventsSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "event_attendees",
+			arguments: map[string]interface{}{"user_id": "adele@contoso.com", "event_id": "event-id", "expand_instances": true, "max_instances": 1},
+			routes: graphtest.Routes{
+				"GET " + eventPath: event,
+				"GET " + eventPath + "/instances": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "instance-1", "type": "occurrence", "start": event["start"], "end": event["end"], "attendees": event["attendees"]},
+						map[string]interface{}{"id": "instance-2", "type": "occurrence"},
+					},
+				},
+			},
+		},
+		{
+			tool:      "event_attendees",
+			arguments: map[string]interface{}{"user_id": "adele@contoso.com", "event_id": "event-id", "expand_instances": true},
+			routes: graphtest.Routes{
+				"GET " + eventPath: map[string]interface{}{"id": "event-id", "subject": "One off", "type": "singleInstance"},
+			},
+		},
+		{
+			tool:      "calendars",
+			arguments: map[string]interface{}{"user_id": "adele@contoso.com"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/adele@contoso.com/calendars": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":                "calendar-id",
+							"name":              "Calendar",
+							"color":             "auto",
+							"hexColor":          "#ff0000",
+							"canEdit":           true,
+							"canShare":          true,
+							"isDefaultCalendar": true,
+							"owner":             map[string]interface{}{"name": "Adele Vance", "address": "adele@contoso.com"},
+						},
+					},
+				},
+			},
+		},
+		{
+			tool:      "events",
+			arguments: map[string]interface{}{"user_id": "adele@contoso.com", "start": "2024-01-01", "end": "2024-01-08"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/adele@contoso.com/calendarView": map[string]interface{}{"value": []interface{}{event}},
+			},
+		},
+	}
+}
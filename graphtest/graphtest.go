@@ -0,0 +1,177 @@
+// Package graphtest provides a Microsoft Graph client answered by an http.Handler, to test the
+// tools without a tenant.
+package graphtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/schema"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/microsoft/kiota-abstractions-go/authentication"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+)
+
+// handlerTransport serves the requests with a handler instead of sending them.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	t.handler.ServeHTTP(recorder, req)
+	response := recorder.Result()
+	response.Request = req
+	return response, nil
+}
+
+// NewClient creates a Microsoft Graph client whose requests are served by the handler. The
+// requests keep their Microsoft Graph URL, so the handler sees paths like /v1.0/users. The
+// requests are neither authenticated nor retried.
+func NewClient(handler http.Handler) (*msgraphsdk.GraphServiceClient, error) {
+
+	adapter, err := msgraphsdk.NewGraphRequestAdapterWithParseNodeFactoryAndSerializationWriterFactoryAndHttpClient(
+		&authentication.AnonymousAuthenticationProvider{},
+		nil,
+		nil,
+		&http.Client{Transport: handlerTransport{handler: handler}},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request adapter: %v", err)
+	}
+
+	return msgraphsdk.NewGraphServiceClient(adapter), nil
+}
+
+// WriteJSON answers a request with the given status and the JSON encoding of the body.
+func WriteJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// WriteError answers a request with a Microsoft Graph error.
+func WriteError(w http.ResponseWriter, status int, code, message string) {
+	WriteJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+// Routes are the answers of a fake Microsoft Graph, keyed by the method and path of the request,
+// e.g. "GET /v1.0/users". A value is encoded as JSON, except an int which is a status without
+// content, a string which is sent as text, a []byte which is sent as is, an Error, and a
+// func(*http.Request) interface{} whose answer depends on the request, e.g. on its query.
+type Routes map[string]interface{}
+
+// Error is a Microsoft Graph error answered by Routes.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+// Handler returns the handler answering the requests with the routes. Requests without a
+// route fail the test.
+func (routes Routes) Handler(t testing.TB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		route := r.Method + " " + r.URL.Path
+		answer, ok := routes[route]
+		if !ok {
+			t.Errorf("unexpected request %s", route)
+			WriteError(w, http.StatusNotFound, "Request_ResourceNotFound", "unexpected request "+route)
+			return
+		}
+
+		if fn, ok := answer.(func(*http.Request) interface{}); ok {
+			answer = fn(r)
+		}
+
+		switch a := answer.(type) {
+		case int:
+			w.WriteHeader(a)
+		case string:
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(a))
+		case []byte:
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(a)
+		case Error:
+			WriteError(w, a.Status, a.Code, a.Message)
+		default:
+			WriteJSON(w, http.StatusOK, a)
+		}
+	})
+}
+
+// CallTool calls the registered tool with the arguments and a client answered by the routes, and
+// returns its result as is.
+func CallTool(t testing.TB, name string, arguments map[string]interface{}, routes Routes) *mcp.CallToolResult {
+
+	t.Helper()
+
+	tool, ok := collection.Tools[name]
+	if !ok {
+		t.Fatalf("tool %s is not registered", name)
+	}
+
+	cl, err := NewClient(routes.Handler(t))
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = arguments
+
+	result, err := tool.Processor(baggage.WithInfomation(cl)(context.Background()), request)
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %v", name, err)
+	}
+
+	return result
+}
+
+// CheckTool calls the registered tool with the arguments and a client answered by the routes,
+// then validates its JSON result against the OutputSchema of the tool. It returns the decoded
+// result for further checks.
+func CheckTool(t testing.TB, name string, arguments map[string]interface{}, routes Routes) interface{} {
+
+	t.Helper()
+
+	tool, ok := collection.Tools[name]
+	if !ok {
+		t.Fatalf("tool %s is not registered", name)
+	}
+
+	result := CallTool(t, name, arguments, routes)
+	if result == nil || result.IsError || len(result.Content) == 0 {
+		t.Fatalf("%s: unexpected result: %+v", name, result)
+	}
+	text, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatalf("%s: unexpected content: %+v", name, result.Content[0])
+	}
+
+	t.Logf("%s: %s", name, text.Text)
+
+	var data interface{}
+	if err := json.Unmarshal([]byte(text.Text), &data); err != nil {
+		t.Fatalf("%s: the result is not JSON: %v\n%s", name, err, text.Text)
+	}
+	if err := schema.Validate(tool.OutputSchema, data); err != nil {
+		t.Errorf("%s: the result does not match the output schema:\n%v", name, err)
+	}
+
+	return data
+}
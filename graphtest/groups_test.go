@@ -0,0 +1,200 @@
+package graphtest_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// group is a sample Microsoft 365 group.
+var group = map[string]interface{}{
+	"id":              "group-id",
+	"displayName":     "Sales",
+	"mail":            "sales@contoso.com",
+	"mailNickname":    "sales",
+	"mailEnabled":     true,
+	"securityEnabled": false,
+	"groupTypes":      []interface{}{"Unified"},
+	"description":     "The sales team",
+	"visibility":      "Private",
+}
+
+// userMember is a sample user member of a group.
+var userMember = map[string]interface{}{"@odata.type": "#microsoft.graph.user", "id": "user-id", "displayName": "Adele Vance", "userPrincipalName": "adele@contoso.com"}
+
+// groupsSamples are the sample calls of the tools of the groups package.
+func groupsSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "groups",
+			arguments: map[string]interface{}{"name": "Sales"},
+			routes: graphtest.Routes{
+				"GET /v1.0/groups": map[string]interface{}{"value": []interface{}{group}},
+			},
+		},
+		{
+			tool:      "create_group",
+			arguments: map[string]interface{}{"display_name": "Sales", "mail_nickname": "sales", "description": "The sales team", "group_types": "Unified"},
+			routes: graphtest.Routes{
+				"POST /v1.0/groups": group,
+			},
+		},
+		{
+			tool:      "add_group_members",
+			arguments: map[string]interface{}{"group_id": "group-id", "user_ids": "adele@contoso.com,unknown@contoso.com"},
+			routes: graphtest.Routes{
+				"GET /v1.0/groups/group-id":           map[string]interface{}{"id": "group-id"},
+				"GET /v1.0/users/adele@contoso.com":   map[string]interface{}{"id": "user-id"},
+				"GET /v1.0/users/unknown@contoso.com": graphtest.Error{Status: http.StatusNotFound, Code: "Request_ResourceNotFound", Message: "Resource 'unknown@contoso.com' does not exist."},
+				"PATCH /v1.0/groups/group-id":         http.StatusNoContent,
+			},
+		},
+		{
+			tool:      "update_group_members",
+			arguments: map[string]interface{}{"group_id": "group-id", "action": "add", "member_ids": "9f4b6f2c-3b4a-4a8e-8f4e-2a8f1e6c7d10,5c1e8a3d-7f2b-4e6a-9d0c-3b8f2a1e4d57"},
+			routes: graphtest.Routes{
+				"GET /v1.0/groups/group-id":               map[string]interface{}{"id": "group-id"},
+				"POST /v1.0/groups/group-id/members/$ref": http.StatusNoContent,
+			},
+		},
+		{
+			tool:      "update_group_members",
+			arguments: map[string]interface{}{"group_id": "group-id", "action": "remove", "member_ids": "9f4b6f2c-3b4a-4a8e-8f4e-2a8f1e6c7d10"},
+			routes: graphtest.Routes{
+				"GET /v1.0/groups/group-id": map[string]interface{}{"id": "group-id"},
+				"DELETE /v1.0/groups/group-id/members/9f4b6f2c-3b4a-4a8e-8f4e-2a8f1e6c7d10/$ref": graphtest.Error{Status: http.StatusBadRequest, Code: "Request_BadRequest", Message: "The member is not in the group."},
+			},
+		},
+		{
+			tool:      "group_membership_delta",
+			arguments: map[string]interface{}{"group_ids": "group-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/groups/delta()": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":          "group-id",
+							"displayName": "Sales",
+							"members@delta": []interface{}{
+								map[string]interface{}{"@odata.type": "#microsoft.graph.user", "id": "user-id"},
+								map[string]interface{}{"@odata.type": "#microsoft.graph.user", "id": "former-id", "@removed": map[string]interface{}{"reason": "deleted"}},
+							},
+						},
+						map[string]interface{}{"id": "deleted-id", "@removed": map[string]interface{}{"reason": "changed"}},
+					},
+					"@odata.deltaLink": "https://graph.microsoft.com/v1.0/groups/delta()?$deltatoken=token",
+				},
+			},
+		},
+		{
+			tool: "dynamic_groups",
+			routes: graphtest.Routes{
+				"GET /v1.0/groups": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "dynamic-id", "displayName": "Sales users", "membershipRule": `(user.department -eq "Sales")`, "membershipRuleProcessingState": "On"},
+						map[string]interface{}{"id": "failed-id", "displayName": "Broken", "membershipRule": `(user.x -eq "y")`, "membershipRuleProcessingState": "Paused"},
+					},
+				},
+				"GET /beta/groups": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "dynamic-id", "membershipRuleProcessingStatus": map[string]interface{}{"status": "Succeeded", "lastMembershipUpdated": "2024-01-02T03:04:05Z"}},
+						map[string]interface{}{"id": "failed-id", "membershipRuleProcessingStatus": map[string]interface{}{"status": "Failed", "errorMessage": "Invalid property user.x"}},
+					},
+				},
+			},
+		},
+		{
+			tool:      "dynamic_groups",
+			arguments: map[string]interface{}{"mode": "evaluate", "member_id": "user-id", "membership_rule": `(user.department -eq "Sales")`},
+			routes: graphtest.Routes{
+				"POST /beta/groups/evaluateDynamicMembership": map[string]interface{}{
+					"membershipRule":                 `(user.department -eq "Sales")`,
+					"membershipRuleEvaluationResult": false,
+					"membershipRuleEvaluationDetails": map[string]interface{}{
+						"ruleClauses": []interface{}{
+							map[string]interface{}{"membershipRuleClause": `user.department -eq "Sales"`, "result": false, "actualValue": "Marketing"},
+						},
+					},
+				},
+			},
+		},
+		{
+			tool:      "group_lifecycle",
+			arguments: map[string]interface{}{"within_days": 30},
+			routes: graphtest.Routes{
+				"GET /v1.0/groupLifecyclePolicies": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "policy-id", "groupLifetimeInDays": 180, "managedGroupTypes": "All", "alternateNotificationEmails": "admin@contoso.com"},
+					},
+				},
+				"GET /v1.0/groups": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":                 "group-id",
+							"displayName":        "Sales",
+							"mail":               "sales@contoso.com",
+							"renewedDateTime":    "2024-01-01T00:00:00Z",
+							"expirationDateTime": time.Now().AddDate(0, 0, 10).UTC().Format(time.RFC3339),
+						},
+						map[string]interface{}{"id": "later-id", "expirationDateTime": time.Now().AddDate(1, 0, 0).UTC().Format(time.RFC3339)},
+					},
+				},
+			},
+		},
+		{
+			tool:      "group_lifecycle",
+			arguments: map[string]interface{}{},
+			routes: graphtest.Routes{
+				"GET /v1.0/groupLifecyclePolicies": map[string]interface{}{"value": []interface{}{}},
+			},
+		},
+		{
+			tool:      "nested_group_membership",
+			arguments: map[string]interface{}{"group_id": "group-id", "max_depth": 1},
+			routes: graphtest.Routes{
+				"GET /v1.0/groups/group-id": group,
+				"GET /v1.0/groups/group-id/members": map[string]interface{}{
+					"value": []interface{}{userMember, map[string]interface{}{"@odata.type": "#microsoft.graph.group", "id": "nested-id", "displayName": "Sales EMEA"}},
+				},
+			},
+		},
+		{
+			tool:      "nested_group_membership",
+			arguments: map[string]interface{}{"group_id": "group-id", "mode": "flat"},
+			routes: graphtest.Routes{
+				"GET /v1.0/groups/group-id": group,
+				"GET /v1.0/groups/group-id/members": map[string]interface{}{
+					"value": []interface{}{userMember, map[string]interface{}{"@odata.type": "#microsoft.graph.group", "id": "nested-id", "displayName": "Sales EMEA"}},
+				},
+				"GET /v1.0/groups/nested-id/members": map[string]interface{}{"value": []interface{}{userMember}},
+			},
+		},
+		{
+			tool:      "owned_groups",
+			arguments: map[string]interface{}{"user_id": "adele@contoso.com"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/adele@contoso.com/ownedObjects/graph.group": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":                            "group-id",
+							"displayName":                   "Sales",
+							"mail":                          "sales@contoso.com",
+							"groupTypes":                    []interface{}{"Unified", "DynamicMembership"},
+							"membershipRule":                `(user.department -eq "Sales")`,
+							"membershipRuleProcessingState": "Paused",
+							"resourceProvisioningOptions":   []interface{}{"Team"},
+							"serviceProvisioningErrors": []interface{}{
+								map[string]interface{}{"@odata.type": "#microsoft.graph.serviceProvisioningXmlError", "createdDateTime": "2024-01-02T03:04:05Z", "serviceInstance": "exchange/namprd", "isResolved": false, "errorDetail": "<xml/>"},
+							},
+							"onPremisesProvisioningErrors": []interface{}{
+								map[string]interface{}{"category": "PropertyConflict", "propertyCausingError": "ProxyAddresses", "value": "smtp:sales@contoso.com", "occurredDateTime": "2024-01-02T03:04:05Z"},
+							},
+						},
+					},
+				},
+				"GET /v1.0/groups/group-id/members/$count": "24000",
+			},
+		},
+	}
+}
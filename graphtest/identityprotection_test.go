@@ -0,0 +1,73 @@
+package graphtest_test
+
+import (
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// identityprotectionSamples are the sample calls of the tools of the identityprotection package.
+func identityprotectionSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "risky_users",
+			arguments: map[string]interface{}{"mode": "users", "risk_level": "high"},
+			routes: graphtest.Routes{
+				"GET /v1.0/identityProtection/riskyUsers": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":                      "user-id",
+							"userDisplayName":         "Adele Vance",
+							"userPrincipalName":       "adele@contoso.com",
+							"riskLevel":               "high",
+							"riskState":               "atRisk",
+							"riskDetail":              "none",
+							"riskLastUpdatedDateTime": "2024-01-02T03:04:05Z",
+							"isDeleted":               false,
+							"isProcessing":            false,
+						},
+					},
+				},
+			},
+		},
+		{
+			tool:      "risky_users",
+			arguments: map[string]interface{}{"mode": "detections"},
+			routes: graphtest.Routes{
+				"GET /v1.0/identityProtection/riskDetections": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":                  "detection-id",
+							"userId":              "user-id",
+							"userPrincipalName":   "adele@contoso.com",
+							"riskEventType":       "unfamiliarFeatures",
+							"riskLevel":           "medium",
+							"riskState":           "atRisk",
+							"riskDetail":          "none",
+							"source":              "IdentityProtection",
+							"ipAddress":           "203.0.113.1",
+							"location":            map[string]interface{}{"city": "Paris", "state": "Paris", "countryOrRegion": "FR"},
+							"detectedDateTime":    "2024-01-02T03:04:05Z",
+							"lastUpdatedDateTime": "2024-01-02T04:04:05Z",
+						},
+					},
+				},
+			},
+		},
+		{
+			tool:      "risky_users_action",
+			arguments: map[string]interface{}{"action": "dismiss", "user_ids": "user-id"},
+			routes: graphtest.Routes{
+				"POST /v1.0/identityProtection/riskyUsers/dismiss": http.StatusNoContent,
+			},
+		},
+		{
+			tool:      "risky_users_action",
+			arguments: map[string]interface{}{"action": "confirm_compromised", "user_ids": "user-id"},
+			routes: graphtest.Routes{
+				"POST /v1.0/identityProtection/riskyUsers/confirmCompromised": graphtest.Error{Status: http.StatusForbidden, Code: "Authorization_RequestDenied", Message: "Insufficient privileges to complete the operation."},
+			},
+		},
+	}
+}
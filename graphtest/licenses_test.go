@@ -0,0 +1,34 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// licensesSamples are the sample calls of the tools of the licenses package.
+func licensesSamples() []sample {
+
+	return []sample{
+		{
+			tool: "licenses",
+			routes: graphtest.Routes{
+				"GET /v1.0/subscribedSkus": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":               "sku-id",
+							"skuId":            "c7df2760-2c81-4ef7-b578-5b5392b571df",
+							"skuPartNumber":    "ENTERPRISEPREMIUM",
+							"appliesTo":        "User",
+							"capabilityStatus": "Enabled",
+							"consumedUnits":    20,
+							"prepaidUnits":     map[string]interface{}{"enabled": 25, "suspended": 0, "warning": 5, "lockedOut": 0},
+							"servicePlans": []interface{}{
+								map[string]interface{}{"servicePlanId": "efb87545-963c-4e0d-99df-69c6916d9eb0", "servicePlanName": "EXCHANGE_S_ENTERPRISE", "provisioningStatus": "Success", "appliesTo": "User"},
+							},
+						},
+						map[string]interface{}{"skuId": "6fd2c87f-b296-42f0-b197-1e91e994b900", "skuPartNumber": "ENTERPRISEPACK"},
+					},
+				},
+			},
+		},
+	}
+}
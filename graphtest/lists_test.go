@@ -0,0 +1,99 @@
+package graphtest_test
+
+import (
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+const listPath = "/v1.0/sites/site-id/lists/list-id"
+
+// items are sample list items with their fields.
+var items = map[string]interface{}{
+	"value": []interface{}{
+		map[string]interface{}{
+			"id":                   "1",
+			"webUrl":               "https://contoso.sharepoint.com/Lists/Issues/1",
+			"createdDateTime":      "2024-01-02T03:04:05Z",
+			"lastModifiedDateTime": "2024-01-03T03:04:05Z",
+			"createdBy":            map[string]interface{}{"user": map[string]interface{}{"displayName": "Adele Vance"}},
+			"lastModifiedBy":       map[string]interface{}{"user": map[string]interface{}{"displayName": "Alex Wilber"}},
+			"fields":               map[string]interface{}{"Title": "Printer down", "Status": "Open", "Priority": 2, "Urgent": true},
+		},
+		map[string]interface{}{
+			"id":     "2",
+			"fields": map[string]interface{}{"Title": "New laptop", "Status": "Closed"},
+		},
+	},
+}
+
+// unindexedItems answers the filtered query of a large list with an error, and the unfiltered one with the items.
+func unindexedItems(r *http.Request) interface{} {
+
+	if r.URL.Query().Has("$filter") {
+		return graphtest.Error{Status: http.StatusBadRequest, Code: "invalidRequest", Message: "Field 'Status' cannot be referenced in filter or orderby as it is not indexed."}
+	}
+
+	return items
+}
+
+// listsSamples are the sample calls of the tools of the lists package.
+func listsSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "lists",
+			arguments: map[string]interface{}{"site_id": "site-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/sites/site-id/lists": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":              "list-id",
+							"name":            "Issues",
+							"displayName":     "Issues",
+							"description":     "IT issues",
+							"webUrl":          "https://contoso.sharepoint.com/Lists/Issues",
+							"createdDateTime": "2024-01-02T03:04:05Z",
+							"list":            map[string]interface{}{"template": "genericList", "hidden": false},
+						},
+					},
+				},
+			},
+		},
+		{
+			tool:      "lists",
+			arguments: map[string]interface{}{"site_id": "site-id", "list_id": "list-id", "include_hidden": true},
+			routes: graphtest.Routes{
+				"GET " + listPath + "/columns": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "status-id", "name": "Status", "displayName": "Status", "description": "The status", "hidden": false, "readOnly": false, "required": true, "indexed": false, "columnGroup": "Custom Columns", "choice": map[string]interface{}{"choices": []interface{}{"Open", "Closed"}}},
+						map[string]interface{}{"id": "owner-id", "name": "Owner", "displayName": "Owner", "lookup": map[string]interface{}{"listId": "people-id", "columnName": "Title"}},
+						map[string]interface{}{"id": "title-id", "name": "Title", "displayName": "Title", "text": map[string]interface{}{}},
+						map[string]interface{}{"id": "hidden-id", "name": "_ModerationStatus", "hidden": true, "columnGroup": "_Hidden"},
+					},
+				},
+			},
+		},
+		{
+			tool:      "list_items",
+			arguments: map[string]interface{}{"site_id": "site-id", "list_id": "list-id"},
+			routes: graphtest.Routes{
+				"GET " + listPath + "/items": items,
+			},
+		},
+		{
+			tool:      "lists",
+			arguments: map[string]interface{}{"site_id": "site-id", "list_id": "list-id", "mode": "query", "field": "Status", "value": "Open"},
+			routes: graphtest.Routes{
+				"GET " + listPath + "/items": items,
+			},
+		},
+		{
+			tool:      "lists",
+			arguments: map[string]interface{}{"site_id": "site-id", "list_id": "list-id", "mode": "query", "field": "Status", "value": "Open"},
+			routes: graphtest.Routes{
+				"GET " + listPath + "/items": unindexedItems,
+			},
+		},
+	}
+}
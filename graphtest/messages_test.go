@@ -0,0 +1,36 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// messagesSamples are the sample calls of the tools of the messages package.
+func messagesSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "messages",
+			arguments: map[string]interface{}{"user_id": "adele@contoso.com", "top": 2},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/adele@contoso.com/mailFolders/inbox/messages": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":               "message-id",
+							"subject":          "Quarterly results",
+							"from":             map[string]interface{}{"emailAddress": map[string]interface{}{"name": "Alex Wilber", "address": "alex@contoso.com"}},
+							"receivedDateTime": "2024-01-02T03:04:05Z",
+							"bodyPreview":      "The results are in",
+							"body":             map[string]interface{}{"contentType": "html", "content": "<p>The results are <b>in</b></p>"},
+							"isRead":           false,
+							"hasAttachments":   true,
+						},
+						map[string]interface{}{
+							"id":   "text-id",
+							"body": map[string]interface{}{"contentType": "text", "content": "Plain text"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// organizationSamples are the sample calls of the tools of the organization package.
+func organizationSamples() []sample {
+
+	return []sample{
+		{
+			tool: "organization",
+			routes: graphtest.Routes{
+				"GET /v1.0/organization": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":                "tenant-id",
+							"displayName":       "Contoso",
+							"city":              "Redmond",
+							"country":           "United States",
+							"countryLetterCode": "US",
+							"tenantType":        "AAD",
+							"createdDateTime":   "2020-01-02T03:04:05Z",
+							"verifiedDomains": []interface{}{
+								map[string]interface{}{"name": "contoso.com", "type": "Managed", "capabilities": "Email, OfficeCommunicationsOnline", "isDefault": true, "isInitial": false},
+								map[string]interface{}{"name": "contoso.onmicrosoft.com", "type": "Managed", "isDefault": false, "isInitial": true},
+							},
+							"assignedPlans": []interface{}{
+								map[string]interface{}{"service": "exchange", "capabilityStatus": "Enabled", "servicePlanId": "efb87545-963c-4e0d-99df-69c6916d9eb0"},
+								map[string]interface{}{"service": "exchange", "capabilityStatus": "Deleted", "servicePlanId": "9aaf7827-d63c-4b61-89c3-182f06f82e5c"},
+								map[string]interface{}{"capabilityStatus": "Enabled", "servicePlanId": "4828c8ec-dc2e-4779-b502-87ac9ce28ab7"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
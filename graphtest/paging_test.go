@@ -0,0 +1,32 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// pagingSamples are the sample calls of the tools of the paging package.
+func pagingSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "next_page",
+			arguments: map[string]interface{}{"next_link": "https://graph.microsoft.com/v1.0/users?$skiptoken=page2"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users": map[string]interface{}{
+					"value":           []interface{}{map[string]interface{}{"id": "user-id", "displayName": "Adele Vance"}},
+					"@odata.nextLink": "https://graph.microsoft.com/v1.0/users?$skiptoken=page3",
+				},
+			},
+		},
+		{
+			tool:      "next_page",
+			arguments: map[string]interface{}{"next_link": "https://graph.microsoft.com/v1.0/groups/delta()?$skiptoken=last"},
+			routes: graphtest.Routes{
+				"GET /v1.0/groups/delta()": map[string]interface{}{
+					"value":            []interface{}{},
+					"@odata.deltaLink": "https://graph.microsoft.com/v1.0/groups/delta()?$deltatoken=token",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,143 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+const crossTenantPath = "/v1.0/policies/crossTenantAccessPolicy"
+
+// b2bSetting is a sample B2B setting allowing every user to every application.
+var b2bSetting = map[string]interface{}{
+	"usersAndGroups": map[string]interface{}{"accessType": "allowed", "targets": []interface{}{map[string]interface{}{"target": "AllUsers", "targetType": "user"}}},
+	"applications":   map[string]interface{}{"accessType": "allowed", "targets": []interface{}{map[string]interface{}{"target": "AllApplications", "targetType": "application"}}},
+}
+
+// inboundTrust is a sample inbound trust accepting the MFA of the partner.
+var inboundTrust = map[string]interface{}{"isMfaAccepted": true, "isCompliantDeviceAccepted": false, "isHybridAzureADJoinedDeviceAccepted": false}
+
+// policiesSamples are the sample calls of the tools of the policies package.
+func policiesSamples() []sample {
+
+	return []sample{
+		{
+			tool: "authentication_methods_policy",
+			routes: graphtest.Routes{
+				"GET /v1.0/policies/authenticationMethodsPolicy": map[string]interface{}{
+					"displayName":          "Authentication Methods Policy",
+					"policyVersion":        "1.5",
+					"policyMigrationState": "migrationComplete",
+					"lastModifiedDateTime": "2024-01-02T03:04:05Z",
+					"reconfirmationInDays": 90,
+					"authenticationMethodConfigurations": []interface{}{
+						map[string]interface{}{
+							"@odata.type":                      "#microsoft.graph.fido2AuthenticationMethodConfiguration",
+							"id":                               "Fido2",
+							"state":                            "enabled",
+							"isAttestationEnforced":            true,
+							"isSelfServiceRegistrationAllowed": true,
+							"keyRestrictions":                  map[string]interface{}{"isEnforced": true, "enforcementType": "allow", "aaGuids": []interface{}{"cb69481e-8ff7-4039-93ec-0a2729a154a8"}},
+							"includeTargets":                   []interface{}{map[string]interface{}{"id": "all_users", "targetType": "group", "isRegistrationRequired": false}},
+							"excludeTargets":                   []interface{}{map[string]interface{}{"id": "group-id", "targetType": "group"}},
+						},
+						map[string]interface{}{
+							"@odata.type":           "#microsoft.graph.microsoftAuthenticatorAuthenticationMethodConfiguration",
+							"id":                    "MicrosoftAuthenticator",
+							"state":                 "enabled",
+							"isSoftwareOathEnabled": false,
+							"includeTargets":        []interface{}{map[string]interface{}{"id": "all_users", "targetType": "group", "isRegistrationRequired": false, "authenticationMode": "any"}},
+						},
+						map[string]interface{}{
+							"@odata.type":    "#microsoft.graph.smsAuthenticationMethodConfiguration",
+							"id":             "Sms",
+							"state":          "disabled",
+							"includeTargets": []interface{}{map[string]interface{}{"id": "all_users", "targetType": "group", "isUsableForSignIn": true}},
+						},
+						map[string]interface{}{
+							"@odata.type":              "#microsoft.graph.temporaryAccessPassAuthenticationMethodConfiguration",
+							"id":                       "TemporaryAccessPass",
+							"state":                    "enabled",
+							"defaultLifetimeInMinutes": 60,
+							"minimumLifetimeInMinutes": 60,
+							"maximumLifetimeInMinutes": 480,
+							"defaultLength":            8,
+							"isUsableOnce":             false,
+						},
+						map[string]interface{}{"@odata.type": "#microsoft.graph.emailAuthenticationMethodConfiguration", "id": "Email", "state": "enabled", "allowExternalIdToUseEmailOtp": "default"},
+						map[string]interface{}{"@odata.type": "#microsoft.graph.voiceAuthenticationMethodConfiguration", "id": "Voice", "state": "disabled", "isOfficePhoneAllowed": false},
+						map[string]interface{}{"@odata.type": "#microsoft.graph.softwareOathAuthenticationMethodConfiguration", "id": "SoftwareOath", "state": "disabled"},
+						map[string]interface{}{
+							"@odata.type":                     "#microsoft.graph.x509CertificateAuthenticationMethodConfiguration",
+							"id":                              "X509Certificate",
+							"state":                           "disabled",
+							"authenticationModeConfiguration": map[string]interface{}{"x509CertificateAuthenticationDefaultMode": "x509CertificateSingleFactor"},
+						},
+					},
+				},
+			},
+		},
+		{
+			tool: "cross_tenant_access",
+			routes: graphtest.Routes{
+				"GET " + crossTenantPath + "/default": map[string]interface{}{
+					"isServiceDefault":         true,
+					"b2bCollaborationInbound":  b2bSetting,
+					"b2bCollaborationOutbound": b2bSetting,
+					"b2bDirectConnectInbound":  b2bSetting,
+					"b2bDirectConnectOutbound": b2bSetting,
+					"inboundTrust":             inboundTrust,
+				},
+				"GET " + crossTenantPath + "/partners": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"tenantId":                    "partner-tenant-id",
+							"isServiceProvider":           false,
+							"isInMultiTenantOrganization": true,
+							"b2bCollaborationInbound":     b2bSetting,
+							"inboundTrust":                inboundTrust,
+						},
+					},
+				},
+			},
+		},
+		{
+			tool: "cross_tenant_access",
+			routes: graphtest.Routes{
+				"GET " + crossTenantPath + "/default":  map[string]interface{}{"isServiceDefault": true},
+				"GET " + crossTenantPath + "/partners": map[string]interface{}{"value": []interface{}{}},
+			},
+		},
+		{
+			tool:      "conditional_access_what_if",
+			arguments: map[string]interface{}{"user_id": "user-id", "app_id": "00000003-0000-0ff1-ce00-000000000000", "device_platform": "windows", "country": "US", "applied_only": false},
+			routes: graphtest.Routes{
+				"POST /beta/identity/conditionalAccess/evaluate": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":              "mfa-id",
+							"displayName":     "Require MFA",
+							"state":           "enabled",
+							"policyApplies":   true,
+							"grantControls":   map[string]interface{}{"operator": "OR", "builtInControls": []interface{}{"mfa", "compliantDevice"}, "termsOfUse": []interface{}{"terms-id"}},
+							"sessionControls": map[string]interface{}{"@odata.type": "#microsoft.graph.conditionalAccessSessionControls", "signInFrequency": map[string]interface{}{"value": 1}, "persistentBrowser": nil},
+						},
+						map[string]interface{}{
+							"id":            "strength-id",
+							"displayName":   "Phishing resistant admins",
+							"state":         "enabled",
+							"policyApplies": true,
+							"grantControls": map[string]interface{}{"operator": "AND", "authenticationStrength": map[string]interface{}{"displayName": "Phishing-resistant MFA"}},
+						},
+						map[string]interface{}{
+							"id":              "block-id",
+							"displayName":     "Block legacy",
+							"state":           "enabledForReportingButNotEnforced",
+							"policyApplies":   false,
+							"analysisReasons": "clientApps",
+							"grantControls":   map[string]interface{}{"operator": "OR", "builtInControls": []interface{}{"block"}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package graphtest_test
+
+import (
+	"time"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// activityReport is a sample OneDrive activity report, one of its users being concealed.
+const activityReport = "\xef\xbb\xbfReport Refresh Date,User Principal Name,Is Deleted,Deleted Date,Last Activity Date,Viewed Or Edited File Count,Synced File Count,Shared Internally File Count,Shared Externally File Count,Assigned Products,Report Period\n" +
+	"2024-01-02,adele@contoso.com,False,,2024-01-01,12,3,1,0,OFFICE 365 E5,30\n" +
+	"2024-01-02,0123456789ABCDEF0123456789ABCDEF,False,,,,,,,OFFICE 365 E5,30\n"
+
+// mailboxReport is a sample mailbox usage report.
+const mailboxReport = "\xef\xbb\xbfReport Refresh Date,User Principal Name,Display Name,Is Deleted,Deleted Date,Created Date,Last Activity Date,Item Count,Storage Used (Byte),Issue Warning Quota (Byte),Prohibit Send Quota (Byte),Prohibit Send/Receive Quota (Byte),Deleted Item Count,Deleted Item Size (Byte),Has Archive,Report Period\n" +
+	"2024-01-02,adele@contoso.com,Adele Vance,False,,2020-01-01,2024-01-01,1500,104857600,105226698752,106300440576,107374182400,10,2048,True,30\n"
+
+// reportsSamples are the sample calls of the tools of the reports package.
+func reportsSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "file_activity",
+			arguments: map[string]interface{}{"service": "onedrive", "period": "D30"},
+			routes: graphtest.Routes{
+				"GET /v1.0/reports/getOneDriveActivityUserDetail(period='D30')": []byte(activityReport),
+			},
+		},
+		{
+			tool:      "file_activity",
+			arguments: map[string]interface{}{"service": "sharepoint", "period": "D7"},
+			routes: graphtest.Routes{
+				"GET /v1.0/reports/getSharePointActivityUserDetail(period='D7')": []byte("Report Refresh Date,User Principal Name,Visited Page Count\n2024-01-02,adele@contoso.com,4\n"),
+			},
+		},
+		{
+			tool: "mailbox_usage",
+			routes: graphtest.Routes{
+				"GET /v1.0/reports/getMailboxUsageDetail(period='D30')": []byte(mailboxReport),
+			},
+		},
+		{
+			tool:      "registration_trends",
+			arguments: map[string]interface{}{"period": "D7"},
+			routes: graphtest.Routes{
+				"GET /v1.0/reports/authenticationMethods/usersRegisteredByFeature()": map[string]interface{}{
+					"totalUserCount": 3,
+					"userRegistrationFeatureCounts": []interface{}{
+						map[string]interface{}{"feature": "mfaCapable", "userCount": 2},
+						map[string]interface{}{"feature": "passwordlessCapable", "userCount": 0},
+					},
+				},
+				"GET /beta/reports/authenticationMethods/userMfaSignInSummary": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"createdDateTime": time.Now().UTC().AddDate(0, 0, -1).Format(time.RFC3339), "totalSignIns": 10, "multiFactorSignIns": 4, "singleFactorSignIns": 6},
+						map[string]interface{}{"createdDateTime": time.Now().UTC().AddDate(0, 0, -2).Format(time.RFC3339), "totalSignIns": 0, "multiFactorSignIns": 0, "singleFactorSignIns": 0},
+					},
+				},
+			},
+		},
+		{
+			tool: "registration_trends",
+			routes: graphtest.Routes{
+				"GET /v1.0/reports/authenticationMethods/usersRegisteredByFeature()": map[string]interface{}{"totalUserCount": 0},
+				"GET /beta/reports/authenticationMethods/userMfaSignInSummary":       graphtest.Error{Status: 403, Code: "Authentication_RequestFromNonPremiumTenantOrB2CTenant", Message: "Tenant does not have a SKU required for this call."},
+			},
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// roleDefinition is a sample expanded role definition of a schedule instance.
+var roleDefinition = map[string]interface{}{
+	"id":          "62e90394-69f5-4237-9190-012177145e10",
+	"displayName": "Global Administrator",
+}
+
+// rolesSamples are the sample calls of the tools of the roles package.
+func rolesSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "principal_roles",
+			arguments: map[string]interface{}{"principal_id": "user-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/roleManagement/directory/roleEligibilityScheduleInstances": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":               "eligibility-id",
+							"principalId":      "user-id",
+							"roleDefinitionId": "62e90394-69f5-4237-9190-012177145e10",
+							"roleDefinition":   roleDefinition,
+							"directoryScopeId": "/",
+							"memberType":       "Direct",
+							"startDateTime":    "2024-01-02T03:04:05Z",
+							"endDateTime":      "2025-01-02T03:04:05Z",
+						},
+					},
+				},
+				"GET /v1.0/roleManagement/directory/roleAssignmentScheduleInstances": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":               "assignment-id",
+							"principalId":      "user-id",
+							"roleDefinitionId": "62e90394-69f5-4237-9190-012177145e10",
+							"roleDefinition":   roleDefinition,
+							"directoryScopeId": "/",
+							"memberType":       "Direct",
+							"assignmentType":   "Activated",
+							"startDateTime":    "2024-01-02T03:04:05Z",
+						},
+					},
+				},
+			},
+		},
+		{
+			tool:      "principal_roles",
+			arguments: map[string]interface{}{"principal_id": "user-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/roleManagement/directory/roleEligibilityScheduleInstances": map[string]interface{}{"value": []interface{}{}},
+				"GET /v1.0/roleManagement/directory/roleAssignmentScheduleInstances":  map[string]interface{}{"value": []interface{}{}},
+			},
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// securitySamples are the sample calls of the tools of the security package.
+func securitySamples() []sample {
+
+	return []sample{
+		{
+			tool:      "secure_score",
+			arguments: map[string]interface{}{"top": float64(1)},
+			routes: graphtest.Routes{
+				"GET /v1.0/security/secureScores": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":              "score-id",
+							"currentScore":    41.5,
+							"maxScore":        120,
+							"createdDateTime": "2024-01-02T03:04:05Z",
+							"enabledServices": []interface{}{"HasExchange", "HasSharePoint"},
+							"controlScores": []interface{}{
+								map[string]interface{}{"controlName": "MFARegistrationV2", "score": 5},
+							},
+						},
+					},
+				},
+				"GET /v1.0/security/secureScoreControlProfiles": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":                 "MFARegistrationV2",
+							"title":              "Ensure all users can complete multifactor authentication",
+							"controlCategory":    "Identity",
+							"service":            "AzureAD",
+							"rank":               1,
+							"userImpact":         "Moderate",
+							"implementationCost": "Moderate",
+							"threats":            []interface{}{"Account Breach"},
+							"actionUrl":          "https://aka.ms/mfa",
+							"maxScore":           9,
+						},
+						map[string]interface{}{
+							"id":       "BlockLegacyAuthentication",
+							"title":    "Block legacy authentication",
+							"maxScore": 8,
+						},
+					},
+				},
+			},
+		},
+		{
+			tool: "secure_score",
+			routes: graphtest.Routes{
+				"GET /v1.0/security/secureScores": map[string]interface{}{"value": []interface{}{}},
+			},
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// serviceprincipalsSamples are the sample calls of the tools of the serviceprincipals package.
+func serviceprincipalsSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "service_principals",
+			arguments: map[string]interface{}{"name": "Auto", "matchMode": "startswith"},
+			routes: graphtest.Routes{
+				"GET /v1.0/servicePrincipals": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":                        "sp-id",
+							"appId":                     "app-id",
+							"displayName":               "Automation",
+							"servicePrincipalType":      "Application",
+							"accountEnabled":            true,
+							"appRoleAssignmentRequired": false,
+							"appOwnerOrganizationId":    "0f6c7a54-7d41-4b5b-9d4e-5c0b8a4d3e21",
+							"tags":                      []interface{}{"WindowsAzureActiveDirectoryIntegratedApp"},
+						},
+						map[string]interface{}{
+							"id":          "other-sp-id",
+							"appId":       "other-app-id",
+							"displayName": "Automation Runner",
+						},
+					},
+				},
+			},
+		},
+		{
+			tool:      "api_permissions",
+			arguments: map[string]interface{}{"permission": "user."},
+			routes: graphtest.Routes{
+				"GET /v1.0/servicePrincipals": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":          "graph-sp-id",
+							"appId":       "00000003-0000-0000-c000-000000000000",
+							"displayName": "Microsoft Graph",
+							"oauth2PermissionScopes": []interface{}{
+								map[string]interface{}{
+									"id":                      "e1fe6dd8-ba31-4d61-89e7-88639da4683d",
+									"value":                   "User.Read",
+									"type":                    "User",
+									"adminConsentDisplayName": "Sign in and read user profile",
+									"adminConsentDescription": "Allows users to sign-in to the app, and allows the app to read the profile of signed-in users.",
+									"userConsentDisplayName":  "Sign you in and read your profile",
+									"isEnabled":               true,
+								},
+								map[string]interface{}{"id": "14dad69e-099b-42c9-810b-d002981feec1", "value": "profile", "type": "User"},
+							},
+							"appRoles": []interface{}{
+								map[string]interface{}{
+									"id":                 "df021288-bdef-4463-88db-98f22de89214",
+									"value":              "User.Read.All",
+									"displayName":        "Read all users' full profiles",
+									"description":        "Allows the app to read user profiles without a signed in user.",
+									"allowedMemberTypes": []interface{}{"Application"},
+									"isEnabled":          true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// settingsSamples are the sample calls of the tools of the settings package.
+func settingsSamples() []sample {
+
+	return []sample{
+		{
+			tool: "directory_settings",
+			routes: graphtest.Routes{
+				"GET /v1.0/groupSettings": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":          "setting-id",
+							"displayName": "Group.Unified",
+							"templateId":  "62375ab9-6b52-47ed-826b-58e47e0e304b",
+							"values": []interface{}{
+								map[string]interface{}{"name": "EnableGroupCreation", "value": "false"},
+								map[string]interface{}{"name": "GroupCreationAllowedGroupId"},
+							},
+						},
+					},
+				},
+				"GET /v1.0/groupSettingTemplates": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "62375ab9-6b52-47ed-826b-58e47e0e304b", "displayName": "Group.Unified"},
+						map[string]interface{}{
+							"id":          "08d542b9-071f-4e16-94b0-74abb372e3d9",
+							"displayName": "Group.Unified.Guest",
+							"description": "Settings for a specific Unified Group",
+							"values": []interface{}{
+								map[string]interface{}{"name": "AllowToAddGuests", "type": "System.Boolean", "defaultValue": "true"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,155 @@
+package graphtest_test
+
+import (
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// activityStat is a sample activity of a site over a time range.
+var activityStat = map[string]interface{}{
+	"startDateTime":  "2024-01-01T00:00:00Z",
+	"endDateTime":    "2024-01-08T00:00:00Z",
+	"access":         map[string]interface{}{"actionCount": 120, "actorCount": 14},
+	"edit":           map[string]interface{}{"actionCount": 8, "actorCount": 3},
+	"isTrending":     true,
+	"incompleteData": map[string]interface{}{"missingDataBeforeDateTime": "2023-12-01T00:00:00Z", "wasThrottled": false},
+}
+
+// page is a sample site page with a text web part.
+var page = map[string]interface{}{
+	"@odata.type":     "#microsoft.graph.sitePage",
+	"id":              "page-id",
+	"name":            "welcome.aspx",
+	"title":           "Welcome",
+	"webUrl":          "https://contoso.sharepoint.com/sites/marketing/SitePages/welcome.aspx",
+	"pageLayout":      "article",
+	"publishingState": map[string]interface{}{"level": "checkout", "versionId": "0.1"},
+	"canvasLayout": map[string]interface{}{
+		"horizontalSections": []interface{}{
+			map[string]interface{}{
+				"layout": "oneColumn",
+				"columns": []interface{}{
+					map[string]interface{}{
+						"webparts": []interface{}{
+							map[string]interface{}{"@odata.type": "#microsoft.graph.textWebPart", "innerHtml": "<p>Hello <b>world</b></p>"},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+// publishedPage is the sample page once published.
+var publishedPage = map[string]interface{}{
+	"@odata.type":     "#microsoft.graph.sitePage",
+	"id":              "page-id",
+	"title":           "Welcome",
+	"webUrl":          "https://contoso.sharepoint.com/sites/marketing/SitePages/welcome.aspx",
+	"publishingState": map[string]interface{}{"level": "published", "versionId": "1.0"},
+}
+
+// sitesSamples are the sample calls of the tools of the sites package.
+func sitesSamples() []sample {
+
+	published := false
+
+	return []sample{
+		{
+			tool:      "sites",
+			arguments: map[string]interface{}{"name": "Market", "matchMode": "startswith"},
+			routes: graphtest.Routes{
+				"GET /v1.0/sites": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":             "site-id",
+							"displayName":    "Marketing",
+							"isPersonalSite": false,
+							"webUrl":         "https://contoso.sharepoint.com/sites/marketing",
+							"analytics":      map[string]interface{}{"allTime": activityStat},
+							"sharepointIds":  map[string]interface{}{"siteId": "site-guid", "siteUrl": "https://contoso.sharepoint.com/sites/marketing", "webId": "web-guid"},
+							"siteCollection": map[string]interface{}{"hostname": "contoso.sharepoint.com", "dataLocationCode": "EUR"},
+						},
+					},
+				},
+				"GET /v1.0/sites/site-id/sites": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "subsite-id", "displayName": "Campaigns"},
+					},
+				},
+				"GET /v1.0/sites/site-id/pages/graph.sitePage":         map[string]interface{}{"value": []interface{}{page}},
+				"GET /v1.0/sites/site-id/pages/page-id/graph.sitePage": page,
+			},
+		},
+		{
+			tool:      "sites",
+			arguments: map[string]interface{}{"id": "site-id", "etag": "\"etag-1\""},
+			routes: graphtest.Routes{
+				"GET /v1.0/sites/site-id": http.StatusNotModified,
+			},
+		},
+		{
+			tool:      "site_analytics",
+			arguments: map[string]interface{}{"site_id": "site-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/sites/site-id/analytics/allTime":       activityStat,
+				"GET /v1.0/sites/site-id/analytics/lastSevenDays": activityStat,
+			},
+		},
+		{
+			tool:      "site_analytics",
+			arguments: map[string]interface{}{"site_id": "site-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/sites/site-id/analytics/allTime": graphtest.Error{Status: http.StatusNotFound, Code: "itemNotFound", Message: "The analytics are not available."},
+			},
+		},
+		{
+			tool:      "site_content_types",
+			arguments: map[string]interface{}{"site_id": "site-id", "include_built_in": true},
+			routes: graphtest.Routes{
+				"GET /v1.0/sites/site-id/contentTypes": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "0x0101", "name": "Document", "group": "Document Content Types", "parentId": "0x01", "isBuiltIn": true},
+						map[string]interface{}{"id": "0x01", "name": "Item", "group": "List Content Types", "isBuiltIn": true},
+						map[string]interface{}{
+							"id":               "0x010100A1",
+							"name":             "Contract",
+							"description":      "A signed contract",
+							"group":            "Legal",
+							"parentId":         "0x0101",
+							"isBuiltIn":        false,
+							"hidden":           false,
+							"readOnly":         false,
+							"sealed":           false,
+							"documentTemplate": map[string]interface{}{"fileName": "contract.dotx", "folderName": "Forms"},
+						},
+					},
+				},
+			},
+		},
+		{
+			tool:      "create_site_page",
+			arguments: map[string]interface{}{"site_id": "site-id", "title": "Welcome", "content": "Hello **world**"},
+			routes: graphtest.Routes{
+				"POST /v1.0/sites/site-id/pages": page,
+			},
+		},
+		{
+			tool:      "publish_site_page",
+			arguments: map[string]interface{}{"site_id": "site-id", "page_id": "page-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/sites/site-id/pages/page-id/graph.sitePage": func(r *http.Request) interface{} {
+					if published {
+						return publishedPage
+					}
+					return page
+				},
+				"POST /v1.0/sites/site-id/pages/page-id/graph.sitePage/publish": func(r *http.Request) interface{} {
+					published = true
+					return http.StatusNoContent
+				},
+			},
+		},
+	}
+}
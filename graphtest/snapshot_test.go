@@ -0,0 +1,46 @@
+package graphtest_test
+
+import (
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// snapshotSamples are the sample calls of the tools of the snapshot package.
+func snapshotSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "directory_snapshot",
+			arguments: map[string]interface{}{"max_items": float64(1)},
+			routes: graphtest.Routes{
+				"GET /v1.0/organization": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "tenant-id", "displayName": "Contoso"},
+					},
+				},
+				"GET /v1.0/users": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "user-id", "displayName": "Adele Vance", "userPrincipalName": "adele@contoso.com"},
+					},
+				},
+				"GET /v1.0/groups": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "group-id", "displayName": "Sales", "groupTypes": []interface{}{}, "securityEnabled": true, "mailEnabled": false},
+					},
+				},
+				"GET /v1.0/applications": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "application-id", "appId": "app-id", "displayName": "Automation"},
+					},
+				},
+				"GET /v1.0/servicePrincipals": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "sp-id", "appId": "app-id", "displayName": "Automation", "servicePrincipalType": "Application"},
+					},
+				},
+				"GET /v1.0/sites": graphtest.Error{Status: http.StatusForbidden, Code: "accessDenied", Message: "Access denied"},
+			},
+		},
+	}
+}
@@ -0,0 +1,182 @@
+package graphtest_test
+
+import (
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// channels are sample channels of a team, a standard and a private one.
+var channels = map[string]interface{}{
+	"value": []interface{}{
+		map[string]interface{}{
+			"id":             "general-id",
+			"displayName":    "General",
+			"description":    "The general channel",
+			"membershipType": "standard",
+			"webUrl":         "https://teams.microsoft.com/l/channel/general-id",
+		},
+		map[string]interface{}{
+			"id":             "private-id",
+			"displayName":    "Leadership",
+			"membershipType": "private",
+		},
+	},
+}
+
+// members are sample members of a channel, with an owner and a guest.
+var members = map[string]interface{}{
+	"value": []interface{}{
+		map[string]interface{}{
+			"@odata.type": "#microsoft.graph.aadUserConversationMember",
+			"id":          "member-1",
+			"displayName": "Adele Vance",
+			"userId":      "user-id",
+			"email":       "adele@contoso.com",
+			"roles":       []interface{}{"owner"},
+		},
+		map[string]interface{}{
+			"@odata.type": "#microsoft.graph.aadUserConversationMember",
+			"id":          "member-2",
+			"displayName": "Guest",
+			"userId":      "guest-id",
+			"roles":       []interface{}{"guest"},
+		},
+	},
+}
+
+// teamsSamples are the sample calls of the tools of the teams package.
+func teamsSamples() []sample {
+
+	return []sample{
+		{
+			tool: "teams",
+			routes: graphtest.Routes{
+				"GET /v1.0/groups": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "team-id", "displayName": "Sales", "description": "The sales team", "mail": "sales@contoso.com", "visibility": "Private"},
+					},
+				},
+			},
+		},
+		{
+			tool:      "teams",
+			arguments: map[string]interface{}{"team_id": "team-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/teams/team-id/channels": channels,
+			},
+		},
+		{
+			tool:      "channel_members",
+			arguments: map[string]interface{}{"team_id": "team-id", "channel_id": "private-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/teams/team-id/channels/private-id":         map[string]interface{}{"id": "private-id", "displayName": "Leadership", "membershipType": "private"},
+				"GET /v1.0/teams/team-id/channels/private-id/members": members,
+			},
+		},
+		{
+			tool:      "user_channels",
+			arguments: map[string]interface{}{"user_id": "adele@contoso.com"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/adele@contoso.com": map[string]interface{}{"id": "user-id"},
+				"GET /v1.0/users/user-id/joinedTeams": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"id": "team-id", "displayName": "Sales"},
+					},
+				},
+				"GET /v1.0/teams/team-id/channels":                    channels,
+				"GET /v1.0/teams/team-id/channels/private-id/members": members,
+			},
+		},
+		{
+			tool:      "team_settings",
+			arguments: map[string]interface{}{"team_id": "team-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/teams/team-id": map[string]interface{}{
+					"id":                "team-id",
+					"displayName":       "Sales",
+					"isArchived":        false,
+					"visibility":        "private",
+					"memberSettings":    map[string]interface{}{"allowCreateUpdateChannels": true, "allowCreatePrivateChannels": false, "allowDeleteChannels": false, "allowAddRemoveApps": true, "allowCreateUpdateRemoveTabs": true, "allowCreateUpdateRemoveConnectors": false},
+					"messagingSettings": map[string]interface{}{"allowUserEditMessages": true, "allowUserDeleteMessages": true, "allowOwnerDeleteMessages": true, "allowTeamMentions": true, "allowChannelMentions": true},
+					"funSettings":       map[string]interface{}{"allowGiphy": true, "giphyContentRating": "moderate", "allowStickersAndMemes": true, "allowCustomMemes": false},
+				},
+				"GET /v1.0/teams/team-id/channels": channels,
+				"GET /v1.0/teams/team-id/channels/general-id/tabs": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":            "tab-id",
+							"displayName":   "Wiki",
+							"webUrl":        "https://teams.microsoft.com/l/entity/tab-id",
+							"configuration": map[string]interface{}{"contentUrl": "https://contoso.com/wiki", "websiteUrl": "https://contoso.com"},
+							"teamsApp":      map[string]interface{}{"id": "com.microsoft.teamspace.tab.wiki", "displayName": "Wiki", "distributionMethod": "store"},
+						},
+					},
+				},
+				"GET /v1.0/teams/team-id/channels/private-id/tabs": graphtest.Error{Status: 403, Code: "Forbidden", Message: "Access denied"},
+			},
+		},
+		{
+			tool:      "teams_apps",
+			arguments: map[string]interface{}{"distribution_method": "organization"},
+			routes: graphtest.Routes{
+				"GET /v1.0/appCatalogs/teamsApps": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":                 "app-id",
+							"externalId":         "external-id",
+							"displayName":        "Expenses",
+							"distributionMethod": "organization",
+							"appDefinitions": []interface{}{
+								map[string]interface{}{"version": "1.0.0", "publishingState": "published", "description": "Submit expenses"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			tool:      "call_record",
+			arguments: map[string]interface{}{"call_id": "call-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/communications/callRecords/call-id": map[string]interface{}{
+					"id":            "call-id",
+					"type":          "groupCall",
+					"modalities":    []interface{}{"audio", "video"},
+					"startDateTime": "2024-01-02T03:04:05Z",
+					"endDateTime":   "2024-01-02T03:34:05Z",
+					"joinWebUrl":    "https://teams.microsoft.com/l/meetup-join/call-id",
+					"organizer":     map[string]interface{}{"user": map[string]interface{}{"id": "user-id", "displayName": "Adele Vance"}},
+					"participants": []interface{}{
+						map[string]interface{}{"user": map[string]interface{}{"id": "user-id", "displayName": "Adele Vance"}},
+						map[string]interface{}{"user": map[string]interface{}{"id": "other-id"}},
+					},
+				},
+				"GET /v1.0/communications/callRecords/call-id/sessions": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":            "session-id",
+							"modalities":    []interface{}{"audio"},
+							"startDateTime": "2024-01-02T03:04:05Z",
+							"endDateTime":   "2024-01-02T03:34:05Z",
+							"caller":        map[string]interface{}{"@odata.type": "#microsoft.graph.callRecords.participantEndpoint", "identity": map[string]interface{}{"user": map[string]interface{}{"displayName": "Adele Vance"}}},
+							"callee":        map[string]interface{}{"@odata.type": "#microsoft.graph.callRecords.participantEndpoint", "name": "Room 12"},
+							"segments": []interface{}{
+								map[string]interface{}{
+									"id":          "segment-id",
+									"failureInfo": map[string]interface{}{"reason": "Network unreachable", "stage": "midcall"},
+									"media": []interface{}{
+										map[string]interface{}{
+											"streams": []interface{}{
+												map[string]interface{}{"maxPacketLossRate": 0.2, "maxJitter": "PT0.05S", "maxRoundTripTime": "PT0.6S", "averageAudioDegradation": 1.5},
+												map[string]interface{}{"maxPacketLossRate": 0.01, "maxJitter": "PT0.01S", "maxRoundTripTime": "PT0.1S"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
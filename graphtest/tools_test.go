@@ -0,0 +1,144 @@
+package graphtest_test
+
+import (
+	"sort"
+	"testing"
+
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/accessreviews"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/applications"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/audit"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/auditlogs"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/consents"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/contacts"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/devicemanagement"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/devices"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/directoryroles"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/domains"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/drive"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/drives"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/events"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/groups"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/identityprotection"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/licenses"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/lists"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/messages"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/organization"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/paging"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/policies"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/reports"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/roles"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/security"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/serviceprincipals"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/settings"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/sites"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/snapshot"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/teams"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/users"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// sample is a call of a tool, with the answers of Microsoft Graph to the requests it sends.
+type sample struct {
+	name      string
+	tool      string
+	arguments map[string]interface{}
+	routes    graphtest.Routes
+}
+
+// samples returns the sample calls of every tool, keyed by tool name.
+func samples() map[string][]sample {
+
+	all := [][]sample{
+		accessreviewsSamples(),
+		applicationsSamples(),
+		auditSamples(),
+		auditlogsSamples(),
+		consentsSamples(),
+		contactsSamples(),
+		devicemanagementSamples(),
+		devicesSamples(),
+		directoryrolesSamples(),
+		domainsSamples(),
+		driveSamples(),
+		drivesSamples(),
+		eventsSamples(),
+		groupsSamples(),
+		identityprotectionSamples(),
+		licensesSamples(),
+		listsSamples(),
+		messagesSamples(),
+		organizationSamples(),
+		pagingSamples(),
+		policiesSamples(),
+		reportsSamples(),
+		rolesSamples(),
+		securitySamples(),
+		serviceprincipalsSamples(),
+		settingsSamples(),
+		sitesSamples(),
+		snapshotSamples(),
+		teamsSamples(),
+		usersSamples(),
+	}
+
+	samples := map[string][]sample{}
+	for _, list := range all {
+		for _, s := range list {
+			samples[s.tool] = append(samples[s.tool], s)
+		}
+	}
+
+	return samples
+}
+
+// TestOutputSchemas calls every registered tool with its samples and validates the result
+// against the output schema of the tool. A tool without sample fails the test.
+func TestOutputSchemas(t *testing.T) {
+
+	samples := samples()
+
+	names := []string{}
+	for name := range collection.Tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			if len(samples[name]) == 0 {
+				t.Fatalf("the tool %s has no sample", name)
+			}
+			for _, s := range samples[name] {
+				if s.name == "" {
+					graphtest.CheckTool(t, s.tool, s.arguments, s.routes)
+					continue
+				}
+				t.Run(s.name, func(t *testing.T) {
+					graphtest.CheckTool(t, s.tool, s.arguments, s.routes)
+				})
+			}
+		})
+	}
+
+	// Samples of tools that are not registered would never run
+	for name := range samples {
+		if _, ok := collection.Tools[name]; !ok {
+			t.Errorf("the tool %s of a sample is not registered", name)
+		}
+	}
+}
+
+// TestDebugArgument checks that every tool reading from Microsoft Graph declares the debug
+// argument, so that clients offer it.
+func TestDebugArgument(t *testing.T) {
+
+	for name, tool := range collection.Tools {
+		if tool.Write {
+			continue
+		}
+		if _, ok := tool.Tool.InputSchema.Properties["debug"]; !ok {
+			t.Errorf("the tool %s does not declare the debug argument", name)
+		}
+	}
+}
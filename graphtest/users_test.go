@@ -0,0 +1,237 @@
+package graphtest_test
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+)
+
+// user is a sample member user.
+var user = map[string]interface{}{
+	"id":                "user-id",
+	"displayName":       "Adele Vance",
+	"userPrincipalName": "adele@contoso.com",
+	"mail":              "adele@contoso.com",
+	"givenName":         "Adele",
+	"surname":           "Vance",
+	"jobTitle":          "Retail Manager",
+	"mobilePhone":       "+1 425 555 0109",
+	"officeLocation":    "18/2111",
+	"businessPhones":    []interface{}{"+1 425 555 0109"},
+	"preferredLanguage": "en-US",
+}
+
+// pendingGuest is a sample guest user who has not accepted their invitation.
+var pendingGuest = map[string]interface{}{
+	"id":                              "guest-id",
+	"displayName":                     "Guest",
+	"mail":                            "guest@fabrikam.com",
+	"userPrincipalName":               "guest_fabrikam.com#EXT#@contoso.onmicrosoft.com",
+	"userType":                        "Guest",
+	"createdDateTime":                 "2024-01-02T03:04:05Z",
+	"externalUserState":               "PendingAcceptance",
+	"externalUserStateChangeDateTime": "2024-01-02T03:04:05Z",
+}
+
+// jpeg is the start of a JPEG image.
+var jpeg = []byte{0xff, 0xd8, 0xff, 0xe0, 0x00, 0x10, 'J', 'F', 'I', 'F', 0x00}
+
+// png is the start of a PNG image.
+var png = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0x00, 0x00, 0x00, 0x0d}
+
+// usersSamples are the sample calls of the tools of the users package.
+func usersSamples() []sample {
+
+	return []sample{
+		{
+			tool:      "users",
+			arguments: map[string]interface{}{"include_status": true, "with_groups": true, "expand_org": true},
+			routes: graphtest.Routes{
+				"GET /v1.0/users": map[string]interface{}{
+					"value": []interface{}{user},
+				},
+				"GET /v1.0/users/user-id/memberOf": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"@odata.type": "#microsoft.graph.group", "id": "group-id", "displayName": "Sales"},
+						map[string]interface{}{"@odata.type": "#microsoft.graph.directoryRole", "id": "role-id", "displayName": "Global Reader"},
+					},
+				},
+				"GET /v1.0/users/user-id/manager": map[string]interface{}{"@odata.type": "#microsoft.graph.user", "id": "manager-id", "displayName": "Megan Bowen"},
+				"GET /v1.0/users/user-id/directReports": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"@odata.type": "#microsoft.graph.user", "id": "report-id", "displayName": "Alex Wilber"},
+					},
+				},
+			},
+		},
+		{
+			tool:      "users",
+			arguments: map[string]interface{}{"mode": "get", "id": "user-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/user-id": user,
+			},
+		},
+		{
+			tool:      "users",
+			arguments: map[string]interface{}{"mode": "get", "id": "user-id", "etag": "W/\"etag-1\""},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/user-id": http.StatusNotModified,
+			},
+		},
+		{
+			tool:      "users",
+			arguments: map[string]interface{}{"mode": "search", "query": "adele"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users": map[string]interface{}{
+					"value": []interface{}{user},
+				},
+			},
+		},
+		{
+			tool:      "users",
+			arguments: map[string]interface{}{"mode": "delta"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/delta()": map[string]interface{}{
+					"@odata.deltaLink": "https://graph.microsoft.com/v1.0/users/delta()?$deltatoken=token",
+					"value": []interface{}{
+						user,
+						map[string]interface{}{"id": "removed-id", "@removed": map[string]interface{}{"reason": "changed"}},
+					},
+				},
+			},
+		},
+		{
+			tool:      "user_sign_in_activity",
+			arguments: map[string]interface{}{"inactive_since": "2024-01-01"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":                "user-id",
+							"displayName":       "Adele Vance",
+							"userPrincipalName": "adele@contoso.com",
+							"accountEnabled":    true,
+							"userType":          "Member",
+							"createdDateTime":   "2020-01-02T03:04:05Z",
+							"signInActivity":    map[string]interface{}{"lastSignInDateTime": "2023-06-02T03:04:05Z", "lastNonInteractiveSignInDateTime": "2023-06-03T03:04:05Z"},
+						},
+						map[string]interface{}{"id": "never-id", "displayName": "Never Signed In", "accountEnabled": false},
+					},
+				},
+			},
+		},
+		{
+			tool:      "guests",
+			arguments: map[string]interface{}{"state": "PendingAcceptance", "with_sponsors": true},
+			routes: graphtest.Routes{
+				"GET /v1.0/users": map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id":                "guest-id",
+							"displayName":       "Guest",
+							"mail":              "guest@fabrikam.com",
+							"createdDateTime":   "2024-01-02T03:04:05Z",
+							"externalUserState": "PendingAcceptance",
+							"sponsors": []interface{}{
+								map[string]interface{}{"@odata.type": "#microsoft.graph.user", "id": "user-id", "displayName": "Adele Vance", "userPrincipalName": "adele@contoso.com"},
+								map[string]interface{}{"@odata.type": "#microsoft.graph.group", "id": "group-id", "displayName": "Sales"},
+							},
+						},
+						map[string]interface{}{"id": "accepted-id", "externalUserState": "Accepted"},
+					},
+				},
+			},
+		},
+		{
+			tool:      "resend_invitation",
+			arguments: map[string]interface{}{"user_ids": "guest-id, user-id, missing-id"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/guest-id":   pendingGuest,
+				"GET /v1.0/users/user-id":    user,
+				"GET /v1.0/users/missing-id": graphtest.Error{Status: http.StatusNotFound, Code: "Request_ResourceNotFound", Message: "Resource 'missing-id' does not exist."},
+				"POST /v1.0/invitations": map[string]interface{}{
+					"status":          "PendingAcceptance",
+					"inviteRedeemUrl": "https://login.microsoftonline.com/redeem?token",
+				},
+			},
+		},
+		{
+			tool:      "create_user",
+			arguments: map[string]interface{}{"display_name": "Adele Vance", "user_principal_name": "adele@contoso.com", "password": "xWwvJ]6NMw+bWH-d"},
+			routes: graphtest.Routes{
+				"POST /v1.0/users": user,
+			},
+		},
+		{
+			tool:      "update_user",
+			arguments: map[string]interface{}{"user_id": "user-id", "job_title": "Retail Director", "account_enabled": false},
+			routes: graphtest.Routes{
+				"PATCH /v1.0/users/user-id": http.StatusNoContent,
+			},
+		},
+		{
+			tool:      "update_user_attributes",
+			arguments: map[string]interface{}{"user_id": "user-id", "attributes": map[string]interface{}{"usageLocation": "FR", "employeeType": nil}},
+			routes: graphtest.Routes{
+				"PATCH /v1.0/users/user-id": http.StatusNoContent,
+			},
+		},
+		{
+			tool:      "update_user_manager",
+			arguments: map[string]interface{}{"user_id": "user-id", "action": "set", "manager_id": "megan@contoso.com"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/megan@contoso.com":    map[string]interface{}{"id": "manager-id"},
+				"PUT /v1.0/users/user-id/manager/$ref": http.StatusNoContent,
+			},
+		},
+		{
+			tool:      "update_user_manager",
+			arguments: map[string]interface{}{"user_id": "user-id", "action": "remove"},
+			routes: graphtest.Routes{
+				"DELETE /v1.0/users/user-id/manager/$ref": http.StatusNoContent,
+			},
+		},
+		{
+			tool:      "delete_user",
+			arguments: map[string]interface{}{"user_id": "9f4b6f2c-3b4a-4a8e-8f4e-2a8f1e6c7d10", "permanent": true},
+			routes: graphtest.Routes{
+				"DELETE /v1.0/users/9f4b6f2c-3b4a-4a8e-8f4e-2a8f1e6c7d10":                  http.StatusNoContent,
+				"DELETE /v1.0/directory/deletedItems/9f4b6f2c-3b4a-4a8e-8f4e-2a8f1e6c7d10": graphtest.Error{Status: http.StatusForbidden, Code: "Authorization_RequestDenied", Message: "Insufficient privileges to complete the operation."},
+			},
+		},
+		{
+			tool:      "restore_user",
+			arguments: map[string]interface{}{"user_id": "9f4b6f2c-3b4a-4a8e-8f4e-2a8f1e6c7d10"},
+			routes: graphtest.Routes{
+				"POST /v1.0/directory/deletedItems/9f4b6f2c-3b4a-4a8e-8f4e-2a8f1e6c7d10/restore": map[string]interface{}{
+					"@odata.type":       "#microsoft.graph.user",
+					"id":                "9f4b6f2c-3b4a-4a8e-8f4e-2a8f1e6c7d10",
+					"displayName":       "Adele Vance",
+					"userPrincipalName": "9f4b6f2c3b4a4a8e8f4e2a8f1e6c7d10AdeleV@contoso.com",
+				},
+			},
+		},
+		{
+			tool:      "reset_password",
+			arguments: map[string]interface{}{"user_id": "user-id", "new_password": "xWwvJ]6NMw+bWH-d", "force_change": false},
+			routes: graphtest.Routes{
+				"PATCH /v1.0/users/user-id": http.StatusNoContent,
+			},
+		},
+		{
+			tool:      "user_photo",
+			arguments: map[string]interface{}{"user_id": "user-id", "size": "48x48"},
+			routes: graphtest.Routes{
+				"GET /v1.0/users/user-id/photos/48x48/$value": jpeg,
+			},
+		},
+		{
+			tool:      "upload_user_photo",
+			arguments: map[string]interface{}{"user_id": "user-id", "image": base64.StdEncoding.EncodeToString(png)},
+			routes: graphtest.Routes{
+				"PUT /v1.0/users/user-id/photo/$value": http.StatusNoContent,
+			},
+		},
+	}
+}
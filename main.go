@@ -4,13 +4,41 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	// Import all the tools implemented here.
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/accessreviews"
 	_ "github.com/acuvity/mcp-server-microsoft-graph/api/applications"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/audit"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/auditlogs"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/consents"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/contacts"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/devicemanagement"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/devices"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/directoryroles"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/domains"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/drive"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/drives"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/events"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/groups"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/identityprotection"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/licenses"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/lists"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/messages"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/organization"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/paging"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/policies"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/reports"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/roles"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/security"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/serviceprincipals"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/settings"
 	_ "github.com/acuvity/mcp-server-microsoft-graph/api/sites"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/snapshot"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/teams"
 	_ "github.com/acuvity/mcp-server-microsoft-graph/api/users"
 	"github.com/acuvity/mcp-server-microsoft-graph/cmd/cli"
 	"github.com/acuvity/mcp-server-microsoft-graph/mcp"
@@ -58,9 +86,56 @@ func main() {
 		},
 	}
 
+	var exportPagesCommand = &cobra.Command{
+		Use:   "export-pages",
+		Short: "Export the pages of a site as Markdown files.",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return viper.BindPFlags(cmd.Flags())
+		},
+		RunE: cli.ExportPages,
+	}
+	exportPagesCommand.Flags().String("site-id", "", "ID of the site to export")
+	exportPagesCommand.Flags().String("output-dir", "pages", "Directory receiving the Markdown files")
+	cliCommand.AddCommand(exportPagesCommand)
+
+	var snapshotCommand = &cobra.Command{
+		Use:   "snapshot",
+		Short: "Export a snapshot of the users, groups, applications, service principals and sites.",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return viper.BindPFlags(cmd.Flags())
+		},
+		RunE: cli.Snapshot,
+	}
+	snapshotCommand.Flags().String("output", "", "File receiving the snapshot, the standard output if empty")
+	snapshotCommand.Flags().Int("max-items", 0, "Maximum number of objects per section (0 for no limit)")
+	snapshotCommand.Flags().Int("max-bytes", 0, "Maximum size of the sections (0 for no limit)")
+	snapshotCommand.Flags().Duration("timeout", 30*time.Minute, "Time given to the enumerations (0 for no limit)")
+	cliCommand.AddCommand(snapshotCommand)
+
+	var snapshotDiffCommand = &cobra.Command{
+		Use:   "snapshot-diff <old> <new>",
+		Short: "Compare two snapshots and print the users, groups, applications, service principals and sites added, removed or modified.",
+		Args:  cobra.ExactArgs(2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return viper.BindPFlags(cmd.Flags())
+		},
+		RunE: cli.SnapshotDiff,
+	}
+	cliCommand.AddCommand(snapshotDiffCommand)
+
+	var printSchemaCmd = &cobra.Command{
+		Use:   "print-schema [tool...]",
+		Short: "Prints the JSON schema of the tools output and exit.",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return viper.BindPFlags(cmd.Flags())
+		},
+		RunE: cli.PrintSchema,
+	}
+
 	rootCmd.AddCommand(
 		versionCmd,
 		cliCommand,
+		printSchemaCmd,
 	)
 
 	rootCmd.PersistentFlags().String("tenant-id", "", "Microsoft Tenant ID")
@@ -68,6 +143,10 @@ func main() {
 	rootCmd.PersistentFlags().String("client-secret", "", "Microsoft Client Secret")
 	rootCmd.PersistentFlags().String("transport", "sse", "MCP transport type (stdio or sse)")
 	rootCmd.PersistentFlags().String("service-name", "localhost", "Microsoft Service Name")
+	rootCmd.PersistentFlags().Bool("enable-write", false, "Expose the tools modifying the tenant")
+	rootCmd.PersistentFlags().Bool("resolve-names", false, "Resolve directory object ids to display names in tool results")
+	rootCmd.PersistentFlags().Int("default-limit", 100, "Maximum number of results of listing tools called without filter nor limit (0 for no limit)")
+	rootCmd.PersistentFlags().Bool("log-requests", false, "Log the method and URL of every Microsoft Graph request")
 
 	viper.SetConfigName("config") // name of the file (without extension)
 	viper.SetConfigType("yaml")   // or viper.SetConfigType("json") if it's json
@@ -0,0 +1,218 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingRegex    = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	unorderedRegex  = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	orderedRegex    = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	ruleRegex       = regexp.MustCompile(`^(-{3,}|\*{3,}|_{3,})$`)
+	inlineCodeRegex = regexp.MustCompile("`([^`]+)`")
+	inlineBoldRegex = regexp.MustCompile(`(\*\*|__)(.+?)(\*\*|__)`)
+	inlineEmRegex   = regexp.MustCompile(`(^|[^*\w])[*_]([^*_]+)[*_]`)
+)
+
+// ToHTML converts Markdown content to HTML, such as the content of a SharePoint text web part.
+// It is the counterpart of FromHTML and handles the same elements: headings, paragraphs, bold,
+// italic, links, images, lists, code, blockquotes and horizontal rules. Raw HTML is escaped.
+func ToHTML(markdownContent string) string {
+
+	var result strings.Builder
+
+	// The open block is closed when a line of another kind, or a blank line, is met
+	block := ""
+	paragraph := []string{}
+	closeBlock := func() {
+		switch block {
+		case "p":
+			result.WriteString("<p>" + strings.Join(paragraph, "<br>") + "</p>")
+			paragraph = paragraph[:0]
+		case "ul", "ol", "blockquote":
+			result.WriteString("</" + block + ">")
+		}
+		block = ""
+	}
+	openBlock := func(tag string) {
+		if block == tag {
+			return
+		}
+		closeBlock()
+		if tag != "p" {
+			result.WriteString("<" + tag + ">")
+		}
+		block = tag
+	}
+
+	lines := strings.Split(strings.ReplaceAll(markdownContent, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+
+		// Code blocks are kept as is, up to the closing fence
+		if strings.HasPrefix(line, "```") {
+			closeBlock()
+			code := []string{}
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, html.EscapeString(lines[i]))
+			}
+			result.WriteString("<pre><code>" + strings.Join(code, "\n") + "</code></pre>")
+			continue
+		}
+
+		if match := headingRegex.FindStringSubmatch(line); match != nil {
+			closeBlock()
+			level := strconv.Itoa(len(match[1]))
+			result.WriteString("<h" + level + ">" + inlineToHTML(match[2]) + "</h" + level + ">")
+			continue
+		}
+
+		switch {
+		case line == "":
+			closeBlock()
+		case ruleRegex.MatchString(line):
+			closeBlock()
+			result.WriteString("<hr>")
+		case unorderedRegex.MatchString(line):
+			openBlock("ul")
+			result.WriteString("<li>" + inlineToHTML(unorderedRegex.FindStringSubmatch(line)[1]) + "</li>")
+		case orderedRegex.MatchString(line):
+			openBlock("ol")
+			result.WriteString("<li>" + inlineToHTML(orderedRegex.FindStringSubmatch(line)[1]) + "</li>")
+		case strings.HasPrefix(line, ">"):
+			openBlock("blockquote")
+			result.WriteString("<p>" + inlineToHTML(strings.TrimSpace(strings.TrimPrefix(line, ">"))) + "</p>")
+		default:
+			openBlock("p")
+			paragraph = append(paragraph, inlineToHTML(line))
+		}
+	}
+	closeBlock()
+
+	return result.String()
+}
+
+// allowedSchemes are the URL schemes links and images may use, other targets with a scheme, such
+// as javascript:, are dropped. Relative targets are allowed.
+var allowedSchemes = []string{"http", "https", "mailto"}
+
+// inlineToHTML escapes a line of Markdown and converts its inline elements. Code spans are
+// converted first and set aside so that their content is not formatted, as are the tags of
+// links and images so that their URL is not.
+func inlineToHTML(text string) string {
+
+	text = html.EscapeString(strings.NewReplacer("\x00", "", "\x01", "").Replace(text))
+
+	codes := []string{}
+	text = inlineCodeRegex.ReplaceAllStringFunc(text, func(code string) string {
+		codes = append(codes, "<code>"+inlineCodeRegex.FindStringSubmatch(code)[1]+"</code>")
+		return "\x00"
+	})
+
+	tags := []string{}
+	text = linksToHTML(text, func(tag string) string {
+		tags = append(tags, tag)
+		return "\x01"
+	})
+
+	text = inlineBoldRegex.ReplaceAllString(text, "<strong>$2</strong>")
+	text = inlineEmRegex.ReplaceAllString(text, "$1<em>$2</em>")
+
+	for _, tag := range tags {
+		text = strings.Replace(text, "\x01", tag, 1)
+	}
+	for _, code := range codes {
+		text = strings.Replace(text, "\x00", code, 1)
+	}
+
+	return text
+}
+
+// linksToHTML converts the links and images of escaped Markdown text. The tags are passed to
+// setAside, which returns the text replacing them. Targets may contain balanced parentheses. The
+// links whose target is not allowed are replaced by their text, the images by their alt text.
+func linksToHTML(text string, setAside func(tag string) string) string {
+
+	var result strings.Builder
+
+	for i := 0; i < len(text); {
+		image := strings.HasPrefix(text[i:], "![")
+		start := i
+		if image {
+			start++
+		}
+
+		label, target, end, ok := parseLink(text, start)
+		if !ok || (!image && label == "") {
+			result.WriteByte(text[i])
+			i++
+			continue
+		}
+
+		switch {
+		case !allowedURL(target):
+			result.WriteString(label)
+		case image:
+			result.WriteString(setAside(`<img src="` + target + `" alt="` + label + `">`))
+		default:
+			result.WriteString(setAside(`<a href="`+target+`">`) + label + "</a>")
+		}
+		i = end
+	}
+
+	return result.String()
+}
+
+// parseLink parses the link starting with the '[' at start, and returns its label, its target and
+// the position following it. ok is false if there is no link at start.
+func parseLink(text string, start int) (label string, target string, end int, ok bool) {
+
+	if start >= len(text) || text[start] != '[' {
+		return "", "", 0, false
+	}
+	closing := strings.IndexByte(text[start+1:], ']')
+	if closing < 0 {
+		return "", "", 0, false
+	}
+	closing += start + 1
+	if closing+1 >= len(text) || text[closing+1] != '(' {
+		return "", "", 0, false
+	}
+
+	// The target ends with the parenthesis closing the opening one
+	depth := 0
+	for j := closing + 2; j < len(text); j++ {
+		switch c := text[j]; {
+		case c <= ' ':
+			return "", "", 0, false
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth == 0 {
+				if j == closing+2 {
+					return "", "", 0, false
+				}
+				return text[start+1 : closing], text[closing+2 : j], j + 1, true
+			}
+			depth--
+		}
+	}
+
+	return "", "", 0, false
+}
+
+// allowedURL reports whether an escaped link target is relative or uses an allowed scheme.
+func allowedURL(target string) bool {
+
+	target = strings.ToLower(html.UnescapeString(target))
+	colon := strings.IndexAny(target, ":/?#")
+	if colon < 0 || target[colon] != ':' {
+		return true
+	}
+
+	return slices.Contains(allowedSchemes, target[:colon])
+}
@@ -0,0 +1,50 @@
+package markdown
+
+import (
+	"testing"
+)
+
+func TestToHTML(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{"heading", "# Title", "<h1>Title</h1>"},
+		{"heading with closing hashes", "### Section ###", "<h3>Section</h3>"},
+		{"paragraph", "first line\nsecond line\n\nnext", "<p>first line<br>second line</p><p>next</p>"},
+		{"bold and italic", "**bold** and *italic*", "<p><strong>bold</strong> and <em>italic</em></p>"},
+		{"unordered list", "- one\n- two", "<ul><li>one</li><li>two</li></ul>"},
+		{"ordered list", "1. one\n2. two", "<ol><li>one</li><li>two</li></ol>"},
+		{"list then paragraph", "- one\ntext", "<ul><li>one</li></ul><p>text</p>"},
+		{"blockquote", "> quoted", "<blockquote><p>quoted</p></blockquote>"},
+		{"rule", "---", "<hr>"},
+		{"code block", "```\n<b>x</b>\n**y**\n```", "<pre><code>&lt;b&gt;x&lt;/b&gt;\n**y**</code></pre>"},
+		{"inline code", "run `a *b* <c>`", "<p>run <code>a *b* &lt;c&gt;</code></p>"},
+		{"raw html escaped", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{"quotes escaped", `say "hi"`, "<p>say &#34;hi&#34;</p>"},
+		{"link", "[docs](https://contoso.com/docs)", `<p><a href="https://contoso.com/docs">docs</a></p>`},
+		{"relative link", "[docs](/sites/docs)", `<p><a href="/sites/docs">docs</a></p>`},
+		{"mailto link", "[mail](mailto:adele@contoso.com)", `<p><a href="mailto:adele@contoso.com">mail</a></p>`},
+		{"bold link text", "[**docs**](https://contoso.com)", `<p><a href="https://contoso.com"><strong>docs</strong></a></p>`},
+		{"url not formatted", "[x](https://contoso.com/a_b_c)", `<p><a href="https://contoso.com/a_b_c">x</a></p>`},
+		{"balanced parentheses", "[wiki](https://en.wikipedia.org/wiki/Go_(language)) after", `<p><a href="https://en.wikipedia.org/wiki/Go_(language)">wiki</a> after</p>`},
+		{"javascript link dropped", "[x](javascript:alert(1))", "<p>x</p>"},
+		{"javascript link in upper case dropped", "[x](JavaScript:alert(1))", "<p>x</p>"},
+		{"data link dropped", "[x](data:text/html;base64,PHNjcmlwdD4=)", "<p>x</p>"},
+		{"quote in url escaped", `[x](https://contoso.com/"onmouseover="alert(1))`, `<p><a href="https://contoso.com/&#34;onmouseover=&#34;alert(1)">x</a></p>`},
+		{"image", "![logo](https://contoso.com/logo.png)", `<p><img src="https://contoso.com/logo.png" alt="logo"></p>`},
+		{"javascript image dropped", "![logo](javascript:alert(1))", "<p>logo</p>"},
+		{"not a link", "[x] (y)", "<p>[x] (y)</p>"},
+		{"unbalanced target", "[x](https://contoso.com/(a", "<p>[x](https://contoso.com/(a</p>"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ToHTML(test.markdown); got != test.want {
+				t.Errorf("got\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
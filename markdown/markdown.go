@@ -0,0 +1,171 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// FromHTML converts HTML content, such as a SharePoint web part or a mail body, to Markdown.
+func FromHTML(htmlContent string) string {
+	// Unescape HTML entities
+	unescaped := html.UnescapeString(htmlContent)
+
+	// Create a string builder for the result
+	var result strings.Builder
+
+	// Basic HTML to Markdown conversions - these are simplified and won't handle all HTML
+
+	// Replace headings
+	h1Regex := regexp.MustCompile(`<h1[^>]*>(.*?)</h1>`)
+	unescaped = h1Regex.ReplaceAllString(unescaped, "# $1\n\n")
+
+	h2Regex := regexp.MustCompile(`<h2[^>]*>(.*?)</h2>`)
+	unescaped = h2Regex.ReplaceAllString(unescaped, "## $1\n\n")
+
+	h3Regex := regexp.MustCompile(`<h3[^>]*>(.*?)</h3>`)
+	unescaped = h3Regex.ReplaceAllString(unescaped, "### $1\n\n")
+
+	h4Regex := regexp.MustCompile(`<h4[^>]*>(.*?)</h4>`)
+	unescaped = h4Regex.ReplaceAllString(unescaped, "#### $1\n\n")
+
+	// Replace paragraph tags
+	pRegex := regexp.MustCompile(`<p[^>]*>(.*?)</p>`)
+	unescaped = pRegex.ReplaceAllString(unescaped, "$1\n\n")
+
+	// Replace bold tags
+	boldRegex := regexp.MustCompile(`<(b|strong)[^>]*>(.*?)</\\1>`)
+	unescaped = boldRegex.ReplaceAllString(unescaped, "**$2**")
+
+	// Replace italic tags
+	italicRegex := regexp.MustCompile(`<(i|em)[^>]*>(.*?)</\\1>`)
+	unescaped = italicRegex.ReplaceAllString(unescaped, "*$2*")
+
+	// Replace links
+	linkRegex := regexp.MustCompile(`<a[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	unescaped = linkRegex.ReplaceAllString(unescaped, "[$2]($1)")
+
+	// Replace unordered lists
+	unescaped = strings.Replace(unescaped, "<ul>", "\n", -1)
+	unescaped = strings.Replace(unescaped, "</ul>", "\n", -1)
+	liRegex := regexp.MustCompile(`<li[^>]*>(.*?)</li>`)
+	unescaped = liRegex.ReplaceAllString(unescaped, "- $1\n")
+
+	// Replace ordered lists
+	unescaped = strings.Replace(unescaped, "<ol>", "\n", -1)
+	unescaped = strings.Replace(unescaped, "</ol>", "\n", -1)
+	olLiRegex := regexp.MustCompile(`<li[^>]*>(.*?)</li>`)
+	unescaped = olLiRegex.ReplaceAllString(unescaped, "1. $1\n")
+
+	// Replace images
+	imgRegex := regexp.MustCompile(`<img[^>]*src="([^"]*)"[^>]*alt="([^"]*)"[^>]*>`)
+	unescaped = imgRegex.ReplaceAllString(unescaped, "![$2]($1)")
+
+	// Handle tables
+	tableRegex := regexp.MustCompile(`<table[^>]*>(.*?)</table>`)
+	tableMatches := tableRegex.FindAllStringSubmatch(unescaped, -1)
+	for _, match := range tableMatches {
+		fullTableHTML := match[0]
+		tableContent := match[1]
+
+		var mdTable strings.Builder
+
+		// Extract rows
+		trRegex := regexp.MustCompile(`<tr[^>]*>(.*?)</tr>`)
+		rows := trRegex.FindAllStringSubmatch(tableContent, -1)
+
+		// Process header row
+		if len(rows) > 0 {
+			thRegex := regexp.MustCompile(`<th[^>]*>(.*?)</th>`)
+			headerCells := thRegex.FindAllStringSubmatch(rows[0][1], -1)
+
+			if len(headerCells) > 0 {
+				// This is a header row
+				for _, cell := range headerCells {
+					mdTable.WriteString("| ")
+					mdTable.WriteString(strings.TrimSpace(cell[1]))
+					mdTable.WriteString(" ")
+				}
+				mdTable.WriteString("|\n")
+
+				// Add separator row
+				for i := 0; i < len(headerCells); i++ {
+					mdTable.WriteString("| --- ")
+				}
+				mdTable.WriteString("|\n")
+			} else {
+				// No header cells, check for data cells in the first row for table structure
+				tdRegex := regexp.MustCompile(`<td[^>]*>(.*?)</td>`)
+				firstRowCells := tdRegex.FindAllStringSubmatch(rows[0][1], -1)
+
+				// Create header based on number of columns
+				for i := 0; i < len(firstRowCells); i++ {
+					mdTable.WriteString("| Column ")
+					mdTable.WriteString(fmt.Sprintf("%d", i+1))
+					mdTable.WriteString(" ")
+				}
+				mdTable.WriteString("|\n")
+
+				// Add separator row
+				for i := 0; i < len(firstRowCells); i++ {
+					mdTable.WriteString("| --- ")
+				}
+				mdTable.WriteString("|\n")
+			}
+		}
+
+		// Process data rows
+		for _, row := range rows {
+			tdRegex := regexp.MustCompile(`<td[^>]*>(.*?)</td>`)
+			cells := tdRegex.FindAllStringSubmatch(row[1], -1)
+
+			for _, cell := range cells {
+				mdTable.WriteString("| ")
+				mdTable.WriteString(strings.TrimSpace(cell[1]))
+				mdTable.WriteString(" ")
+			}
+			mdTable.WriteString("|\n")
+		}
+
+		// Replace the HTML table with the Markdown table
+		unescaped = strings.Replace(unescaped, fullTableHTML, mdTable.String(), 1)
+	}
+
+	// Handle code blocks
+	preRegex := regexp.MustCompile(`<pre[^>]*>(.*?)</pre>`)
+	unescaped = preRegex.ReplaceAllString(unescaped, "```\n$1\n```\n\n")
+
+	codeRegex := regexp.MustCompile(`<code[^>]*>(.*?)</code>`)
+	unescaped = codeRegex.ReplaceAllString(unescaped, "`$1`")
+
+	// Replace blockquotes
+	blockquoteRegex := regexp.MustCompile(`<blockquote[^>]*>(.*?)</blockquote>`)
+	unescaped = blockquoteRegex.ReplaceAllString(unescaped, "> $1\n\n")
+
+	// Replace horizontal rules
+	hrRegex := regexp.MustCompile(`<hr[^>]*>`)
+	unescaped = hrRegex.ReplaceAllString(unescaped, "---\n\n")
+
+	// Replace divs and spans with their content
+	divRegex := regexp.MustCompile(`<(div|span)[^>]*>(.*?)</\\1>`)
+	for divRegex.MatchString(unescaped) {
+		unescaped = divRegex.ReplaceAllString(unescaped, "$2")
+	}
+
+	// Replace breaks with newlines
+	brRegex := regexp.MustCompile(`<br[^>]*>`)
+	unescaped = brRegex.ReplaceAllString(unescaped, "\n")
+
+	// Remove other HTML tags
+	tagRegex := regexp.MustCompile(`<[^>]*>`)
+	unescaped = tagRegex.ReplaceAllString(unescaped, "")
+
+	// Clean up extra whitespace
+	unescaped = strings.TrimSpace(unescaped)
+	spaceRegex := regexp.MustCompile(`\n{3,}`)
+	unescaped = spaceRegex.ReplaceAllString(unescaped, "\n\n")
+
+	result.WriteString(unescaped)
+	return result.String()
+}
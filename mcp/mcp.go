@@ -2,15 +2,90 @@ package mcp
 
 import (
 	"fmt"
+	"log"
+	"strings"
 
 	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
 	"github.com/acuvity/mcp-server-microsoft-graph/client"
 	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/acuvity/mcp-server-microsoft-graph/permissions"
+	"github.com/acuvity/mcp-server-microsoft-graph/trace"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+// Options configures the MCP server.
+type Options struct {
+	// ClientID is the id of the application, used to verify the permissions of write tools.
+	ClientID string
+	// EnableWrite exposes the tools modifying the tenant.
+	EnableWrite bool
+	// ResolveNames resolves directory object ids to display names in tool results.
+	ResolveNames bool
+	// DefaultLimit caps the listing tools called without filter nor limit, zero disables the cap.
+	DefaultLimit int
+	// ToolDefaults are the default values of the arguments of each tool, applied when the caller
+	// does not give them.
+	ToolDefaults collection.Defaults
+	// LogRequests logs the method and URL of every Microsoft Graph request.
+	LogRequests bool
+}
+
+// NewServer creates the MCP server exposing the registered tools.
+func NewServer(options Options) *server.MCPServer {
+
+	paginate.DefaultLimit = options.DefaultLimit
+	trace.Log = options.LogRequests
+
+	transforms := []output.Transformer{}
+	if options.ResolveNames {
+		transforms = append(transforms, output.ResolveNames)
+	}
+	transforms = append(transforms, output.ClientFilter, output.KeyBy, output.GroupBy, output.ClientSort)
+
+	// The defaults are merged first, so that the other middlewares see them, and the requests
+	// are traced around the output transformers, so that their failures are traced too
+	opts := []server.ServerOption{
+		server.WithToolHandlerMiddleware(collection.DefaultsMiddleware(options.ToolDefaults)),
+		server.WithToolHandlerMiddleware(trace.Middleware),
+		server.WithToolHandlerMiddleware(output.Middleware(transforms...)),
+		server.WithToolHandlerMiddleware(paginate.ProgressMiddleware),
+	}
+
+	// Create a new MCP server
+	s := server.NewMCPServer(
+		"Microsoft MCP Server",
+		"1.0.0",
+		opts...,
+	)
+
+	for _, tool := range collection.Tools {
+		if !tool.Write {
+			s.AddTool(tool.Tool, tool.Processor)
+			continue
+		}
+		// Write tools are only exposed on demand, and check their permissions first
+		if options.EnableWrite {
+			s.AddTool(tool.Tool, permissions.Preflight(options.ClientID, tool.RequiredScopes, tool.Processor))
+		}
+	}
+
+	return s
+}
+
+// NewSSEServer creates the SSE transport of the MCP server. Tools are called with the given
+// Microsoft Graph client, which lets a fake client be injected. The SSE server is an
+// http.Handler, so it can also be served by an httptest.Server.
+func NewSSEServer(s *server.MCPServer, cl *msgraphsdk.GraphServiceClient, baseURL string) *server.SSEServer {
+	return server.NewSSEServer(s, server.WithBaseURL(baseURL), server.WithSSEContextFunc(baggage.WithInfomationFromRequest(cl)))
+}
+
+// Run starts the MCP server on the configured transport.
 func Run(cmd *cobra.Command, args []string) error {
 
 	cl, err := client.GetClient(
@@ -22,16 +97,20 @@ func Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("error creating client: %v", err)
 	}
 
-	// Create a new MCP server
-	s := server.NewMCPServer(
-		"Microsoft MCP Server",
-		"1.0.0",
-	)
-
-	for _, tool := range collection.Tools {
-		s.AddTool(tool.Tool, tool.Processor)
+	defaults, err := toolDefaults()
+	if err != nil {
+		return err
 	}
 
+	s := NewServer(Options{
+		ClientID:     viper.GetString("client-id"),
+		EnableWrite:  viper.GetBool("enable-write"),
+		ResolveNames: viper.GetBool("resolve-names"),
+		DefaultLimit: viper.GetInt("default-limit"),
+		ToolDefaults: defaults,
+		LogRequests:  viper.GetBool("log-requests"),
+	})
+
 	// Start the server
 	switch viper.GetString("transport") {
 	case "stdio":
@@ -39,11 +118,8 @@ func Run(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("server error: %v", err)
 		}
 	case "sse":
-		server := server.NewSSEServer(s, server.WithBaseURL(fmt.Sprintf("http://%s:8000", viper.GetString("service-name"))), server.WithSSEContextFunc(baggage.WithInfomationFromRequest(cl)))
-		if server == nil {
-			return fmt.Errorf("server error: %v", err)
-		}
-		if err := server.Start(":8000"); err != nil {
+		sseServer := NewSSEServer(s, cl, fmt.Sprintf("http://%s:8000", viper.GetString("service-name")))
+		if err := sseServer.Start(":8000"); err != nil {
 			return fmt.Errorf("server error: %v", err)
 		}
 	default:
@@ -51,3 +127,45 @@ func Run(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// toolDefaults reads the tool-defaults section of the configuration, mapping each tool name to
+// the default values of its arguments. The configuration lowercases the argument names, so they
+// are mapped back to the names the tool declares, and unknown arguments are rejected.
+func toolDefaults() (collection.Defaults, error) {
+
+	defaults := make(collection.Defaults)
+	for name, arguments := range viper.GetStringMap("tool-defaults") {
+		tool, ok := collection.Tools[name]
+		if !ok {
+			log.Printf("tool-defaults: ignoring unknown tool '%s'", name)
+			continue
+		}
+		values, ok := arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("tool-defaults: the arguments of '%s' are not a map", name)
+		}
+		defaults[name] = make(map[string]interface{}, len(values))
+		for key, value := range values {
+			argument := declaredArgument(tool.Tool, key)
+			if argument == "" {
+				return nil, fmt.Errorf("tool-defaults: '%s' has no argument '%s'", name, key)
+			}
+			defaults[name][argument] = value
+		}
+	}
+
+	return defaults, nil
+}
+
+// declaredArgument returns the name the tool declares for the given argument, compared case
+// insensitively, or an empty string if the tool has no such argument.
+func declaredArgument(tool mcp.Tool, key string) string {
+
+	for property := range tool.InputSchema.Properties {
+		if strings.EqualFold(property, key) {
+			return property
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,318 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/organization"
+	_ "github.com/acuvity/mcp-server-microsoft-graph/api/users"
+	"github.com/acuvity/mcp-server-microsoft-graph/args"
+	"github.com/acuvity/mcp-server-microsoft-graph/collection"
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/viper"
+)
+
+// organizationGraph answers the organization of the tenant, and fails any other request.
+var organizationGraph = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1.0/organization" {
+		graphtest.WriteError(w, http.StatusNotFound, "Request_ResourceNotFound", "unexpected request "+r.URL.Path)
+		return
+	}
+	graphtest.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"value": []interface{}{
+			map[string]interface{}{
+				"id":          "tenant-id",
+				"displayName": "Contoso",
+				"verifiedDomains": []interface{}{
+					map[string]interface{}{"name": "contoso.com", "isDefault": true},
+				},
+			},
+		},
+	})
+})
+
+// serveSSE serves the MCP server over SSE on an ephemeral port, with a Microsoft Graph client
+// answered by the handler, and returns its base URL. The server is closed with the test.
+func serveSSE(t *testing.T, graph http.Handler) string {
+
+	t.Helper()
+
+	cl, err := graphtest.NewClient(graph)
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	baseURL := "http://" + listener.Addr().String()
+	httpServer := &http.Server{Handler: NewSSEServer(NewServer(Options{}), cl, baseURL)}
+	go func() { _ = httpServer.Serve(listener) }()
+	t.Cleanup(func() { _ = httpServer.Close() })
+
+	return baseURL
+}
+
+// startSSEServer serves the MCP server over SSE with a Microsoft Graph client answered by the
+// handler, and returns an MCP client connected and initialized with the given options. The server
+// and the client are closed with the test.
+func startSSEServer(t *testing.T, graph http.Handler, options ...transport.ClientOption) *client.Client {
+
+	t.Helper()
+
+	baseURL := serveSSE(t, graph)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	c, err := client.NewSSEMCPClient(baseURL+"/sse", options...)
+	if err != nil {
+		t.Fatalf("creating MCP client: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("starting MCP client: %v", err)
+	}
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "test", Version: "1.0.0"}
+	if _, err := c.Initialize(ctx, initRequest); err != nil {
+		t.Fatalf("initializing: %v", err)
+	}
+
+	return c
+}
+
+// callTool calls the tool over the client and returns its result.
+func callTool(t *testing.T, c *client.Client, name string, arguments map[string]interface{}) *mcp.CallToolResult {
+
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	callRequest := mcp.CallToolRequest{}
+	callRequest.Params.Name = name
+	callRequest.Params.Arguments = arguments
+	result, err := c.CallTool(ctx, callRequest)
+	if err != nil {
+		t.Fatalf("calling tool: %v", err)
+	}
+	if len(result.Content) == 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	return result
+}
+
+func TestSSEServerCallsTool(t *testing.T) {
+
+	c := startSSEServer(t, organizationGraph)
+
+	result := callTool(t, c, "organization", nil)
+	if result.IsError {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		t.Fatalf("unexpected content: %+v", result.Content[0])
+	}
+
+	var organization map[string]interface{}
+	if err := json.Unmarshal([]byte(text.Text), &organization); err != nil {
+		t.Fatalf("decoding result: %v", err)
+	}
+	if organization["id"] != "tenant-id" || organization["displayName"] != "Contoso" {
+		t.Errorf("unexpected organization: %v", organization)
+	}
+	domains, _ := organization["verifiedDomains"].(map[string]interface{})
+	if _, ok := domains["contoso.com"]; !ok {
+		t.Errorf("missing verified domain: %v", organization["verifiedDomains"])
+	}
+}
+
+func TestSSEServerToolError(t *testing.T) {
+
+	graph := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		graphtest.WriteError(w, http.StatusNotFound, "Request_ResourceNotFound", "Resource 'unknown' does not exist.")
+	})
+	c := startSSEServer(t, graph)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The error of Microsoft Graph reaches the caller over the wire
+	callRequest := mcp.CallToolRequest{}
+	callRequest.Params.Name = "users"
+	callRequest.Params.Arguments = map[string]interface{}{"mode": "get", "id": "unknown"}
+	result, err := c.CallTool(ctx, callRequest)
+	if err == nil && (result == nil || !result.IsError) {
+		t.Fatalf("expected an error, got %+v", result)
+	}
+	if err != nil && !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSSEServerDoesNotForwardAuthorization(t *testing.T) {
+
+	// The tools call Microsoft Graph with the credentials of the server, never with those of the caller
+	graph := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authorization := r.Header.Get("Authorization"); authorization != "" {
+			t.Errorf("the caller authorization was forwarded to Microsoft Graph: %s", authorization)
+		}
+		organizationGraph(w, r)
+	})
+	c := startSSEServer(t, graph, transport.WithHeaders(map[string]string{"Authorization": "Bearer caller-token"}))
+
+	if result := callTool(t, c, "organization", nil); result.IsError {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestSSEServerBaseURL(t *testing.T) {
+
+	baseURL := serveSSE(t, organizationGraph)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/sse", nil)
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("connecting: %v", err)
+	}
+	defer response.Body.Close()
+
+	// The first event tells the client where to post its messages, under the base URL
+	scanner := bufio.NewScanner(response.Body)
+	endpoint := ""
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			endpoint = data
+			break
+		}
+	}
+	if !strings.HasPrefix(endpoint, baseURL+"/message?sessionId=") {
+		t.Errorf("got message endpoint %s, want it under %s", endpoint, baseURL)
+	}
+}
+
+func TestToolDefaults(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		config  string
+		want    collection.Defaults
+		wantErr string
+	}{
+		{
+			name: "argument names restored",
+			config: `
+tool-defaults:
+  users:
+    matchMode: startswith
+    keyBy: mail
+    limit: 100
+`,
+			want: collection.Defaults{"users": {"matchMode": "startswith", "keyBy": "mail", "limit": 100}},
+		},
+		{
+			name: "unknown tool ignored",
+			config: `
+tool-defaults:
+  unknown:
+    limit: 100
+`,
+			want: collection.Defaults{},
+		},
+		{
+			name: "unknown argument rejected",
+			config: `
+tool-defaults:
+  users:
+    unknown: 1
+`,
+			wantErr: "tool-defaults: 'users' has no argument 'unknown'",
+		},
+		{
+			name: "arguments not a map",
+			config: `
+tool-defaults:
+  users: 100
+`,
+			wantErr: "tool-defaults: the arguments of 'users' are not a map",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			viper.SetConfigType("yaml")
+			if err := viper.ReadConfig(bytes.NewBufferString(test.config)); err != nil {
+				t.Fatalf("reading config: %v", err)
+			}
+
+			got, err := toolDefaults()
+			if test.wantErr != "" {
+				if err == nil || err.Error() != test.wantErr {
+					t.Fatalf("got error %v, want %s", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got defaults %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestToolDefaultsCallerWins(t *testing.T) {
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigType("yaml")
+	if err := viper.ReadConfig(bytes.NewBufferString("tool-defaults:\n  users:\n    matchMode: startswith\n")); err != nil {
+		t.Fatalf("reading config: %v", err)
+	}
+	defaults, err := toolDefaults()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got struct {
+		MatchMode string `json:"matchMode"`
+	}
+	handler := collection.DefaultsMiddleware(defaults)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, args.Decode(request, &got)
+	})
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "users"
+	request.Params.Arguments = map[string]interface{}{"matchMode": "exact"}
+	if _, err := handler(context.Background(), request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.MatchMode != "exact" {
+		t.Errorf("got matchMode %s, want exact", got.MatchMode)
+	}
+}
@@ -0,0 +1,49 @@
+package odata
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+)
+
+// ErrorMessage returns a readable message for an error returned by Microsoft Graph.
+// OData errors are reported with their code and message, other errors as is.
+func ErrorMessage(err error) string {
+
+	var odataErr *odataerrors.ODataError
+	if !errors.As(err, &odataErr) || odataErr.GetErrorEscaped() == nil {
+		return err.Error()
+	}
+
+	mainErr := odataErr.GetErrorEscaped()
+	code, message := "", ""
+	if mainErr.GetCode() != nil {
+		code = *mainErr.GetCode()
+	}
+	if mainErr.GetMessage() != nil {
+		message = *mainErr.GetMessage()
+	}
+
+	switch {
+	case code != "" && message != "":
+		return fmt.Sprintf("%s: %s", code, message)
+	case message != "":
+		return message
+	case code != "":
+		return code
+	default:
+		return err.Error()
+	}
+}
+
+// StatusCode returns the HTTP status code of an error returned by Microsoft Graph, or 0 if it has none.
+func StatusCode(err error) int {
+
+	var apiErr interface{ GetStatusCode() int }
+	if errors.As(err, &apiErr) {
+		return apiErr.GetStatusCode()
+	}
+
+	return 0
+}
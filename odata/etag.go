@@ -0,0 +1,43 @@
+package odata
+
+import (
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	khttp "github.com/microsoft/kiota-http-go"
+)
+
+// Conditional carries the headers and options of a single object lookup sent with If-None-Match,
+// and captures the ETag returned by Graph so that callers can supply it on their next lookup.
+type Conditional struct {
+	Headers    *abstractions.RequestHeaders
+	Options    []abstractions.RequestOption
+	inspection *khttp.HeadersInspectionOptions
+}
+
+// NewConditional returns a conditional lookup for the given ETag.
+// If the ETag is empty, the object is always returned.
+func NewConditional(etag string) *Conditional {
+
+	headers := abstractions.NewRequestHeaders()
+	if etag != "" {
+		headers.Add("If-None-Match", etag)
+	}
+
+	inspection := khttp.NewHeadersInspectionOptions()
+	inspection.InspectResponseHeaders = true
+
+	return &Conditional{
+		Headers:    headers,
+		Options:    []abstractions.RequestOption{inspection},
+		inspection: inspection,
+	}
+}
+
+// ETag returns the ETag of the object returned by Graph, or an empty string if there is none.
+func (c *Conditional) ETag() string {
+
+	if values := c.inspection.GetResponseHeaders().Get("ETag"); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
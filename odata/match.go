@@ -0,0 +1,43 @@
+package odata
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Modes of a name lookup.
+const (
+	MatchExact      = "exact"
+	MatchStartsWith = "startswith"
+	MatchContains   = "contains"
+)
+
+// WithMatchMode adds the matchMode argument choosing how the name argument of a tool is matched.
+func WithMatchMode() mcp.ToolOption {
+	return mcp.WithString("matchMode",
+		mcp.Enum(MatchExact, MatchStartsWith, MatchContains),
+		mcp.DefaultString(MatchExact),
+		mcp.Description("How the name is matched: 'exact' (default), 'startswith' or 'contains'. 'contains' matches whole words, e.g. 'sales' matches 'EMEA Sales Team'."),
+	)
+}
+
+// Match returns the query matching the name on the field with the given mode: a $filter for
+// exact and startswith, a $search for contains. $search is an advanced query, the request
+// needs the ConsistencyLevel header.
+func Match(field string, name string, mode string) (filter *string, search *string, err error) {
+
+	switch mode {
+	case MatchExact, "":
+		f := Eq(field, name)
+		return &f, nil, nil
+	case MatchStartsWith:
+		f := fmt.Sprintf("startswith(%s,%s)", field, Quote(name))
+		return &f, nil, nil
+	case MatchContains:
+		s := Search(field, name)
+		return nil, &s, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported matchMode '%s'", mode)
+	}
+}
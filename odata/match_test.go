@@ -0,0 +1,54 @@
+package odata
+
+import (
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		mode       string
+		value      string
+		wantFilter string
+		wantSearch string
+		wantErr    bool
+	}{
+		{"default", "", "Sales", "displayName eq 'Sales'", "", false},
+		{"exact", MatchExact, "Sales", "displayName eq 'Sales'", "", false},
+		{"exact with a quote", MatchExact, "O'Brien", "displayName eq 'O''Brien'", "", false},
+		{"prefix", MatchStartsWith, "Sal", "startswith(displayName,'Sal')", "", false},
+		{"prefix with a quote", MatchStartsWith, "O'B", "startswith(displayName,'O''B')", "", false},
+		{"contains", MatchContains, "sales", "", `"displayName:sales"`, false},
+		{"contains with double quotes", MatchContains, `sales" OR "x`, "", `"displayName:sales OR x"`, false},
+		{"unsupported", "endswith", "Sales", "", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, search, err := Match("displayName", test.value, test.mode)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Match(%q, %q) error = %v, want error %v", test.value, test.mode, err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if got := deref(filter); got != test.wantFilter {
+				t.Errorf("Match(%q, %q) filter = %q, want %q", test.value, test.mode, got, test.wantFilter)
+			}
+			if got := deref(search); got != test.wantSearch {
+				t.Errorf("Match(%q, %q) search = %q, want %q", test.value, test.mode, got, test.wantSearch)
+			}
+		})
+	}
+}
+
+// deref returns the value of the string, or an empty string if it is nil.
+func deref(s *string) string {
+
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
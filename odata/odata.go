@@ -0,0 +1,104 @@
+package odata
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// fieldRegex matches a property name, optionally a path to a nested property.
+var fieldRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*(/[A-Za-z][A-Za-z0-9_]*)*$`)
+
+// guidRegex matches a GUID, the format of directory object ids.
+var guidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsGUID reports whether the value is a GUID, like the id of a directory object.
+func IsGUID(value string) bool {
+	return guidRegex.MatchString(value)
+}
+
+// Quote returns the value as an OData string literal, escaping single quotes.
+func Quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// Eq returns an equality filter on the field for the given value.
+func Eq(field string, value string) string {
+	return field + " eq " + Quote(value)
+}
+
+// Fields validates a list of property names used in $select against an allow-list.
+// Names are trimmed and empty ones are dropped. An empty allow-list only checks the syntax.
+func Fields(fields []string, allowed []string) ([]string, error) {
+
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, field := range allowed {
+		allowedSet[strings.ToLower(field)] = true
+	}
+
+	valid := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !fieldRegex.MatchString(field) {
+			return nil, fmt.Errorf("invalid field name '%s'", field)
+		}
+		if len(allowedSet) > 0 && !allowedSet[strings.ToLower(field)] {
+			return nil, fmt.Errorf("field '%s' is not supported", field)
+		}
+		valid = append(valid, field)
+	}
+
+	return valid, nil
+}
+
+// OrderBy validates a list of $orderby clauses ("field" or "field asc|desc") against an allow-list.
+func OrderBy(clauses []string, allowed []string) ([]string, error) {
+
+	valid := make([]string, 0, len(clauses))
+	for _, clause := range clauses {
+		parts := strings.Fields(clause)
+		if len(parts) == 0 {
+			continue
+		}
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("invalid order by clause '%s'", clause)
+		}
+
+		field, err := Fields(parts[:1], allowed)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(parts) == 2 {
+			direction := strings.ToLower(parts[1])
+			if direction != "asc" && direction != "desc" {
+				return nil, fmt.Errorf("invalid order by direction '%s'", parts[1])
+			}
+			valid = append(valid, field[0]+" "+direction)
+			continue
+		}
+		valid = append(valid, field[0])
+	}
+
+	return valid, nil
+}
+
+// SearchTerm strips control characters and double quotes from a $search term.
+func SearchTerm(term string) string {
+
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' || r == '\\' {
+			return -1
+		}
+		return r
+	}, term))
+}
+
+// Search returns a $search expression matching the term on the field.
+func Search(field string, term string) string {
+	return `"` + field + ":" + SearchTerm(term) + `"`
+}
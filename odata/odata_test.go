@@ -0,0 +1,174 @@
+package odata
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestQuote(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "Sales", "'Sales'"},
+		{"embedded single quote", "O'Brien", "'O''Brien'"},
+		{"closing quote", "Sales'", "'Sales'''"},
+		{"or 1 eq 1 payload", "x' or 1 eq 1 or 'a' eq 'a", "'x'' or 1 eq 1 or ''a'' eq ''a'"},
+		{"double quotes", `say "hello"`, `'say "hello"'`},
+		{"dollar", "$top=1", "'$top=1'"},
+		{"ampersand", "a&$filter=id eq 'b'", "'a&$filter=id eq ''b'''"},
+		{"keyword", "and", "'and'"},
+		{"function", "startswith(displayName,'a')", "'startswith(displayName,''a'')'"},
+		{"empty", "", "''"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Quote(test.value); got != test.want {
+				t.Errorf("Quote(%q) = %q, want %q", test.value, got, test.want)
+			}
+		})
+	}
+}
+
+func TestEq(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "Adele", "givenName eq 'Adele'"},
+		{"embedded single quote", "D'Arcy", "givenName eq 'D''Arcy'"},
+		{"or 1 eq 1 payload", "' or 1 eq 1 or '", "givenName eq ''' or 1 eq 1 or '''"},
+		{"keyword", "not", "givenName eq 'not'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Eq("givenName", test.value); got != test.want {
+				t.Errorf("Eq(%q) = %q, want %q", test.value, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSearchTerm(t *testing.T) {
+
+	tests := []struct {
+		name string
+		term string
+		want string
+	}{
+		{"plain", "sales", "sales"},
+		{"double quotes", `sales" OR "displayName:admin`, "sales OR displayName:admin"},
+		{"backslash", `sales\"`, "sales"},
+		{"control characters", "sa\x00les\r\n\t", "sales"},
+		{"single quote", "O'Brien", "O'Brien"},
+		{"dollar and ampersand", "a&$top=1", "a&$top=1"},
+		{"surrounding spaces", "  sales  ", "sales"},
+		{"only quotes", `""`, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := SearchTerm(test.term); got != test.want {
+				t.Errorf("SearchTerm(%q) = %q, want %q", test.term, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+
+	tests := []struct {
+		name string
+		term string
+		want string
+	}{
+		{"plain", "sales", `"displayName:sales"`},
+		{"closing the phrase", `sales" OR "mail:admin`, `"displayName:sales OR mail:admin"`},
+		{"or 1 eq 1 payload", "' or 1 eq 1", `"displayName:' or 1 eq 1"`},
+		{"keyword", "AND", `"displayName:AND"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Search("displayName", test.term); got != test.want {
+				t.Errorf("Search(%q) = %q, want %q", test.term, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+
+	allowed := []string{"id", "displayName", "mail", "signInActivity/lastSignInDateTime"}
+
+	tests := []struct {
+		name    string
+		fields  []string
+		want    []string
+		wantErr bool
+	}{
+		{"allowed", []string{"id", " displayName ", ""}, []string{"id", "displayName"}, false},
+		{"case insensitive", []string{"MAIL"}, []string{"MAIL"}, false},
+		{"nested", []string{"signInActivity/lastSignInDateTime"}, []string{"signInActivity/lastSignInDateTime"}, false},
+		{"not allowed", []string{"passwordProfile"}, nil, true},
+		{"keyword", []string{"or"}, nil, true},
+		{"or 1 eq 1 payload", []string{"id or 1 eq 1"}, nil, true},
+		{"single quote", []string{"id'"}, nil, true},
+		{"double quote", []string{`id"`}, nil, true},
+		{"dollar", []string{"$expand"}, nil, true},
+		{"ampersand", []string{"id&$expand=manager"}, nil, true},
+		{"comma", []string{"id,mail"}, nil, true},
+		{"parenthesis", []string{"id)"}, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Fields(test.fields, allowed)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Fields(%q) error = %v, want error %v", test.fields, err, test.wantErr)
+			}
+			if !test.wantErr && !slices.Equal(got, test.want) {
+				t.Errorf("Fields(%q) = %q, want %q", test.fields, got, test.want)
+			}
+		})
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+
+	allowed := []string{"displayName", "createdDateTime"}
+
+	tests := []struct {
+		name    string
+		clauses []string
+		want    []string
+		wantErr bool
+	}{
+		{"field", []string{"displayName"}, []string{"displayName"}, false},
+		{"direction", []string{"createdDateTime DESC", "displayName asc"}, []string{"createdDateTime desc", "displayName asc"}, false},
+		{"invalid direction", []string{"displayName down"}, nil, true},
+		{"or 1 eq 1 payload", []string{"displayName or 1 eq 1"}, nil, true},
+		{"keyword as field", []string{"desc"}, nil, true},
+		{"dollar", []string{"displayName&$top=1"}, nil, true},
+		{"second clause in direction", []string{"displayName desc,id"}, nil, true},
+		{"single quote", []string{"displayName'"}, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := OrderBy(test.clauses, allowed)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("OrderBy(%q) error = %v, want error %v", test.clauses, err, test.wantErr)
+			}
+			if !test.wantErr && !slices.Equal(got, test.want) {
+				t.Errorf("OrderBy(%q) = %q, want %q", test.clauses, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package odata
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// rejectedFieldRegexes match the property named in the errors Graph returns for an unknown field in $select.
+var rejectedFieldRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`property named '([^']+)'`),
+	regexp.MustCompile(`[Pp]roperty '([^']+)' does not exist`),
+}
+
+// SelectWithFallback calls fetch with the fields to select. When Graph rejects the request with a
+// 400 naming one of the fields, the field is dropped and fetch is called again with the others.
+// When the 400 is about the selection without naming a field, fetch is called once more without
+// selection to get the default projection. It returns the dropped fields, so the caller can warn
+// about them, along with the error of the last call.
+func SelectWithFallback(fields []string, fetch func(fields []string) error) ([]string, error) {
+
+	dropped := []string{}
+
+	for {
+		err := fetch(fields)
+		if err == nil || len(fields) == 0 || StatusCode(err) != http.StatusBadRequest {
+			return dropped, err
+		}
+
+		message := ErrorMessage(err)
+		index := rejectedField(message, fields)
+		if index < 0 {
+			if !strings.Contains(strings.ToLower(message), "select") {
+				return dropped, err
+			}
+			return append(dropped, fields...), fetch(nil)
+		}
+
+		dropped = append(dropped, fields[index])
+		fields = append(fields[:index:index], fields[index+1:]...)
+	}
+}
+
+// SelectWarning returns the warning reporting the fields dropped from the selection, or an empty string if none was.
+func SelectWarning(dropped []string) string {
+
+	if len(dropped) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("the fields '%s' are not supported and were left out of the selection", strings.Join(dropped, "', '"))
+}
+
+// SelectNote adds the warning reporting the fields dropped from the selection to the result of a call, if any was.
+func SelectNote(result *mcp.CallToolResult, dropped []string) *mcp.CallToolResult {
+
+	warning := SelectWarning(dropped)
+	if result == nil || result.IsError || warning == "" {
+		return result
+	}
+
+	result.Content = append(result.Content, mcp.NewTextContent(warning))
+	return result
+}
+
+// rejectedField returns the index of the field named by the error message, or -1 if it names none of them.
+func rejectedField(message string, fields []string) int {
+
+	for _, regex := range rejectedFieldRegexes {
+		match := regex.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		for i, field := range fields {
+			// Nested fields are reported by their last segment
+			if strings.EqualFold(field, match[1]) || strings.HasSuffix(strings.ToLower(field), "/"+strings.ToLower(match[1])) {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
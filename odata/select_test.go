@@ -0,0 +1,114 @@
+package odata
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+)
+
+// graphError returns an error as returned by Microsoft Graph.
+func graphError(status int, code, message string) error {
+
+	mainErr := odataerrors.NewMainError()
+	mainErr.SetCode(&code)
+	mainErr.SetMessage(&message)
+
+	err := odataerrors.NewODataError()
+	err.SetErrorEscaped(mainErr)
+	err.SetStatusCode(status)
+
+	return err
+}
+
+func TestSelectWithFallback(t *testing.T) {
+
+	var calls [][]string
+	fetch := func(fields []string) error {
+		calls = append(calls, slices.Clone(fields))
+		if slices.Contains(fields, "employeeLeaveDateTime") {
+			return graphError(http.StatusBadRequest, "Request_BadRequest", "Could not find a property named 'employeeLeaveDateTime' on type 'microsoft.graph.user'.")
+		}
+		return nil
+	}
+
+	dropped, err := SelectWithFallback([]string{"id", "employeeLeaveDateTime", "mail"}, fetch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(dropped, []string{"employeeLeaveDateTime"}) {
+		t.Errorf("got dropped fields %v", dropped)
+	}
+	if len(calls) != 2 || !slices.Equal(calls[1], []string{"id", "mail"}) {
+		t.Errorf("expected a retry without the rejected field, got calls %v", calls)
+	}
+
+	result := SelectNote(mcp.NewToolResultText("{}"), dropped)
+	if len(result.Content) != 2 {
+		t.Fatalf("expected the result and a warning, got %+v", result.Content)
+	}
+	warning, _ := mcp.AsTextContent(result.Content[1])
+	if warning.Text != "the fields 'employeeLeaveDateTime' are not supported and were left out of the selection" {
+		t.Errorf("unexpected warning: %s", warning.Text)
+	}
+}
+
+func TestSelectWithFallbackUnnamedField(t *testing.T) {
+
+	var calls [][]string
+	fetch := func(fields []string) error {
+		calls = append(calls, fields)
+		if len(fields) > 0 {
+			return graphError(http.StatusBadRequest, "BadRequest", "Invalid $select properties.")
+		}
+		return nil
+	}
+
+	dropped, err := SelectWithFallback([]string{"id", "mail"}, fetch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(dropped, []string{"id", "mail"}) || len(calls) != 2 || calls[1] != nil {
+		t.Errorf("expected a retry without selection, got dropped %v and calls %v", dropped, calls)
+	}
+}
+
+func TestSelectWithFallbackOtherErrors(t *testing.T) {
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"not a bad request", graphError(http.StatusForbidden, "Authorization_RequestDenied", "Insufficient privileges to complete the operation.")},
+		{"bad request not about the selection", graphError(http.StatusBadRequest, "Request_BadRequest", "Invalid filter clause.")},
+		{"not a Graph error", fmt.Errorf("connection reset")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			dropped, err := SelectWithFallback([]string{"id", "mail"}, func(fields []string) error {
+				calls++
+				return test.err
+			})
+			if err != test.err || len(dropped) != 0 || calls != 1 {
+				t.Errorf("expected the error to be returned as is, got %v, dropped %v after %d calls", err, dropped, calls)
+			}
+		})
+	}
+}
+
+func TestSelectNoteWithoutDroppedFields(t *testing.T) {
+
+	result := SelectNote(mcp.NewToolResultText("{}"), nil)
+	if len(result.Content) != 1 {
+		t.Errorf("unexpected warning: %+v", result.Content)
+	}
+	if warning := SelectWarning([]string{"a", "b"}); !strings.Contains(warning, "'a', 'b'") {
+		t.Errorf("unexpected warning: %s", warning)
+	}
+}
@@ -0,0 +1,288 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// predicateRegex matches a single client-side filter predicate: <field> <operator> <value>.
+var predicateRegex = regexp.MustCompile(`^\s*([A-Za-z@][A-Za-z0-9_.@]*)\s+(eq|ne|gt|ge|lt|le|contains|startswith)\s+(.+?)\s*$`)
+
+// andRegex matches the 'and' keywords joining the predicates of a client-side filter.
+var andRegex = regexp.MustCompile(`(?i)\s+and\s+`)
+
+// WithClientFilter adds the clientFilter argument to a listing tool.
+func WithClientFilter() mcp.ToolOption {
+	return mcp.WithString("clientFilter",
+		mcp.Description("Filter applied by the server once all the results have been retrieved, for fields Microsoft Graph cannot filter on. "+
+			"Predicates are '<field> <eq|ne|gt|ge|lt|le|contains|startswith> <value>' joined with 'and', e.g. \"jobTitle contains 'manager' and accountEnabled eq true\". "+
+			"Every result has to be retrieved first: prefer the server side filters when available."),
+	)
+}
+
+// WithClientSort adds the clientSort argument to a listing tool.
+func WithClientSort() mcp.ToolOption {
+	return mcp.WithString("clientSort",
+		mcp.Description("Sort the results by a field, e.g. 'displayName' or 'createdDateTime desc', once all of them have been retrieved. "+
+			"The results are then returned as a list instead of a map keyed by id. Every result has to be retrieved first."),
+	)
+}
+
+// predicate is a parsed client-side filter predicate.
+type predicate struct {
+	field    string
+	operator string
+	value    string
+}
+
+// ClientFilter is a transformer keeping only the records of a listing result matching the clientFilter argument.
+func ClientFilter(ctx context.Context, request mcp.CallToolRequest, data interface{}) (interface{}, error) {
+
+	filter, _ := request.Params.Arguments["clientFilter"].(string)
+	if filter == "" {
+		return data, nil
+	}
+
+	records, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("clientFilter is not supported for this result")
+	}
+
+	predicates, err := parseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterRecords(records, predicates), nil
+}
+
+// ClientSort is a transformer returning the records of a listing result as a list ordered by the clientSort argument.
+func ClientSort(ctx context.Context, request mcp.CallToolRequest, data interface{}) (interface{}, error) {
+
+	clause, _ := request.Params.Arguments["clientSort"].(string)
+	if clause == "" {
+		return data, nil
+	}
+	if field, _ := request.Params.Arguments["groupBy"].(string); field != "" {
+		return nil, fmt.Errorf("clientSort cannot be combined with groupBy")
+	}
+
+	records, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("clientSort is not supported for this result")
+	}
+
+	parts := strings.Fields(clause)
+	if len(parts) == 0 || len(parts) > 2 {
+		return nil, fmt.Errorf("invalid clientSort '%s', expected '<field> [asc|desc]'", clause)
+	}
+	descending := false
+	if len(parts) == 2 {
+		switch strings.ToLower(parts[1]) {
+		case "asc":
+		case "desc":
+			descending = true
+		default:
+			return nil, fmt.Errorf("invalid clientSort direction '%s', expected asc or desc", parts[1])
+		}
+	}
+
+	return sortRecords(records, parts[0], descending), nil
+}
+
+// parseFilter parses a client-side filter into its predicates.
+func parseFilter(filter string) ([]predicate, error) {
+
+	var predicates []predicate
+	for _, part := range splitFilter(filter) {
+		matches := predicateRegex.FindStringSubmatch(part)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid clientFilter predicate '%s', expected '<field> <operator> <value>'", strings.TrimSpace(part))
+		}
+		predicates = append(predicates, predicate{
+			field:    matches[1],
+			operator: matches[2],
+			value:    unquote(matches[3]),
+		})
+	}
+
+	return predicates, nil
+}
+
+// splitFilter splits a client-side filter on its 'and' keywords, leaving the quoted values whole.
+func splitFilter(filter string) []string {
+
+	var parts []string
+	start := 0
+	for _, loc := range andRegex.FindAllStringIndex(filter, -1) {
+		// After an odd number of quotes the keyword is inside a value, escaped quotes counting twice
+		if strings.Count(filter[start:loc[0]], "'")%2 == 1 {
+			continue
+		}
+		parts = append(parts, filter[start:loc[0]])
+		start = loc[1]
+	}
+
+	return append(parts, filter[start:])
+}
+
+// filterRecords returns the records matching all the predicates.
+func filterRecords(records map[string]interface{}, predicates []predicate) map[string]interface{} {
+
+	filtered := make(map[string]interface{})
+
+	for key, record := range records {
+		object, ok := record.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		matches := true
+		for _, p := range predicates {
+			if !p.match(lookup(object, p.field)) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered[key] = record
+		}
+	}
+
+	return filtered
+}
+
+// sortRecords returns the records as a list ordered by the field. Records without the
+// field come last, ties are broken by the original key to keep the order stable.
+func sortRecords(records map[string]interface{}, field string, descending bool) []interface{} {
+
+	keys := make([]string, 0, len(records))
+	for key := range records {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	value := func(key string) interface{} {
+		if object, ok := records[key].(map[string]interface{}); ok {
+			return lookup(object, field)
+		}
+		return nil
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := value(keys[i]), value(keys[j])
+		if a == nil || b == nil {
+			return a != nil
+		}
+		if descending {
+			return compare(a, b) > 0
+		}
+		return compare(a, b) < 0
+	})
+
+	sorted := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, records[key])
+	}
+
+	return sorted
+}
+
+// match reports whether the value satisfies the predicate.
+func (p predicate) match(value interface{}) bool {
+
+	if value == nil {
+		switch p.operator {
+		case "eq":
+			return p.value == "null"
+		case "ne":
+			return p.value != "null"
+		default:
+			return false
+		}
+	}
+
+	// A list matches if any of its values does
+	if list, ok := value.([]interface{}); ok {
+		for _, item := range list {
+			if p.match(item) {
+				return true
+			}
+		}
+		return false
+	}
+
+	text := fmt.Sprint(value)
+	switch p.operator {
+	case "eq":
+		return compare(value, p.value) == 0
+	case "ne":
+		return compare(value, p.value) != 0
+	case "gt":
+		return compare(value, p.value) > 0
+	case "ge":
+		return compare(value, p.value) >= 0
+	case "lt":
+		return compare(value, p.value) < 0
+	case "le":
+		return compare(value, p.value) <= 0
+	case "contains":
+		return strings.Contains(strings.ToLower(text), strings.ToLower(p.value))
+	case "startswith":
+		return strings.HasPrefix(strings.ToLower(text), strings.ToLower(p.value))
+	default:
+		return false
+	}
+}
+
+// compare compares two values numerically when both are numbers, case-insensitively as text otherwise.
+func compare(a interface{}, b interface{}) int {
+
+	textA, textB := fmt.Sprint(a), fmt.Sprint(b)
+
+	numberA, errA := strconv.ParseFloat(textA, 64)
+	numberB, errB := strconv.ParseFloat(textB, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case numberA < numberB:
+			return -1
+		case numberA > numberB:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(strings.ToLower(textA), strings.ToLower(textB))
+}
+
+// lookup returns the value of a field of a record, following dots into nested objects.
+func lookup(object map[string]interface{}, field string) interface{} {
+
+	var value interface{} = object
+	for _, part := range strings.Split(field, ".") {
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = nested[part]
+	}
+
+	return value
+}
+
+// unquote removes the quotes around a filter value, unescaping doubled single quotes.
+func unquote(value string) string {
+
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+
+	return value
+}
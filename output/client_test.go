@@ -0,0 +1,132 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// clientRecords are sample records, some lacking fields or holding them with other types.
+func clientRecords() map[string]interface{} {
+	return map[string]interface{}{
+		"1": map[string]interface{}{"jobTitle": "Sales Manager", "accountEnabled": true, "age": float64(9), "address": map[string]interface{}{"city": "Paris"}},
+		"2": map[string]interface{}{"jobTitle": "Engineer", "accountEnabled": false, "age": "10", "tags": []interface{}{"vip", "remote"}},
+		"3": map[string]interface{}{"accountEnabled": "true", "age": float64(2)},
+		"4": map[string]interface{}{"jobTitle": nil},
+	}
+}
+
+// clientRequest returns a request with the given arguments.
+func clientRequest(arguments map[string]interface{}) mcp.CallToolRequest {
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = arguments
+
+	return request
+}
+
+func TestClientFilter(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		filter string
+		want   []string
+	}{
+		{"contains is case insensitive", "jobTitle contains 'manager'", []string{"1"}},
+		{"startswith", "jobTitle startswith 'eng'", []string{"2"}},
+		{"missing field eq null", "jobTitle eq null", []string{"3", "4"}},
+		{"missing field ne", "jobTitle ne 'Engineer'", []string{"1", "3", "4"}},
+		{"missing field never contains", "jobTitle contains 'e'", []string{"1", "2"}},
+		{"missing field never compares", "age lt 100", []string{"1", "2", "3"}},
+		{"boolean and string", "accountEnabled eq true", []string{"1", "3"}},
+		{"number and numeric string", "age gt 5", []string{"1", "2"}},
+		{"numbers compare numerically", "age ge 10", []string{"2"}},
+		{"list matches any value", "tags eq 'remote'", []string{"2"}},
+		{"nested field", "address.city eq 'paris'", []string{"1"}},
+		{"and", "accountEnabled eq true and age lt 5", []string{"3"}},
+		{"quoted value with a quote", "jobTitle eq 'O''Brien'", []string{}},
+		{"and inside a quoted value", "jobTitle ne 'Sales and Marketing' and accountEnabled eq true", []string{"1", "3"}},
+		{"and after an escaped quote", "jobTitle ne 'O''Brien and co' and age lt 5", []string{"3"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := ClientFilter(context.Background(), clientRequest(map[string]interface{}{"clientFilter": test.filter}), clientRecords())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			keys := []string{}
+			for key := range data.(map[string]interface{}) {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			if !slices.Equal(keys, test.want) {
+				t.Errorf("clientFilter %q kept %v, want %v", test.filter, keys, test.want)
+			}
+		})
+	}
+}
+
+func TestClientFilterInvalid(t *testing.T) {
+
+	for _, filter := range []string{"jobTitle", "jobTitle like 'a'", "jobTitle eq 'a' and accountEnabled"} {
+		if _, err := ClientFilter(context.Background(), clientRequest(map[string]interface{}{"clientFilter": filter}), clientRecords()); err == nil {
+			t.Errorf("expected an error for clientFilter %q", filter)
+		}
+	}
+}
+
+func TestClientSort(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		clause string
+		want   []string
+	}{
+		{"text ascending", "jobTitle", []string{"Engineer", "Sales Manager", "", ""}},
+		{"text descending", "jobTitle desc", []string{"Sales Manager", "Engineer", "", ""}},
+		{"mixed numbers ascending", "age asc", []string{"2", "9", "10", ""}},
+		{"mixed numbers descending", "age DESC", []string{"10", "9", "2", ""}},
+		{"nested field", "address.city desc", []string{"Paris", "", "", ""}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := ClientSort(context.Background(), clientRequest(map[string]interface{}{"clientSort": test.clause}), clientRecords())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			sorted := data.([]interface{})
+			field := strings.Fields(test.clause)[0]
+			got := []string{}
+			for _, record := range sorted {
+				value := lookup(record.(map[string]interface{}), field)
+				if value == nil {
+					value = ""
+				}
+				got = append(got, fmt.Sprint(value))
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("clientSort %q ordered %v, want %v", test.clause, got, test.want)
+			}
+		})
+	}
+}
+
+func TestClientSortInvalid(t *testing.T) {
+
+	for _, arguments := range []map[string]interface{}{
+		{"clientSort": "jobTitle up"},
+		{"clientSort": "jobTitle asc extra"},
+		{"clientSort": "jobTitle", "groupBy": "department"},
+	} {
+		if _, err := ClientSort(context.Background(), clientRequest(arguments), clientRecords()); err == nil {
+			t.Errorf("expected an error for %v", arguments)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// noValueBucket is the bucket counting the records without the grouped field.
+const noValueBucket = "(none)"
+
+// WithGroupBy adds the groupBy argument to a listing tool.
+func WithGroupBy() mcp.ToolOption {
+	return mcp.WithString("groupBy",
+		mcp.Description("Return the number of results per value of this field (e.g. department, or address.city for a nested field) instead of the results themselves."),
+	)
+}
+
+// GroupBy is a transformer replacing a listing result by the number of records
+// per value of the field given in the groupBy argument.
+func GroupBy(ctx context.Context, request mcp.CallToolRequest, data interface{}) (interface{}, error) {
+
+	field, _ := request.Params.Arguments["groupBy"].(string)
+	if field == "" {
+		return data, nil
+	}
+
+	records, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("groupBy is not supported for this result")
+	}
+
+	return map[string]interface{}{
+		"groupBy": field,
+		"total":   len(records),
+		"counts":  countBy(records, field),
+	}, nil
+}
+
+// countBy counts the records per value of the field, following dots into nested objects.
+// A record holding a list of values is counted once in each of their buckets.
+func countBy(records map[string]interface{}, field string) map[string]int {
+
+	counts := map[string]int{}
+
+	for _, record := range records {
+		object, ok := record.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		switch value := lookup(object, field).(type) {
+		case nil:
+			counts[noValueBucket]++
+		case []interface{}:
+			if len(value) == 0 {
+				counts[noValueBucket]++
+			}
+			for _, item := range value {
+				counts[fmt.Sprint(item)]++
+			}
+		default:
+			counts[fmt.Sprint(value)]++
+		}
+	}
+
+	return counts
+}
@@ -0,0 +1,49 @@
+package output
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestGroupBy(t *testing.T) {
+
+	records := map[string]interface{}{
+		"1": map[string]interface{}{"department": "Sales", "groupTypes": []interface{}{"Unified", "DynamicMembership"}, "address": map[string]interface{}{"city": "Paris"}},
+		"2": map[string]interface{}{"department": "Sales", "groupTypes": []interface{}{"Unified"}, "address": map[string]interface{}{"city": "Lyon"}},
+		"3": map[string]interface{}{"department": nil, "groupTypes": []interface{}{}, "address": map[string]interface{}{}},
+		"4": map[string]interface{}{"groupTypes": nil},
+		"5": "not an object",
+	}
+
+	tests := []struct {
+		name  string
+		field string
+		want  map[string]int
+	}{
+		{"nil and missing values", "department", map[string]int{"Sales": 2, noValueBucket: 2}},
+		{"array-valued field", "groupTypes", map[string]int{"Unified": 2, "DynamicMembership": 1, noValueBucket: 2}},
+		{"nested path", "address.city", map[string]int{"Paris": 1, "Lyon": 1, noValueBucket: 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := mcp.CallToolRequest{}
+			request.Params.Arguments = map[string]interface{}{"groupBy": test.field}
+
+			data, err := GroupBy(context.Background(), request, records)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			grouped := data.(map[string]interface{})
+			if grouped["groupBy"] != test.field || grouped["total"] != len(records) {
+				t.Errorf("unexpected grouped result: %v", grouped)
+			}
+			if counts := grouped["counts"].(map[string]int); !reflect.DeepEqual(counts, test.want) {
+				t.Errorf("got counts %v, want %v", counts, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultKeyBy is the field listing results are keyed by when no keyBy argument is given.
+const defaultKeyBy = "id"
+
+// WithKeyBy adds the keyBy argument to a listing tool.
+func WithKeyBy() mcp.ToolOption {
+	return mcp.WithString("keyBy",
+		mcp.Enum("id", "displayName", "userPrincipalName"),
+		mcp.DefaultString(defaultKeyBy),
+		mcp.Description("The field used as the key of the returned results. Duplicated keys are suffixed with #2, #3, ..."),
+	)
+}
+
+// KeyBy is a transformer re-keying a listing result by the field given in the keyBy argument.
+// Records without the field keep their original key.
+func KeyBy(ctx context.Context, request mcp.CallToolRequest, data interface{}) (interface{}, error) {
+
+	field, _ := request.Params.Arguments["keyBy"].(string)
+	if field == "" || field == defaultKeyBy {
+		return data, nil
+	}
+
+	records, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("keyBy is not supported for this result")
+	}
+
+	return rekey(records, field), nil
+}
+
+// rekey returns the records keyed by the value of the field. The records are visited in the
+// order of their original key so that duplicates are suffixed the same way on every call.
+func rekey(records map[string]interface{}, field string) map[string]interface{} {
+
+	originalKeys := make([]string, 0, len(records))
+	for key := range records {
+		originalKeys = append(originalKeys, key)
+	}
+	sort.Strings(originalKeys)
+
+	rekeyed := make(map[string]interface{}, len(records))
+	for _, originalKey := range originalKeys {
+		record := records[originalKey]
+
+		key := originalKey
+		if object, ok := record.(map[string]interface{}); ok {
+			if value, ok := object[field].(string); ok && value != "" {
+				key = value
+			}
+		}
+
+		unique := key
+		for i := 2; rekeyed[unique] != nil; i++ {
+			unique = fmt.Sprintf("%s#%d", key, i)
+		}
+		rekeyed[unique] = record
+	}
+
+	return rekeyed
+}
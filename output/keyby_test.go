@@ -0,0 +1,67 @@
+package output
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestKeyByCollision(t *testing.T) {
+
+	records := map[string]interface{}{
+		"id-3": map[string]interface{}{"id": "id-3", "displayName": "Sales"},
+		"id-1": map[string]interface{}{"id": "id-1", "displayName": "Sales"},
+		"id-2": map[string]interface{}{"id": "id-2", "displayName": "Sales"},
+		"id-4": map[string]interface{}{"id": "id-4", "displayName": "Marketing"},
+		"id-5": map[string]interface{}{"id": "id-5"},
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{"keyBy": "displayName"}
+
+	// The duplicates are suffixed in the order of their original key, the same way on every call
+	for range 3 {
+		data, err := KeyBy(context.Background(), request, records)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rekeyed := data.(map[string]interface{})
+
+		if len(rekeyed) != len(records) {
+			t.Fatalf("records were lost on collision: %v", rekeyed)
+		}
+		want := map[string]string{
+			"Sales":     "id-1",
+			"Sales#2":   "id-2",
+			"Sales#3":   "id-3",
+			"Marketing": "id-4",
+			"id-5":      "id-5",
+		}
+		for key, id := range want {
+			record, ok := rekeyed[key].(map[string]interface{})
+			if !ok || record["id"] != id {
+				t.Errorf("expected %s to hold %s, got %v", key, id, rekeyed[key])
+			}
+		}
+	}
+}
+
+func TestKeyByDefault(t *testing.T) {
+
+	records := map[string]interface{}{"id-1": map[string]interface{}{"id": "id-1"}}
+
+	data, err := KeyBy(context.Background(), mcp.CallToolRequest{}, records)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := data.(map[string]interface{})["id-1"]; !ok {
+		t.Errorf("records should keep their key: %v", data)
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{"keyBy": "displayName"}
+	if _, err := KeyBy(context.Background(), request, []interface{}{}); err == nil {
+		t.Errorf("expected an error for a result which is not a map")
+	}
+}
@@ -0,0 +1,179 @@
+package output
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/mark3labs/mcp-go/mcp"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/directoryobjects"
+)
+
+// maxResolvedNames bounds the number of ids resolved in a single tool call.
+// It also matches the maximum number of ids accepted by getByIds.
+const maxResolvedNames = 1000
+
+// idFields maps the fields holding a bare directory object id to the
+// field receiving the resolved display name.
+var idFields = map[string]string{
+	"managerId":   "managerDisplayName",
+	"ownerId":     "ownerDisplayName",
+	"memberId":    "memberDisplayName",
+	"principalId": "principalDisplayName",
+	"resourceId":  "resourceDisplayName",
+	"clientId":    "clientDisplayName",
+}
+
+// objectFields lists the fields holding directory object references
+// (a single object or a list of objects) that may lack a displayName.
+var objectFields = map[string]bool{
+	"manager":       true,
+	"owners":        true,
+	"members":       true,
+	"directReports": true,
+	"memberOf":      true,
+	"sponsors":      true,
+}
+
+// ResolveNames is a transformer adding display names next to the directory object ids
+// found in the tool result. When the names cannot be looked up, the ids are left unresolved
+// with a warning.
+func ResolveNames(ctx context.Context, request mcp.CallToolRequest, data interface{}) (interface{}, error) {
+
+	client, ok := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+	if !ok || client == nil {
+		return data, nil
+	}
+
+	ids := map[string]string{}
+	collectIDs(data, ids)
+	if len(ids) == 0 {
+		return data, nil
+	}
+
+	if err := LookupNames(ctx, client, ids); err != nil {
+		Warn(ctx, fmt.Sprintf("The display names of the directory objects could not be resolved, their ids are returned as is: %s", odata.ErrorMessage(err)))
+		return data, nil
+	}
+
+	applyNames(data, ids)
+	return data, nil
+}
+
+// collectIDs walks the data and records every directory object id needing a name.
+// The number of collected ids is bounded by maxResolvedNames.
+func collectIDs(data interface{}, ids map[string]string) {
+
+	add := func(id string) {
+		if _, ok := ids[id]; ok || len(ids) >= maxResolvedNames || !odata.IsGUID(id) {
+			return
+		}
+		ids[id] = ""
+	}
+
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if _, ok := idFields[key]; ok {
+				if id, ok := value.(string); ok {
+					add(id)
+				}
+			}
+			if objectFields[key] {
+				forEachObject(value, func(object map[string]interface{}) {
+					if _, ok := object["displayName"]; ok {
+						return
+					}
+					if id, ok := object["id"].(string); ok {
+						add(id)
+					}
+				})
+			}
+			collectIDs(value, ids)
+		}
+	case []interface{}:
+		for _, value := range v {
+			collectIDs(value, ids)
+		}
+	}
+}
+
+// applyNames walks the data and writes the resolved names next to the ids.
+func applyNames(data interface{}, names map[string]string) {
+
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if nameKey, ok := idFields[key]; ok {
+				if id, ok := value.(string); ok && names[id] != "" {
+					v[nameKey] = names[id]
+				}
+			}
+			if objectFields[key] {
+				forEachObject(value, func(object map[string]interface{}) {
+					if _, ok := object["displayName"]; ok {
+						return
+					}
+					if id, ok := object["id"].(string); ok && names[id] != "" {
+						object["displayName"] = names[id]
+					}
+				})
+			}
+			applyNames(value, names)
+		}
+	case []interface{}:
+		for _, value := range v {
+			applyNames(value, names)
+		}
+	}
+}
+
+// forEachObject calls fn for the value if it is an object, or for each object of the value if it is a list.
+func forEachObject(value interface{}, fn func(map[string]interface{})) {
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		fn(v)
+	case []interface{}:
+		for _, item := range v {
+			if object, ok := item.(map[string]interface{}); ok {
+				fn(object)
+			}
+		}
+	}
+}
+
+// LookupNames resolves the ids keying names through directoryObjects/getByIds in a single batch,
+// leaving the ids it cannot resolve untouched.
+func LookupNames(ctx context.Context, client *msgraphsdk.GraphServiceClient, names map[string]string) error {
+
+	ids := make([]string, 0, len(names))
+	for id := range names {
+		ids = append(ids, id)
+	}
+
+	body := directoryobjects.NewGetByIdsPostRequestBody()
+	body.SetIds(ids)
+
+	result, err := client.DirectoryObjects().GetByIds().PostAsGetByIdsPostResponse(ctx, body, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, object := range result.GetValue() {
+		if object.GetId() == nil {
+			continue
+		}
+		if named, ok := object.(interface{ GetDisplayName() *string }); ok && named.GetDisplayName() != nil {
+			names[*object.GetId()] = *named.GetDisplayName()
+			continue
+		}
+		if displayName, ok := object.GetAdditionalData()["displayName"].(*string); ok && displayName != nil {
+			names[*object.GetId()] = *displayName
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/graphtest"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	managerID = "11111111-1111-1111-1111-111111111111"
+	ownerID   = "22222222-2222-2222-2222-222222222222"
+	unknownID = "33333333-3333-3333-3333-333333333333"
+)
+
+// namesPayload is a sample tool result referencing directory objects by id.
+const namesPayload = `{
+  "user-1": {
+    "displayName": "Adele Vance",
+    "managerId": "` + managerID + `",
+    "owners": [{"id": "` + ownerID + `"}, {"id": "` + unknownID + `"}],
+    "memberOf": [{"id": "` + managerID + `", "displayName": "Kept"}]
+  }
+}`
+
+// resolveNames runs ResolveNames on the sample payload, with getByIds served by the handler.
+func resolveNames(t *testing.T, getByIds http.HandlerFunc) *mcp.CallToolResult {
+
+	cl, err := graphtest.NewClient(getByIds)
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	ctx := baggage.WithInfomation(cl)(context.Background())
+
+	handler := Middleware(ResolveNames)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(namesPayload), nil
+	})
+	result, err := handler(ctx, mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %+v", result)
+	}
+
+	return result
+}
+
+// decodeUser returns the user of the transformed sample payload.
+func decodeUser(t *testing.T, result *mcp.CallToolResult) map[string]interface{} {
+
+	text, _ := mcp.AsTextContent(result.Content[0])
+	var data map[string]map[string]interface{}
+	if err := json.Unmarshal([]byte(text.Text), &data); err != nil {
+		t.Fatalf("decoding result: %v", err)
+	}
+
+	return data["user-1"]
+}
+
+func TestResolveNames(t *testing.T) {
+
+	var requested []string
+	result := resolveNames(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1.0/directoryObjects/getByIds" {
+			graphtest.WriteError(w, http.StatusNotFound, "Request_ResourceNotFound", "unexpected request "+r.URL.Path)
+			return
+		}
+		var body struct {
+			Ids []string `json:"ids"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requested = body.Ids
+
+		graphtest.WriteJSON(w, http.StatusOK, map[string]interface{}{
+			"value": []interface{}{
+				map[string]interface{}{"@odata.type": "#microsoft.graph.user", "id": managerID, "displayName": "Miriam Graham"},
+				map[string]interface{}{"@odata.type": "#microsoft.graph.servicePrincipal", "id": ownerID, "displayName": "Sync App"},
+			},
+		})
+	})
+
+	if len(requested) != 3 {
+		t.Errorf("expected the 3 distinct ids to be looked up, got %v", requested)
+	}
+	if len(result.Content) != 1 {
+		t.Errorf("unexpected warnings: %+v", result.Content[1:])
+	}
+
+	user := decodeUser(t, result)
+	if user["managerDisplayName"] != "Miriam Graham" {
+		t.Errorf("manager not resolved: %v", user)
+	}
+	owners, _ := user["owners"].([]interface{})
+	if len(owners) != 2 {
+		t.Fatalf("unexpected owners: %v", user["owners"])
+	}
+	if owner, _ := owners[0].(map[string]interface{}); owner["displayName"] != "Sync App" {
+		t.Errorf("owner not resolved: %v", owner)
+	}
+	if owner, _ := owners[1].(map[string]interface{}); owner["displayName"] != nil {
+		t.Errorf("unknown owner should be left unresolved: %v", owner)
+	}
+	memberOf, _ := user["memberOf"].([]interface{})
+	if group, _ := memberOf[0].(map[string]interface{}); group["displayName"] != "Kept" {
+		t.Errorf("existing display name should be kept: %v", group)
+	}
+}
+
+func TestResolveNamesFailure(t *testing.T) {
+
+	result := resolveNames(t, func(w http.ResponseWriter, r *http.Request) {
+		graphtest.WriteError(w, http.StatusForbidden, "Authorization_RequestDenied", "Insufficient privileges to complete the operation.")
+	})
+
+	user := decodeUser(t, result)
+	if user["managerId"] != managerID || user["managerDisplayName"] != nil {
+		t.Errorf("the ids should be left unresolved: %v", user)
+	}
+
+	if len(result.Content) != 2 {
+		t.Fatalf("expected the result and a warning, got %+v", result.Content)
+	}
+	warning, _ := mcp.AsTextContent(result.Content[1])
+	if !strings.Contains(warning.Text, "Authorization_RequestDenied") {
+		t.Errorf("unexpected warning: %s", warning.Text)
+	}
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Transformer rewrites the decoded JSON payload of a tool result.
+type Transformer func(ctx context.Context, request mcp.CallToolRequest, data interface{}) (interface{}, error)
+
+// warningsKey is the context key of the warnings raised by the transformers.
+type warningsKey struct{}
+
+// Warn adds a warning to the tool result, for a transformer leaving its data unchanged
+// when it cannot apply instead of failing the call.
+func Warn(ctx context.Context, warning string) {
+	if warnings, ok := ctx.Value(warningsKey{}).(*[]string); ok && !slices.Contains(*warnings, warning) {
+		*warnings = append(*warnings, warning)
+	}
+}
+
+// Middleware returns a tool handler middleware applying the transformers, in order, to every
+// JSON text content of a successful tool result. Non JSON content is left untouched.
+// The warnings raised by the transformers are appended to the result.
+func Middleware(transforms ...Transformer) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+			result, err := next(ctx, request)
+			if err != nil || result == nil || result.IsError {
+				return result, err
+			}
+
+			var warnings []string
+			ctx = context.WithValue(ctx, warningsKey{}, &warnings)
+
+			for i, content := range result.Content {
+				text, ok := mcp.AsTextContent(content)
+				if !ok {
+					continue
+				}
+
+				var data interface{}
+				if err := json.Unmarshal([]byte(text.Text), &data); err != nil {
+					continue
+				}
+
+				for _, transform := range transforms {
+					data, err = transform(ctx, request, data)
+					if err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+				}
+
+				jsonData, err := json.MarshalIndent(data, "", "  ")
+				if err != nil {
+					return nil, err
+				}
+				result.Content[i] = mcp.NewTextContent(string(jsonData))
+			}
+
+			for _, warning := range warnings {
+				result.Content = append(result.Content, mcp.NewTextContent(warning))
+			}
+
+			return result, nil
+		}
+	}
+}
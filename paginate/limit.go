@@ -0,0 +1,82 @@
+package paginate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// DefaultLimit caps the listing tools called without any filter nor limit, to avoid
+// enumerating the whole tenant by accident. Zero disables the cap.
+var DefaultLimit = 100
+
+// aggregations are the output options computing their result over every record returned by the
+// tool. Capping these calls would make partial counts and selections look complete.
+var aggregations = []string{"groupBy", "clientFilter", "clientSort"}
+
+// WithLimit adds the limit argument to a listing tool.
+func WithLimit() mcp.ToolOption {
+	return mcp.WithNumber("limit",
+		mcp.Description("The maximum number of results to return. When neither a filter, a groupBy, a clientFilter, a clientSort nor a limit is given, the results are capped to the server default limit."),
+	)
+}
+
+// Limit returns the number of results a listing tool should return given the limit argument of
+// the request, zero meaning all of them. An explicit limit always applies, otherwise DefaultLimit
+// applies to unfiltered calls and capped is set. Aggregated calls count as filtered.
+func Limit(request mcp.CallToolRequest, limit int, filtered bool) (int, bool, error) {
+
+	if limit < 0 {
+		return 0, false, fmt.Errorf("limit must be a positive number")
+	}
+	limit, capped := Cap(limit, filtered || Aggregated(request))
+
+	return limit, capped, nil
+}
+
+// Cap returns the limit given by the caller, or DefaultLimit with capped set when the call is
+// neither limited nor filtered.
+func Cap(limit int, filtered bool) (int, bool) {
+
+	if limit > 0 || filtered || DefaultLimit <= 0 {
+		return limit, false
+	}
+
+	return DefaultLimit, true
+}
+
+// Aggregated reports whether the call groups, filters or sorts the result on the client side,
+// which needs all the records to be enumerated.
+func Aggregated(request mcp.CallToolRequest) bool {
+
+	for _, name := range aggregations {
+		if value, _ := request.Params.Arguments[name].(string); value != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CappedNote adds a note to the result of a call capped to the default limit, when the
+// limit has been reached and more results may exist.
+func CappedNote(result *mcp.CallToolResult, limit int) *mcp.CallToolResult {
+
+	if result == nil || result.IsError || len(result.Content) == 0 {
+		return result
+	}
+
+	text, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		return result
+	}
+	var records map[string]interface{}
+	if err := json.Unmarshal([]byte(text.Text), &records); err != nil || len(records) < limit {
+		return result
+	}
+
+	result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf("No filter nor limit was given: the results were capped to %d. Pass a filter, or an explicit limit to get more.", limit)))
+
+	return result
+}
@@ -0,0 +1,74 @@
+package paginate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/acuvity/mcp-server-microsoft-graph/output"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestLimit(t *testing.T) {
+
+	DefaultLimit = 100
+
+	tests := []struct {
+		name       string
+		arguments  map[string]interface{}
+		limit      int
+		filtered   bool
+		wantLimit  int
+		wantCapped bool
+	}{
+		{"unfiltered", nil, 0, false, 100, true},
+		{"filtered", nil, 0, true, 0, false},
+		{"explicit limit", nil, 10, false, 10, false},
+		{"groupBy", map[string]interface{}{"groupBy": "department"}, 0, false, 0, false},
+		{"clientFilter", map[string]interface{}{"clientFilter": "accountEnabled eq false"}, 0, false, 0, false},
+		{"clientSort", map[string]interface{}{"clientSort": "displayName desc"}, 0, false, 0, false},
+		{"groupBy with explicit limit", map[string]interface{}{"groupBy": "department"}, 10, false, 10, false},
+		{"empty groupBy", map[string]interface{}{"groupBy": ""}, 0, false, 100, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := mcp.CallToolRequest{}
+			request.Params.Arguments = test.arguments
+
+			limit, capped, err := Limit(request, test.limit, test.filtered)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if limit != test.wantLimit || capped != test.wantCapped {
+				t.Errorf("got limit %d capped %v, want limit %d capped %v", limit, capped, test.wantLimit, test.wantCapped)
+			}
+		})
+	}
+}
+
+func TestCappedNoteIsKeptByGroupBy(t *testing.T) {
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{"groupBy": "department"}
+
+	handler := output.Middleware(output.GroupBy)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return CappedNote(mcp.NewToolResultText(`{"1": {"department": "sales"}, "2": {"department": "sales"}}`), 2), nil
+	})
+
+	result, err := handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Content) != 2 {
+		t.Fatalf("expected the grouped result and the note, got %+v", result.Content)
+	}
+	grouped, _ := mcp.AsTextContent(result.Content[0])
+	if !strings.Contains(grouped.Text, `"sales": 2`) {
+		t.Errorf("unexpected grouped result: %s", grouped.Text)
+	}
+	note, _ := mcp.AsTextContent(result.Content[1])
+	if !strings.Contains(note.Text, "capped to 2") {
+		t.Errorf("unexpected note: %s", note.Text)
+	}
+}
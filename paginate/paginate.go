@@ -0,0 +1,79 @@
+package paginate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	msgraphcore "github.com/microsoftgraph/msgraph-sdk-go-core"
+)
+
+// progressInterval is the number of items processed between two progress reports.
+const progressInterval = 100
+
+// Progress is called with the number of items processed so far.
+type Progress func(processed int)
+
+// progressKey is a custom context key for storing the progress callback.
+type progressKey struct{}
+
+// WithProgress returns a context reporting the iteration progress to fn.
+func WithProgress(ctx context.Context, fn Progress) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// Iterate calls fn for each item of every page of a Graph collection response,
+// until fn returns false. Progress is reported if the context carries a callback.
+func Iterate[T any](ctx context.Context, client *msgraphsdk.GraphServiceClient, result interface{}, constructor serialization.ParsableFactory, fn func(T) bool) error {
+
+	pageIterator, err := msgraphcore.NewPageIterator[T](result, client.GetAdapter(), constructor)
+	if err != nil {
+		return fmt.Errorf("error creating page iterator: %v", err)
+	}
+
+	progress, _ := ctx.Value(progressKey{}).(Progress)
+	processed := 0
+
+	err = pageIterator.Iterate(ctx, func(item T) bool {
+		processed++
+		if progress != nil && processed%progressInterval == 0 {
+			progress(processed)
+		}
+		return fn(item)
+	})
+	if err != nil {
+		return fmt.Errorf("error iterating through pages: %v", err)
+	}
+
+	return nil
+}
+
+// ProgressMiddleware is a tool handler middleware sending progress notifications
+// to the client while paginating, when the client asked for them.
+func ProgressMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+		if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+			return next(ctx, request)
+		}
+
+		srv := server.ServerFromContext(ctx)
+		if srv == nil {
+			return next(ctx, request)
+		}
+
+		token := request.Params.Meta.ProgressToken
+		ctx = WithProgress(ctx, func(processed int) {
+			_ = srv.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+				"progressToken": token,
+				"progress":      processed,
+				"message":       fmt.Sprintf("%d items processed", processed),
+			})
+		})
+
+		return next(ctx, request)
+	}
+}
@@ -0,0 +1,222 @@
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/acuvity/mcp-server-microsoft-graph/baggage"
+	"github.com/acuvity/mcp-server-microsoft-graph/odata"
+	"github.com/acuvity/mcp-server-microsoft-graph/paginate"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/serviceprincipals"
+)
+
+// cacheDuration is how long the granted scopes of an application are kept before being looked up again.
+const cacheDuration = 10 * time.Minute
+
+type cacheEntry struct {
+	scopes  []string
+	fetched time.Time
+}
+
+var (
+	cacheLock sync.Mutex
+	cache     = map[string]cacheEntry{}
+)
+
+// Granted returns the application permissions granted to the application with the given client id.
+// The result is cached for cacheDuration. The lock is not held while looking the permissions up, so
+// concurrent calls on an expired entry may each look them up.
+func Granted(ctx context.Context, client *msgraphsdk.GraphServiceClient, clientID string) ([]string, error) {
+
+	cacheLock.Lock()
+	entry, ok := cache[clientID]
+	cacheLock.Unlock()
+
+	if ok && time.Since(entry.fetched) < cacheDuration {
+		return entry.scopes, nil
+	}
+
+	scopes, err := lookupGranted(ctx, client, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheLock.Lock()
+	cache[clientID] = cacheEntry{scopes: scopes, fetched: time.Now()}
+	cacheLock.Unlock()
+
+	return scopes, nil
+}
+
+// supersets are the permissions covering other, narrower permissions besides their own Read
+// counterpart.
+var supersets = map[string][]string{
+	"Directory.Read.All": directoryReads,
+	"Directory.ReadWrite.All": append([]string{
+		"AdministrativeUnit.ReadWrite.All",
+		"Device.ReadWrite.All",
+		"Group.ReadWrite.All",
+		"GroupMember.ReadWrite.All",
+		"OrgContact.ReadWrite.All",
+		"User.ReadWrite.All",
+	}, directoryReads...),
+}
+
+// directoryReads are the permissions covered by Directory.Read.All.
+var directoryReads = []string{
+	"AdministrativeUnit.Read.All",
+	"Application.Read.All",
+	"Device.Read.All",
+	"Domain.Read.All",
+	"Group.Read.All",
+	"GroupMember.Read.All",
+	"OrgContact.Read.All",
+	"Organization.Read.All",
+	"RoleManagement.Read.Directory",
+	"User.Read.All",
+	"User.ReadBasic.All",
+}
+
+// Missing returns the required scopes that are not covered by the granted ones.
+// A ReadWrite permission covers the matching Read permission, and the directory wide
+// permissions cover the ones listed in supersets.
+func Missing(granted []string, required []string) []string {
+
+	grantedSet := make(map[string]bool, len(granted))
+	for _, scope := range granted {
+		grantedSet[scope] = true
+		for _, covered := range supersets[scope] {
+			grantedSet[covered] = true
+		}
+	}
+
+	var missing []string
+	for _, scope := range required {
+		if grantedSet[scope] || grantedSet[strings.Replace(scope, ".Read.", ".ReadWrite.", 1)] {
+			continue
+		}
+		missing = append(missing, scope)
+	}
+
+	return missing
+}
+
+// Preflight returns a tool handler that verifies the application holds the
+// required scopes before running the handler, and refuses to run it otherwise.
+func Preflight(clientID string, required []string, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+		if len(required) == 0 {
+			return next(ctx, request)
+		}
+
+		client := baggage.BaggageFromContext(ctx).(*msgraphsdk.GraphServiceClient)
+		if client == nil {
+			return mcp.NewToolResultError("client not found"), nil
+		}
+
+		granted, err := Granted(ctx, client, clientID)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("unable to verify the permissions granted to the application (Application.Read.All is required for the check): %v", err)), nil
+		}
+
+		if missing := Missing(granted, required); len(missing) > 0 {
+			return mcp.NewToolResultError(fmt.Sprintf(
+				"refusing to run '%s': the application is missing the required Microsoft Graph permission(s) %s (granted: %s)",
+				request.Params.Name,
+				strings.Join(missing, ", "),
+				strings.Join(granted, ", "),
+			)), nil
+		}
+
+		return next(ctx, request)
+	}
+}
+
+// lookupGranted resolves the app role assignments of the application's service principal to permission names.
+func lookupGranted(ctx context.Context, client *msgraphsdk.GraphServiceClient, clientID string) ([]string, error) {
+
+	sps, err := client.ServicePrincipals().Get(ctx, &serviceprincipals.ServicePrincipalsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &serviceprincipals.ServicePrincipalsRequestBuilderGetQueryParameters{
+			Filter: to.Ptr(odata.Eq("appId", clientID)),
+			Select: []string{"id", "appId"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching service principal: %v", err)
+	}
+	if len(sps.GetValue()) == 0 || sps.GetValue()[0].GetId() == nil {
+		return nil, fmt.Errorf("no service principal found for application '%s'", clientID)
+	}
+
+	assignments, err := client.ServicePrincipals().ByServicePrincipalId(*sps.GetValue()[0].GetId()).AppRoleAssignments().Get(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching app role assignments: %v", err)
+	}
+
+	// Resolve app role ids to their values, fetching each resource once
+	resources := map[string]map[string]string{}
+	scopes := []string{}
+	var lookupErr error
+
+	err = paginate.Iterate(ctx, client, assignments, models.CreateAppRoleAssignmentCollectionResponseFromDiscriminatorValue, func(assignment models.AppRoleAssignmentable) bool {
+		if assignment.GetResourceId() == nil || assignment.GetAppRoleId() == nil {
+			return true
+		}
+
+		resourceID := assignment.GetResourceId().String()
+		roles, ok := resources[resourceID]
+		if !ok {
+			roles, lookupErr = appRoles(ctx, client, resourceID)
+			if lookupErr != nil {
+				return false
+			}
+			resources[resourceID] = roles
+		}
+
+		if value, ok := roles[assignment.GetAppRoleId().String()]; ok {
+			scopes = append(scopes, value)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error iterating through app role assignments: %v", err)
+	}
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+
+	sort.Strings(scopes)
+	return scopes, nil
+}
+
+// appRoles returns the app roles exposed by a resource service principal, keyed by role id.
+func appRoles(ctx context.Context, client *msgraphsdk.GraphServiceClient, resourceID string) (map[string]string, error) {
+
+	sp, err := client.ServicePrincipals().ByServicePrincipalId(resourceID).Get(ctx, &serviceprincipals.ServicePrincipalItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &serviceprincipals.ServicePrincipalItemRequestBuilderGetQueryParameters{
+			Select: []string{"id", "appRoles"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching resource service principal '%s': %v", resourceID, err)
+	}
+
+	roles := map[string]string{}
+	for _, role := range sp.GetAppRoles() {
+		if role.GetId() != nil && role.GetValue() != nil {
+			roles[role.GetId().String()] = *role.GetValue()
+		}
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,33 @@
+package permissions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMissing(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		granted  []string
+		required []string
+		want     []string
+	}{
+		{"granted", []string{"User.Read.All"}, []string{"User.Read.All"}, nil},
+		{"not granted", []string{"User.Read.All"}, []string{"Group.Read.All"}, []string{"Group.Read.All"}},
+		{"read covered by readwrite", []string{"Group.ReadWrite.All"}, []string{"Group.Read.All"}, nil},
+		{"readwrite not covered by read", []string{"Group.Read.All"}, []string{"Group.ReadWrite.All"}, []string{"Group.ReadWrite.All"}},
+		{"covered by directory read", []string{"Directory.Read.All"}, []string{"User.Read.All", "Group.Read.All", "RoleManagement.Read.Directory"}, nil},
+		{"write not covered by directory read", []string{"Directory.Read.All"}, []string{"User.Read.All", "User.ReadWrite.All"}, []string{"User.ReadWrite.All"}},
+		{"covered by directory readwrite", []string{"Directory.ReadWrite.All"}, []string{"User.Read.All", "Group.ReadWrite.All", "Directory.Read.All"}, nil},
+		{"unrelated to directory", []string{"Directory.ReadWrite.All"}, []string{"Sites.Read.All"}, []string{"Sites.Read.All"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Missing(test.granted, test.required); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Package schema builds the JSON schemas describing the output of the tools.
+package schema
+
+// Schema is a JSON schema document.
+type Schema map[string]interface{}
+
+// String describes a string value.
+func String() Schema {
+	return Schema{"type": "string"}
+}
+
+// DateTime describes a date formatted as RFC 3339.
+func DateTime() Schema {
+	return Schema{"type": "string", "format": "date-time"}
+}
+
+// Enum describes a string restricted to the given values.
+func Enum(values ...string) Schema {
+	return Schema{"type": "string", "enum": values}
+}
+
+// Integer describes an integer value.
+func Integer() Schema {
+	return Schema{"type": "integer"}
+}
+
+// Number describes a numeric value.
+func Number() Schema {
+	return Schema{"type": "number"}
+}
+
+// Boolean describes a boolean value.
+func Boolean() Schema {
+	return Schema{"type": "boolean"}
+}
+
+// Nullable allows a value described by the given schema to be null.
+func Nullable(s Schema) Schema {
+	nullable := Schema{}
+	for k, v := range s {
+		nullable[k] = v
+	}
+	nullable["type"] = []interface{}{s["type"], "null"}
+	return nullable
+}
+
+// Array describes a list of items.
+func Array(items Schema) Schema {
+	return Schema{"type": "array", "items": items}
+}
+
+// Object describes an object with the given properties. Tools omit the attributes
+// Microsoft Graph does not return, so properties are not required.
+func Object(properties map[string]Schema) Schema {
+	return Schema{"type": "object", "properties": properties}
+}
+
+// Map describes an object whose keys are free, as tools key their results by id.
+func Map(entries Schema) Schema {
+	return Schema{"type": "object", "additionalProperties": entries}
+}
+
+// OneOf describes a value matching exactly one of the given schemas, for tools whose
+// result depends on their arguments.
+func OneOf(schemas ...Schema) Schema {
+	return Schema{"oneOf": schemas}
+}
+
+// Merge returns the union of the given properties, later ones taking precedence.
+func Merge(properties ...map[string]Schema) map[string]Schema {
+	merged := make(map[string]Schema)
+	for _, p := range properties {
+		for k, v := range p {
+			merged[k] = v
+		}
+	}
+	return merged
+}
@@ -0,0 +1,121 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Validate checks a decoded JSON value against the schema, so that a tool result can be compared
+// with the schema it declares. Objects are strict: their attributes must be declared in the
+// properties of the schema, unless it has none or also allows additional properties. It returns
+// every mismatch found, located by its path from the root ($).
+func Validate(s Schema, value interface{}) error {
+
+	var errs []error
+	validate(s, value, "$", &errs)
+
+	return errors.Join(errs...)
+}
+
+// validate appends to errs the mismatches between the value at path and the schema.
+func validate(s Schema, value interface{}, path string, errs *[]error) {
+
+	if schemas, ok := s["oneOf"].([]Schema); ok {
+		for _, candidate := range schemas {
+			if Validate(candidate, value) == nil {
+				return
+			}
+		}
+		*errs = append(*errs, fmt.Errorf("%s: matches none of the possible schemas", path))
+		return
+	}
+
+	types := []string{}
+	switch t := s["type"].(type) {
+	case string:
+		types = append(types, t)
+	case []interface{}:
+		for _, item := range t {
+			if name, ok := item.(string); ok {
+				types = append(types, name)
+			}
+		}
+	default:
+		// A schema without type accepts any value
+		return
+	}
+
+	kind := kindOf(value)
+	if !slices.Contains(types, kind) && !(kind == "integer" && slices.Contains(types, "number")) {
+		*errs = append(*errs, fmt.Errorf("%s: expected %v, got %s", path, types, kind))
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		if values, ok := s["enum"].([]string); ok && !slices.Contains(values, v) {
+			*errs = append(*errs, fmt.Errorf("%s: '%s' is not one of %v", path, v, values))
+		}
+		if s["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				*errs = append(*errs, fmt.Errorf("%s: '%s' is not a date-time", path, v))
+			}
+		}
+	case []interface{}:
+		if items, ok := s["items"].(Schema); ok {
+			for i, item := range v {
+				validate(items, item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	case map[string]interface{}:
+		properties, _ := s["properties"].(map[string]Schema)
+		additional, hasAdditional := s["additionalProperties"].(Schema)
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if property, ok := properties[key]; ok {
+				validate(property, v[key], path+"."+key, errs)
+				continue
+			}
+			switch {
+			case hasAdditional:
+				validate(additional, v[key], path+"."+key, errs)
+			case len(properties) > 0:
+				*errs = append(*errs, fmt.Errorf("%s: '%s' is not declared", path, key))
+			}
+		}
+	}
+}
+
+// kindOf returns the JSON schema type of a decoded JSON value.
+func kindOf(value interface{}) string {
+
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+
+	user := Object(map[string]Schema{
+		"id":              String(),
+		"accountEnabled":  Boolean(),
+		"createdDateTime": DateTime(),
+		"userType":        Enum("Member", "Guest"),
+		"age":             Integer(),
+		"score":           Number(),
+		"department":      Nullable(String()),
+		"groups":          Array(String()),
+		"manager":         Object(map[string]Schema{"id": String()}),
+		"extra":           Object(map[string]Schema{}),
+	})
+	users := Map(user)
+
+	tests := []struct {
+		name    string
+		schema  Schema
+		value   string
+		wantErr string
+	}{
+		{"valid", users, `{"1": {"id": "1", "accountEnabled": true, "createdDateTime": "2024-01-02T03:04:05Z", "userType": "Guest", "age": 3, "score": 1.5, "department": null, "groups": ["a"], "manager": {"id": "2"}, "extra": {"any": 1}}}`, ""},
+		{"missing attributes", users, `{"1": {}}`, ""},
+		{"integer as number", users, `{"1": {"score": 2}}`, ""},
+		{"wrong type", users, `{"1": {"id": 1}}`, "$.1.id: expected [string], got integer"},
+		{"not an integer", users, `{"1": {"age": 1.5}}`, "$.1.age: expected [integer], got number"},
+		{"undeclared attribute", users, `{"1": {"mail": "a@b.c"}}`, "$.1: 'mail' is not declared"},
+		{"undeclared nested attribute", users, `{"1": {"manager": {"id": "2", "mail": "a@b.c"}}}`, "$.1.manager: 'mail' is not declared"},
+		{"not in enum", users, `{"1": {"userType": "Admin"}}`, "'Admin' is not one of"},
+		{"not a date-time", users, `{"1": {"createdDateTime": "yesterday"}}`, "'yesterday' is not a date-time"},
+		{"null not allowed", users, `{"1": {"id": null}}`, "$.1.id: expected [string], got null"},
+		{"wrong item", users, `{"1": {"groups": ["a", 2]}}`, "$.1.groups[1]: expected [string], got integer"},
+		{"one of", OneOf(Object(map[string]Schema{"a": String()}), Object(map[string]Schema{"b": String()})), `{"b": "x"}`, ""},
+		{"none of", OneOf(Object(map[string]Schema{"a": String()}), Object(map[string]Schema{"b": String()})), `{"c": "x"}`, "$: matches none of the possible schemas"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var value interface{}
+			if err := json.Unmarshal([]byte(test.value), &value); err != nil {
+				t.Fatalf("decoding value: %v", err)
+			}
+			err := Validate(test.schema, value)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("got error %v, want %q", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	khttp "github.com/microsoft/kiota-http-go"
+)
+
+// Log prints the method and URL of every Microsoft Graph request to the standard logger.
+var Log = false
+
+// recorderKey is a custom context key for storing the requests recorder.
+type recorderKey struct{}
+
+// recorder collects the requests sent while a tool is called. Requests may be sent concurrently.
+type recorder struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+// Handler is a Graph client middleware recording the method and URL of each request, with its
+// query string, for the tool being called. Headers are never recorded, so neither is the token.
+type Handler struct{}
+
+// Intercept records the request and passes it on to the next middleware.
+func (Handler) Intercept(pipeline khttp.Pipeline, middlewareIndex int, req *http.Request) (*http.Response, error) {
+
+	line := req.Method + " " + req.URL.String()
+	if Log {
+		log.Printf("graph request: %s", line)
+	}
+	if r, ok := req.Context().Value(recorderKey{}).(*recorder); ok {
+		r.mu.Lock()
+		r.requests = append(r.requests, line)
+		r.mu.Unlock()
+	}
+
+	return pipeline.Next(req, middlewareIndex)
+}
+
+// WithDebug adds the debug argument, appending the Graph requests sent to the result of the tool.
+func WithDebug() mcp.ToolOption {
+	return mcp.WithBoolean("debug",
+		mcp.Description("Append the Microsoft Graph requests sent, with their full URL and query string, to the result. Useful to diagnose unexpected filter, search or select results."),
+	)
+}
+
+// Middleware is a tool handler middleware recording the Graph requests sent by the tool when it
+// is called with debug, and appending them to its result as a separate text content, failed
+// results included.
+func Middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+		if !mcp.ParseBoolean(request, "debug", false) {
+			return next(ctx, request)
+		}
+
+		r := &recorder{}
+		result, err := next(context.WithValue(ctx, recorderKey{}, r), request)
+		if result == nil {
+			return result, err
+		}
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf("Microsoft Graph requests (%d):\n%s", len(r.requests), strings.Join(r.requests, "\n"))))
+
+		return result, err
+	}
+}